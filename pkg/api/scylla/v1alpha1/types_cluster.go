@@ -145,6 +145,13 @@ type ScyllaDBClusterExposeOptions struct {
 	// BroadcastOptions defines how ScyllaDB node publishes its IP address to other nodes and clients.
 	// +optional
 	BroadcastOptions *ScyllaDBClusterNodeBroadcastOptions `json:"broadcastOptions,omitempty"`
+
+	// topologyAwareHints controls whether the EndpointSlices of other datacenters managed in each datacenter
+	// carry topology hints. Each endpoint is hinted for the zone of the node it runs on, as given by the node's
+	// `topology.kubernetes.io/zone` label. Endpoints on nodes without a zone aren't hinted.
+	// Only endpoints backed by Pod IPs can be hinted.
+	// +optional
+	TopologyAwareHints *bool `json:"topologyAwareHints,omitempty"`
 }
 
 // ScyllaDBClusterRackStatus is the status of a ScyllaDB rack
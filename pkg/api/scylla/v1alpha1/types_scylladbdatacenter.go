@@ -4,6 +4,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,12 @@ type ScyllaDBDatacenterSpec struct {
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 
+	// imagePullCredentials specifies private registry credentials the operator turns into a kubernetes.io/dockerconfigjson
+	// Secret, referenced by the ServiceAccount of ScyllaDB Pods.
+	// Rotating the referenced credentials rotates the generated Secret.
+	// +optional
+	ImagePullCredentials *ImagePullCredentials `json:"imagePullCredentials,omitempty"`
+
 	// dnsPolicy defines how a pod's DNS will be configured.
 	// +optional
 	DNSPolicy *corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
@@ -88,8 +95,179 @@ type ScyllaDBDatacenterSpec struct {
 	// about readiness gates.
 	// +optional
 	ReadinessGates []corev1.PodReadinessGate `json:"readinessGates,omitempty"`
+
+	// orphanedPersistentVolumeClaimRetentionPolicy controls what happens with the ScyllaDB data PersistentVolumeClaims
+	// that are left behind for nodes which no longer exist after a scale-down.
+	// Retain keeps the PersistentVolumeClaims, Delete removes them.
+	// If not provided, the PersistentVolumeClaims are retained.
+	// +kubebuilder:validation:Enum="Retain";"Delete"
+	// +optional
+	OrphanedPersistentVolumeClaimRetentionPolicy *PersistentVolumeClaimRetentionPolicy `json:"orphanedPersistentVolumeClaimRetentionPolicy,omitempty"`
+
+	// networkPolicy specifies options of the NetworkPolicies isolating ScyllaDB nodes.
+	// If provided, a default deny baseline NetworkPolicy is created and ScyllaDB Pods only admit traffic between the datacenter nodes,
+	// metrics scraping, ScyllaDB Manager Agent API calls, client traffic from the allowed CIDRs and traffic allowed by the allow overlays.
+	// Any other ingress traffic is denied.
+	// If not provided, no NetworkPolicy is created.
+	// +optional
+	NetworkPolicy *NetworkPolicyOptions `json:"networkPolicy,omitempty"`
+
+	// serviceMonitor specifies options of a Prometheus Operator ServiceMonitor scraping ScyllaDB nodes.
+	// If provided, a ServiceMonitor targeting the metrics ports of ScyllaDB nodes is created.
+	// If not provided, no ServiceMonitor is created.
+	// Mutually exclusive with podMonitor.
+	// +optional
+	ServiceMonitor *ServiceMonitorOptions `json:"serviceMonitor,omitempty"`
+
+	// podMonitor specifies options of a Prometheus Operator PodMonitor scraping ScyllaDB nodes.
+	// If provided, a PodMonitor targeting the metrics ports of ScyllaDB Pods is created.
+	// If not provided, no PodMonitor is created.
+	// Mutually exclusive with serviceMonitor.
+	// +optional
+	PodMonitor *PodMonitorOptions `json:"podMonitor,omitempty"`
+
+	// deletionCleanup specifies a Job cleaning up external resources, like backup markers in object storage,
+	// when the ScyllaDBDatacenter is deleted.
+	// If provided, the ScyllaDBDatacenter is only removed after the Job succeeds. A failed Job blocks the removal,
+	// deleting it makes the cleanup run again.
+	// If not provided, no cleanup is run.
+	// +optional
+	DeletionCleanup *DeletionCleanupOptions `json:"deletionCleanup,omitempty"`
+
+	// disruptionTolerance specifies how many ScyllaDB nodes voluntary disruptions, like node drains, may take down at once.
+	// The maxUnavailable of the managed PodDisruptionBudgets is computed from it and the number of nodes they cover,
+	// which is the whole datacenter, or each rack with perRack, and it's recomputed when the datacenter scales.
+	// If not provided, one node of the datacenter may be unavailable.
+	// +optional
+	DisruptionTolerance *DisruptionToleranceOptions `json:"disruptionTolerance,omitempty"`
+
+	// dataDirectoryPreparation specifies an init container preparing the ScyllaDB data directory before ScyllaDB starts.
+	// It's safe to run repeatedly, so it runs on every start of a ScyllaDB node.
+	// If not provided, the data directory is used as it is.
+	// +optional
+	DataDirectoryPreparation *DataDirectoryPreparationOptions `json:"dataDirectoryPreparation,omitempty"`
+}
+
+// DataDirectoryPreparationOptions hold options of the preparation of the ScyllaDB data directory.
+type DataDirectoryPreparationOptions struct {
+	// ownership specifies the user and group IDs, in the "uid:gid" format, the data directory is recursively given to,
+	// e.g. when it was restored or written with a different owner.
+	// The recursive walk only runs again when the ownership or the owner of the data directory itself changes.
+	// If not provided, the ownership is left as it is.
+	// +kubebuilder:validation:Pattern=`^[0-9]+:[0-9]+$`
+	// +optional
+	Ownership *string `json:"ownership,omitempty"`
+
+	// requireXFS makes the preparation fail, and keeps ScyllaDB from starting, when the data directory isn't on an XFS filesystem,
+	// which is the only filesystem ScyllaDB supports for production use.
+	// +optional
+	RequireXFS *bool `json:"requireXFS,omitempty"`
+}
+
+// DisruptionToleranceOptions hold options of the disruptions the ScyllaDB nodes tolerate.
+type DisruptionToleranceOptions struct {
+	// maxUnavailablePercent specifies the percentage of the nodes covered by a PodDisruptionBudget that may be unavailable at once,
+	// rounded down. At least one node may always be unavailable, so voluntary disruptions can make progress.
+	// If not provided, one node may be unavailable.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxUnavailablePercent int32 `json:"maxUnavailablePercent,omitempty"`
+
+	// perRack gives every rack its own PodDisruptionBudget instead of a single one covering the whole datacenter.
+	// The budgets are independent, so every rack may have nodes unavailable at the same time.
+	// With keyspaces replicated to every rack, e.g. a replication factor of 3 with 3 racks, one unavailable node
+	// in every rack takes down more than one replica of the same token ranges, so they lose QUORUM.
+	// Only enable it when the workload tolerates that, or when disruptions are coordinated rack by rack otherwise.
+	// +optional
+	PerRack bool `json:"perRack,omitempty"`
+}
+
+// ImagePullCredentials hold credentials of a private image registry.
+type ImagePullCredentials struct {
+	// registry specifies the registry server the credentials are for, e.g. "registry.example.com".
+	// +kubebuilder:validation:MinLength=1
+	Registry string `json:"registry"`
+
+	// secretName references a Secret in the ScyllaDBDatacenter namespace holding the "username" and "password" keys,
+	// like a kubernetes.io/basic-auth Secret.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// DeletionCleanupOptions hold options of the Job cleaning up external resources when the ScyllaDBDatacenter is deleted.
+type DeletionCleanupOptions struct {
+	// image specifies the container image of the cleanup Job.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// command overrides the entrypoint of the image.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// args specifies the arguments passed to the command.
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// PodMonitorOptions hold options related to the PodMonitor scraping ScyllaDB nodes.
+type PodMonitorOptions struct {
+	// labels specifies custom labels merged into the PodMonitor labels,
+	// e.g. to have it selected by a Prometheus instance.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// ServiceMonitorOptions hold options related to the ServiceMonitor scraping ScyllaDB nodes.
+type ServiceMonitorOptions struct {
+	// labels specifies custom labels merged into the ServiceMonitor labels,
+	// e.g. to have it selected by a Prometheus instance.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NetworkPolicyOptions hold options related to the NetworkPolicy isolating ScyllaDB nodes.
+type NetworkPolicyOptions struct {
+	// allowedClientCIDRs specifies CIDRs that are allowed to reach the client ports (CQL and Alternator)
+	// of ScyllaDB nodes.
+	// +optional
+	AllowedClientCIDRs []string `json:"allowedClientCIDRs,omitempty"`
+
+	// allowOverlays specify additional ingress traffic admitted to ScyllaDB nodes on top of the default deny baseline.
+	// Every overlay is reconciled as a separate NetworkPolicy, which is removed when the overlay is removed.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AllowOverlays []NetworkPolicyAllowOverlay `json:"allowOverlays,omitempty"`
+}
+
+// NetworkPolicyAllowOverlay describes ingress traffic admitted to ScyllaDB nodes.
+type NetworkPolicyAllowOverlay struct {
+	// name uniquely identifies the overlay within the datacenter. It's used as a suffix of the NetworkPolicy name.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// from specifies the sources the traffic is admitted from.
+	// If empty, traffic from all sources is admitted.
+	// +optional
+	From []networkingv1.NetworkPolicyPeer `json:"from,omitempty"`
+
+	// ports specifies the ports of ScyllaDB nodes the traffic is admitted to.
+	// If empty, traffic to all ports is admitted.
+	// +optional
+	Ports []networkingv1.NetworkPolicyPort `json:"ports,omitempty"`
+}
+
+type PersistentVolumeClaimRetentionPolicy string
+
+const (
+	// PersistentVolumeClaimRetentionPolicyRetain keeps the orphaned PersistentVolumeClaims.
+	PersistentVolumeClaimRetentionPolicyRetain PersistentVolumeClaimRetentionPolicy = "Retain"
+
+	// PersistentVolumeClaimRetentionPolicyDelete deletes the orphaned PersistentVolumeClaims.
+	PersistentVolumeClaimRetentionPolicyDelete PersistentVolumeClaimRetentionPolicy = "Delete"
+)
+
 type ObjectTemplateMetadata struct {
 	// labels specify a custom key value map that gets merged with managed object labels.
 	// +optional
@@ -367,6 +545,14 @@ type NodeServiceTemplate struct {
 	// Check Kubernetes corev1.Service documentation about semantic of this field.
 	// +optional
 	InternalTrafficPolicy *corev1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+
+	// publishNotReadyAddresses controls value of service.spec.publishNotReadyAddresses of each node Service.
+	// Setting it to false makes each node Service route only to a ready ScyllaDB Pod, whose readiness includes its readinessGates,
+	// for clients that need ready endpoints only. It can only be set to false when nodes broadcast their Pod IP,
+	// as nodes have to reach each other before they become ready.
+	// If not provided, addresses of not ready Pods are published.
+	// +optional
+	PublishNotReadyAddresses *bool `json:"publishNotReadyAddresses,omitempty"`
 }
 
 // RackExposeOptions hold options related to exposing rack of ScyllaDBDatacenter.
@@ -393,6 +579,39 @@ type ExposeOptions struct {
 
 	// BroadcastOptions defines how ScyllaDB node publishes its IP address to other nodes and clients.
 	BroadcastOptions *NodeBroadcastOptions `json:"broadcastOptions,omitempty"`
+
+	// managementAPI specifies expose options for the ScyllaDB Manager Agent API.
+	// +optional
+	ManagementAPI *ManagementAPIExposeOptions `json:"managementAPI,omitempty"`
+}
+
+// ManagementAPIExposeOptions hold options related to exposing the ScyllaDB Manager Agent API.
+type ManagementAPIExposeOptions struct {
+	// ingress specifies an Ingress configuration options.
+	// If provided, an Ingress routing to the ScyllaDB Manager Agent API port of the identity Service is created.
+	// If not provided, no Ingress is created.
+	// +optional
+	Ingress *ManagementAPIExposeIngressOptions `json:"ingress,omitempty"`
+}
+
+// ManagementAPIExposeIngressOptions defines configuration options of the Ingress exposing the ScyllaDB Manager Agent API.
+type ManagementAPIExposeIngressOptions struct {
+	ObjectTemplateMetadata `json:",inline"`
+
+	// ingressClassName specifies Ingress class name.
+	// If not provided, the cluster default Ingress class is used.
+	// +optional
+	IngressClassName string `json:"ingressClassName,omitempty"`
+
+	// host specifies the host the Ingress routes.
+	// If not provided, the Ingress routes all hosts.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// path specifies the path prefix the Ingress routes.
+	// +kubebuilder:default:="/"
+	// +optional
+	Path string `json:"path,omitempty"`
 }
 
 // CQLExposeOptions hold options related to exposing CQL backend.
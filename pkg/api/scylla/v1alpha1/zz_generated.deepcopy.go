@@ -6,7 +6,8 @@
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -133,6 +134,58 @@ func (in *Components) DeepCopy() *Components {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataDirectoryPreparationOptions) DeepCopyInto(out *DataDirectoryPreparationOptions) {
+	*out = *in
+	if in.Ownership != nil {
+		in, out := &in.Ownership, &out.Ownership
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequireXFS != nil {
+		in, out := &in.RequireXFS, &out.RequireXFS
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDirectoryPreparationOptions.
+func (in *DataDirectoryPreparationOptions) DeepCopy() *DataDirectoryPreparationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DataDirectoryPreparationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionCleanupOptions) DeepCopyInto(out *DeletionCleanupOptions) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionCleanupOptions.
+func (in *DeletionCleanupOptions) DeepCopy() *DeletionCleanupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionCleanupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceDiscovery) DeepCopyInto(out *DeviceDiscovery) {
 	*out = *in
@@ -149,6 +202,22 @@ func (in *DeviceDiscovery) DeepCopy() *DeviceDiscovery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionToleranceOptions) DeepCopyInto(out *DisruptionToleranceOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionToleranceOptions.
+func (in *DisruptionToleranceOptions) DeepCopy() *DisruptionToleranceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionToleranceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExposeOptions) DeepCopyInto(out *ExposeOptions) {
 	*out = *in
@@ -167,6 +236,11 @@ func (in *ExposeOptions) DeepCopyInto(out *ExposeOptions) {
 		*out = new(NodeBroadcastOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagementAPI != nil {
+		in, out := &in.ManagementAPI, &out.ManagementAPI
+		*out = new(ManagementAPIExposeOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -282,6 +356,22 @@ func (in *HTTPSExposeOptions) DeepCopy() *HTTPSExposeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullCredentials) DeepCopyInto(out *ImagePullCredentials) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullCredentials.
+func (in *ImagePullCredentials) DeepCopy() *ImagePullCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressOptions) DeepCopyInto(out *IngressOptions) {
 	*out = *in
@@ -390,6 +480,44 @@ func (in *LoopDeviceConfiguration) DeepCopy() *LoopDeviceConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementAPIExposeIngressOptions) DeepCopyInto(out *ManagementAPIExposeIngressOptions) {
+	*out = *in
+	in.ObjectTemplateMetadata.DeepCopyInto(&out.ObjectTemplateMetadata)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementAPIExposeIngressOptions.
+func (in *ManagementAPIExposeIngressOptions) DeepCopy() *ManagementAPIExposeIngressOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementAPIExposeIngressOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementAPIExposeOptions) DeepCopyInto(out *ManagementAPIExposeOptions) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ManagementAPIExposeIngressOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementAPIExposeOptions.
+func (in *ManagementAPIExposeOptions) DeepCopy() *ManagementAPIExposeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementAPIExposeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MountConfiguration) DeepCopyInto(out *MountConfiguration) {
 	*out = *in
@@ -411,6 +539,64 @@ func (in *MountConfiguration) DeepCopy() *MountConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyAllowOverlay) DeepCopyInto(out *NetworkPolicyAllowOverlay) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]v1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1.NetworkPolicyPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyAllowOverlay.
+func (in *NetworkPolicyAllowOverlay) DeepCopy() *NetworkPolicyAllowOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyAllowOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyOptions) DeepCopyInto(out *NetworkPolicyOptions) {
+	*out = *in
+	if in.AllowedClientCIDRs != nil {
+		in, out := &in.AllowedClientCIDRs, &out.AllowedClientCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowOverlays != nil {
+		in, out := &in.AllowOverlays, &out.AllowOverlays
+		*out = make([]NetworkPolicyAllowOverlay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyOptions.
+func (in *NetworkPolicyOptions) DeepCopy() *NetworkPolicyOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeBroadcastOptions) DeepCopyInto(out *NodeBroadcastOptions) {
 	*out = *in
@@ -556,7 +742,7 @@ func (in *NodeConfigPlacement) DeepCopyInto(out *NodeConfigPlacement) {
 	in.Affinity.DeepCopyInto(&out.Affinity)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -639,7 +825,7 @@ func (in *NodeServiceTemplate) DeepCopyInto(out *NodeServiceTemplate) {
 	in.ObjectTemplateMetadata.DeepCopyInto(&out.ObjectTemplateMetadata)
 	if in.ExternalTrafficPolicy != nil {
 		in, out := &in.ExternalTrafficPolicy, &out.ExternalTrafficPolicy
-		*out = new(v1.ServiceExternalTrafficPolicy)
+		*out = new(corev1.ServiceExternalTrafficPolicy)
 		**out = **in
 	}
 	if in.AllocateLoadBalancerNodePorts != nil {
@@ -654,7 +840,12 @@ func (in *NodeServiceTemplate) DeepCopyInto(out *NodeServiceTemplate) {
 	}
 	if in.InternalTrafficPolicy != nil {
 		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
-		*out = new(v1.ServiceInternalTrafficPolicy)
+		*out = new(corev1.ServiceInternalTrafficPolicy)
+		**out = **in
+	}
+	if in.PublishNotReadyAddresses != nil {
+		in, out := &in.PublishNotReadyAddresses, &out.PublishNotReadyAddresses
+		*out = new(bool)
 		**out = **in
 	}
 	return
@@ -731,22 +922,22 @@ func (in *Placement) DeepCopyInto(out *Placement) {
 	*out = *in
 	if in.NodeAffinity != nil {
 		in, out := &in.NodeAffinity, &out.NodeAffinity
-		*out = new(v1.NodeAffinity)
+		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodAffinity != nil {
 		in, out := &in.PodAffinity, &out.PodAffinity
-		*out = new(v1.PodAffinity)
+		*out = new(corev1.PodAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodAntiAffinity != nil {
 		in, out := &in.PodAntiAffinity, &out.PodAntiAffinity
-		*out = new(v1.PodAntiAffinity)
+		*out = new(corev1.PodAntiAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -769,22 +960,22 @@ func (in *PlacementSpec) DeepCopyInto(out *PlacementSpec) {
 	*out = *in
 	if in.NodeAffinity != nil {
 		in, out := &in.NodeAffinity, &out.NodeAffinity
-		*out = new(v1.NodeAffinity)
+		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodAffinity != nil {
 		in, out := &in.PodAffinity, &out.PodAffinity
-		*out = new(v1.PodAffinity)
+		*out = new(corev1.PodAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodAntiAffinity != nil {
 		in, out := &in.PodAntiAffinity, &out.PodAntiAffinity
-		*out = new(v1.PodAntiAffinity)
+		*out = new(corev1.PodAntiAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -834,6 +1025,29 @@ func (in *PodIPInterfaceOptions) DeepCopy() *PodIPInterfaceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMonitorOptions) DeepCopyInto(out *PodMonitorOptions) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMonitorOptions.
+func (in *PodMonitorOptions) DeepCopy() *PodMonitorOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMonitorOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusExposeOptions) DeepCopyInto(out *PrometheusExposeOptions) {
 	*out = *in
@@ -1466,6 +1680,11 @@ func (in *ScyllaDBClusterExposeOptions) DeepCopyInto(out *ScyllaDBClusterExposeO
 		*out = new(ScyllaDBClusterNodeBroadcastOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologyAwareHints != nil {
+		in, out := &in.TopologyAwareHints, &out.TopologyAwareHints
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1639,7 +1858,7 @@ func (in *ScyllaDBClusterSpec) DeepCopyInto(out *ScyllaDBClusterSpec) {
 	}
 	if in.ReadinessGates != nil {
 		in, out := &in.ReadinessGates, &out.ReadinessGates
-		*out = make([]v1.PodReadinessGate, len(*in))
+		*out = make([]corev1.PodReadinessGate, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -1807,12 +2026,17 @@ func (in *ScyllaDBDatacenterSpec) DeepCopyInto(out *ScyllaDBDatacenterSpec) {
 	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ImagePullCredentials != nil {
+		in, out := &in.ImagePullCredentials, &out.ImagePullCredentials
+		*out = new(ImagePullCredentials)
+		**out = **in
+	}
 	if in.DNSPolicy != nil {
 		in, out := &in.DNSPolicy, &out.DNSPolicy
-		*out = new(v1.DNSPolicy)
+		*out = new(corev1.DNSPolicy)
 		**out = **in
 	}
 	if in.DNSDomains != nil {
@@ -1859,9 +2083,44 @@ func (in *ScyllaDBDatacenterSpec) DeepCopyInto(out *ScyllaDBDatacenterSpec) {
 	}
 	if in.ReadinessGates != nil {
 		in, out := &in.ReadinessGates, &out.ReadinessGates
-		*out = make([]v1.PodReadinessGate, len(*in))
+		*out = make([]corev1.PodReadinessGate, len(*in))
 		copy(*out, *in)
 	}
+	if in.OrphanedPersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.OrphanedPersistentVolumeClaimRetentionPolicy, &out.OrphanedPersistentVolumeClaimRetentionPolicy
+		*out = new(PersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(ServiceMonitorOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodMonitor != nil {
+		in, out := &in.PodMonitor, &out.PodMonitor
+		*out = new(PodMonitorOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionCleanup != nil {
+		in, out := &in.DeletionCleanup, &out.DeletionCleanup
+		*out = new(DeletionCleanupOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionTolerance != nil {
+		in, out := &in.DisruptionTolerance, &out.DisruptionTolerance
+		*out = new(DisruptionToleranceOptions)
+		**out = **in
+	}
+	if in.DataDirectoryPreparation != nil {
+		in, out := &in.DataDirectoryPreparation, &out.DataDirectoryPreparation
+		*out = new(DataDirectoryPreparationOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1961,7 +2220,7 @@ func (in *ScyllaDBManagerAgentTemplate) DeepCopyInto(out *ScyllaDBManagerAgentTe
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CustomConfigSecretRef != nil {
@@ -1971,14 +2230,14 @@ func (in *ScyllaDBManagerAgentTemplate) DeepCopyInto(out *ScyllaDBManagerAgentTe
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2228,7 +2487,7 @@ func (in *ScyllaDBTemplate) DeepCopyInto(out *ScyllaDBTemplate) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Storage != nil {
@@ -2243,14 +2502,14 @@ func (in *ScyllaDBTemplate) DeepCopyInto(out *ScyllaDBTemplate) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2418,6 +2677,29 @@ func (in *ScyllaOperatorConfigStatus) DeepCopy() *ScyllaOperatorConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorOptions) DeepCopyInto(out *ServiceMonitorOptions) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorOptions.
+func (in *ServiceMonitorOptions) DeepCopy() *ServiceMonitorOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Storage) DeepCopyInto(out *Storage) {
 	*out = *in
@@ -94,6 +94,35 @@ func ValidateScyllaDBDatacenterSpec(spec *scyllav1alpha1.ScyllaDBDatacenterSpec,
 		allErrs = append(allErrs, apimachineryvalidation.ValidateNonnegativeField(int64(*spec.MinReadySeconds), fldPath.Child("minReadySeconds"))...)
 	}
 
+	if spec.ServiceMonitor != nil && spec.PodMonitor != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("podMonitor"), "can't be set together with serviceMonitor"))
+	}
+
+	if spec.NetworkPolicy != nil {
+		allErrs = append(allErrs, ValidateScyllaDBDatacenterNetworkPolicyOptions(spec.NetworkPolicy, fldPath.Child("networkPolicy"))...)
+	}
+
+	return allErrs
+}
+
+func ValidateScyllaDBDatacenterNetworkPolicyOptions(options *scyllav1alpha1.NetworkPolicyOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateStructSliceFieldUniqueness(options.AllowOverlays, func(overlay scyllav1alpha1.NetworkPolicyAllowOverlay) string {
+		return overlay.Name
+	}, "name", fldPath.Child("allowOverlays"))...)
+
+	for i, overlay := range options.AllowOverlays {
+		if len(overlay.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowOverlays").Index(i).Child("name"), ""))
+			continue
+		}
+
+		for _, msg := range apimachineryutilvalidation.IsDNS1123Label(overlay.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowOverlays").Index(i).Child("name"), overlay.Name, msg))
+		}
+	}
+
 	return allErrs
 }
 
@@ -214,6 +243,40 @@ func ValidateScyllaDBDatacenterSpecExposeOptions(options *scyllav1alpha1.ExposeO
 		allErrs = append(allErrs, ValidateScyllaDBDatacenterSpecExposeOptionsNodeBroadcastOptions(options.BroadcastOptions, options.NodeService, fldPath.Child("broadcastOptions"))...)
 	}
 
+	if options.ManagementAPI != nil && options.ManagementAPI.Ingress != nil {
+		allErrs = append(allErrs, ValidateScyllaDBDatacenterManagementAPIIngressOptions(options.ManagementAPI.Ingress, fldPath.Child("managementAPI", "ingress"))...)
+	}
+
+	return allErrs
+}
+
+func ValidateScyllaDBDatacenterManagementAPIIngressOptions(options *scyllav1alpha1.ManagementAPIExposeIngressOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(options.IngressClassName) != 0 {
+		for _, msg := range apimachineryvalidation.NameIsDNSSubdomain(options.IngressClassName, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ingressClassName"), options.IngressClassName, msg))
+		}
+	}
+
+	if len(options.Host) != 0 {
+		for _, msg := range apimachineryutilvalidation.IsDNS1123Subdomain(options.Host) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("host"), options.Host, msg))
+		}
+	}
+
+	if len(options.Path) != 0 && !strings.HasPrefix(options.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), options.Path, "must be an absolute path"))
+	}
+
+	if len(options.Annotations) != 0 {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateAnnotations(options.Annotations, fldPath.Child("annotations"))...)
+	}
+
+	if len(options.Labels) != 0 {
+		allErrs = append(allErrs, metav1validation.ValidateLabels(options.Labels, fldPath.Child("labels"))...)
+	}
+
 	return allErrs
 }
 
@@ -250,6 +313,13 @@ func ValidateScyllaDBDatacenterNodeService(options *scyllav1alpha1.ExposeOptions
 	if len(options.NodeService.Annotations) != 0 {
 		allErrs = append(allErrs, apimachineryvalidation.ValidateAnnotations(options.NodeService.Annotations, fldPath.Child("nodeService", "annotations"))...)
 	}
+
+	// Nodes reach each other through the node Services unless they broadcast Pod IPs, which can't happen before they are ready.
+	if options.NodeService.PublishNotReadyAddresses != nil && !*options.NodeService.PublishNotReadyAddresses {
+		if options.BroadcastOptions == nil || options.BroadcastOptions.Nodes.Type != scyllav1alpha1.BroadcastAddressTypePodIP {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeService", "publishNotReadyAddresses"), *options.NodeService.PublishNotReadyAddresses, fmt.Sprintf("can only be false when nodes broadcast address type is %q", scyllav1alpha1.BroadcastAddressTypePodIP)))
+		}
+	}
 	return allErrs
 }
 
@@ -516,9 +586,21 @@ func ValidateScyllaDBDatacenterSpecUpdate(new, old *scyllav1alpha1.ScyllaDBDatac
 			oldRackStorage = *oldRack.ScyllaDB.Storage
 		}
 
+		// Growing the capacity is supported by expanding the existing PVCs, every other change is not.
+		oldRackCapacity, newRackCapacity := oldRackStorage.Capacity, newRackStorage.Capacity
+		oldRackStorage.Capacity, newRackStorage.Capacity = "", ""
 		if !reflect.DeepEqual(oldRackStorage, newRackStorage) {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("racks").Index(i).Child("scyllaDB", "storage"), "changes in storage are currently not supported"))
 		}
+
+		if oldRackCapacity != newRackCapacity {
+			oldCapacity, oldErr := resource.ParseQuantity(oldRackCapacity)
+			newCapacity, newErr := resource.ParseQuantity(newRackCapacity)
+			// Unparsable capacities are reported by the spec validation.
+			if oldErr != nil || newErr != nil || newCapacity.Cmp(oldCapacity) < 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("racks").Index(i).Child("scyllaDB", "storage", "capacity"), "storage capacity can only be increased"))
+			}
+		}
 	}
 
 	var oldClientBroadcastAddressType, newClientBroadcastAddressType *scyllav1alpha1.BroadcastAddressType
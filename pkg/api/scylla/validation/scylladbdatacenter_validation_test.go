@@ -227,6 +227,48 @@ func TestValidateScyllaDBDatacenter(t *testing.T) {
 			expectedErrorList:   nil,
 			expectedErrorString: "",
 		},
+		{
+			name: "not ready addresses can't be excluded from node services when nodes broadcast service IPs",
+			datacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+					NodeService: &scyllav1alpha1.NodeServiceTemplate{
+						Type:                     scyllav1alpha1.NodeServiceTypeClusterIP,
+						PublishNotReadyAddresses: pointer.Ptr(false),
+					},
+				}
+
+				return sdc
+			}(),
+			expectedErrorList: field.ErrorList{
+				&field.Error{Type: field.ErrorTypeInvalid, Field: "spec.exposeOptions.nodeService.publishNotReadyAddresses", BadValue: false, Detail: `can only be false when nodes broadcast address type is "PodIP"`},
+			},
+			expectedErrorString: `spec.exposeOptions.nodeService.publishNotReadyAddresses: Invalid value: false: can only be false when nodes broadcast address type is "PodIP"`,
+		},
+		{
+			name: "not ready addresses can be excluded from node services when nodes broadcast pod IPs",
+			datacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+					NodeService: &scyllav1alpha1.NodeServiceTemplate{
+						Type:                     scyllav1alpha1.NodeServiceTypeClusterIP,
+						PublishNotReadyAddresses: pointer.Ptr(false),
+					},
+					BroadcastOptions: &scyllav1alpha1.NodeBroadcastOptions{
+						Nodes: scyllav1alpha1.BroadcastOptions{
+							Type: scyllav1alpha1.BroadcastAddressTypePodIP,
+						},
+						Clients: scyllav1alpha1.BroadcastOptions{
+							Type: scyllav1alpha1.BroadcastAddressTypeServiceClusterIP,
+						},
+					},
+				}
+
+				return sdc
+			}(),
+			expectedErrorList:   nil,
+			expectedErrorString: "",
+		},
 		{
 			name: "unsupported type of client broadcast address",
 			datacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
@@ -664,6 +706,56 @@ func TestValidateScyllaDBDatacenter(t *testing.T) {
 			},
 			expectedErrorString: `spec.rackTemplate.scyllaDBManagerAgent.customConfigSecretRef: Invalid value: "-hello": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
 		},
+		{
+			name: "serviceMonitor and podMonitor are mutually exclusive",
+			datacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.ServiceMonitor = &scyllav1alpha1.ServiceMonitorOptions{}
+				sdc.Spec.PodMonitor = &scyllav1alpha1.PodMonitorOptions{}
+				return sdc
+			}(),
+			expectedErrorList: field.ErrorList{
+				&field.Error{Type: field.ErrorTypeForbidden, Field: "spec.podMonitor", BadValue: "", Detail: "can't be set together with serviceMonitor"},
+			},
+			expectedErrorString: `spec.podMonitor: Forbidden: can't be set together with serviceMonitor`,
+		},
+		{
+			name: "network policy allow overlays with duplicate and invalid names",
+			datacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.NetworkPolicy = &scyllav1alpha1.NetworkPolicyOptions{
+					AllowOverlays: []scyllav1alpha1.NetworkPolicyAllowOverlay{
+						{Name: "monitoring"},
+						{Name: "monitoring"},
+						{Name: "Invalid_Name"},
+					},
+				}
+				return sdc
+			}(),
+			expectedErrorList: field.ErrorList{
+				&field.Error{Type: field.ErrorTypeDuplicate, Field: "spec.networkPolicy.allowOverlays[1].name", BadValue: "monitoring"},
+				&field.Error{Type: field.ErrorTypeInvalid, Field: "spec.networkPolicy.allowOverlays[2].name", BadValue: "Invalid_Name", Detail: `a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+			},
+			expectedErrorString: `[spec.networkPolicy.allowOverlays[1].name: Duplicate value: "monitoring", spec.networkPolicy.allowOverlays[2].name: Invalid value: "Invalid_Name": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')]`,
+		},
+		{
+			name: "relative management API ingress path",
+			datacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+					ManagementAPI: &scyllav1alpha1.ManagementAPIExposeOptions{
+						Ingress: &scyllav1alpha1.ManagementAPIExposeIngressOptions{
+							Path: "agent",
+						},
+					},
+				}
+				return sdc
+			}(),
+			expectedErrorList: field.ErrorList{
+				&field.Error{Type: field.ErrorTypeInvalid, Field: "spec.exposeOptions.managementAPI.ingress.path", BadValue: "agent", Detail: "must be an absolute path"},
+			},
+			expectedErrorString: `spec.exposeOptions.managementAPI.ingress.path: Invalid value: "agent": must be an absolute path`,
+		},
 	}
 
 	for _, test := range tests {
@@ -757,13 +849,37 @@ func TestValidateScyllaDBDatacenterUpdate(t *testing.T) {
 			expectedErrorString: `spec.clusterName: Invalid value: "foo": field is immutable`,
 		},
 		{
-			name: "rackStorage changed",
+			name: "rackStorage capacity increased",
 			old:  newValidScyllaDBDatacenter(),
 			new: func() *scyllav1alpha1.ScyllaDBDatacenter {
 				sdc := newValidScyllaDBDatacenter()
 				sdc.Spec.Racks[0].RackTemplate.ScyllaDB.Storage.Capacity = "123Gi"
 				return sdc
 			}(),
+			expectedErrorList:   nil,
+			expectedErrorString: "",
+		},
+		{
+			name: "rackStorage capacity decreased",
+			old: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.Racks[0].RackTemplate.ScyllaDB.Storage.Capacity = "123Gi"
+				return sdc
+			}(),
+			new: newValidScyllaDBDatacenter(),
+			expectedErrorList: field.ErrorList{
+				&field.Error{Type: field.ErrorTypeForbidden, Field: "spec.racks[0].scyllaDB.storage.capacity", BadValue: "", Detail: "storage capacity can only be increased"},
+			},
+			expectedErrorString: "spec.racks[0].scyllaDB.storage.capacity: Forbidden: storage capacity can only be increased",
+		},
+		{
+			name: "rackStorage storageClassName changed",
+			old:  newValidScyllaDBDatacenter(),
+			new: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newValidScyllaDBDatacenter()
+				sdc.Spec.Racks[0].RackTemplate.ScyllaDB.Storage.StorageClassName = pointer.Ptr("foo")
+				return sdc
+			}(),
 			expectedErrorList: field.ErrorList{
 				&field.Error{Type: field.ErrorTypeForbidden, Field: "spec.racks[0].scyllaDB.storage", BadValue: "", Detail: "changes in storage are currently not supported"},
 			},
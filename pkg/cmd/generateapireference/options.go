@@ -13,6 +13,7 @@ type GenerateAPIRefsOptions struct {
 	TemplatesDir                  string
 	OutputDir                     string
 	Overwrite                     bool
+	SchemaIndexFile               string
 }
 
 func NewGenerateAPIRefsOptions() *GenerateAPIRefsOptions {
@@ -25,6 +26,7 @@ func NewGenerateAPIRefsOptions() *GenerateAPIRefsOptions {
 func (o *GenerateAPIRefsOptions) AddFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.TemplatesDir, "templates-dir", "", o.TemplatesDir, "A directory containing docs templates.")
 	cmd.PersistentFlags().StringVarP(&o.OutputDir, "output-dir", "", o.OutputDir, "A directory where the generated files should be stored.")
+	cmd.PersistentFlags().StringVarP(&o.SchemaIndexFile, "schema-index-file", "", o.SchemaIndexFile, "A file where a machine-readable JSON index of the API schema should be written. No index is written when empty.")
 	cmd.PersistentFlags().BoolVarP(&o.Overwrite, "overwrite", "", o.Overwrite, "Allows writing to output dir that already contains data. Existing files will be overwritten.")
 }
 
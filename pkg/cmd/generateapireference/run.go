@@ -3,8 +3,10 @@ package generateapireference
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -28,15 +30,17 @@ const (
 )
 
 type ResourceInfo struct {
-	APIVersion       string
-	Group            string
-	Version          string
-	Names            apiextensionsv1.CustomResourceDefinitionNames
-	Scope            apiextensionsv1.ResourceScope
-	Served           bool
-	Storage          bool
-	Property         apiextensionsv1.JSONSchemaProps
-	NestedProperties map[string]apiextensionsv1.JSONSchemaProps
+	APIVersion         string
+	Group              string
+	Version            string
+	Names              apiextensionsv1.CustomResourceDefinitionNames
+	Scope              apiextensionsv1.ResourceScope
+	Served             bool
+	Storage            bool
+	Deprecated         bool
+	DeprecationWarning string
+	Property           apiextensionsv1.JSONSchemaProps
+	NestedProperties   map[string]apiextensionsv1.JSONSchemaProps
 }
 
 func indexNestedItems(arrayProps apiextensionsv1.JSONSchemaProps, key string, accumulator *map[string]apiextensionsv1.JSONSchemaProps) {
@@ -115,6 +119,24 @@ func foldMultiline(s string) string {
 	return strings.TrimSpace(strings.ReplaceAll(s, "\n", " "))
 }
 
+// isDeprecatedDescription reports whether the description carries a deprecation notice,
+// either as a "Deprecated:" paragraph, following the Go convention, or as a statement that the field is deprecated.
+func isDeprecatedDescription(description string) bool {
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Deprecated:") {
+			return true
+		}
+
+		lowerLine := strings.ToLower(line)
+		if strings.Contains(lowerLine, "this field is deprecated") || strings.HasPrefix(lowerLine, "deprecated.") {
+			return true
+		}
+	}
+
+	return false
+}
+
 var templateFuncs = template.FuncMap{
 	"indentNext":    assets.IndentNext,
 	"repeat":        assets.Repeat,
@@ -123,6 +145,7 @@ var templateFuncs = template.FuncMap{
 	"ensurePrefix":  ensurePrefix,
 	"objectLink":    getObjectLink,
 	"foldMultiline": foldMultiline,
+	"isDeprecated":  isDeprecatedDescription,
 }
 
 func (o *GenerateAPIRefsOptions) Run(streams genericclioptions.IOStreams, cmd *cobra.Command) error {
@@ -188,16 +211,22 @@ func (o *GenerateAPIRefsOptions) run(ctx context.Context, streams genericcliopti
 				groups[crd.Spec.Group] = []*ResourceInfo{}
 			}
 			gv := metav1.GroupVersion{Group: crd.Spec.Group, Version: version.Name}
+			var deprecationWarning string
+			if version.DeprecationWarning != nil {
+				deprecationWarning = *version.DeprecationWarning
+			}
 			groups[crd.Spec.Group] = append(groups[crd.Spec.Group], &ResourceInfo{
-				APIVersion:       gv.String(),
-				Group:            gv.Group,
-				Version:          gv.Version,
-				Names:            crd.Spec.Names,
-				Scope:            crd.Spec.Scope,
-				Served:           version.Served,
-				Storage:          version.Storage,
-				Property:         *version.Schema.OpenAPIV3Schema,
-				NestedProperties: IndexNestedProps(version.Schema.OpenAPIV3Schema.Properties),
+				APIVersion:         gv.String(),
+				Group:              gv.Group,
+				Version:            gv.Version,
+				Names:              crd.Spec.Names,
+				Scope:              crd.Spec.Scope,
+				Served:             version.Served,
+				Storage:            version.Storage,
+				Deprecated:         version.Deprecated,
+				DeprecationWarning: deprecationWarning,
+				Property:           *version.Schema.OpenAPIV3Schema,
+				NestedProperties:   IndexNestedProps(version.Schema.OpenAPIV3Schema.Properties),
 			})
 		}
 	}
@@ -220,7 +249,10 @@ func (o *GenerateAPIRefsOptions) run(ctx context.Context, streams genericcliopti
 
 	klog.V(1).InfoS("Generating templates")
 
-	for group, resourceInfos := range groups {
+	// Templates range over maps in the order of sorted keys, so only the groups have to be sorted
+	// to make the output and the order it's written in stable across runs.
+	for _, group := range slices.Sorted(maps.Keys(groups)) {
+		resourceInfos := groups[group]
 		groupDir := filepath.Join(o.OutputDir, group)
 		err := os.Mkdir(groupDir, 0777)
 		if err == nil {
@@ -258,5 +290,18 @@ func (o *GenerateAPIRefsOptions) run(ctx context.Context, streams genericcliopti
 		}
 	}
 
+	if len(o.SchemaIndexFile) != 0 {
+		var resourceInfos []*ResourceInfo
+		for _, group := range slices.Sorted(maps.Keys(groups)) {
+			resourceInfos = append(resourceInfos, groups[group]...)
+		}
+
+		err = writeSchemaIndex(o.SchemaIndexFile, resourceInfos)
+		if err != nil {
+			return err
+		}
+		klog.V(2).InfoS("Created schema index file", "Path", o.SchemaIndexFile)
+	}
+
 	return nil
 }
@@ -1,9 +1,14 @@
 package generateapireference
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/genericclioptions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 )
@@ -133,3 +138,193 @@ func TestIndexNestedProps(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDeprecatedDescription(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		description string
+		expected    bool
+	}{
+		{
+			name:        "regular description",
+			description: "port is the port number.",
+			expected:    false,
+		},
+		{
+			name:        "go style deprecation paragraph",
+			description: "port is the port number.\nDeprecated: `port` is deprecated and may be ignored in the future.",
+			expected:    true,
+		},
+		{
+			name:        "deprecation statement",
+			description: "interval is the task interval.\nThis field is deprecated, please use cron instead.",
+			expected:    true,
+		},
+		{
+			name:        "mention of a deprecated value isn't a deprecation notice",
+			description: "mode selects the mode. The legacy mode is deprecated in favour of the new one.",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := isDeprecatedDescription(tc.description)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerateAPIRefsOptions_runDeprecatedVersion(t *testing.T) {
+	t.Parallel()
+
+	const crd = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    listKind: WidgetList
+    plural: widgets
+    singular: widget
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    deprecated: true
+    deprecationWarning: example.com/v1alpha1 Widget is deprecated; use example.com/v1 Widget
+    schema:
+      openAPIV3Schema:
+        description: Widget is an example.
+        type: object
+        properties:
+          spec:
+            description: spec holds the specification.
+            type: object
+            properties:
+              size:
+                description: |-
+                  size is the widget size.
+                  Deprecated: size is ignored.
+                type: integer
+              color:
+                description: color is the widget color.
+                type: string
+`
+
+	tmpDir := t.TempDir()
+	crdPath := filepath.Join(tmpDir, "crd.yaml")
+	err := os.WriteFile(crdPath, []byte(crd), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := filepath.Join(tmpDir, "output")
+	o := &GenerateAPIRefsOptions{
+		CustomResourceDefinitionPaths: []string{crdPath},
+		TemplatesDir:                  "../../../docs/source/api-reference/templates",
+		OutputDir:                     outputDir,
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	err = o.run(ctx, genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "example.com", "widgets.rst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+
+	for _, expected := range []string{
+		"| **Deprecated**: true\n",
+		".. warning::\n   example.com/v1alpha1 Widget is deprecated; use example.com/v1 Widget\n",
+		"   * - size :bdg-warning:`Deprecated`\n",
+		"   * - color\n",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("expected rendered file to contain %q, got:\n%s", expected, got)
+		}
+	}
+}
+
+func TestGenerateAPIRefsOptions_runIsStable(t *testing.T) {
+	t.Parallel()
+
+	crdPaths, err := filepath.Glob("../../api/*/*/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crdPaths) == 0 {
+		t.Fatal("no CRD files found")
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	generate := func() map[string]string {
+		outputDir := filepath.Join(t.TempDir(), "output")
+		o := &GenerateAPIRefsOptions{
+			CustomResourceDefinitionPaths: crdPaths,
+			TemplatesDir:                  "../../../docs/source/api-reference/templates",
+			OutputDir:                     outputDir,
+		}
+
+		err := o.run(ctx, genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files := map[string]string{}
+		err = filepath.WalkDir(outputDir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(outputDir, path)
+			if err != nil {
+				return err
+			}
+			files[relPath] = string(data)
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return files
+	}
+
+	first := generate()
+	second := generate()
+
+	if len(first) == 0 {
+		t.Fatal("no files were generated")
+	}
+
+	if !apiequality.Semantic.DeepEqual(first, second) {
+		t.Errorf("output of subsequent runs differs:\n%s", cmp.Diff(first, second))
+	}
+}
@@ -0,0 +1,132 @@
+package generateapireference
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// SchemaIndex is a machine-readable index of the API schema, meant to be consumed by other tools.
+type SchemaIndex struct {
+	Resources []SchemaIndexResource `json:"resources"`
+}
+
+type SchemaIndexResource struct {
+	APIVersion string                          `json:"apiVersion"`
+	Kind       string                          `json:"kind"`
+	Scope      apiextensionsv1.ResourceScope   `json:"scope"`
+	Deprecated bool                            `json:"deprecated,omitempty"`
+	Fields     map[string]SchemaIndexFieldInfo `json:"fields"`
+}
+
+// SchemaIndexFieldInfo describes a single field along with the constraints the schema puts on its values.
+type SchemaIndexFieldInfo struct {
+	Type             string                 `json:"type,omitempty"`
+	Format           string                 `json:"format,omitempty"`
+	Required         bool                   `json:"required"`
+	Deprecated       bool                   `json:"deprecated,omitempty"`
+	Nullable         bool                   `json:"nullable,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Default          *apiextensionsv1.JSON  `json:"default,omitempty"`
+	Enum             []apiextensionsv1.JSON `json:"enum,omitempty"`
+	Minimum          *float64               `json:"minimum,omitempty"`
+	ExclusiveMinimum bool                   `json:"exclusiveMinimum,omitempty"`
+	Maximum          *float64               `json:"maximum,omitempty"`
+	ExclusiveMaximum bool                   `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int64                 `json:"minLength,omitempty"`
+	MaxLength        *int64                 `json:"maxLength,omitempty"`
+	Pattern          string                 `json:"pattern,omitempty"`
+	MinItems         *int64                 `json:"minItems,omitempty"`
+	MaxItems         *int64                 `json:"maxItems,omitempty"`
+	UniqueItems      bool                   `json:"uniqueItems,omitempty"`
+}
+
+func makeSchemaIndexFieldInfo(props apiextensionsv1.JSONSchemaProps, required bool) SchemaIndexFieldInfo {
+	return SchemaIndexFieldInfo{
+		Type:             props.Type,
+		Format:           props.Format,
+		Required:         required,
+		Deprecated:       isDeprecatedDescription(props.Description),
+		Nullable:         props.Nullable,
+		Description:      props.Description,
+		Default:          props.Default,
+		Enum:             props.Enum,
+		Minimum:          props.Minimum,
+		ExclusiveMinimum: props.ExclusiveMinimum,
+		Maximum:          props.Maximum,
+		ExclusiveMaximum: props.ExclusiveMaximum,
+		MinLength:        props.MinLength,
+		MaxLength:        props.MaxLength,
+		Pattern:          props.Pattern,
+		MinItems:         props.MinItems,
+		MaxItems:         props.MaxItems,
+		UniqueItems:      props.UniqueItems,
+	}
+}
+
+// indexSchemaFields adds every field of the object to the accumulator, keyed the same way as IndexNestedProps,
+// except that fields of array items are nested under a "[]" suffix of the array field.
+func indexSchemaFields(objectProps apiextensionsv1.JSONSchemaProps, objectKey string, accumulator map[string]SchemaIndexFieldInfo) {
+	for name, props := range objectProps.Properties {
+		key := fmt.Sprintf("%s.%s", objectKey, name)
+		accumulator[key] = makeSchemaIndexFieldInfo(props, slices.Contains(objectProps.Required, name))
+		indexSchemaChildren(props, key, accumulator)
+	}
+}
+
+func indexSchemaChildren(props apiextensionsv1.JSONSchemaProps, key string, accumulator map[string]SchemaIndexFieldInfo) {
+	switch props.Type {
+	case "object":
+		indexSchemaFields(props, key, accumulator)
+	case "array":
+		if props.Items != nil && props.Items.Schema != nil {
+			indexSchemaChildren(*props.Items.Schema, key+"[]", accumulator)
+		}
+	default:
+	}
+}
+
+// MakeSchemaIndex indexes the fields of all resources, sorted by their API version and kind.
+func MakeSchemaIndex(resourceInfos []*ResourceInfo) *SchemaIndex {
+	index := &SchemaIndex{
+		Resources: make([]SchemaIndexResource, 0, len(resourceInfos)),
+	}
+
+	for _, ri := range resourceInfos {
+		fields := map[string]SchemaIndexFieldInfo{}
+		indexSchemaFields(ri.Property, "", fields)
+
+		index.Resources = append(index.Resources, SchemaIndexResource{
+			APIVersion: ri.APIVersion,
+			Kind:       ri.Names.Kind,
+			Scope:      ri.Scope,
+			Deprecated: ri.Deprecated,
+			Fields:     fields,
+		})
+	}
+
+	slices.SortFunc(index.Resources, func(a, b SchemaIndexResource) int {
+		return cmp.Or(cmp.Compare(a.APIVersion, b.APIVersion), cmp.Compare(a.Kind, b.Kind))
+	})
+
+	return index
+}
+
+func writeSchemaIndex(path string, resourceInfos []*ResourceInfo) error {
+	data, err := json.MarshalIndent(MakeSchemaIndex(resourceInfos), "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't marshal schema index: %w", err)
+	}
+	data = append(data, '\n')
+
+	err = os.WriteFile(path, data, 0666)
+	if err != nil {
+		return fmt.Errorf("can't write file %q: %w", path, err)
+	}
+
+	return nil
+}
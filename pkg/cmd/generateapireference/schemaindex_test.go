@@ -0,0 +1,174 @@
+package generateapireference
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/genericclioptions"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestGenerateAPIRefsOptions_runSchemaIndex(t *testing.T) {
+	t.Parallel()
+
+	const crd = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    listKind: WidgetList
+    plural: widgets
+    singular: widget
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        description: Widget is an example.
+        type: object
+        required:
+        - spec
+        properties:
+          spec:
+            description: spec holds the specification.
+            type: object
+            required:
+            - size
+            properties:
+              size:
+                description: size is the widget size.
+                type: integer
+                format: int32
+                minimum: 1
+                maximum: 10
+              parts:
+                description: parts lists the widget parts.
+                type: array
+                maxItems: 3
+                items:
+                  type: object
+                  properties:
+                    name:
+                      description: name is the part name.
+                      type: string
+                      pattern: ^[a-z]+$
+`
+
+	tmpDir := t.TempDir()
+	crdPath := filepath.Join(tmpDir, "crd.yaml")
+	err := os.WriteFile(crdPath, []byte(crd), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaIndexFile := filepath.Join(tmpDir, "schema-index.json")
+	o := &GenerateAPIRefsOptions{
+		CustomResourceDefinitionPaths: []string{crdPath},
+		TemplatesDir:                  "../../../docs/source/api-reference/templates",
+		OutputDir:                     filepath.Join(tmpDir, "output"),
+		SchemaIndexFile:               schemaIndexFile,
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	err = o.run(ctx, genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(schemaIndexFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := &SchemaIndex{}
+	err = json.Unmarshal(data, index)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(index.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(index.Resources))
+	}
+	resource := index.Resources[0]
+
+	if resource.APIVersion != "example.com/v1" || resource.Kind != "Widget" {
+		t.Errorf("expected resource example.com/v1 Widget, got %s %s", resource.APIVersion, resource.Kind)
+	}
+
+	expectedFields := map[string]SchemaIndexFieldInfo{
+		".spec": {
+			Type:        "object",
+			Required:    true,
+			Description: "spec holds the specification.",
+		},
+		".spec.size": {
+			Type:        "integer",
+			Format:      "int32",
+			Required:    true,
+			Description: "size is the widget size.",
+			Minimum:     pointer.Ptr[float64](1),
+			Maximum:     pointer.Ptr[float64](10),
+		},
+		".spec.parts": {
+			Type:        "array",
+			Required:    false,
+			Description: "parts lists the widget parts.",
+			MaxItems:    pointer.Ptr[int64](3),
+		},
+		".spec.parts[].name": {
+			Type:        "string",
+			Required:    false,
+			Description: "name is the part name.",
+			Pattern:     "^[a-z]+$",
+		},
+	}
+	if !apiequality.Semantic.DeepEqual(resource.Fields, expectedFields) {
+		t.Errorf("expected and got fields differ:\n%s", cmp.Diff(expectedFields, resource.Fields))
+	}
+}
+
+func TestMakeSchemaIndexIsSorted(t *testing.T) {
+	t.Parallel()
+
+	newResourceInfo := func(apiVersion, kind string) *ResourceInfo {
+		return &ResourceInfo{
+			APIVersion: apiVersion,
+			Names:      apiextensionsv1.CustomResourceDefinitionNames{Kind: kind},
+		}
+	}
+
+	index := MakeSchemaIndex([]*ResourceInfo{
+		newResourceInfo("example.com/v1", "B"),
+		newResourceInfo("example.com/v1", "A"),
+		newResourceInfo("example.com/v1alpha1", "A"),
+		newResourceInfo("alpha.example.com/v1", "C"),
+	})
+
+	var got []string
+	for _, r := range index.Resources {
+		got = append(got, r.APIVersion+" "+r.Kind)
+	}
+
+	expected := []string{
+		"alpha.example.com/v1 C",
+		"example.com/v1 A",
+		"example.com/v1 B",
+		"example.com/v1alpha1 A",
+	}
+	if !apiequality.Semantic.DeepEqual(got, expected) {
+		t.Errorf("expected and got resources differ:\n%s", cmp.Diff(expected, got))
+	}
+}
@@ -24,27 +24,33 @@ import (
 	"github.com/scylladb/scylla-operator/pkg/controller/scylladbmonitoring"
 	"github.com/scylladb/scylla-operator/pkg/controller/scyllaoperatorconfig"
 	"github.com/scylladb/scylla-operator/pkg/crypto"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
 	monitoringversionedclient "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/clientset/versioned"
 	monitoringinformers "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/informers/externalversions"
+	monitoringv1informers "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/informers/externalversions/monitoring/v1"
 	"github.com/scylladb/scylla-operator/pkg/genericclioptions"
 	"github.com/scylladb/scylla-operator/pkg/leaderelection"
 	"github.com/scylladb/scylla-operator/pkg/naming"
 	remoteclient "github.com/scylladb/scylla-operator/pkg/remoteclient/client"
 	remoteinformers "github.com/scylladb/scylla-operator/pkg/remoteclient/informers"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
 	"github.com/scylladb/scylla-operator/pkg/signals"
 	"github.com/scylladb/scylla-operator/pkg/version"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	apimachineryutilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	cliflag "k8s.io/component-base/cli/flag"
@@ -212,6 +218,11 @@ func (o *OperatorOptions) Complete(cmd *cobra.Command) error {
 		return err
 	}
 
+	// Warnings returned to applies validating fields with warnings are reported as events, the rest is logged.
+	warningHandler := resourceapply.NewWarningHandler(rest.WarningLogger{})
+	o.RestConfig.WarningHandlerWithContext = warningHandler
+	o.ProtoConfig.WarningHandlerWithContext = warningHandler
+
 	o.kubeClient, err = kubernetes.NewForConfig(o.ProtoConfig)
 	if err != nil {
 		return fmt.Errorf("can't build kubernetes clientset: %w", err)
@@ -347,19 +358,45 @@ func (o *OperatorOptions) run(ctx context.Context, streams genericclioptions.IOS
 
 	monitoringInformers := monitoringinformers.NewSharedInformerFactory(o.monitoringClient, resyncPeriod)
 
+	// ScyllaDBDatacenter ServiceMonitors and PodMonitors are opt-in, so the prometheus-operator CRDs aren't required for it to run.
+	monitoringResources, err := o.getAvailableMonitoringResources()
+	if err != nil {
+		return fmt.Errorf("can't discover monitoring resources: %w", err)
+	}
+
+	var sdcServiceMonitorInformer monitoringv1informers.ServiceMonitorInformer
+	if monitoringResources.Has("servicemonitors") {
+		sdcServiceMonitorInformer = monitoringInformers.Monitoring().V1().ServiceMonitors()
+	} else {
+		klog.InfoS("ServiceMonitor CRD isn't installed, ScyllaDBDatacenter ServiceMonitors are disabled until the operator restarts")
+	}
+
+	var sdcPodMonitorInformer monitoringv1informers.PodMonitorInformer
+	if monitoringResources.Has("podmonitors") {
+		sdcPodMonitorInformer = monitoringInformers.Monitoring().V1().PodMonitors()
+	} else {
+		klog.InfoS("PodMonitor CRD isn't installed, ScyllaDBDatacenter PodMonitors are disabled until the operator restarts")
+	}
+
 	sdcc, err := scylladbdatacenter.NewController(
 		o.kubeClient,
 		o.scyllaClient.ScyllaV1alpha1(),
+		o.monitoringClient.MonitoringV1(),
 		kubeInformers.Core().V1().Pods(),
 		kubeInformers.Core().V1().Services(),
 		kubeInformers.Core().V1().Secrets(),
 		kubeInformers.Core().V1().ConfigMaps(),
 		kubeInformers.Core().V1().ServiceAccounts(),
+		kubeInformers.Rbac().V1().Roles(),
 		kubeInformers.Rbac().V1().RoleBindings(),
 		kubeInformers.Apps().V1().StatefulSets(),
 		kubeInformers.Policy().V1().PodDisruptionBudgets(),
 		kubeInformers.Networking().V1().Ingresses(),
 		kubeInformers.Batch().V1().Jobs(),
+		kubeInformers.Core().V1().PersistentVolumeClaims(),
+		kubeInformers.Networking().V1().NetworkPolicies(),
+		sdcServiceMonitorInformer,
+		sdcPodMonitorInformer,
 		scyllaInformers.Scylla().V1alpha1().ScyllaDBDatacenters(),
 		o.OperatorImage,
 		o.CQLSIngressPort,
@@ -632,6 +669,26 @@ func (o *OperatorOptions) run(ctx context.Context, streams genericclioptions.IOS
 				}
 			},
 		}),
+		remoteKubernetesInformer.ForResource(&corev1.Node{}, remoteinformers.ClusterListWatch[kubernetes.Interface]{
+			ListFunc: func(client remoteclient.ClusterClientInterface[kubernetes.Interface], cluster, ns string) cache.ListFunc {
+				return func(options metav1.ListOptions) (runtime.Object, error) {
+					clusterClient, err := client.Cluster(cluster)
+					if err != nil {
+						return nil, err
+					}
+					return clusterClient.CoreV1().Nodes().List(ctx, options)
+				}
+			},
+			WatchFunc: func(client remoteclient.ClusterClientInterface[kubernetes.Interface], cluster, ns string) cache.WatchFunc {
+				return func(options metav1.ListOptions) (watch.Interface, error) {
+					clusterClient, err := client.Cluster(cluster)
+					if err != nil {
+						return nil, err
+					}
+					return clusterClient.CoreV1().Nodes().Watch(ctx, options)
+				}
+			},
+		}),
 		remoteOperatorManagedResourcesOnlyInformer.ForResource(&corev1.ConfigMap{}, remoteinformers.ClusterListWatch[kubernetes.Interface]{
 			ListFunc: func(client remoteclient.ClusterClientInterface[kubernetes.Interface], cluster, ns string) cache.ListFunc {
 				return func(options metav1.ListOptions) (runtime.Object, error) {
@@ -827,3 +884,23 @@ func (o *OperatorOptions) run(ctx context.Context, streams genericclioptions.IOS
 
 	return nil
 }
+
+// getAvailableMonitoringResources returns names of the monitoring.coreos.com/v1 resources served by the cluster.
+func (o *OperatorOptions) getAvailableMonitoringResources() (sets.Set[string], error) {
+	resources := sets.New[string]()
+
+	resourceList, err := o.kubeClient.Discovery().ServerResourcesForGroupVersion(monitoringv1.SchemeGroupVersion.String())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return resources, nil
+		}
+
+		return nil, err
+	}
+
+	for _, r := range resourceList.APIResources {
+		resources.Insert(r.Name)
+	}
+
+	return resources, nil
+}
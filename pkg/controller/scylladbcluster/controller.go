@@ -65,6 +65,7 @@ type Controller struct {
 	remoteEndpointSliceLister      remotelister.GenericClusterLister[discoveryv1listers.EndpointSliceLister]
 	remoteEndpointsLister          remotelister.GenericClusterLister[corev1listers.EndpointsLister]
 	remotePodLister                remotelister.GenericClusterLister[corev1listers.PodLister]
+	remoteNodeLister               remotelister.GenericClusterLister[corev1listers.NodeLister]
 	remoteConfigMapLister          remotelister.GenericClusterLister[corev1listers.ConfigMapLister]
 	remoteSecretLister             remotelister.GenericClusterLister[corev1listers.SecretLister]
 
@@ -92,6 +93,7 @@ func NewController(
 	remoteEndpointSliceInformer remoteinformers.GenericClusterInformer,
 	remoteEndpointsInformer remoteinformers.GenericClusterInformer,
 	remotePodInformer remoteinformers.GenericClusterInformer,
+	remoteNodeInformer remoteinformers.GenericClusterInformer,
 	remoteConfigMapInformer remoteinformers.GenericClusterInformer,
 	remoteSecretInformer remoteinformers.GenericClusterInformer,
 ) (*Controller, error) {
@@ -117,6 +119,7 @@ func NewController(
 		remoteEndpointSliceLister:      remotelister.NewClusterLister(discoveryv1listers.NewEndpointSliceLister, remoteEndpointSliceInformer.Indexer().Cluster),
 		remoteEndpointsLister:          remotelister.NewClusterLister(corev1listers.NewEndpointsLister, remoteEndpointsInformer.Indexer().Cluster),
 		remotePodLister:                remotelister.NewClusterLister(corev1listers.NewPodLister, remotePodInformer.Indexer().Cluster),
+		remoteNodeLister:               remotelister.NewClusterLister(corev1listers.NewNodeLister, remoteNodeInformer.Indexer().Cluster),
 		remoteConfigMapLister:          remotelister.NewClusterLister(corev1listers.NewConfigMapLister, remoteConfigMapInformer.Indexer().Cluster),
 		remoteSecretLister:             remotelister.NewClusterLister(corev1listers.NewSecretLister, remoteSecretInformer.Indexer().Cluster),
 
@@ -132,6 +135,7 @@ func NewController(
 			remoteEndpointSliceInformer.Informer().HasSynced,
 			remoteEndpointsInformer.Informer().HasSynced,
 			remotePodInformer.Informer().HasSynced,
+			remoteNodeInformer.Informer().HasSynced,
 			remoteConfigMapInformer.Informer().HasSynced,
 			remoteSecretInformer.Informer().HasSynced,
 		},
@@ -234,6 +238,9 @@ func NewController(
 		},
 	)
 
+	// Remote Nodes are only used to look up the zones of remote Pods. Pods are reconciled when they get scheduled
+	// and nodes don't change zones, so Node events aren't handled.
+
 	remoteConfigMapInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    scc.addRemoteConfigMap,
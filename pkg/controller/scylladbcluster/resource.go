@@ -289,7 +289,7 @@ func MakeRemoteScyllaDBDatacenters(sc *scyllav1alpha1.ScyllaDBCluster, dc *scyll
 	}, nil
 }
 
-func MakeRemoteEndpointSlices(sc *scyllav1alpha1.ScyllaDBCluster, dc *scyllav1alpha1.ScyllaDBClusterDatacenter, remoteNamespace *corev1.Namespace, remoteController metav1.Object, remoteNamespaces map[string]*corev1.Namespace, remoteServiceLister remotelister.GenericClusterLister[corev1listers.ServiceLister], remotePodLister remotelister.GenericClusterLister[corev1listers.PodLister], managingClusterDomain string) ([]metav1.Condition, []*discoveryv1.EndpointSlice, error) {
+func MakeRemoteEndpointSlices(sc *scyllav1alpha1.ScyllaDBCluster, dc *scyllav1alpha1.ScyllaDBClusterDatacenter, remoteNamespace *corev1.Namespace, remoteController metav1.Object, remoteNamespaces map[string]*corev1.Namespace, remoteServiceLister remotelister.GenericClusterLister[corev1listers.ServiceLister], remotePodLister remotelister.GenericClusterLister[corev1listers.PodLister], remoteNodeLister remotelister.GenericClusterLister[corev1listers.NodeLister], managingClusterDomain string) ([]metav1.Condition, []*discoveryv1.EndpointSlice, error) {
 	var progressingConditions []metav1.Condition
 	var remoteEndpointSlices []*discoveryv1.EndpointSlice
 
@@ -298,6 +298,11 @@ func MakeRemoteEndpointSlices(sc *scyllav1alpha1.ScyllaDBCluster, dc *scyllav1al
 		nodeBroadcastType = sc.Spec.ExposeOptions.BroadcastOptions.Nodes.Type
 	}
 
+	topologyAwareHints := false
+	if sc.Spec.ExposeOptions != nil && sc.Spec.ExposeOptions.TopologyAwareHints != nil {
+		topologyAwareHints = *sc.Spec.ExposeOptions.TopologyAwareHints
+	}
+
 	for _, otherDC := range sc.Spec.Datacenters {
 		if dc.Name == otherDC.Name {
 			continue
@@ -375,14 +380,34 @@ func MakeRemoteEndpointSlices(sc *scyllav1alpha1.ScyllaDBCluster, dc *scyllav1al
 				terminating := dcPod.DeletionTimestamp != nil
 				serving := ready && !terminating
 
-				dcEs.Endpoints = append(dcEs.Endpoints, discoveryv1.Endpoint{
+				ep := discoveryv1.Endpoint{
 					Addresses: []string{dcPod.Status.PodIP},
 					Conditions: discoveryv1.EndpointConditions{
 						Ready:       pointer.Ptr(ready),
 						Serving:     pointer.Ptr(serving),
 						Terminating: pointer.Ptr(terminating),
 					},
-				})
+				}
+
+				if topologyAwareHints {
+					zone, err := getPodZone(remoteNodeLister.Cluster(otherDC.RemoteKubernetesClusterName), dcPod)
+					if err != nil {
+						return progressingConditions, nil, fmt.Errorf("can't get zone of Pod %q in %q ScyllaDBCluster %q Datacenter: %w", naming.ObjRef(dcPod), naming.ObjRef(sc), otherDC.Name, err)
+					}
+
+					if len(zone) != 0 {
+						ep.Zone = pointer.Ptr(zone)
+						ep.Hints = &discoveryv1.EndpointHints{
+							ForZones: []discoveryv1.ForZone{
+								{
+									Name: zone,
+								},
+							},
+						}
+					}
+				}
+
+				dcEs.Endpoints = append(dcEs.Endpoints, ep)
 			}
 
 		case scyllav1alpha1.BroadcastAddressTypeServiceLoadBalancerIngress:
@@ -442,6 +467,23 @@ func MakeRemoteEndpointSlices(sc *scyllav1alpha1.ScyllaDBCluster, dc *scyllav1al
 	return progressingConditions, remoteEndpointSlices, nil
 }
 
+// getPodZone returns the zone of the node the Pod is scheduled on, or an empty string if it's not known.
+func getPodZone(nodeLister corev1listers.NodeLister, pod *corev1.Pod) (string, error) {
+	if len(pod.Spec.NodeName) == 0 {
+		return "", nil
+	}
+
+	node, err := nodeLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("can't get Node %q: %w", pod.Spec.NodeName, err)
+	}
+
+	return node.Labels[corev1.LabelTopologyZone], nil
+}
+
 func mergeScyllaV1Alpha1Placement(placementGetters ...func() *scyllav1alpha1.Placement) *scyllav1alpha1.Placement {
 	placementGetters = oslices.FilterOut(placementGetters, func(getter func() *scyllav1alpha1.Placement) bool {
 		return getter() == nil
@@ -1,16 +1,25 @@
 package scylladbcluster
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
 	"github.com/scylladb/scylla-operator/pkg/pointer"
+	remotelister "github.com/scylladb/scylla-operator/pkg/remoteclient/lister"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -2398,3 +2407,247 @@ func TestMakeScyllaDBDatacenters(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeRemoteEndpointSlicesTopologyAwareHints(t *testing.T) {
+	t.Parallel()
+
+	newCluster := func(topologyAwareHints *bool) *scyllav1alpha1.ScyllaDBCluster {
+		return &scyllav1alpha1.ScyllaDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "scylla",
+			},
+			Spec: scyllav1alpha1.ScyllaDBClusterSpec{
+				Datacenters: []scyllav1alpha1.ScyllaDBClusterDatacenter{
+					{
+						Name:                        "dc1",
+						RemoteKubernetesClusterName: "dc1-rkc",
+					},
+					{
+						Name:                        "dc2",
+						RemoteKubernetesClusterName: "dc2-rkc",
+					},
+				},
+				ExposeOptions: &scyllav1alpha1.ScyllaDBClusterExposeOptions{
+					TopologyAwareHints: topologyAwareHints,
+				},
+			},
+		}
+	}
+
+	newPod := func(name, ip, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "scylla-dc2",
+				Labels: map[string]string{
+					"scylla/cluster":               "cluster-dc2",
+					"app":                          "scylla",
+					"app.kubernetes.io/name":       "scylla",
+					"app.kubernetes.io/managed-by": "scylla-operator",
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	newNode := func(name, zone string) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{},
+			},
+		}
+		if len(zone) != 0 {
+			node.Labels[corev1.LabelTopologyZone] = zone
+		}
+		return node
+	}
+
+	newEndpoint := func(ip string, zone string) discoveryv1.Endpoint {
+		ep := discoveryv1.Endpoint{
+			Addresses: []string{ip},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       pointer.Ptr(true),
+				Serving:     pointer.Ptr(true),
+				Terminating: pointer.Ptr(false),
+			},
+		}
+		if len(zone) != 0 {
+			ep.Zone = pointer.Ptr(zone)
+			ep.Hints = &discoveryv1.EndpointHints{
+				ForZones: []discoveryv1.ForZone{
+					{
+						Name: zone,
+					},
+				},
+			}
+		}
+		return ep
+	}
+
+	pods := []*corev1.Pod{
+		newPod("cluster-dc2-rack-1", "10.0.0.2", "node-b"),
+		newPod("cluster-dc2-rack-0", "10.0.0.1", "node-a"),
+		newPod("cluster-dc2-rack-2", "10.0.0.3", "node-without-zone"),
+		newPod("cluster-dc2-rack-3", "10.0.0.4", "missing-node"),
+	}
+	nodes := []*corev1.Node{
+		newNode("node-a", "zone-a"),
+		newNode("node-b", "zone-b"),
+		newNode("node-without-zone", ""),
+	}
+
+	tt := []struct {
+		name              string
+		cluster           *scyllav1alpha1.ScyllaDBCluster
+		expectedEndpoints []discoveryv1.Endpoint
+	}{
+		{
+			name:    "endpoints aren't hinted by default",
+			cluster: newCluster(nil),
+			expectedEndpoints: []discoveryv1.Endpoint{
+				newEndpoint("10.0.0.1", ""),
+				newEndpoint("10.0.0.2", ""),
+				newEndpoint("10.0.0.3", ""),
+				newEndpoint("10.0.0.4", ""),
+			},
+		},
+		{
+			name:    "endpoints aren't hinted when topology aware hints are disabled",
+			cluster: newCluster(pointer.Ptr(false)),
+			expectedEndpoints: []discoveryv1.Endpoint{
+				newEndpoint("10.0.0.1", ""),
+				newEndpoint("10.0.0.2", ""),
+				newEndpoint("10.0.0.3", ""),
+				newEndpoint("10.0.0.4", ""),
+			},
+		},
+		{
+			name:    "endpoints are hinted for the zones of their nodes when topology aware hints are enabled",
+			cluster: newCluster(pointer.Ptr(true)),
+			expectedEndpoints: []discoveryv1.Endpoint{
+				newEndpoint("10.0.0.1", "zone-a"),
+				newEndpoint("10.0.0.2", "zone-b"),
+				newEndpoint("10.0.0.3", ""),
+				newEndpoint("10.0.0.4", ""),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithCancel(context.Background())
+			defer ctxCancel()
+
+			podIndexers := map[string]cache.Indexer{}
+			nodeIndexers := map[string]cache.Indexer{}
+			for _, cluster := range []string{"dc1-rkc", "dc2-rkc"} {
+				podIndexers[cluster] = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+				nodeIndexers[cluster] = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			}
+			for _, pod := range pods {
+				err := podIndexers["dc2-rkc"].Add(pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, node := range nodes {
+				err := nodeIndexers["dc2-rkc"].Add(node)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			remotePodLister := remotelister.NewClusterLister(corev1listers.NewPodLister, func(cluster string) cache.Indexer {
+				return podIndexers[cluster]
+			})
+			remoteNodeLister := remotelister.NewClusterLister(corev1listers.NewNodeLister, func(cluster string) cache.Indexer {
+				return nodeIndexers[cluster]
+			})
+
+			remoteNamespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "scylla-dc1",
+				},
+			}
+			remoteNamespaces := map[string]*corev1.Namespace{
+				"dc1-rkc": remoteNamespace,
+				"dc2-rkc": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "scylla-dc2",
+					},
+				},
+			}
+			remoteController := &scyllav1alpha1.RemoteOwner{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster-abc",
+					Namespace: "scylla-dc1",
+					UID:       "1234",
+				},
+			}
+
+			kubeClient := kubefake.NewSimpleClientset()
+			endpointSliceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			endpointSliceLister := discoveryv1listers.NewEndpointSliceLister(endpointSliceIndexer)
+
+			// The second round reconciles the same state and must not change anything.
+			for i := range 2 {
+				progressingConditions, endpointSlices, err := MakeRemoteEndpointSlices(
+					tc.cluster,
+					&tc.cluster.Spec.Datacenters[0],
+					remoteNamespace,
+					remoteController,
+					remoteNamespaces,
+					remotelister.NewClusterLister(corev1listers.NewServiceLister, func(string) cache.Indexer {
+						return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+					}),
+					remotePodLister,
+					remoteNodeLister,
+					testClusterDomain,
+				)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(progressingConditions) != 0 {
+					t.Errorf("expected no progressing conditions, got %v", progressingConditions)
+				}
+				if len(endpointSlices) != 1 {
+					t.Fatalf("expected 1 EndpointSlice, got %d", len(endpointSlices))
+				}
+
+				if !equality.Semantic.DeepEqual(endpointSlices[0].Endpoints, tc.expectedEndpoints) {
+					t.Errorf("expected and got endpoints differ:\n%s", cmp.Diff(tc.expectedEndpoints, endpointSlices[0].Endpoints))
+				}
+
+				es, changed, err := resourceapply.ApplyEndpointSlice(ctx, kubeClient.DiscoveryV1(), endpointSliceLister, record.NewFakeRecorder(10), endpointSlices[0], resourceapply.ApplyOptions{})
+				if err != nil {
+					t.Fatalf("can't apply EndpointSlice: %v", err)
+				}
+
+				expectedChanged := i == 0
+				if changed != expectedChanged {
+					t.Errorf("expected changed %t in round %d, got %t", expectedChanged, i, changed)
+				}
+
+				err = endpointSliceIndexer.Add(es)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,25 +1,37 @@
 package scylladbdatacenter
 
 const (
-	serviceAccountControllerProgressingCondition = "ServiceAccountControllerProgressing"
-	serviceAccountControllerDegradedCondition    = "ServiceAccountControllerDegraded"
-	roleBindingControllerProgressingCondition    = "RoleBindingControllerProgressing"
-	roleBindingControllerDegradedCondition       = "RoleBindingControllerDegraded"
-	agentTokenControllerProgressingCondition     = "AgentTokenControllerProgressing"
-	agentTokenControllerDegradedCondition        = "AgentTokenControllerDegraded"
-	certControllerProgressingCondition           = "CertControllerProgressing"
-	certControllerDegradedCondition              = "CertControllerDegraded"
-	statefulSetControllerAvailableCondition      = "StatefulSetControllerAvailable"
-	statefulSetControllerProgressingCondition    = "StatefulSetControllerProgressing"
-	statefulSetControllerDegradedCondition       = "StatefulSetControllerDegraded"
-	serviceControllerProgressingCondition        = "ServiceControllerProgressing"
-	serviceControllerDegradedCondition           = "ServiceControllerDegraded"
-	pdbControllerProgressingCondition            = "PDBControllerProgressing"
-	pdbControllerDegradedCondition               = "PDBControllerDegraded"
-	ingressControllerProgressingCondition        = "IngressControllerProgressing"
-	ingressControllerDegradedCondition           = "IngressControllerDegraded"
-	jobControllerProgressingCondition            = "JobControllerProgressing"
-	jobControllerDegradedCondition               = "JobControllerDegraded"
-	configControllerProgressingCondition         = "ConfigControllerProgressing"
-	configControllerDegradedCondition            = "ConfigControllerDegraded"
+	serviceAccountControllerProgressingCondition  = "ServiceAccountControllerProgressing"
+	serviceAccountControllerDegradedCondition     = "ServiceAccountControllerDegraded"
+	roleBindingControllerProgressingCondition     = "RoleBindingControllerProgressing"
+	roleBindingControllerDegradedCondition        = "RoleBindingControllerDegraded"
+	agentTokenControllerProgressingCondition      = "AgentTokenControllerProgressing"
+	agentTokenControllerDegradedCondition         = "AgentTokenControllerDegraded"
+	certControllerProgressingCondition            = "CertControllerProgressing"
+	certControllerDegradedCondition               = "CertControllerDegraded"
+	statefulSetControllerAvailableCondition       = "StatefulSetControllerAvailable"
+	statefulSetControllerProgressingCondition     = "StatefulSetControllerProgressing"
+	statefulSetControllerDegradedCondition        = "StatefulSetControllerDegraded"
+	serviceControllerProgressingCondition         = "ServiceControllerProgressing"
+	serviceControllerDegradedCondition            = "ServiceControllerDegraded"
+	pdbControllerProgressingCondition             = "PDBControllerProgressing"
+	pdbControllerDegradedCondition                = "PDBControllerDegraded"
+	ingressControllerProgressingCondition         = "IngressControllerProgressing"
+	ingressControllerDegradedCondition            = "IngressControllerDegraded"
+	jobControllerProgressingCondition             = "JobControllerProgressing"
+	jobControllerDegradedCondition                = "JobControllerDegraded"
+	configControllerProgressingCondition          = "ConfigControllerProgressing"
+	configControllerDegradedCondition             = "ConfigControllerDegraded"
+	pvcControllerProgressingCondition             = "PVCControllerProgressing"
+	pvcControllerDegradedCondition                = "PVCControllerDegraded"
+	networkPolicyControllerProgressingCondition   = "NetworkPolicyControllerProgressing"
+	networkPolicyControllerDegradedCondition      = "NetworkPolicyControllerDegraded"
+	serviceMonitorControllerProgressingCondition  = "ServiceMonitorControllerProgressing"
+	serviceMonitorControllerDegradedCondition     = "ServiceMonitorControllerDegraded"
+	podMonitorControllerProgressingCondition      = "PodMonitorControllerProgressing"
+	podMonitorControllerDegradedCondition         = "PodMonitorControllerDegraded"
+	deletionCleanupControllerProgressingCondition = "DeletionCleanupControllerProgressing"
+	deletionCleanupControllerDegradedCondition    = "DeletionCleanupControllerDegraded"
+	imagePullSecretControllerProgressingCondition = "ImagePullSecretControllerProgressing"
+	imagePullSecretControllerDegradedCondition    = "ImagePullSecretControllerDegraded"
 )
@@ -12,7 +12,12 @@ import (
 	scyllav1alpha1listers "github.com/scylladb/scylla-operator/pkg/client/scylla/listers/scylla/v1alpha1"
 	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
 	"github.com/scylladb/scylla-operator/pkg/crypto"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
+	monitoringv1client "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/clientset/versioned/typed/monitoring/v1"
+	monitoringv1informers "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/informers/externalversions/monitoring/v1"
+	monitoringv1listers "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/listers/monitoring/v1"
 	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
 	"github.com/scylladb/scylla-operator/pkg/scheme"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -61,20 +66,26 @@ type Controller struct {
 	operatorImage   string
 	cqlsIngressPort int
 
-	kubeClient   kubernetes.Interface
-	scyllaClient scyllav1alpha1client.ScyllaV1alpha1Interface
+	kubeClient       kubernetes.Interface
+	scyllaClient     scyllav1alpha1client.ScyllaV1alpha1Interface
+	monitoringClient monitoringv1client.MonitoringV1Interface
 
 	podLister                corev1listers.PodLister
 	serviceLister            corev1listers.ServiceLister
 	secretLister             corev1listers.SecretLister
 	configMapLister          corev1listers.ConfigMapLister
 	serviceAccountLister     corev1listers.ServiceAccountLister
+	roleLister               rbacv1listers.RoleLister
 	roleBindingLister        rbacv1listers.RoleBindingLister
 	statefulSetLister        appsv1listers.StatefulSetLister
 	pdbLister                policyv1listers.PodDisruptionBudgetLister
 	ingressLister            networkingv1listers.IngressLister
 	scyllaDBDatacenterLister scyllav1alpha1listers.ScyllaDBDatacenterLister
 	jobLister                batchv1listers.JobLister
+	pvcLister                corev1listers.PersistentVolumeClaimLister
+	networkPolicyLister      networkingv1listers.NetworkPolicyLister
+	serviceMonitorLister     monitoringv1listers.ServiceMonitorLister
+	podMonitorLister         monitoringv1listers.PodMonitorLister
 
 	cachesToSync []cache.InformerSynced
 
@@ -89,16 +100,22 @@ type Controller struct {
 func NewController(
 	kubeClient kubernetes.Interface,
 	scyllaClient scyllav1alpha1client.ScyllaV1alpha1Interface,
+	monitoringClient monitoringv1client.MonitoringV1Interface,
 	podInformer corev1informers.PodInformer,
 	serviceInformer corev1informers.ServiceInformer,
 	secretInformer corev1informers.SecretInformer,
 	configMapInformer corev1informers.ConfigMapInformer,
 	serviceAccountInformer corev1informers.ServiceAccountInformer,
+	roleInformer rbacv1informers.RoleInformer,
 	roleBindingInformer rbacv1informers.RoleBindingInformer,
 	statefulSetInformer appsv1informers.StatefulSetInformer,
 	pdbInformer policyv1informers.PodDisruptionBudgetInformer,
 	ingressInformer networkingv1informers.IngressInformer,
 	jobInformer batchv1informers.JobInformer,
+	pvcInformer corev1informers.PersistentVolumeClaimInformer,
+	networkPolicyInformer networkingv1informers.NetworkPolicyInformer,
+	serviceMonitorInformer monitoringv1informers.ServiceMonitorInformer,
+	podMonitorInformer monitoringv1informers.PodMonitorInformer,
 	scyllaDBDatacenterInformer scyllav1alpha1informers.ScyllaDBDatacenterInformer,
 	operatorImage string,
 	cqlsIngressPort int,
@@ -112,20 +129,24 @@ func NewController(
 		operatorImage:   operatorImage,
 		cqlsIngressPort: cqlsIngressPort,
 
-		kubeClient:   kubeClient,
-		scyllaClient: scyllaClient,
+		kubeClient:       kubeClient,
+		scyllaClient:     scyllaClient,
+		monitoringClient: monitoringClient,
 
 		podLister:                podInformer.Lister(),
 		serviceLister:            serviceInformer.Lister(),
 		secretLister:             secretInformer.Lister(),
 		configMapLister:          configMapInformer.Lister(),
 		serviceAccountLister:     serviceAccountInformer.Lister(),
+		roleLister:               roleInformer.Lister(),
 		roleBindingLister:        roleBindingInformer.Lister(),
 		statefulSetLister:        statefulSetInformer.Lister(),
 		pdbLister:                pdbInformer.Lister(),
 		ingressLister:            ingressInformer.Lister(),
 		scyllaDBDatacenterLister: scyllaDBDatacenterInformer.Lister(),
 		jobLister:                jobInformer.Lister(),
+		pvcLister:                pvcInformer.Lister(),
+		networkPolicyLister:      networkPolicyInformer.Lister(),
 
 		cachesToSync: []cache.InformerSynced{
 			podInformer.Informer().HasSynced,
@@ -133,12 +154,15 @@ func NewController(
 			secretInformer.Informer().HasSynced,
 			configMapInformer.Informer().HasSynced,
 			serviceAccountInformer.Informer().HasSynced,
+			roleInformer.Informer().HasSynced,
 			roleBindingInformer.Informer().HasSynced,
 			statefulSetInformer.Informer().HasSynced,
 			pdbInformer.Informer().HasSynced,
 			ingressInformer.Informer().HasSynced,
 			scyllaDBDatacenterInformer.Informer().HasSynced,
 			jobInformer.Informer().HasSynced,
+			pvcInformer.Informer().HasSynced,
+			networkPolicyInformer.Informer().HasSynced,
 		},
 
 		eventRecorder: eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "scylladbdatacenter-controller"}),
@@ -192,12 +216,25 @@ func NewController(
 		DeleteFunc: sdcc.deletePod,
 	})
 
+	// We need PVC events to expand them and to delete the orphaned ones once their Pods are gone.
+	pvcInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sdcc.addPVC,
+		UpdateFunc: sdcc.updatePVC,
+		DeleteFunc: sdcc.deletePVC,
+	})
+
 	serviceAccountInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    sdcc.addServiceAccount,
 		UpdateFunc: sdcc.updateServiceAccount,
 		DeleteFunc: sdcc.deleteServiceAccount,
 	})
 
+	roleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sdcc.addRole,
+		UpdateFunc: sdcc.updateRole,
+		DeleteFunc: sdcc.deleteRole,
+	})
+
 	roleBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    sdcc.addRoleBinding,
 		UpdateFunc: sdcc.updateRoleBinding,
@@ -222,6 +259,35 @@ func NewController(
 		DeleteFunc: sdcc.deleteIngress,
 	})
 
+	networkPolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sdcc.addNetworkPolicy,
+		UpdateFunc: sdcc.updateNetworkPolicy,
+		DeleteFunc: sdcc.deleteNetworkPolicy,
+	})
+
+	// The prometheus-operator CRDs are optional, the informers are nil when they aren't installed.
+	if serviceMonitorInformer != nil {
+		sdcc.serviceMonitorLister = serviceMonitorInformer.Lister()
+		sdcc.cachesToSync = append(sdcc.cachesToSync, serviceMonitorInformer.Informer().HasSynced)
+
+		serviceMonitorInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    sdcc.addServiceMonitor,
+			UpdateFunc: sdcc.updateServiceMonitor,
+			DeleteFunc: sdcc.deleteServiceMonitor,
+		})
+	}
+
+	if podMonitorInformer != nil {
+		sdcc.podMonitorLister = podMonitorInformer.Lister()
+		sdcc.cachesToSync = append(sdcc.cachesToSync, podMonitorInformer.Informer().HasSynced)
+
+		podMonitorInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    sdcc.addPodMonitor,
+			UpdateFunc: sdcc.updatePodMonitor,
+			DeleteFunc: sdcc.deletePodMonitor,
+		})
+	}
+
 	scyllaDBDatacenterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    sdcc.addScyllaDBDatacenter,
 		UpdateFunc: sdcc.updateScyllaDBDatacenter,
@@ -372,6 +438,27 @@ func (sdcc *Controller) enqueueOwnerThroughStatefulSetOwner(depth int, obj kubei
 	sdcc.handlers.Enqueue(depth+1, sdc, op)
 }
 
+// enqueueOwnerThroughClusterNameLabel enqueues the ScyllaDBDatacenter named by the cluster name label of obj.
+// PVCs created from volumeClaimTemplates have no controllerRef pointing to the ScyllaDBDatacenter, so they are matched by their labels.
+func (sdcc *Controller) enqueueOwnerThroughClusterNameLabel(depth int, obj kubeinterfaces.ObjectInterface, op controllerhelpers.HandlerOperationType) {
+	sdcName, ok := obj.GetLabels()[naming.ClusterNameLabel]
+	if !ok {
+		return
+	}
+
+	sdc, err := sdcc.scyllaDBDatacenterLister.ScyllaDBDatacenters(obj.GetNamespace()).Get(sdcName)
+	if err != nil {
+		return
+	}
+
+	if !naming.ManagedBySelector(sdc).Matches(labels.Set(obj.GetLabels())) {
+		return
+	}
+
+	klog.V(4).InfoS("Enqueuing ScyllaDBDatacenter of labeled object", "Object", klog.KObj(obj), "ScyllaDBDatacenter", klog.KObj(sdc))
+	sdcc.handlers.Enqueue(depth+1, sdc, op)
+}
+
 func (sdcc *Controller) addService(obj interface{}) {
 	sdcc.handlers.HandleAdd(
 		obj.(*corev1.Service),
@@ -464,6 +551,29 @@ func (sdcc *Controller) deleteServiceAccount(obj interface{}) {
 	)
 }
 
+func (sdcc *Controller) addRole(obj interface{}) {
+	sdcc.handlers.HandleAdd(
+		obj.(*rbacv1.Role),
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
+func (sdcc *Controller) updateRole(old, cur interface{}) {
+	sdcc.handlers.HandleUpdate(
+		old.(*rbacv1.Role),
+		cur.(*rbacv1.Role),
+		sdcc.handlers.EnqueueOwner,
+		sdcc.deleteRole,
+	)
+}
+
+func (sdcc *Controller) deleteRole(obj interface{}) {
+	sdcc.handlers.HandleDelete(
+		obj,
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
 func (sdcc *Controller) addRoleBinding(obj interface{}) {
 	sdcc.handlers.HandleAdd(
 		obj.(*rbacv1.RoleBinding),
@@ -510,6 +620,29 @@ func (sdcc *Controller) deletePod(obj interface{}) {
 	)
 }
 
+func (sdcc *Controller) addPVC(obj interface{}) {
+	sdcc.handlers.HandleAdd(
+		obj.(*corev1.PersistentVolumeClaim),
+		sdcc.enqueueOwnerThroughClusterNameLabel,
+	)
+}
+
+func (sdcc *Controller) updatePVC(old, cur interface{}) {
+	sdcc.handlers.HandleUpdate(
+		old.(*corev1.PersistentVolumeClaim),
+		cur.(*corev1.PersistentVolumeClaim),
+		sdcc.enqueueOwnerThroughClusterNameLabel,
+		sdcc.deletePVC,
+	)
+}
+
+func (sdcc *Controller) deletePVC(obj interface{}) {
+	sdcc.handlers.HandleDelete(
+		obj,
+		sdcc.enqueueOwnerThroughClusterNameLabel,
+	)
+}
+
 func (sdcc *Controller) addStatefulSet(obj interface{}) {
 	sdcc.handlers.HandleAdd(
 		obj.(*appsv1.StatefulSet),
@@ -579,6 +712,75 @@ func (sdcc *Controller) deleteIngress(obj interface{}) {
 	)
 }
 
+func (sdcc *Controller) addNetworkPolicy(obj interface{}) {
+	sdcc.handlers.HandleAdd(
+		obj.(*networkingv1.NetworkPolicy),
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
+func (sdcc *Controller) updateNetworkPolicy(old, cur interface{}) {
+	sdcc.handlers.HandleUpdate(
+		old.(*networkingv1.NetworkPolicy),
+		cur.(*networkingv1.NetworkPolicy),
+		sdcc.handlers.EnqueueOwner,
+		sdcc.deleteNetworkPolicy,
+	)
+}
+
+func (sdcc *Controller) deleteNetworkPolicy(obj interface{}) {
+	sdcc.handlers.HandleDelete(
+		obj,
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
+func (sdcc *Controller) addServiceMonitor(obj interface{}) {
+	sdcc.handlers.HandleAdd(
+		obj.(*monitoringv1.ServiceMonitor),
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
+func (sdcc *Controller) updateServiceMonitor(old, cur interface{}) {
+	sdcc.handlers.HandleUpdate(
+		old.(*monitoringv1.ServiceMonitor),
+		cur.(*monitoringv1.ServiceMonitor),
+		sdcc.handlers.EnqueueOwner,
+		sdcc.deleteServiceMonitor,
+	)
+}
+
+func (sdcc *Controller) deleteServiceMonitor(obj interface{}) {
+	sdcc.handlers.HandleDelete(
+		obj,
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
+func (sdcc *Controller) addPodMonitor(obj interface{}) {
+	sdcc.handlers.HandleAdd(
+		obj.(*monitoringv1.PodMonitor),
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
+func (sdcc *Controller) updatePodMonitor(old, cur interface{}) {
+	sdcc.handlers.HandleUpdate(
+		old.(*monitoringv1.PodMonitor),
+		cur.(*monitoringv1.PodMonitor),
+		sdcc.handlers.EnqueueOwner,
+		sdcc.deletePodMonitor,
+	)
+}
+
+func (sdcc *Controller) deletePodMonitor(obj interface{}) {
+	sdcc.handlers.HandleDelete(
+		obj,
+		sdcc.handlers.EnqueueOwner,
+	)
+}
+
 func (sdcc *Controller) addScyllaDBDatacenter(obj interface{}) {
 	sdcc.handlers.HandleAdd(
 		obj.(*scyllav1alpha1.ScyllaDBDatacenter),
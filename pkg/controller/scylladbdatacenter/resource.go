@@ -1,10 +1,12 @@
 package scylladbdatacenter
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"maps"
 	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,12 +16,14 @@ import (
 	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
 	"github.com/scylladb/scylla-operator/pkg/cmdutil"
 	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
 	"github.com/scylladb/scylla-operator/pkg/features"
 	"github.com/scylladb/scylla-operator/pkg/helpers"
 	oslices "github.com/scylladb/scylla-operator/pkg/helpers/slices"
 	"github.com/scylladb/scylla-operator/pkg/internalapi"
 	"github.com/scylladb/scylla-operator/pkg/naming"
 	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourcemerge"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryutilintstr "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 )
@@ -53,6 +58,7 @@ const (
 	portNameCQLShardAware    = "cql-shard-aware"
 	portNameCQLSSLShardAware = "cql-ssl-shard-aware"
 	portNameThrift           = "thrift"
+	portNameAgentAPI         = "agent-api"
 
 	alternatorInsecurePort     = 8000
 	alternatorInsecurePortName = "alternator"
@@ -192,6 +198,7 @@ func MemberService(sdc *scyllav1alpha1.ScyllaDBDatacenter, rackName, name string
 		svc.Spec.AllocateLoadBalancerNodePorts = copyReferencedValue(ns.AllocateLoadBalancerNodePorts)
 		svc.Spec.LoadBalancerClass = copyReferencedValue(ns.LoadBalancerClass)
 		svc.Spec.ExternalTrafficPolicy = getValueOrDefault(ns.ExternalTrafficPolicy, "")
+		svc.Spec.PublishNotReadyAddresses = getValueOrDefault(ns.PublishNotReadyAddresses, true)
 	}
 
 	rackSpec, _, ok := oslices.Find(sdc.Spec.Racks, func(rs scyllav1alpha1.RackSpec) bool {
@@ -208,9 +215,56 @@ func MemberService(sdc *scyllav1alpha1.ScyllaDBDatacenter, rackName, name string
 		svc.Annotations = helpers.MergeMaps(svc.Annotations, rackSpec.ExposeOptions.NodeService.Annotations)
 	}
 
+	setManagedNodeServiceAnnotations(svc, oldService, sdc, rackSpec)
+
 	return svc, nil
 }
 
+// setManagedNodeServiceAnnotations records which annotations of the member Service come from the node service templates
+// and marks the ones that were dropped from the templates for removal.
+// Without it, the apply would carry the dropped annotations over from the existing Service forever,
+// e.g. leaving a stale external-dns hostname behind.
+func setManagedNodeServiceAnnotations(svc *corev1.Service, oldService *corev1.Service, sdc *scyllav1alpha1.ScyllaDBDatacenter, rackSpec scyllav1alpha1.RackSpec) {
+	managedKeys := sets.New[string]()
+	if sdc.Spec.ExposeOptions != nil && sdc.Spec.ExposeOptions.NodeService != nil {
+		managedKeys.Insert(slices.Collect(maps.Keys(sdc.Spec.ExposeOptions.NodeService.Annotations))...)
+	}
+	if rackSpec.ExposeOptions != nil && rackSpec.ExposeOptions.NodeService != nil {
+		managedKeys.Insert(slices.Collect(maps.Keys(rackSpec.ExposeOptions.NodeService.Annotations))...)
+	}
+
+	if oldService != nil {
+		oldManagedKeysValue, ok := oldService.Annotations[naming.ManagedNodeServiceAnnotationKeysAnnotation]
+		if ok && len(oldManagedKeysValue) != 0 {
+			for _, k := range strings.Split(oldManagedKeysValue, ",") {
+				if managedKeys.Has(k) {
+					continue
+				}
+
+				_, isStillRequired := svc.Annotations[k]
+				if isStillRequired {
+					continue
+				}
+
+				_, isPresent := oldService.Annotations[k]
+				if !isPresent {
+					continue
+				}
+
+				svc.Annotations[resourcemerge.ToRemovalKey(k)] = ""
+			}
+		}
+
+		if ok && managedKeys.Len() == 0 {
+			svc.Annotations[resourcemerge.ToRemovalKey(naming.ManagedNodeServiceAnnotationKeysAnnotation)] = ""
+		}
+	}
+
+	if managedKeys.Len() != 0 {
+		svc.Annotations[naming.ManagedNodeServiceAnnotationKeysAnnotation] = strings.Join(sets.List(managedKeys), ",")
+	}
+}
+
 func getServicePorts(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]corev1.ServicePort, error) {
 	ports := []corev1.ServicePort{
 		{
@@ -242,7 +296,7 @@ func getServicePorts(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]corev1.ServicePo
 			Port: 7199,
 		},
 		{
-			Name: "agent-api",
+			Name: portNameAgentAPI,
 			Port: 10001,
 		},
 		{
@@ -1058,6 +1112,12 @@ wait
 	if sysctlContainer != nil {
 		sts.Spec.Template.Spec.InitContainers = append(sts.Spec.Template.Spec.InitContainers, *sysctlContainer)
 	}
+
+	dataDirPreparationContainer := dataDirPreparationInitContainer(sdc, sidecarImage)
+	if dataDirPreparationContainer != nil {
+		sts.Spec.Template.Spec.InitContainers = append(sts.Spec.Template.Spec.InitContainers, *dataDirPreparationContainer)
+	}
+
 	if rack.ScyllaDB != nil {
 		for _, vm := range rack.ScyllaDB.VolumeMounts {
 			sts.Spec.Template.Spec.Containers[0].VolumeMounts = append(sts.Spec.Template.Spec.Containers[0].VolumeMounts, *vm.DeepCopy())
@@ -1082,6 +1142,10 @@ wait
 		sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, *agentContainer)
 	}
 
+	if existingSts != nil {
+		preserveEquivalentEphemeralStorage(sts, existingSts)
+	}
+
 	return sts, nil
 }
 
@@ -1199,6 +1263,70 @@ func sysctlInitContainer(sdc *scyllav1alpha1.ScyllaDBDatacenter, image string) (
 	}, nil
 }
 
+// dataDirPreparationInitContainer returns the init container preparing the data directory, or nil if it isn't requested.
+// Every step checks the current state first, so running it on every start of a node only changes what's needed.
+func dataDirPreparationInitContainer(sdc *scyllav1alpha1.ScyllaDBDatacenter, image string) *corev1.Container {
+	options := sdc.Spec.DataDirectoryPreparation
+	if options == nil {
+		return nil
+	}
+
+	var steps []string
+	if options.RequireXFS != nil && *options.RequireXFS {
+		steps = append(steps, fmt.Sprintf(`fs_type="$( stat -f -c '%%T' %[1]q )"
+if [ "${fs_type}" != "xfs" ]; then
+  echo "Data directory %[1]s is on a ${fs_type} filesystem, XFS is required." >&2
+  exit 1
+fi`, naming.DataDir))
+	}
+
+	if options.Ownership != nil {
+		// Walking the whole data directory takes long on big volumes, so it only runs when the ownership changed
+		// since the last walk, which is recorded by the marker, or when the data directory itself has a different owner.
+		uid, gid, _ := strings.Cut(*options.Ownership, ":")
+		steps = append(steps, fmt.Sprintf(`if [ "$( cat %[2]q 2>/dev/null || true )" != "%[3]s:%[4]s" ] || [ "$( stat -c '%%u:%%g' %[1]q )" != "%[3]s:%[4]s" ]; then
+  find %[1]q \( ! -user %[3]s -o ! -group %[4]s \) -exec chown --no-dereference %[3]s:%[4]s {} +
+  echo "%[3]s:%[4]s" > %[2]q
+  chown %[3]s:%[4]s %[2]q
+fi`, naming.DataDir, naming.DataDirOwnershipMarkerPath, uid, gid))
+	}
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	return &corev1.Container{
+		Name:            naming.DataDirPreparationContainerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command: []string{
+			"/bin/sh",
+			"-euc",
+			strings.Join(steps, "\n"),
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  pointer.Ptr(rootUID),
+			RunAsGroup: pointer.Ptr(rootGID),
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("50Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("50Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      naming.PVCTemplateName,
+				MountPath: naming.DataDir,
+			},
+		},
+	}
+}
+
 func getScyllaDBManagerAgentContainer(r scyllav1alpha1.RackSpec, sdc *scyllav1alpha1.ScyllaDBDatacenter) (*corev1.Container, error) {
 	if sdc.Spec.ScyllaDBManagerAgent == nil {
 		return nil, nil
@@ -1289,8 +1417,26 @@ exec scylla-manager-agent \
 	return cnt, nil
 }
 
-func MakePodDisruptionBudget(sdc *scyllav1alpha1.ScyllaDBDatacenter) *policyv1.PodDisruptionBudget {
-	maxUnavailable := apimachineryutilintstr.FromInt(1)
+// makePodDisruptionBudgetMaxUnavailable returns the maxUnavailable of a PodDisruptionBudget covering the given number of nodes,
+// see ScyllaDBDatacenterSpec.DisruptionTolerance.
+func makePodDisruptionBudgetMaxUnavailable(sdc *scyllav1alpha1.ScyllaDBDatacenter, nodes int32) apimachineryutilintstr.IntOrString {
+	if sdc.Spec.DisruptionTolerance == nil {
+		return apimachineryutilintstr.FromInt(1)
+	}
+
+	return apimachineryutilintstr.FromInt32(max(1, nodes*sdc.Spec.DisruptionTolerance.MaxUnavailablePercent/100))
+}
+
+func MakePodDisruptionBudget(sdc *scyllav1alpha1.ScyllaDBDatacenter) (*policyv1.PodDisruptionBudget, error) {
+	var nodes int32
+	for _, rack := range sdc.Spec.Racks {
+		rackNodes, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			return nil, fmt.Errorf("can't get node count of rack %q: %w", rack.Name, err)
+		}
+		nodes += *rackNodes
+	}
+	maxUnavailable := makePodDisruptionBudgetMaxUnavailable(sdc, nodes)
 
 	selectorLabels := naming.ClusterLabels(sdc)
 
@@ -1320,7 +1466,72 @@ func MakePodDisruptionBudget(sdc *scyllav1alpha1.ScyllaDBDatacenter) *policyv1.P
 			MaxUnavailable: &maxUnavailable,
 			Selector:       selector,
 		},
+	}, nil
+}
+
+// MakeRackPodDisruptionBudgets returns a PodDisruptionBudget for every rack, selecting only the ScyllaDB Pods of that rack.
+func MakeRackPodDisruptionBudgets(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]*policyv1.PodDisruptionBudget, error) {
+	var pdbs []*policyv1.PodDisruptionBudget
+
+	for _, rack := range sdc.Spec.Racks {
+		rackNodes, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			return nil, fmt.Errorf("can't get node count of rack %q: %w", rack.Name, err)
+		}
+		maxUnavailable := makePodDisruptionBudgetMaxUnavailable(sdc, *rackNodes)
+
+		selectorLabels, err := naming.RackSelectorLabels(rack, sdc)
+		if err != nil {
+			return nil, fmt.Errorf("can't get selector labels for rack %q: %w", rack.Name, err)
+		}
+
+		labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+		maps.Copy(labels, selectorLabels)
+
+		annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+		// Ignore any Job Pods that share the selector with ScyllaDB Pods, they shouldn't be accounted for PDB.
+		selector := metav1.SetAsLabelSelector(selectorLabels)
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      "batch.kubernetes.io/job-name",
+			Operator: metav1.LabelSelectorOpDoesNotExist,
+		})
+
+		pdbs = append(pdbs, &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.PodDisruptionBudgetNameForRack(rack, sdc),
+				Namespace: sdc.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+				},
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailable,
+				Selector:       selector,
+			},
+		})
+	}
+
+	return pdbs, nil
+}
+
+// MakePodDisruptionBudgets returns the PodDisruptionBudgets that should exist for the datacenter.
+// The datacenter is covered by the cluster-wide PodDisruptionBudget, unless per-rack PodDisruptionBudgets are requested,
+// see DisruptionToleranceOptions.PerRack.
+// The two are mutually exclusive because the eviction API refuses to evict Pods matched by more than one PodDisruptionBudget.
+func MakePodDisruptionBudgets(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]*policyv1.PodDisruptionBudget, error) {
+	if sdc.Spec.DisruptionTolerance != nil && sdc.Spec.DisruptionTolerance.PerRack {
+		return MakeRackPodDisruptionBudgets(sdc)
 	}
+
+	pdb, err := MakePodDisruptionBudget(sdc)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*policyv1.PodDisruptionBudget{pdb}, nil
 }
 
 func MakeIngresses(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service) []*networkingv1.Ingress {
@@ -1477,10 +1688,106 @@ func MakeAgentAuthTokenSecret(sdc *scyllav1alpha1.ScyllaDBDatacenter, authToken
 	}, nil
 }
 
+type dockerConfigJSONAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigJSONAuth `json:"auths"`
+}
+
+// MakePullSecret returns the kubernetes.io/dockerconfigjson Secret built from the registry credentials
+// in the credentials Secret referenced by spec.imagePullCredentials.
+func MakePullSecret(sdc *scyllav1alpha1.ScyllaDBDatacenter, credentials *corev1.Secret) (*corev1.Secret, error) {
+	username, ok := credentials.Data[corev1.BasicAuthUsernameKey]
+	if !ok || len(username) == 0 {
+		return nil, fmt.Errorf("secret %q is missing key %q", naming.ObjRef(credentials), corev1.BasicAuthUsernameKey)
+	}
+
+	password, ok := credentials.Data[corev1.BasicAuthPasswordKey]
+	if !ok || len(password) == 0 {
+		return nil, fmt.Errorf("secret %q is missing key %q", naming.ObjRef(credentials), corev1.BasicAuthPasswordKey)
+	}
+
+	data, err := json.Marshal(dockerConfigJSON{
+		Auths: map[string]dockerConfigJSONAuth{
+			sdc.Spec.ImagePullCredentials.Registry: {
+				Username: string(username),
+				Password: string(password),
+				Auth:     base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password))),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal docker config: %w", err)
+	}
+
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ImagePullSecretName(sdc),
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		},
+	}, nil
+}
+
 func ImageForCluster(c *scyllav1.ScyllaCluster) string {
 	return fmt.Sprintf("%s:%s", c.Spec.Repository, c.Spec.Version)
 }
 
+// preserveEquivalentEphemeralStorage keeps the ephemeral-storage quantities of the existing StatefulSet containers
+// when they are equal to the required ones, so an equal quantity expressed differently, like "1Gi" and "1073741824",
+// doesn't change the StatefulSet hash and roll the Pods.
+func preserveEquivalentEphemeralStorage(sts *appsv1.StatefulSet, existingSts *appsv1.StatefulSet) {
+	existingContainers := map[string]*corev1.Container{}
+	for i := range existingSts.Spec.Template.Spec.Containers {
+		existingContainers[existingSts.Spec.Template.Spec.Containers[i].Name] = &existingSts.Spec.Template.Spec.Containers[i]
+	}
+
+	for i := range sts.Spec.Template.Spec.Containers {
+		c := &sts.Spec.Template.Spec.Containers[i]
+		existingContainer, ok := existingContainers[c.Name]
+		if !ok {
+			continue
+		}
+
+		// The resources may share the maps with the spec, so they are copied before being changed.
+		c.Resources = *c.Resources.DeepCopy()
+		for _, rls := range []struct {
+			required corev1.ResourceList
+			existing corev1.ResourceList
+		}{
+			{required: c.Resources.Limits, existing: existingContainer.Resources.Limits},
+			{required: c.Resources.Requests, existing: existingContainer.Resources.Requests},
+		} {
+			q, ok := rls.required[corev1.ResourceEphemeralStorage]
+			if !ok {
+				continue
+			}
+
+			existingQ, ok := rls.existing[corev1.ResourceEphemeralStorage]
+			if ok && q.Cmp(existingQ) == 0 {
+				rls.required[corev1.ResourceEphemeralStorage] = existingQ.DeepCopy()
+			}
+		}
+	}
+}
+
 func getValueOrDefault[T any](v *T, def T) T {
 	if v != nil {
 		return *v
@@ -1495,12 +1802,90 @@ func copyReferencedValue[T any](v *T) *T {
 	return nil
 }
 
+// MakeManagementIngress returns the Ingress exposing the ScyllaDB Manager Agent API through the identity Service,
+// or nil when it isn't enabled.
+func MakeManagementIngress(sdc *scyllav1alpha1.ScyllaDBDatacenter) *networkingv1.Ingress {
+	if sdc.Spec.ExposeOptions == nil || sdc.Spec.ExposeOptions.ManagementAPI == nil || sdc.Spec.ExposeOptions.ManagementAPI.Ingress == nil {
+		return nil
+	}
+
+	ingressOptions := sdc.Spec.ExposeOptions.ManagementAPI.Ingress
+
+	annotations := map[string]string{}
+	if ingressOptions.Annotations != nil {
+		maps.Copy(annotations, ingressOptions.Annotations)
+	} else {
+		maps.Copy(annotations, cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys))
+	}
+
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	maps.Copy(labels, ingressOptions.Labels)
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+	labels[naming.ScyllaIngressTypeLabel] = string(naming.ScyllaIngressTypeManagementAPI)
+
+	path := ingressOptions.Path
+	if len(path) == 0 {
+		path = "/"
+	}
+
+	var ingressClassName *string
+	if len(ingressOptions.IngressClassName) != 0 {
+		// When not set, the class defaulted by the cluster is carried forward by the apply.
+		ingressClassName = pointer.Ptr(ingressOptions.IngressClassName)
+	}
+
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.ManagementAPIIngressName(sdc),
+			Namespace:   sdc.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ingressClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: ingressOptions.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: pointer.Ptr(networkingv1.PathTypePrefix),
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: naming.IdentityServiceName(sdc),
+											Port: networkingv1.ServiceBackendPort{
+												Name: portNameAgentAPI,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func MakeServiceAccount(sdc *scyllav1alpha1.ScyllaDBDatacenter) *corev1.ServiceAccount {
 	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
 	maps.Copy(labels, naming.ClusterLabels(sdc))
 
 	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
 
+	var imagePullSecrets []corev1.LocalObjectReference
+	if sdc.Spec.ImagePullCredentials != nil {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{
+			Name: naming.ImagePullSecretName(sdc),
+		})
+	}
+
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      naming.MemberServiceAccountNameForScyllaDBDatacenter(sdc.Name),
@@ -1511,6 +1896,7 @@ func MakeServiceAccount(sdc *scyllav1alpha1.ScyllaDBDatacenter) *corev1.ServiceA
 			Labels:      labels,
 			Annotations: annotations,
 		},
+		ImagePullSecrets: imagePullSecrets,
 	}
 }
 
@@ -1548,6 +1934,67 @@ func MakeRoleBinding(sdc *scyllav1alpha1.ScyllaDBDatacenter) *rbacv1.RoleBinding
 	}
 }
 
+func MakeAgentRole(sdc *scyllav1alpha1.ScyllaDBDatacenter) *rbacv1.Role {
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.AgentRoleNameForScyllaDBDatacenter(sdc.Name),
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{corev1.GroupName},
+				Resources: []string{"pods", "services"},
+				Verbs:     []string{"get", "list"},
+			},
+		},
+	}
+}
+
+func MakeAgentRoleBinding(sdc *scyllav1alpha1.ScyllaDBDatacenter) *rbacv1.RoleBinding {
+	saName := naming.MemberServiceAccountNameForScyllaDBDatacenter(sdc.Name)
+	roleName := naming.AgentRoleNameForScyllaDBDatacenter(sdc.Name)
+
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      roleName,
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup:  corev1.GroupName,
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: sdc.Namespace,
+				Name:      saName,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     roleName,
+		},
+	}
+}
+
 func MakeJobs(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service, image string) ([]*batchv1.Job, []metav1.Condition, error) {
 	var jobs []*batchv1.Job
 	var progressingConditions []metav1.Condition
@@ -1649,8 +2096,8 @@ func MakeJobs(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev
 				}
 			}
 
-			jobs = append(jobs, &batchv1.Job{
-				ObjectMeta: metav1.ObjectMeta{
+			jobs = append(jobs, controllerhelpers.MakeJob(
+				metav1.ObjectMeta{
 					Name:      naming.CleanupJobForService(svc.Name),
 					Namespace: sdc.Namespace,
 					OwnerReferences: []metav1.OwnerReference{
@@ -1659,7 +2106,7 @@ func MakeJobs(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev
 					Labels:      labels,
 					Annotations: annotations,
 				},
-				Spec: batchv1.JobSpec{
+				batchv1.JobSpec{
 					Selector:       nil,
 					ManualSelector: pointer.Ptr(false),
 					Template: corev1.PodTemplateSpec{
@@ -1704,13 +2151,59 @@ func MakeJobs(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev
 						},
 					},
 				},
-			})
+			))
 		}
 	}
 
 	return jobs, progressingConditions, nil
 }
 
+// MakeDeletionCleanupJob returns the Job cleaning up external resources when the ScyllaDBDatacenter is deleted.
+func MakeDeletionCleanupJob(sdc *scyllav1alpha1.ScyllaDBDatacenter) *batchv1.Job {
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	labels[naming.ClusterNameLabel] = sdc.Name
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	controllerRef := metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK)
+	// The Job is created when the ScyllaDBDatacenter is already being deleted, it must not hold it back any longer than the finalizer does.
+	controllerRef.BlockOwnerDeletion = pointer.Ptr(false)
+
+	return controllerhelpers.MakeJob(
+		metav1.ObjectMeta{
+			Name:      naming.DeletionCleanupJobName(sdc),
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*controllerRef,
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		batchv1.JobSpec{
+			Selector:       nil,
+			ManualSelector: pointer.Ptr(false),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:            naming.CleanupContainerName,
+							Image:           sdc.Spec.DeletionCleanup.Image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         sdc.Spec.DeletionCleanup.Command,
+							Args:            sdc.Spec.DeletionCleanup.Args,
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
 func MakeManagedScyllaDBConfigMaps(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]*corev1.ConfigMap, error) {
 	var managedCMs []*corev1.ConfigMap
 
@@ -2098,3 +2591,361 @@ func cloneMapExcludingKeysOrEmpty[M ~map[K]V, S ~[]K, K comparable, V any](m M,
 	}
 	return r
 }
+
+// MakeScyllaNetworkPolicy makes a NetworkPolicy that isolates ScyllaDB Pods of the datacenter.
+// It admits traffic between the datacenter nodes on all ScyllaDB ports, metrics scraping and Manager Agent API calls
+// from anywhere, and traffic to client ports from the allowed CIDRs. Any other ingress traffic is denied.
+func MakeScyllaNetworkPolicy(sdc *scyllav1alpha1.ScyllaDBDatacenter) (*networkingv1.NetworkPolicy, error) {
+	selectorLabels := naming.ClusterLabels(sdc)
+
+	servicePorts, err := getServicePorts(sdc)
+	if err != nil {
+		return nil, fmt.Errorf("can't get service ports: %w", err)
+	}
+
+	newNetworkPolicyPort := func(port int32) networkingv1.NetworkPolicyPort {
+		return networkingv1.NetworkPolicyPort{
+			Protocol: pointer.Ptr(corev1.ProtocolTCP),
+			Port:     pointer.Ptr(apimachineryutilintstr.FromInt32(port)),
+		}
+	}
+
+	var nodePorts, clientPorts, publicPorts []networkingv1.NetworkPolicyPort
+	for _, sp := range servicePorts {
+		np := newNetworkPolicyPort(sp.Port)
+		nodePorts = append(nodePorts, np)
+
+		switch sp.Name {
+		case portNameCQL, portNameCQLSSL, portNameCQLShardAware, portNameCQLSSLShardAware, alternatorInsecurePortName, alternatorTLSPortName:
+			clientPorts = append(clientPorts, np)
+		case "prometheus", "agent-prometheus", "node-exporter", portNameAgentAPI:
+			publicPorts = append(publicPorts, np)
+		}
+	}
+
+	ingressRules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: metav1.SetAsLabelSelector(selectorLabels),
+				},
+			},
+			Ports: nodePorts,
+		},
+		{
+			// Metrics are scraped and the Manager Agent API is called from outside the datacenter, e.g. by the Operator
+			// and ScyllaDB Manager. The Agent API is authenticated with a token.
+			Ports: publicPorts,
+		},
+	}
+
+	if sdc.Spec.NetworkPolicy != nil && len(sdc.Spec.NetworkPolicy.AllowedClientCIDRs) > 0 {
+		var clientPeers []networkingv1.NetworkPolicyPeer
+		for _, cidr := range sdc.Spec.NetworkPolicy.AllowedClientCIDRs {
+			clientPeers = append(clientPeers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{
+					CIDR: cidr,
+				},
+			})
+		}
+
+		ingressRules = append(ingressRules, networkingv1.NetworkPolicyIngressRule{
+			From:  clientPeers,
+			Ports: clientPorts,
+		})
+	}
+
+	return newScyllaNetworkPolicy(sdc, naming.NetworkPolicyName(sdc), ingressRules), nil
+}
+
+// MakeScyllaDefaultDenyNetworkPolicy makes the baseline NetworkPolicy that selects ScyllaDB Pods of the datacenter
+// without admitting any ingress traffic, so only the traffic admitted by the other NetworkPolicies reaches them.
+func MakeScyllaDefaultDenyNetworkPolicy(sdc *scyllav1alpha1.ScyllaDBDatacenter) *networkingv1.NetworkPolicy {
+	return newScyllaNetworkPolicy(sdc, naming.DefaultDenyNetworkPolicyName(sdc), nil)
+}
+
+// MakeScyllaAllowOverlayNetworkPolicy makes a NetworkPolicy admitting the ingress traffic described by the allow overlay.
+func MakeScyllaAllowOverlayNetworkPolicy(sdc *scyllav1alpha1.ScyllaDBDatacenter, overlay *scyllav1alpha1.NetworkPolicyAllowOverlay) *networkingv1.NetworkPolicy {
+	overlay = overlay.DeepCopy()
+
+	return newScyllaNetworkPolicy(sdc, naming.AllowOverlayNetworkPolicyName(sdc, overlay.Name), []networkingv1.NetworkPolicyIngressRule{
+		{
+			From:  overlay.From,
+			Ports: overlay.Ports,
+		},
+	})
+}
+
+// MakeScyllaNetworkPolicies makes all NetworkPolicies isolating ScyllaDB Pods of the datacenter in a deterministic order:
+// the default deny baseline, the NetworkPolicy admitting the traffic required by ScyllaDB and the allow overlays sorted by their name.
+// It returns no NetworkPolicies when the isolation isn't enabled.
+func MakeScyllaNetworkPolicies(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]*networkingv1.NetworkPolicy, error) {
+	if sdc.Spec.NetworkPolicy == nil {
+		return nil, nil
+	}
+
+	networkPolicy, err := MakeScyllaNetworkPolicy(sdc)
+	if err != nil {
+		return nil, fmt.Errorf("can't make network policy: %w", err)
+	}
+
+	networkPolicies := []*networkingv1.NetworkPolicy{
+		MakeScyllaDefaultDenyNetworkPolicy(sdc),
+		networkPolicy,
+	}
+
+	overlays := slices.Clone(sdc.Spec.NetworkPolicy.AllowOverlays)
+	slices.SortStableFunc(overlays, func(a, b scyllav1alpha1.NetworkPolicyAllowOverlay) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range overlays {
+		networkPolicies = append(networkPolicies, MakeScyllaAllowOverlayNetworkPolicy(sdc, &overlays[i]))
+	}
+
+	return networkPolicies, nil
+}
+
+func newScyllaNetworkPolicy(sdc *scyllav1alpha1.ScyllaDBDatacenter, name string, ingressRules []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	selectorLabels := naming.ClusterLabels(sdc)
+
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	maps.Copy(labels, selectorLabels)
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: *metav1.SetAsLabelSelector(selectorLabels),
+			Ingress:     ingressRules,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
+
+func makeMonitorRelabelConfig(sourceLabel, regex, targetLabel, replacement string) monitoringv1.RelabelConfig {
+	return monitoringv1.RelabelConfig{
+		SourceLabels: []monitoringv1.LabelName{monitoringv1.LabelName(sourceLabel)},
+		Regex:        regex,
+		TargetLabel:  targetLabel,
+		Replacement:  pointer.Ptr(replacement),
+	}
+}
+
+// makeNodeExporterRelabelConfigs returns the relabeling of node exporter targets.
+// clusterSourceLabel is the discovery meta label holding the cluster name, which differs between Services and Pods.
+func makeNodeExporterRelabelConfigs(clusterSourceLabel string) []monitoringv1.RelabelConfig {
+	return []monitoringv1.RelabelConfig{
+		makeMonitorRelabelConfig("__address__", `(.*):\d+`, "instance", "${1}"),
+		makeMonitorRelabelConfig("__address__", `([^:]+)`, "instance", "${1}"),
+		makeMonitorRelabelConfig("instance", `(.*)`, "__address__", "${1}:9100"),
+		makeMonitorRelabelConfig(clusterSourceLabel, "(.+)", "cluster", "${1}"),
+		makeMonitorRelabelConfig("__meta_kubernetes_pod_label_scylla_datacenter", "(.+)", "dc", "${1}"),
+		// ScyllaDB Monitoring OS Metrics dashboard expects node exporter metrics to have 'job=node_exporter'.
+		makeMonitorRelabelConfig("__meta_kubernetes_endpoint_port_name", "(.+)", "job", "node_exporter"),
+	}
+}
+
+// makeScyllaDBRelabelConfigs returns the relabeling of ScyllaDB targets.
+// clusterSourceLabel is the discovery meta label holding the cluster name, which differs between Services and Pods.
+func makeScyllaDBRelabelConfigs(clusterSourceLabel string) []monitoringv1.RelabelConfig {
+	return []monitoringv1.RelabelConfig{
+		makeMonitorRelabelConfig("__address__", "(.*):.+", "instance", "${1}"),
+		makeMonitorRelabelConfig(clusterSourceLabel, "(.+)", "cluster", "${1}"),
+		makeMonitorRelabelConfig("__meta_kubernetes_pod_label_scylla_datacenter", "(.+)", "dc", "${1}"),
+	}
+}
+
+// makeScyllaDBMetricRelabelConfigs returns the relabeling of ScyllaDB metrics that the ScyllaDB Monitoring dashboards expect.
+func makeScyllaDBMetricRelabelConfigs() []monitoringv1.RelabelConfig {
+	return []monitoringv1.RelabelConfig{
+		makeMonitorRelabelConfig("version", "(.+)", "CPU", "cpu"),
+		makeMonitorRelabelConfig("version", "(.+)", "CQL", "cql"),
+		makeMonitorRelabelConfig("version", "(.+)", "OS", "os"),
+		makeMonitorRelabelConfig("version", "(.+)", "IO", "io"),
+		makeMonitorRelabelConfig("version", "(.+)", "Errors", "errors"),
+		{
+			Regex:  "help|exported_instance",
+			Action: "labeldrop",
+		},
+		makeMonitorRelabelConfig("version", `([0-9]+\.[0-9]+)(\.?[0-9]*).*`, "svr", "$1$2"),
+	}
+}
+
+// MakeServiceMonitor returns a ServiceMonitor scraping the metrics ports of all member Services of the datacenter.
+// The relabeling matches the one used by ScyllaDBMonitoring, so the dashboards work with both.
+func MakeServiceMonitor(sdc *scyllav1alpha1.ScyllaDBDatacenter) *monitoringv1.ServiceMonitor {
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	if sdc.Spec.ServiceMonitor != nil {
+		maps.Copy(labels, sdc.Spec.ServiceMonitor.Labels)
+	}
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	selectorLabels := naming.ClusterLabels(sdc)
+	selectorLabels[naming.ScyllaServiceTypeLabel] = string(naming.ScyllaServiceTypeMember)
+
+	const clusterSourceLabel = "__meta_kubernetes_service_label_scylla_cluster"
+
+	return &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ServiceMonitorName(sdc),
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			JobLabel: naming.ClusterNameLabel,
+			Selector: *metav1.SetAsLabelSelector(selectorLabels),
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:           "node-exporter",
+					HonorLabels:    false,
+					RelabelConfigs: makeNodeExporterRelabelConfigs(clusterSourceLabel),
+				},
+				{
+					Port:                 "prometheus",
+					HonorLabels:          false,
+					MetricRelabelConfigs: makeScyllaDBMetricRelabelConfigs(),
+					RelabelConfigs:       makeScyllaDBRelabelConfigs(clusterSourceLabel),
+				},
+			},
+		},
+	}
+}
+
+// MakePodMonitor returns a PodMonitor scraping the metrics ports of all ScyllaDB Pods of the datacenter.
+// It's an alternative to MakeServiceMonitor for setups that don't discover targets through Services,
+// with the same relabeling.
+func MakePodMonitor(sdc *scyllav1alpha1.ScyllaDBDatacenter) *monitoringv1.PodMonitor {
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	if sdc.Spec.PodMonitor != nil {
+		maps.Copy(labels, sdc.Spec.PodMonitor.Labels)
+	}
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+
+	annotations := cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys)
+
+	const clusterSourceLabel = "__meta_kubernetes_pod_label_scylla_cluster"
+
+	return &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.PodMonitorName(sdc),
+			Namespace: sdc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			JobLabel: naming.ClusterNameLabel,
+			Selector: *metav1.SetAsLabelSelector(naming.ClusterLabels(sdc)),
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+				{
+					Port:           pointer.Ptr("node-exporter"),
+					HonorLabels:    false,
+					RelabelConfigs: makeNodeExporterRelabelConfigs(clusterSourceLabel),
+				},
+				{
+					Port:                 pointer.Ptr("prometheus"),
+					HonorLabels:          false,
+					MetricRelabelConfigs: makeScyllaDBMetricRelabelConfigs(),
+					RelabelConfigs:       makeScyllaDBRelabelConfigs(clusterSourceLabel),
+				},
+			},
+		},
+	}
+}
+
+// exporterTargetGroup is a target group of the Prometheus file based service discovery.
+type exporterTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// MakeExporterConfigMap renders the scrape targets of the metrics exporter, one per node and metrics port.
+// Targets are sorted, so the rendering only changes when the set of targets does, not with the order of the racks.
+func MakeExporterConfigMap(sdc *scyllav1alpha1.ScyllaDBDatacenter) (*corev1.ConfigMap, error) {
+	scrapePorts := []struct {
+		job  string
+		port int32
+	}{
+		{
+			job:  "scylla",
+			port: 9180,
+		},
+		{
+			job:  "node-exporter",
+			port: 9100,
+		},
+	}
+
+	var memberServiceNames []string
+	for _, rack := range sdc.Spec.Racks {
+		nodes, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			return nil, fmt.Errorf("can't get rack %q node count: %w", rack.Name, err)
+		}
+
+		for i := range int(*nodes) {
+			memberServiceNames = append(memberServiceNames, naming.MemberServiceName(rack, sdc, i))
+		}
+	}
+	slices.Sort(memberServiceNames)
+
+	targetGroups := make([]exporterTargetGroup, 0, len(scrapePorts))
+	for _, sp := range scrapePorts {
+		targets := make([]string, 0, len(memberServiceNames))
+		for _, svcName := range memberServiceNames {
+			targets = append(targets, fmt.Sprintf("%s.%s.svc:%d", svcName, sdc.Namespace, sp.port))
+		}
+
+		targetGroups = append(targetGroups, exporterTargetGroup{
+			Targets: targets,
+			Labels: map[string]string{
+				"job":        sp.job,
+				"cluster":    sdc.Spec.ClusterName,
+				"datacenter": naming.GetScyllaDBDatacenterGossipDatacenterName(sdc),
+			},
+		})
+	}
+
+	scrapeTargets, err := json.MarshalIndent(targetGroups, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal scrape targets: %w", err)
+	}
+
+	labels := cloneMapExcludingKeysOrEmpty(sdc.Labels, nonPropagatedLabelKeys)
+	maps.Copy(labels, naming.ClusterLabels(sdc))
+	labels[naming.ConfigMapTypeLabel] = string(naming.ExporterConfigConfigMapType)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   sdc.Namespace,
+			Name:        naming.ExporterConfigMapName(sdc),
+			Labels:      labels,
+			Annotations: cloneMapExcludingKeysOrEmpty(sdc.Annotations, nonPropagatedAnnotationKeys),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sdc, scyllav1alpha1.ScyllaDBDatacenterGVK),
+			},
+		},
+		Data: map[string]string{
+			naming.ExporterScrapeTargetsKey: string(scrapeTargets),
+		},
+	}, nil
+}
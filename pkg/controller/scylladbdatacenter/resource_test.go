@@ -329,6 +329,7 @@ func TestMemberService(t *testing.T) {
 					},
 					Annotations: map[string]string{
 						"foo": "bar",
+						"internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys": "foo",
 					},
 					OwnerReferences: basicSCOwnerRefs,
 				},
@@ -486,6 +487,7 @@ func TestMemberService(t *testing.T) {
 						annotations := basicSVCAnnotations()
 						annotations["custom-rack-template-service-annotation"] = "custom-rack-template-service-annotation-value"
 						annotations["custom-rack-service-annotation"] = "custom-rack-service-annotation-value"
+						annotations["internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys"] = "custom-rack-service-annotation,custom-rack-template-service-annotation"
 						return annotations
 					}(),
 					OwnerReferences: basicSCOwnerRefs,
@@ -544,6 +546,7 @@ func TestMemberService(t *testing.T) {
 					Annotations: func() map[string]string {
 						annotations := basicSVCAnnotations()
 						annotations["custom-rack-service-annotation"] = "bar"
+						annotations["internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys"] = "custom-rack-service-annotation"
 						return annotations
 					}(),
 					OwnerReferences: basicSCOwnerRefs,
@@ -618,6 +621,87 @@ func TestMemberService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "external-dns annotation from node service template is added",
+			scyllaDBDatacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := basicSC.DeepCopy()
+				sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+					NodeService: &scyllav1alpha1.NodeServiceTemplate{
+						ObjectTemplateMetadata: scyllav1alpha1.ObjectTemplateMetadata{
+							Annotations: map[string]string{
+								"external-dns.alpha.kubernetes.io/hostname": "a.example.com",
+							},
+						},
+						Type: scyllav1alpha1.NodeServiceTypeClusterIP,
+					},
+				}
+				return sdc
+			}(),
+			rackName:   basicRackName,
+			svcName:    basicSVCName,
+			oldService: nil,
+			jobs:       nil,
+			expectedService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   basicSVCName,
+					Labels: basicSVCLabels(),
+					Annotations: map[string]string{
+						"external-dns.alpha.kubernetes.io/hostname":                                  "a.example.com",
+						"internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys": "external-dns.alpha.kubernetes.io/hostname",
+					},
+					OwnerReferences: basicSCOwnerRefs,
+				},
+				Spec: corev1.ServiceSpec{
+					Type:                     corev1.ServiceTypeClusterIP,
+					Selector:                 basicSVCSelector,
+					PublishNotReadyAddresses: true,
+					Ports:                    basicPorts,
+				},
+			},
+		},
+		{
+			name: "external-dns annotation removed from node service template is marked for removal",
+			scyllaDBDatacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := basicSC.DeepCopy()
+				sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+					NodeService: &scyllav1alpha1.NodeServiceTemplate{
+						Type: scyllav1alpha1.NodeServiceTypeClusterIP,
+					},
+				}
+				return sdc
+			}(),
+			rackName: basicRackName,
+			svcName:  basicSVCName,
+			oldService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: basicSVCName,
+					Annotations: map[string]string{
+						"external-dns.alpha.kubernetes.io/hostname":                                  "a.example.com",
+						"internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys": "external-dns.alpha.kubernetes.io/hostname",
+					},
+				},
+			},
+			jobs: nil,
+			expectedService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   basicSVCName,
+					Labels: basicSVCLabels(),
+					Annotations: func() map[string]string {
+						annotations := basicSVCAnnotations()
+						annotations["external-dns.alpha.kubernetes.io/hostname-"] = ""
+						annotations["internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys-"] = ""
+						return annotations
+					}(),
+					OwnerReferences: basicSCOwnerRefs,
+				},
+				Spec: corev1.ServiceSpec{
+					Type:                     corev1.ServiceTypeClusterIP,
+					Selector:                 basicSVCSelector,
+					PublishNotReadyAddresses: true,
+					Ports:                    basicPorts,
+				},
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -1816,6 +1900,139 @@ func TestStatefulSetForRackWithReversedTLSFeature(t *testing.T) {
 	t.Run("", TestStatefulSetForRack)
 }
 
+func TestStatefulSetForRackDataDirPreparation(t *testing.T) {
+	t.Parallel()
+
+	newScyllaDBDatacenter := func(options *scyllav1alpha1.DataDirectoryPreparationOptions) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.Spec.DataDirectoryPreparation = options
+		return sdc
+	}
+
+	newDataDirPreparationContainer := func(script string) corev1.Container {
+		return corev1.Container{
+			Name:            naming.DataDirPreparationContainerName,
+			Image:           "scylladb/scylla-operator:latest",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command: []string{
+				"/bin/sh",
+				"-euc",
+				script,
+			},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:  pointer.Ptr(rootUID),
+				RunAsGroup: pointer.Ptr(rootGID),
+			},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10m"),
+					corev1.ResourceMemory: resource.MustParse("50Mi"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10m"),
+					corev1.ResourceMemory: resource.MustParse("50Mi"),
+				},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      naming.PVCTemplateName,
+					MountPath: naming.DataDir,
+				},
+			},
+		}
+	}
+
+	const xfsScript = `fs_type="$( stat -f -c '%T' "/var/lib/scylla" )"
+if [ "${fs_type}" != "xfs" ]; then
+  echo "Data directory /var/lib/scylla is on a ${fs_type} filesystem, XFS is required." >&2
+  exit 1
+fi`
+	const ownershipScript = `if [ "$( cat "/var/lib/scylla/.scylla-operator-ownership" 2>/dev/null || true )" != "999:1000" ] || [ "$( stat -c '%u:%g' "/var/lib/scylla" )" != "999:1000" ]; then
+  find "/var/lib/scylla" \( ! -user 999 -o ! -group 1000 \) -exec chown --no-dereference 999:1000 {} +
+  echo "999:1000" > "/var/lib/scylla/.scylla-operator-ownership"
+  chown 999:1000 "/var/lib/scylla/.scylla-operator-ownership"
+fi`
+
+	tt := []struct {
+		name                       string
+		scyllaDBDatacenter         *scyllav1alpha1.ScyllaDBDatacenter
+		existingScyllaDBDatacenter *scyllav1alpha1.ScyllaDBDatacenter
+		expectedContainers         []corev1.Container
+	}{
+		{
+			name:               "no init container when preparation isn't requested",
+			scyllaDBDatacenter: newScyllaDBDatacenter(nil),
+			expectedContainers: nil,
+		},
+		{
+			name:               "no init container when no preparation step is enabled",
+			scyllaDBDatacenter: newScyllaDBDatacenter(&scyllav1alpha1.DataDirectoryPreparationOptions{RequireXFS: pointer.Ptr(false)}),
+			expectedContainers: nil,
+		},
+		{
+			name: "injects the init container with all requested steps",
+			scyllaDBDatacenter: newScyllaDBDatacenter(&scyllav1alpha1.DataDirectoryPreparationOptions{
+				Ownership:  pointer.Ptr("999:1000"),
+				RequireXFS: pointer.Ptr(true),
+			}),
+			expectedContainers: []corev1.Container{
+				newDataDirPreparationContainer(xfsScript + "\n" + ownershipScript),
+			},
+		},
+		{
+			name: "doesn't duplicate the init container when the StatefulSet already has it",
+			scyllaDBDatacenter: newScyllaDBDatacenter(&scyllav1alpha1.DataDirectoryPreparationOptions{
+				Ownership: pointer.Ptr("999:1000"),
+			}),
+			existingScyllaDBDatacenter: newScyllaDBDatacenter(&scyllav1alpha1.DataDirectoryPreparationOptions{
+				Ownership: pointer.Ptr("999:1000"),
+			}),
+			expectedContainers: []corev1.Container{
+				newDataDirPreparationContainer(ownershipScript),
+			},
+		},
+		{
+			name:               "removes the init container when preparation is disabled",
+			scyllaDBDatacenter: newScyllaDBDatacenter(nil),
+			existingScyllaDBDatacenter: newScyllaDBDatacenter(&scyllav1alpha1.DataDirectoryPreparationOptions{
+				RequireXFS: pointer.Ptr(true),
+			}),
+			expectedContainers: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var existingSts *appsv1.StatefulSet
+			if tc.existingScyllaDBDatacenter != nil {
+				var err error
+				existingSts, err = StatefulSetForRack(tc.existingScyllaDBDatacenter.Spec.Racks[0], tc.existingScyllaDBDatacenter, nil, "scylladb/scylla-operator:latest", 0, "")
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sts, err := StatefulSetForRack(tc.scyllaDBDatacenter.Spec.Racks[0], tc.scyllaDBDatacenter, existingSts, "scylladb/scylla-operator:latest", 0, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotContainers []corev1.Container
+			for _, c := range sts.Spec.Template.Spec.InitContainers {
+				if c.Name == naming.DataDirPreparationContainerName {
+					gotContainers = append(gotContainers, c)
+				}
+			}
+
+			if !apiequality.Semantic.DeepEqual(gotContainers, tc.expectedContainers) {
+				t.Errorf("expected and got data dir preparation containers differ:\n%s", cmp.Diff(tc.expectedContainers, gotContainers))
+			}
+		})
+	}
+}
+
 func TestMakeIngresses(t *testing.T) {
 	basicScyllaCluster := &scyllav1alpha1.ScyllaDBDatacenter{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2683,6 +2900,7 @@ func TestMakeJobs(t *testing.T) {
 					Spec: batchv1.JobSpec{
 						Selector:       nil,
 						ManualSelector: pointer.Ptr(false),
+						BackoffLimit:   pointer.Ptr(int32(6)),
 						Template: corev1.PodTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Annotations: map[string]string{
@@ -2897,6 +3115,7 @@ func TestMakeJobs(t *testing.T) {
 					Spec: batchv1.JobSpec{
 						Selector:       nil,
 						ManualSelector: pointer.Ptr(false),
+						BackoffLimit:   pointer.Ptr(int32(6)),
 						Template: corev1.PodTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Annotations: map[string]string{
@@ -3024,6 +3243,7 @@ func TestMakeJobs(t *testing.T) {
 					Spec: batchv1.JobSpec{
 						Selector:       nil,
 						ManualSelector: pointer.Ptr(false),
+						BackoffLimit:   pointer.Ptr(int32(6)),
 						Template: corev1.PodTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Annotations: map[string]string{
@@ -3167,6 +3387,7 @@ func TestMakeJobs(t *testing.T) {
 					Spec: batchv1.JobSpec{
 						Selector:       nil,
 						ManualSelector: pointer.Ptr(false),
+						BackoffLimit:   pointer.Ptr(int32(6)),
 						Template: corev1.PodTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Annotations: map[string]string{
@@ -7,6 +7,7 @@ import (
 
 	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
 	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
 	"github.com/scylladb/scylla-operator/pkg/naming"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -126,6 +127,21 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		objectErrs = append(objectErrs, err)
 	}
 
+	roles, err := controllerhelpers.GetObjects[CT, *rbacv1.Role](
+		ctx,
+		sdc,
+		scyllav1alpha1.ScyllaDBDatacenterGVK,
+		sdcSelector,
+		controllerhelpers.ControlleeManagerGetObjectsFuncs[CT, *rbacv1.Role]{
+			GetControllerUncachedFunc: sdcc.scyllaClient.ScyllaDBDatacenters(sdc.Namespace).Get,
+			ListObjectsFunc:           sdcc.roleLister.Roles(sdc.Namespace).List,
+			PatchObjectFunc:           sdcc.kubeClient.RbacV1().Roles(sdc.Namespace).Patch,
+		},
+	)
+	if err != nil {
+		objectErrs = append(objectErrs, err)
+	}
+
 	roleBindings, err := controllerhelpers.GetObjects[CT, *rbacv1.RoleBinding](
 		ctx,
 		sdc,
@@ -171,6 +187,30 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		objectErrs = append(objectErrs, err)
 	}
 
+	// NetworkPolicies have always been created with the managed labels, so there is nothing to adopt or orphan.
+	networkPolicyMap, err := controllerhelpers.ListManagedObjects(sdc, sdcc.networkPolicyLister.NetworkPolicies(sdc.Namespace).List)
+	if err != nil {
+		objectErrs = append(objectErrs, err)
+	}
+
+	// ServiceMonitors have always been created with the managed labels, so there is nothing to adopt or orphan.
+	var serviceMonitorMap map[string]*monitoringv1.ServiceMonitor
+	if sdcc.serviceMonitorLister != nil {
+		serviceMonitorMap, err = controllerhelpers.ListManagedObjects(sdc, sdcc.serviceMonitorLister.ServiceMonitors(sdc.Namespace).List)
+		if err != nil {
+			objectErrs = append(objectErrs, err)
+		}
+	}
+
+	// PodMonitors have always been created with the managed labels, so there is nothing to adopt or orphan.
+	var podMonitorMap map[string]*monitoringv1.PodMonitor
+	if sdcc.podMonitorLister != nil {
+		podMonitorMap, err = controllerhelpers.ListManagedObjects(sdc, sdcc.podMonitorLister.PodMonitors(sdc.Namespace).List)
+		if err != nil {
+			objectErrs = append(objectErrs, err)
+		}
+	}
+
 	jobMap, err := controllerhelpers.GetObjects[CT, *batchv1.Job](
 		ctx,
 		sdc,
@@ -194,11 +234,49 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 	status := sdcc.calculateStatus(sdc, statefulSetMap)
 
 	if sdc.DeletionTimestamp != nil {
-		return sdcc.updateStatus(ctx, sdc, status)
+		err = controllerhelpers.RunSync(
+			&status.Conditions,
+			deletionCleanupControllerProgressingCondition,
+			deletionCleanupControllerDegradedCondition,
+			sdc.Generation,
+			func() ([]metav1.Condition, error) {
+				return sdcc.syncFinalizer(ctx, sdc)
+			},
+		)
+		return apimachineryutilerrors.NewAggregate([]error{err, sdcc.updateStatus(ctx, sdc, status)})
+	}
+
+	hasFinalizer := controllerhelpers.HasFinalizer(sdc, naming.ScyllaDBDatacenterDeletionCleanupFinalizer)
+	if sdc.Spec.DeletionCleanup != nil && !hasFinalizer {
+		err = sdcc.addFinalizer(ctx, sdc)
+		if err != nil {
+			return fmt.Errorf("can't add finalizer: %w", err)
+		}
+		return nil
+	}
+	if sdc.Spec.DeletionCleanup == nil && hasFinalizer {
+		err = sdcc.removeFinalizer(ctx, sdc)
+		if err != nil {
+			return fmt.Errorf("can't remove finalizer: %w", err)
+		}
+		return nil
 	}
 
 	var errs []error
 
+	err = controllerhelpers.RunSync(
+		&status.Conditions,
+		imagePullSecretControllerProgressingCondition,
+		imagePullSecretControllerDegradedCondition,
+		sdc.Generation,
+		func() ([]metav1.Condition, error) {
+			return sdcc.syncImagePullSecret(ctx, sdc, secretMap)
+		},
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("can't sync image pull secret: %w", err))
+	}
+
 	err = controllerhelpers.RunSync(
 		&status.Conditions,
 		serviceAccountControllerProgressingCondition,
@@ -218,11 +296,11 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		roleBindingControllerDegradedCondition,
 		sdc.Generation,
 		func() ([]metav1.Condition, error) {
-			return sdcc.syncRoleBindings(ctx, sdc, roleBindings)
+			return sdcc.syncRBAC(ctx, sdc, roles, roleBindings)
 		},
 	)
 	if err != nil {
-		errs = append(errs, fmt.Errorf("can't sync role bindings: %w", err))
+		errs = append(errs, fmt.Errorf("can't sync RBAC: %w", err))
 	}
 
 	err = controllerhelpers.RunSync(
@@ -257,7 +335,22 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		configControllerDegradedCondition,
 		sdc.Generation,
 		func() ([]metav1.Condition, error) {
-			return sdcc.syncConfigs(ctx, sdc)
+			var progressingConditions []metav1.Condition
+			var configErrs []error
+
+			configProgressingConditions, err := sdcc.syncConfigs(ctx, sdc)
+			progressingConditions = append(progressingConditions, configProgressingConditions...)
+			if err != nil {
+				configErrs = append(configErrs, err)
+			}
+
+			exporterProgressingConditions, err := sdcc.syncExporterConfig(ctx, sdc, configMapMap)
+			progressingConditions = append(progressingConditions, exporterProgressingConditions...)
+			if err != nil {
+				configErrs = append(configErrs, fmt.Errorf("can't sync exporter config: %w", err))
+			}
+
+			return progressingConditions, apimachineryutilerrors.NewAggregate(configErrs)
 		},
 	)
 	if err != nil {
@@ -270,7 +363,7 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		statefulSetControllerDegradedCondition,
 		sdc.Generation,
 		func() ([]metav1.Condition, error) {
-			return sdcc.syncStatefulSets(ctx, key, sdc, status, statefulSetMap, serviceMap, configMapMap)
+			return sdcc.syncStatefulSets(ctx, key, sdc, status, statefulSetMap, serviceMap, configMapMap, secretMap)
 		},
 	)
 	if err != nil {
@@ -308,6 +401,40 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		errs = append(errs, fmt.Errorf("can't sync pdbs: %w", err))
 	}
 
+	err = controllerhelpers.RunSync(
+		&status.Conditions,
+		pvcControllerProgressingCondition,
+		pvcControllerDegradedCondition,
+		sdc.Generation,
+		func() ([]metav1.Condition, error) {
+			var progressingConditions []metav1.Condition
+			var pvcErrs []error
+
+			storageProgressingConditions, err := sdcc.syncPVCStorage(ctx, sdc)
+			progressingConditions = append(progressingConditions, storageProgressingConditions...)
+			if err != nil {
+				pvcErrs = append(pvcErrs, fmt.Errorf("can't sync pvc storage: %w", err))
+			}
+
+			orphanedProgressingConditions, err := sdcc.syncOrphanedPVCs(ctx, sdc, statefulSetMap)
+			progressingConditions = append(progressingConditions, orphanedProgressingConditions...)
+			if err != nil {
+				pvcErrs = append(pvcErrs, fmt.Errorf("can't sync orphaned pvcs: %w", err))
+			}
+
+			labelsProgressingConditions, err := sdcc.syncPVCLabels(ctx, sdc)
+			progressingConditions = append(progressingConditions, labelsProgressingConditions...)
+			if err != nil {
+				pvcErrs = append(pvcErrs, fmt.Errorf("can't sync pvc labels: %w", err))
+			}
+
+			return progressingConditions, apimachineryutilerrors.NewAggregate(pvcErrs)
+		},
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("can't sync pvcs: %w", err))
+	}
+
 	err = controllerhelpers.RunSync(
 		&status.Conditions,
 		ingressControllerProgressingCondition,
@@ -321,6 +448,45 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		errs = append(errs, fmt.Errorf("can't sync ingresses: %w", err))
 	}
 
+	err = controllerhelpers.RunSync(
+		&status.Conditions,
+		networkPolicyControllerProgressingCondition,
+		networkPolicyControllerDegradedCondition,
+		sdc.Generation,
+		func() ([]metav1.Condition, error) {
+			return sdcc.syncNetworkPolicies(ctx, sdc, networkPolicyMap)
+		},
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("can't sync network policies: %w", err))
+	}
+
+	err = controllerhelpers.RunSync(
+		&status.Conditions,
+		serviceMonitorControllerProgressingCondition,
+		serviceMonitorControllerDegradedCondition,
+		sdc.Generation,
+		func() ([]metav1.Condition, error) {
+			return sdcc.syncServiceMonitors(ctx, sdc, serviceMonitorMap)
+		},
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("can't sync service monitors: %w", err))
+	}
+
+	err = controllerhelpers.RunSync(
+		&status.Conditions,
+		podMonitorControllerProgressingCondition,
+		podMonitorControllerDegradedCondition,
+		sdc.Generation,
+		func() ([]metav1.Condition, error) {
+			return sdcc.syncPodMonitors(ctx, sdc, podMonitorMap)
+		},
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("can't sync pod monitors: %w", err))
+	}
+
 	err = controllerhelpers.RunSync(
 		&status.Conditions,
 		jobControllerProgressingCondition,
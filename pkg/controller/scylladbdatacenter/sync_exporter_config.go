@@ -0,0 +1,60 @@
+// Copyright (C) 2025 ScyllaDB
+
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// syncExporterConfig applies the scrape targets of the metrics exporter and prunes the exporter configs
+// that are no longer required, e.g. after the ScyllaDBDatacenter was renamed by a migration.
+func (sdcc *Controller) syncExporterConfig(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	configMaps map[string]*corev1.ConfigMap,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	requiredConfigMap, err := MakeExporterConfigMap(sdc)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't make exporter config: %w", err)
+	}
+
+	exporterConfigMaps := map[string]*corev1.ConfigMap{}
+	for name, cm := range configMaps {
+		if cm.Labels[naming.ConfigMapTypeLabel] == string(naming.ExporterConfigConfigMapType) {
+			exporterConfigMaps[name] = cm
+		}
+	}
+
+	err = controllerhelpers.Prune(
+		ctx,
+		[]*corev1.ConfigMap{requiredConfigMap},
+		exporterConfigMaps,
+		&controllerhelpers.PruneControlFuncs{
+			DeleteFunc: sdcc.kubeClient.CoreV1().ConfigMaps(sdc.Namespace).Delete,
+		},
+		sdcc.eventRecorder,
+	)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't prune exporter config(s): %w", err)
+	}
+
+	_, changed, err := resourceapply.ApplyConfigMap(ctx, sdcc.kubeClient.CoreV1(), sdcc.configMapLister, sdcc.eventRecorder, requiredConfigMap, resourceapply.ApplyOptions{})
+	if changed {
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, configControllerProgressingCondition, requiredConfigMap, "apply", sdc.Generation)
+	}
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't apply configmap %q: %w", naming.ObjRef(requiredConfigMap), err)
+	}
+
+	return progressingConditions, nil
+}
@@ -0,0 +1,209 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMakeExporterConfigMap(t *testing.T) {
+	t.Parallel()
+
+	sdc := newScyllaDBDatacenterWithRacks("a", "b")
+
+	cm, err := MakeExporterConfigMap(sdc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedScrapeTargets := `[
+  {
+    "targets": [
+      "basic-dc-a-0.default.svc:9180",
+      "basic-dc-b-0.default.svc:9180"
+    ],
+    "labels": {
+      "cluster": "basic",
+      "datacenter": "dc",
+      "job": "scylla"
+    }
+  },
+  {
+    "targets": [
+      "basic-dc-a-0.default.svc:9100",
+      "basic-dc-b-0.default.svc:9100"
+    ],
+    "labels": {
+      "cluster": "basic",
+      "datacenter": "dc",
+      "job": "node-exporter"
+    }
+  }
+]`
+	if got := cm.Data[naming.ExporterScrapeTargetsKey]; got != expectedScrapeTargets {
+		t.Errorf("expected and got scrape targets differ:\n%s", cmp.Diff(expectedScrapeTargets, got))
+	}
+
+	if cm.Labels[naming.ConfigMapTypeLabel] != string(naming.ExporterConfigConfigMapType) {
+		t.Errorf("expected config map type label %q, got %q", naming.ExporterConfigConfigMapType, cm.Labels[naming.ConfigMapTypeLabel])
+	}
+}
+
+func TestController_syncExporterConfig(t *testing.T) {
+	t.Parallel()
+
+	newStaleExporterConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "old-exporter-config",
+				Namespace: "default",
+				UID:       "old-exporter-config-uid",
+				Labels: map[string]string{
+					naming.ConfigMapTypeLabel: string(naming.ExporterConfigConfigMapType),
+				},
+			},
+		}
+	}
+
+	newOtherConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic-config",
+				Namespace: "default",
+				UID:       "basic-config-uid",
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		initialSDC             *scyllav1alpha1.ScyllaDBDatacenter
+		existingObjects        []runtime.Object
+		sdc                    *scyllav1alpha1.ScyllaDBDatacenter
+		expectedWrites         []string
+		expectedConfigMaps     []string
+		expectedProgressingLen int
+	}{
+		{
+			name:                   "creates the exporter config",
+			initialSDC:             nil,
+			sdc:                    newScyllaDBDatacenterWithRacks("a", "b"),
+			expectedWrites:         []string{"create configmaps"},
+			expectedConfigMaps:     []string{"basic-exporter-config"},
+			expectedProgressingLen: 1,
+		},
+		{
+			name:                   "reordering the targets doesn't apply",
+			initialSDC:             newScyllaDBDatacenterWithRacks("a", "b"),
+			sdc:                    newScyllaDBDatacenterWithRacks("b", "a"),
+			expectedWrites:         nil,
+			expectedConfigMaps:     []string{"basic-exporter-config"},
+			expectedProgressingLen: 0,
+		},
+		{
+			name:       "adding a scrape target applies",
+			initialSDC: newScyllaDBDatacenterWithRacks("a", "b"),
+			sdc: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newScyllaDBDatacenterWithRacks("a", "b")
+				sdc.Spec.Racks[1].Nodes = pointer.Ptr[int32](2)
+				return sdc
+			}(),
+			expectedWrites:         []string{"update configmaps"},
+			expectedConfigMaps:     []string{"basic-exporter-config"},
+			expectedProgressingLen: 1,
+		},
+		{
+			name:       "prunes stale exporter configs only",
+			initialSDC: newScyllaDBDatacenterWithRacks("a"),
+			existingObjects: []runtime.Object{
+				newStaleExporterConfigMap(),
+				newOtherConfigMap(),
+			},
+			sdc:                    newScyllaDBDatacenterWithRacks("a"),
+			expectedWrites:         []string{"delete configmaps"},
+			expectedConfigMaps:     []string{"basic-config", "basic-exporter-config"},
+			expectedProgressingLen: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			existingObjects := slices.Clone(tc.existingObjects)
+			if tc.initialSDC != nil {
+				cm, err := MakeExporterConfigMap(tc.initialSDC)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = resourceapply.SetHashAnnotation(cm)
+				if err != nil {
+					t.Fatal(err)
+				}
+				existingObjects = append(existingObjects, cm)
+			}
+
+			client := fake.NewSimpleClientset(existingObjects...)
+			sdcc, _ := newTestController(t, ctx, client)
+
+			// The controller only sees the ConfigMaps it owns, the existing ones in the test count as such.
+			configMaps := map[string]*corev1.ConfigMap{}
+			cmList, err := client.CoreV1().ConfigMaps(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range cmList.Items {
+				configMaps[cmList.Items[i].Name] = &cmList.Items[i]
+			}
+
+			client.ClearActions()
+
+			progressingConditions, err := sdcc.syncExporterConfig(ctx, tc.sdc, configMaps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(progressingConditions) != tc.expectedProgressingLen {
+				t.Errorf("expected %d progressing conditions, got %d: %v", tc.expectedProgressingLen, len(progressingConditions), progressingConditions)
+			}
+
+			var gotWrites []string
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "list" || action.GetVerb() == "get" {
+					continue
+				}
+				gotWrites = append(gotWrites, action.GetVerb()+" "+action.GetResource().Resource)
+			}
+			if !reflect.DeepEqual(gotWrites, tc.expectedWrites) {
+				t.Errorf("expected and got writes differ:\n%s", cmp.Diff(tc.expectedWrites, gotWrites))
+			}
+
+			cmList, err = client.CoreV1().ConfigMaps(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotConfigMaps []string
+			for _, cm := range cmList.Items {
+				gotConfigMaps = append(gotConfigMaps, cm.Name)
+			}
+			if !reflect.DeepEqual(gotConfigMaps, tc.expectedConfigMaps) {
+				t.Errorf("expected and got configmaps differ:\n%s", cmp.Diff(tc.expectedConfigMaps, gotConfigMaps))
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 ScyllaDB
+
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// syncFinalizer runs the deletion cleanup Job of a ScyllaDBDatacenter being deleted
+// and removes the finalizer once the Job succeeds. A failed Job is returned as an error, keeping the finalizer in place.
+func (sdcc *Controller) syncFinalizer(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	if !controllerhelpers.HasFinalizer(sdc, naming.ScyllaDBDatacenterDeletionCleanupFinalizer) {
+		klog.V(4).InfoS("Object is already finalized", "ScyllaDBDatacenter", klog.KObj(sdc), "UID", sdc.UID)
+		return progressingConditions, nil
+	}
+
+	if sdc.Spec.DeletionCleanup == nil {
+		klog.V(4).InfoS("Deletion cleanup is disabled, removing finalizer", "ScyllaDBDatacenter", klog.KObj(sdc), "UID", sdc.UID)
+		err := sdcc.removeFinalizer(ctx, sdc)
+		if err != nil {
+			return progressingConditions, fmt.Errorf("can't remove finalizer: %w", err)
+		}
+		return progressingConditions, nil
+	}
+
+	klog.V(4).InfoS("Finalizing object", "ScyllaDBDatacenter", klog.KObj(sdc), "UID", sdc.UID)
+
+	job := MakeDeletionCleanupJob(sdc)
+	fresh, changed, err := resourceapply.ApplyJob(ctx, sdcc.kubeClient.BatchV1(), sdcc.jobLister, sdcc.eventRecorder, job, resourceapply.ApplyOptions{})
+	if changed {
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, deletionCleanupControllerProgressingCondition, job, "apply", sdc.Generation)
+	}
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't apply deletion cleanup Job: %w", err)
+	}
+
+	completed, err := controllerhelpers.IsJobCompleted(fresh)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't clean up after ScyllaDBDatacenter %q: %w", naming.ObjRef(sdc), err)
+	}
+
+	if !completed {
+		progressingConditions = append(progressingConditions, metav1.Condition{
+			Type:               deletionCleanupControllerProgressingCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "WaitingForJobCompletion",
+			Message:            fmt.Sprintf("Waiting for Job %q to complete.", naming.ObjRef(fresh)),
+			ObservedGeneration: sdc.Generation,
+		})
+		return progressingConditions, nil
+	}
+
+	klog.V(2).InfoS("Deletion cleanup Job has completed, removing finalizer", "ScyllaDBDatacenter", klog.KObj(sdc), "Job", klog.KObj(fresh))
+	err = sdcc.removeFinalizer(ctx, sdc)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't remove finalizer: %w", err)
+	}
+
+	return progressingConditions, nil
+}
+
+func (sdcc *Controller) addFinalizer(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter) error {
+	patch, err := controllerhelpers.AddFinalizerPatch(sdc, naming.ScyllaDBDatacenterDeletionCleanupFinalizer)
+	if err != nil {
+		return fmt.Errorf("can't create add finalizer patch: %w", err)
+	}
+
+	if patch == nil {
+		return nil
+	}
+
+	_, err = sdcc.scyllaClient.ScyllaDBDatacenters(sdc.Namespace).Patch(ctx, sdc.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("can't patch ScyllaDBDatacenter %q: %w", naming.ObjRef(sdc), err)
+	}
+
+	klog.V(2).InfoS("Added finalizer to ScyllaDBDatacenter", "ScyllaDBDatacenter", klog.KObj(sdc))
+	return nil
+}
+
+func (sdcc *Controller) removeFinalizer(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter) error {
+	return controllerhelpers.RemoveFinalizer(ctx, sdc, naming.ScyllaDBDatacenterDeletionCleanupFinalizer, sdcc.scyllaClient.ScyllaDBDatacenters(sdc.Namespace).Patch)
+}
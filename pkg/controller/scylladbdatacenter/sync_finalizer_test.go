@@ -0,0 +1,136 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	scyllafake "github.com/scylladb/scylla-operator/pkg/client/scylla/clientset/versioned/fake"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncFinalizer(t *testing.T) {
+	t.Parallel()
+
+	newDeletedSDC := func() *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.DeletionTimestamp = &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+		sdc.Finalizers = []string{naming.ScyllaDBDatacenterDeletionCleanupFinalizer}
+		sdc.Spec.DeletionCleanup = &scyllav1alpha1.DeletionCleanupOptions{
+			Image: "docker.io/scylladb/backup-cleanup:latest",
+			Args:  []string{"--remove-markers"},
+		}
+		return sdc
+	}
+
+	tt := []struct {
+		name                   string
+		jobCondition           batchv1.JobConditionType
+		expectedFinalizers     []string
+		expectedDegradedStatus metav1.ConditionStatus
+	}{
+		{
+			name:                   "finalizer is removed after the cleanup job succeeds",
+			jobCondition:           batchv1.JobComplete,
+			expectedFinalizers:     nil,
+			expectedDegradedStatus: metav1.ConditionFalse,
+		},
+		{
+			name:                   "finalizer is retained and degraded condition is set when the cleanup job fails",
+			jobCondition:           batchv1.JobFailed,
+			expectedFinalizers:     []string{naming.ScyllaDBDatacenterDeletionCleanupFinalizer},
+			expectedDegradedStatus: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			sdc := newDeletedSDC()
+			kubeClient := fake.NewSimpleClientset()
+			scyllaClient := scyllafake.NewSimpleClientset(sdc)
+
+			// runSync mirrors how sync runs the finalizer for objects being deleted.
+			runSync := func() []metav1.Condition {
+				t.Helper()
+
+				sdcc, _ := newTestController(t, ctx, kubeClient)
+				sdcc.scyllaClient = scyllaClient.ScyllaV1alpha1()
+
+				var conditions []metav1.Condition
+				_ = controllerhelpers.RunSync(
+					&conditions,
+					deletionCleanupControllerProgressingCondition,
+					deletionCleanupControllerDegradedCondition,
+					sdc.Generation,
+					func() ([]metav1.Condition, error) {
+						return sdcc.syncFinalizer(ctx, sdc)
+					},
+				)
+				return conditions
+			}
+
+			getFinalizers := func() []string {
+				t.Helper()
+
+				fresh, err := scyllaClient.ScyllaV1alpha1().ScyllaDBDatacenters(sdc.Namespace).Get(ctx, sdc.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return fresh.Finalizers
+			}
+
+			conditions := runSync()
+			if !apimeta.IsStatusConditionTrue(conditions, deletionCleanupControllerProgressingCondition) {
+				t.Errorf("expected the finalizer to be progressing while the job runs, got %v", conditions)
+			}
+
+			job, err := kubeClient.BatchV1().Jobs(sdc.Namespace).Get(ctx, naming.DeletionCleanupJobName(sdc), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected the cleanup job to be created: %v", err)
+			}
+			if got := job.Spec.Template.Spec.Containers[0].Image; got != sdc.Spec.DeletionCleanup.Image {
+				t.Errorf("expected job image %q, got %q", sdc.Spec.DeletionCleanup.Image, got)
+			}
+
+			finalizers := getFinalizers()
+			if len(finalizers) != 1 {
+				t.Fatalf("expected the finalizer to be retained while the job runs, got %v", finalizers)
+			}
+
+			job = job.DeepCopy()
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:   tc.jobCondition,
+					Status: corev1.ConditionTrue,
+				},
+			}
+			_, err = kubeClient.BatchV1().Jobs(sdc.Namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			conditions = runSync()
+			degradedCondition := apimeta.FindStatusCondition(conditions, deletionCleanupControllerDegradedCondition)
+			if degradedCondition == nil || degradedCondition.Status != tc.expectedDegradedStatus {
+				t.Errorf("expected degraded condition status %q, got %v", tc.expectedDegradedStatus, degradedCondition)
+			}
+
+			finalizers = getFinalizers()
+			if len(finalizers) != len(tc.expectedFinalizers) {
+				t.Errorf("expected finalizers %v, got %v", tc.expectedFinalizers, finalizers)
+			}
+		})
+	}
+}
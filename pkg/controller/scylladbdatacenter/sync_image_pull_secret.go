@@ -0,0 +1,78 @@
+// Copyright (C) 2025 ScyllaDB
+
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (sdcc *Controller) syncImagePullSecret(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	secrets map[string]*corev1.Secret,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	if sdc.Spec.ImagePullCredentials == nil {
+		existing, ok := secrets[naming.ImagePullSecretName(sdc)]
+		if !ok || existing.DeletionTimestamp != nil {
+			return progressingConditions, nil
+		}
+
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, imagePullSecretControllerProgressingCondition, existing, "delete", sdc.Generation)
+		err := sdcc.kubeClient.CoreV1().Secrets(existing.Namespace).Delete(ctx, existing.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID: &existing.UID,
+			},
+			PropagationPolicy: pointer.Ptr(metav1.DeletePropagationBackground),
+		})
+		resourceapply.ReportDeleteEvent(sdcc.eventRecorder, existing, err)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return progressingConditions, fmt.Errorf("can't delete image pull secret: %w", err)
+		}
+
+		return progressingConditions, nil
+	}
+
+	credentialsSecretName := sdc.Spec.ImagePullCredentials.SecretName
+	credentials, err := sdcc.secretLister.Secrets(sdc.Namespace).Get(credentialsSecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			progressingConditions = append(progressingConditions, metav1.Condition{
+				Type:               imagePullSecretControllerProgressingCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "WaitingForSecret",
+				Message:            fmt.Sprintf("Waiting for Secret %q to exist.", naming.ManualRef(sdc.Namespace, credentialsSecretName)),
+				ObservedGeneration: sdc.Generation,
+			})
+			return progressingConditions, nil
+		}
+		return progressingConditions, fmt.Errorf("can't get secret %q: %w", naming.ManualRef(sdc.Namespace, credentialsSecretName), err)
+	}
+
+	secret, err := MakePullSecret(sdc, credentials)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't make image pull secret: %w", err)
+	}
+
+	// Events only ever reference the Secret, its data never leaves the apply.
+	_, changed, err := resourceapply.ApplySecret(ctx, sdcc.kubeClient.CoreV1(), sdcc.secretLister, sdcc.eventRecorder, secret, resourceapply.ApplyOptions{})
+	if changed {
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, imagePullSecretControllerProgressingCondition, secret, "apply", sdc.Generation)
+	}
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't apply image pull secret: %w", err)
+	}
+
+	return progressingConditions, nil
+}
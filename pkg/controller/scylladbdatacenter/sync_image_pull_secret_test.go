@@ -0,0 +1,161 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncImagePullSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	sdc := newBasicScyllaDBDatacenter()
+	sdc.Spec.ImagePullCredentials = &scyllav1alpha1.ImagePullCredentials{
+		Registry:   "registry.example.com",
+		SecretName: "registry-credentials",
+	}
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-credentials",
+			Namespace: sdc.Namespace,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("scylla"),
+			corev1.BasicAuthPasswordKey: []byte("first-password"),
+		},
+	}
+
+	client := fake.NewSimpleClientset(credentials)
+
+	sync := func() []string {
+		t.Helper()
+
+		sdcc, recorder := newTestController(t, ctx, client)
+		secrets, err := client.CoreV1().Secrets(sdc.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var managedSecrets []*corev1.Secret
+		for i := range secrets.Items {
+			if metav1.IsControlledBy(&secrets.Items[i], sdc) {
+				managedSecrets = append(managedSecrets, &secrets.Items[i])
+			}
+		}
+
+		_, err = sdcc.syncImagePullSecret(ctx, sdc, mapByName(managedSecrets))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		close(recorder.Events)
+		var events []string
+		for e := range recorder.Events {
+			events = append(events, e)
+		}
+		return events
+	}
+
+	expectAuth := func(password string) {
+		t.Helper()
+
+		secret, err := client.CoreV1().Secrets(sdc.Namespace).Get(ctx, naming.ImagePullSecretName(sdc), metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			t.Errorf("expected secret type %q, got %q", corev1.SecretTypeDockerConfigJson, secret.Type)
+		}
+
+		var config dockerConfigJSON
+		err = json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		auth, ok := config.Auths["registry.example.com"]
+		if !ok {
+			t.Fatalf("expected docker config to hold credentials of the registry, got %v", config.Auths)
+		}
+		if auth.Username != "scylla" || auth.Password != password {
+			t.Errorf("expected credentials %q:%q, got %q:%q", "scylla", password, auth.Username, auth.Password)
+		}
+	}
+
+	expectNoCredentialsInEvents := func(events []string) {
+		t.Helper()
+
+		for _, e := range events {
+			if strings.Contains(e, "password") {
+				t.Errorf("expected the event to not leak credentials, got %q", e)
+			}
+		}
+	}
+
+	events := sync()
+	expectAuth("first-password")
+	expectNoCredentialsInEvents(events)
+	expectedEvents := []string{"Normal SecretCreated Secret default/basic-image-pull created"}
+	if !reflect.DeepEqual(events, expectedEvents) {
+		t.Errorf("expected and got events differ:\n%s", cmp.Diff(expectedEvents, events))
+	}
+
+	events = sync()
+	if len(events) != 0 {
+		t.Errorf("expected no events when nothing changed, got %q", events)
+	}
+
+	rotated := credentials.DeepCopy()
+	rotated.Data[corev1.BasicAuthPasswordKey] = []byte("second-password")
+	_, err := client.CoreV1().Secrets(sdc.Namespace).Update(ctx, rotated, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events = sync()
+	expectAuth("second-password")
+	expectNoCredentialsInEvents(events)
+	expectedEvents = []string{"Normal SecretUpdated Secret default/basic-image-pull updated"}
+	if !reflect.DeepEqual(events, expectedEvents) {
+		t.Errorf("expected and got events differ:\n%s", cmp.Diff(expectedEvents, events))
+	}
+
+	sdc.Spec.ImagePullCredentials = nil
+	sync()
+	_, err = client.CoreV1().Secrets(sdc.Namespace).Get(ctx, naming.ImagePullSecretName(sdc), metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the image pull secret to be deleted when credentials are removed, got %v", err)
+	}
+}
+
+func TestMakeServiceAccountImagePullSecrets(t *testing.T) {
+	t.Parallel()
+
+	sdc := newBasicScyllaDBDatacenter()
+	if sa := MakeServiceAccount(sdc); sa.ImagePullSecrets != nil {
+		t.Errorf("expected no image pull secrets without credentials, got %v", sa.ImagePullSecrets)
+	}
+
+	sdc.Spec.ImagePullCredentials = &scyllav1alpha1.ImagePullCredentials{
+		Registry:   "registry.example.com",
+		SecretName: "registry-credentials",
+	}
+	expected := []corev1.LocalObjectReference{{Name: "basic-image-pull"}}
+	if sa := MakeServiceAccount(sdc); !reflect.DeepEqual(sa.ImagePullSecrets, expected) {
+		t.Errorf("expected and got image pull secrets differ:\n%s", cmp.Diff(expected, sa.ImagePullSecrets))
+	}
+}
@@ -24,6 +24,11 @@ func (sdcc *Controller) syncIngresses(
 
 	requiredIngresses := MakeIngresses(sdc, services)
 
+	managementIngress := MakeManagementIngress(sdc)
+	if managementIngress != nil {
+		requiredIngresses = append(requiredIngresses, managementIngress)
+	}
+
 	// Delete any excessive Ingresses.
 	// Delete has to be the fist action to avoid getting stuck on quota.
 	var deletionErrors []error
@@ -0,0 +1,155 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncIngressesManagementAPI(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(ingressOptions *scyllav1alpha1.ManagementAPIExposeIngressOptions) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newBasicScyllaDBDatacenter()
+		if ingressOptions != nil {
+			sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+				ManagementAPI: &scyllav1alpha1.ManagementAPIExposeOptions{
+					Ingress: ingressOptions,
+				},
+			}
+		}
+		return sdc
+	}
+
+	newIngress := func(host, path string) *networkingv1.Ingress {
+		ingress := MakeManagementIngress(newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+			Host: host,
+			Path: path,
+		}))
+		ingress.UID = "ingress-uid"
+		return ingress
+	}
+
+	tt := []struct {
+		name                   string
+		sdc                    *scyllav1alpha1.ScyllaDBDatacenter
+		existingIngresses      []*networkingv1.Ingress
+		expectedIngresses      []*networkingv1.Ingress
+		expectedProgressingLen int
+	}{
+		{
+			name:                   "doesn't create an ingress when it isn't enabled",
+			sdc:                    newSDC(nil),
+			existingIngresses:      nil,
+			expectedIngresses:      nil,
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "creates an ingress when it's enabled",
+			sdc: newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+				Host: "agent.scylladb.com",
+			}),
+			existingIngresses: nil,
+			expectedIngresses: []*networkingv1.Ingress{
+				MakeManagementIngress(newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+					Host: "agent.scylladb.com",
+				})),
+			},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "prunes the ingress when it's disabled",
+			sdc:  newSDC(nil),
+			existingIngresses: []*networkingv1.Ingress{
+				newIngress("agent.scylladb.com", "/"),
+			},
+			expectedIngresses:      nil,
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "updates the ingress when its host changes",
+			sdc: newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+				Host: "new-agent.scylladb.com",
+			}),
+			existingIngresses: []*networkingv1.Ingress{
+				newIngress("agent.scylladb.com", "/"),
+			},
+			expectedIngresses: []*networkingv1.Ingress{
+				MakeManagementIngress(newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+					Host: "new-agent.scylladb.com",
+				})),
+			},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "updates the ingress when its path changes",
+			sdc: newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+				Host: "agent.scylladb.com",
+				Path: "/agent",
+			}),
+			existingIngresses: []*networkingv1.Ingress{
+				newIngress("agent.scylladb.com", "/"),
+			},
+			expectedIngresses: []*networkingv1.Ingress{
+				MakeManagementIngress(newSDC(&scyllav1alpha1.ManagementAPIExposeIngressOptions{
+					Host: "agent.scylladb.com",
+					Path: "/agent",
+				})),
+			},
+			expectedProgressingLen: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var existingObjects []runtime.Object
+			for _, ingress := range tc.existingIngresses {
+				existingObjects = append(existingObjects, ingress)
+			}
+
+			client := fake.NewSimpleClientset(existingObjects...)
+			sdcc, _ := newTestController(t, ctx, client)
+
+			progressingConditions, err := sdcc.syncIngresses(ctx, tc.sdc, mapByName(tc.existingIngresses), map[string]*corev1.Service{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(progressingConditions) != tc.expectedProgressingLen {
+				t.Errorf("expected %d progressing conditions, got %d: %v", tc.expectedProgressingLen, len(progressingConditions), progressingConditions)
+			}
+
+			gotIngressList, err := client.NetworkingV1().Ingresses(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotIngresses []*networkingv1.Ingress
+			for i := range gotIngressList.Items {
+				ingress := gotIngressList.Items[i].DeepCopy()
+				// Drop fields managed by the apply.
+				ingress.UID = ""
+				delete(ingress.Annotations, naming.ManagedHash)
+				gotIngresses = append(gotIngresses, ingress)
+			}
+			if !reflect.DeepEqual(gotIngresses, tc.expectedIngresses) {
+				t.Errorf("expected and got ingresses differ:\n%s", cmp.Diff(tc.expectedIngresses, gotIngresses))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func (sdcc *Controller) syncNetworkPolicies(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	networkPolicies map[string]*networkingv1.NetworkPolicy,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	requiredNetworkPolicies, err := MakeScyllaNetworkPolicies(sdc)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't make network policies: %w", err)
+	}
+
+	// Delete any excessive NetworkPolicies.
+	// Delete has to be the fist action to avoid getting stuck on quota.
+	var deletionErrors []error
+	for _, networkPolicy := range networkPolicies {
+		if networkPolicy.DeletionTimestamp != nil {
+			continue
+		}
+
+		isRequired := false
+		for _, req := range requiredNetworkPolicies {
+			if networkPolicy.Name == req.Name {
+				isRequired = true
+			}
+		}
+		if isRequired {
+			continue
+		}
+
+		propagationPolicy := metav1.DeletePropagationBackground
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, networkPolicyControllerProgressingCondition, networkPolicy, "delete", sdc.Generation)
+		err = sdcc.kubeClient.NetworkingV1().NetworkPolicies(networkPolicy.Namespace).Delete(ctx, networkPolicy.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID: &networkPolicy.UID,
+			},
+			PropagationPolicy: &propagationPolicy,
+		})
+		resourceapply.ReportDeleteEvent(sdcc.eventRecorder, networkPolicy, err)
+		deletionErrors = append(deletionErrors, err)
+	}
+	err = apimachineryutilerrors.NewAggregate(deletionErrors)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't delete network policy(s): %w", err)
+	}
+
+	for _, requiredNetworkPolicy := range requiredNetworkPolicies {
+		_, changed, err := resourceapply.ApplyNetworkPolicy(ctx, sdcc.kubeClient.NetworkingV1(), sdcc.networkPolicyLister, sdcc.eventRecorder, requiredNetworkPolicy, resourceapply.ApplyOptions{})
+		if changed {
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, networkPolicyControllerProgressingCondition, requiredNetworkPolicy, "apply", sdc.Generation)
+		}
+		if err != nil {
+			return progressingConditions, fmt.Errorf("can't apply network policy: %w", err)
+		}
+	}
+
+	return progressingConditions, nil
+}
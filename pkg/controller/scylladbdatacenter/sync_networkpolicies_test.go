@@ -0,0 +1,265 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncNetworkPolicies(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(networkPolicy *scyllav1alpha1.NetworkPolicyOptions) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.Spec.NetworkPolicy = networkPolicy
+		return sdc
+	}
+
+	// withHashAnnotation marks the NetworkPolicy as applied, so it's only updated when it changes.
+	withHashAnnotation := func(np *networkingv1.NetworkPolicy) *networkingv1.NetworkPolicy {
+		err := resourceapply.SetHashAnnotation(np)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return np
+	}
+
+	newDefaultDenyNetworkPolicy := func() *networkingv1.NetworkPolicy {
+		np := withHashAnnotation(MakeScyllaDefaultDenyNetworkPolicy(newSDC(&scyllav1alpha1.NetworkPolicyOptions{})))
+		np.UID = "np-default-deny-uid"
+		return np
+	}
+
+	newAllowOverlay := func(name string) scyllav1alpha1.NetworkPolicyAllowOverlay {
+		return scyllav1alpha1.NetworkPolicyAllowOverlay{
+			Name: name,
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					IPBlock: &networkingv1.IPBlock{
+						CIDR: "172.16.0.0/12",
+					},
+				},
+			},
+		}
+	}
+
+	newAllowOverlayNetworkPolicy := func(name string) *networkingv1.NetworkPolicy {
+		overlay := newAllowOverlay(name)
+		np := withHashAnnotation(MakeScyllaAllowOverlayNetworkPolicy(newSDC(&scyllav1alpha1.NetworkPolicyOptions{}), &overlay))
+		np.UID = types.UID("np-allow-" + name + "-uid")
+		return np
+	}
+
+	newNetworkPolicy := func(allowedClientCIDRs ...string) *networkingv1.NetworkPolicy {
+		np, err := MakeScyllaNetworkPolicy(newSDC(&scyllav1alpha1.NetworkPolicyOptions{
+			AllowedClientCIDRs: allowedClientCIDRs,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		np = withHashAnnotation(np)
+		np.UID = "np-uid"
+		return np
+	}
+
+	clientCIDRs := func(np *networkingv1.NetworkPolicy) []string {
+		var cidrs []string
+		for _, rule := range np.Spec.Ingress {
+			for _, peer := range rule.From {
+				if peer.IPBlock != nil {
+					cidrs = append(cidrs, peer.IPBlock.CIDR)
+				}
+			}
+		}
+		return cidrs
+	}
+
+	tt := []struct {
+		name                    string
+		sdc                     *scyllav1alpha1.ScyllaDBDatacenter
+		existingNetworkPolicies []*networkingv1.NetworkPolicy
+		expectedNetworkPolicies []string
+		expectedClientCIDRs     []string
+		expectedProgressingLen  int
+	}{
+		{
+			name:                    "doesn't create a network policy when it isn't enabled",
+			sdc:                     newSDC(nil),
+			existingNetworkPolicies: nil,
+			expectedNetworkPolicies: nil,
+			expectedProgressingLen:  0,
+		},
+		{
+			name:                    "creates the baseline and the network policy when it's enabled",
+			sdc:                     newSDC(&scyllav1alpha1.NetworkPolicyOptions{}),
+			existingNetworkPolicies: nil,
+			expectedNetworkPolicies: []string{"basic", "basic-default-deny"},
+			expectedClientCIDRs:     nil,
+			expectedProgressingLen:  2,
+		},
+		{
+			name: "prunes the network policies when it's disabled",
+			sdc:  newSDC(nil),
+			existingNetworkPolicies: []*networkingv1.NetworkPolicy{
+				newDefaultDenyNetworkPolicy(),
+				newNetworkPolicy(),
+				newAllowOverlayNetworkPolicy("monitoring"),
+			},
+			expectedNetworkPolicies: nil,
+			expectedProgressingLen:  3,
+		},
+		{
+			name: "applies an added allow overlay",
+			sdc: newSDC(&scyllav1alpha1.NetworkPolicyOptions{
+				AllowOverlays: []scyllav1alpha1.NetworkPolicyAllowOverlay{
+					newAllowOverlay("monitoring"),
+				},
+			}),
+			existingNetworkPolicies: []*networkingv1.NetworkPolicy{
+				newDefaultDenyNetworkPolicy(),
+				newNetworkPolicy(),
+			},
+			expectedNetworkPolicies: []string{"basic", "basic-allow-monitoring", "basic-default-deny"},
+			expectedProgressingLen:  1,
+		},
+		{
+			name: "prunes a removed allow overlay while the baseline persists",
+			sdc:  newSDC(&scyllav1alpha1.NetworkPolicyOptions{}),
+			existingNetworkPolicies: []*networkingv1.NetworkPolicy{
+				newDefaultDenyNetworkPolicy(),
+				newNetworkPolicy(),
+				newAllowOverlayNetworkPolicy("monitoring"),
+			},
+			expectedNetworkPolicies: []string{"basic", "basic-default-deny"},
+			expectedProgressingLen:  1,
+		},
+		{
+			name: "updates the network policy when allowed client CIDRs change",
+			sdc: newSDC(&scyllav1alpha1.NetworkPolicyOptions{
+				AllowedClientCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			}),
+			existingNetworkPolicies: []*networkingv1.NetworkPolicy{
+				newDefaultDenyNetworkPolicy(),
+				newNetworkPolicy("10.0.0.0/8"),
+			},
+			expectedNetworkPolicies: []string{"basic", "basic-default-deny"},
+			expectedClientCIDRs:     []string{"10.0.0.0/8", "192.168.0.0/16"},
+			expectedProgressingLen:  1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var existingObjects []runtime.Object
+			for _, np := range tc.existingNetworkPolicies {
+				existingObjects = append(existingObjects, np)
+			}
+
+			client := fake.NewSimpleClientset(existingObjects...)
+			sdcc, _ := newTestController(t, ctx, client)
+
+			progressingConditions, err := sdcc.syncNetworkPolicies(ctx, tc.sdc, mapByName(tc.existingNetworkPolicies))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(progressingConditions) != tc.expectedProgressingLen {
+				t.Errorf("expected %d progressing conditions, got %d: %v", tc.expectedProgressingLen, len(progressingConditions), progressingConditions)
+			}
+
+			gotNetworkPolicies, err := client.NetworkingV1().NetworkPolicies(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotNames []string
+			for _, np := range gotNetworkPolicies.Items {
+				gotNames = append(gotNames, np.Name)
+			}
+			slices.Sort(gotNames)
+			if !reflect.DeepEqual(gotNames, tc.expectedNetworkPolicies) {
+				t.Errorf("expected and got network policies differ:\n%s", cmp.Diff(tc.expectedNetworkPolicies, gotNames))
+			}
+
+			for i := range gotNetworkPolicies.Items {
+				np := &gotNetworkPolicies.Items[i]
+				if np.Name != naming.NetworkPolicyName(tc.sdc) {
+					continue
+				}
+
+				gotClientCIDRs := clientCIDRs(np)
+				if !reflect.DeepEqual(gotClientCIDRs, tc.expectedClientCIDRs) {
+					t.Errorf("expected and got client CIDRs differ:\n%s", cmp.Diff(tc.expectedClientCIDRs, gotClientCIDRs))
+				}
+			}
+		})
+	}
+}
+
+func TestMakeScyllaNetworkPolicies(t *testing.T) {
+	t.Parallel()
+
+	sdc := newBasicScyllaDBDatacenter()
+	sdc.Spec.NetworkPolicy = &scyllav1alpha1.NetworkPolicyOptions{
+		AllowOverlays: []scyllav1alpha1.NetworkPolicyAllowOverlay{
+			{Name: "zeta"},
+			{Name: "alpha"},
+			{Name: "monitoring"},
+		},
+	}
+
+	networkPolicies, err := MakeScyllaNetworkPolicies(sdc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotNames []string
+	for _, np := range networkPolicies {
+		gotNames = append(gotNames, np.Name)
+	}
+
+	expectedNames := []string{
+		"basic-default-deny",
+		"basic",
+		"basic-allow-alpha",
+		"basic-allow-monitoring",
+		"basic-allow-zeta",
+	}
+	if !reflect.DeepEqual(gotNames, expectedNames) {
+		t.Errorf("expected and got network policies differ:\n%s", cmp.Diff(expectedNames, gotNames))
+	}
+
+	baseline := networkPolicies[0]
+	if len(baseline.Spec.Ingress) != 0 {
+		t.Errorf("expected the baseline to admit no ingress traffic, got %v", baseline.Spec.Ingress)
+	}
+	if !reflect.DeepEqual(baseline.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}) {
+		t.Errorf("expected the baseline to isolate ingress, got policy types %v", baseline.Spec.PolicyTypes)
+	}
+
+	expectedOverlayNames := []string{"zeta", "alpha", "monitoring"}
+	gotOverlayNames := make([]string, 0, len(sdc.Spec.NetworkPolicy.AllowOverlays))
+	for _, overlay := range sdc.Spec.NetworkPolicy.AllowOverlays {
+		gotOverlayNames = append(gotOverlayNames, overlay.Name)
+	}
+	if !reflect.DeepEqual(gotOverlayNames, expectedOverlayNames) {
+		t.Errorf("ScyllaDBDatacenter was mutated, expected and got overlays differ:\n%s", cmp.Diff(expectedOverlayNames, gotOverlayNames))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func (sdcc *Controller) syncPodDisruptionBudgets(
@@ -17,10 +18,17 @@ func (sdcc *Controller) syncPodDisruptionBudgets(
 	sdc *scyllav1alpha1.ScyllaDBDatacenter,
 	pdbs map[string]*policyv1.PodDisruptionBudget,
 ) ([]metav1.Condition, error) {
-	var err error
 	var progressingConditions []metav1.Condition
 
-	requiredPDB := MakePodDisruptionBudget(sdc)
+	requiredPDBs, err := MakePodDisruptionBudgets(sdc)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't make pdbs: %w", err)
+	}
+
+	requiredPDBNames := sets.New[string]()
+	for _, requiredPDB := range requiredPDBs {
+		requiredPDBNames.Insert(requiredPDB.Name)
+	}
 
 	// Delete any excessive PodDisruptionBudgets.
 	// Delete has to be the fist action to avoid getting stuck on quota.
@@ -30,7 +38,7 @@ func (sdcc *Controller) syncPodDisruptionBudgets(
 			continue
 		}
 
-		if pdb.Name == requiredPDB.Name {
+		if requiredPDBNames.Has(pdb.Name) {
 			continue
 		}
 
@@ -49,16 +57,19 @@ func (sdcc *Controller) syncPodDisruptionBudgets(
 		return progressingConditions, fmt.Errorf("can't delete pdb(s): %w", err)
 	}
 
-	// TODO: Remove forced ownership in v1.5 (#672)
-	_, changed, err := resourceapply.ApplyPodDisruptionBudget(ctx, sdcc.kubeClient.PolicyV1(), sdcc.pdbLister, sdcc.eventRecorder, requiredPDB, resourceapply.ApplyOptions{
-		ForceOwnership: true,
-	})
-	if changed {
-		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, pdbControllerProgressingCondition, requiredPDB, "apply", sdc.Generation)
-	}
-	if err != nil {
-		return progressingConditions, fmt.Errorf("can't apply pdb: %w", err)
+	var errs []error
+	for _, requiredPDB := range requiredPDBs {
+		// TODO: Remove forced ownership in v1.5 (#672)
+		_, changed, err := resourceapply.ApplyPodDisruptionBudget(ctx, sdcc.kubeClient.PolicyV1(), sdcc.pdbLister, sdcc.eventRecorder, requiredPDB, resourceapply.ApplyOptions{
+			ForceOwnership: true,
+		})
+		if changed {
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, pdbControllerProgressingCondition, requiredPDB, "apply", sdc.Generation)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't apply pdb: %w", err))
+		}
 	}
 
-	return progressingConditions, nil
+	return progressingConditions, apimachineryutilerrors.NewAggregate(errs)
 }
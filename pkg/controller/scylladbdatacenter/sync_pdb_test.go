@@ -0,0 +1,324 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachineryutilintstr "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newScyllaDBDatacenterWithRacks(rackNames ...string) *scyllav1alpha1.ScyllaDBDatacenter {
+	sdc := newBasicScyllaDBDatacenter()
+	sdc.Spec.Racks = nil
+	for _, rackName := range rackNames {
+		sdc.Spec.Racks = append(sdc.Spec.Racks, scyllav1alpha1.RackSpec{
+			Name: rackName,
+			RackTemplate: scyllav1alpha1.RackTemplate{
+				Nodes: pointer.Ptr[int32](1),
+			},
+		})
+	}
+	return sdc
+}
+
+func newScyllaDBDatacenterWithRackPDBs(rackNames ...string) *scyllav1alpha1.ScyllaDBDatacenter {
+	sdc := newScyllaDBDatacenterWithRacks(rackNames...)
+	sdc.Spec.DisruptionTolerance = &scyllav1alpha1.DisruptionToleranceOptions{
+		PerRack: true,
+	}
+	return sdc
+}
+
+func TestMakeRackPodDisruptionBudgets(t *testing.T) {
+	t.Parallel()
+
+	sdc := newScyllaDBDatacenterWithRacks("a", "b")
+
+	pdbs, err := MakeRackPodDisruptionBudgets(sdc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pdbs) != len(sdc.Spec.Racks) {
+		t.Fatalf("expected %d pdbs, got %d", len(sdc.Spec.Racks), len(pdbs))
+	}
+
+	for i, rack := range sdc.Spec.Racks {
+		expectedName := "basic-dc-" + rack.Name
+		if pdbs[i].Name != expectedName {
+			t.Errorf("expected pdb name %q, got %q", expectedName, pdbs[i].Name)
+		}
+
+		expectedMatchLabels := map[string]string{
+			"app":                          "scylla",
+			"app.kubernetes.io/managed-by": "scylla-operator",
+			"app.kubernetes.io/name":       "scylla",
+			"scylla/cluster":               "basic",
+			"scylla/datacenter":            "dc",
+			"scylla/rack":                  rack.Name,
+		}
+		if !reflect.DeepEqual(pdbs[i].Spec.Selector.MatchLabels, expectedMatchLabels) {
+			t.Errorf("expected and got match labels differ:\n%s", cmp.Diff(expectedMatchLabels, pdbs[i].Spec.Selector.MatchLabels))
+		}
+	}
+}
+
+func TestController_syncPodDisruptionBudgets(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                string
+		existingSDC         *scyllav1alpha1.ScyllaDBDatacenter
+		sdc                 *scyllav1alpha1.ScyllaDBDatacenter
+		expectedProgressing bool
+		expectedNames       []string
+	}{
+		{
+			name:                "single rack uses the cluster-wide pdb",
+			existingSDC:         nil,
+			sdc:                 newScyllaDBDatacenterWithRacks("a"),
+			expectedProgressing: true,
+			expectedNames:       []string{"basic"},
+		},
+		{
+			name:                "existing multi-rack datacenter keeps its cluster-wide pdb",
+			existingSDC:         newScyllaDBDatacenterWithRacks("a", "b"),
+			sdc:                 newScyllaDBDatacenterWithRacks("a", "b"),
+			expectedProgressing: false,
+			expectedNames:       []string{"basic"},
+		},
+		{
+			name:                "adding a rack without opting in keeps the cluster-wide pdb",
+			existingSDC:         newScyllaDBDatacenterWithRacks("a"),
+			sdc:                 newScyllaDBDatacenterWithRacks("a", "b"),
+			expectedProgressing: false,
+			expectedNames:       []string{"basic"},
+		},
+		{
+			name:                "opting in replaces the cluster-wide pdb with per-rack pdbs",
+			existingSDC:         newScyllaDBDatacenterWithRacks("a", "b"),
+			sdc:                 newScyllaDBDatacenterWithRackPDBs("a", "b"),
+			expectedProgressing: true,
+			expectedNames:       []string{"basic-dc-a", "basic-dc-b"},
+		},
+		{
+			name:                "opting out replaces the per-rack pdbs with the cluster-wide pdb",
+			existingSDC:         newScyllaDBDatacenterWithRackPDBs("a", "b"),
+			sdc:                 newScyllaDBDatacenterWithRacks("a", "b"),
+			expectedProgressing: true,
+			expectedNames:       []string{"basic"},
+		},
+		{
+			name:                "adding a rack creates its pdb",
+			existingSDC:         newScyllaDBDatacenterWithRackPDBs("a", "b"),
+			sdc:                 newScyllaDBDatacenterWithRackPDBs("a", "b", "c"),
+			expectedProgressing: true,
+			expectedNames:       []string{"basic-dc-a", "basic-dc-b", "basic-dc-c"},
+		},
+		{
+			name:                "removing a rack prunes its pdb",
+			existingSDC:         newScyllaDBDatacenterWithRackPDBs("a", "b", "c"),
+			sdc:                 newScyllaDBDatacenterWithRackPDBs("a", "c"),
+			expectedProgressing: true,
+			expectedNames:       []string{"basic-dc-a", "basic-dc-c"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var existingObjects []runtime.Object
+			if tc.existingSDC != nil {
+				existingPDBs, err := MakePodDisruptionBudgets(tc.existingSDC)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, pdb := range existingPDBs {
+					err = resourceapply.SetHashAnnotation(pdb)
+					if err != nil {
+						t.Fatal(err)
+					}
+					existingObjects = append(existingObjects, pdb)
+				}
+			}
+
+			client := fake.NewSimpleClientset(existingObjects...)
+
+			// Reconciliation needs to be stable, so running the sync the second time must not make any changes.
+			for i := range 2 {
+				sdcc, _ := newTestController(t, ctx, client)
+
+				pdbs, err := sdcc.pdbLister.PodDisruptionBudgets(tc.sdc.Namespace).List(labels.Everything())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				progressingConditions, err := sdcc.syncPodDisruptionBudgets(ctx, tc.sdc, mapByName(pdbs))
+				if err != nil {
+					t.Fatalf("iteration %d: unexpected error: %v", i, err)
+				}
+
+				if i == 0 && (len(progressingConditions) != 0) != tc.expectedProgressing {
+					t.Errorf("iteration %d: expected progressing %t, got %v", i, tc.expectedProgressing, progressingConditions)
+				}
+				if i > 0 && len(progressingConditions) != 0 {
+					t.Errorf("iteration %d: expected no progressing conditions, got %v", i, progressingConditions)
+				}
+			}
+
+			gotPDBs, err := client.PolicyV1().PodDisruptionBudgets(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotNames []string
+			for _, pdb := range gotPDBs.Items {
+				gotNames = append(gotNames, pdb.Name)
+			}
+			if !reflect.DeepEqual(gotNames, tc.expectedNames) {
+				t.Errorf("expected and got pdbs differ:\n%s", cmp.Diff(tc.expectedNames, gotNames))
+			}
+		})
+	}
+}
+
+func TestMakePodDisruptionBudgetsMaxUnavailable(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(nodes int32, tolerance *scyllav1alpha1.DisruptionToleranceOptions, rackNames ...string) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newScyllaDBDatacenterWithRacks(rackNames...)
+		for i := range sdc.Spec.Racks {
+			sdc.Spec.Racks[i].Nodes = pointer.Ptr(nodes)
+		}
+		sdc.Spec.DisruptionTolerance = tolerance
+		return sdc
+	}
+
+	tt := []struct {
+		name                   string
+		sdc                    *scyllav1alpha1.ScyllaDBDatacenter
+		expectedMaxUnavailable []apimachineryutilintstr.IntOrString
+	}{
+		{
+			name:                   "one node without a tolerance",
+			sdc:                    newSDC(6, nil, "a"),
+			expectedMaxUnavailable: []apimachineryutilintstr.IntOrString{apimachineryutilintstr.FromInt32(1)},
+		},
+		{
+			name:                   "percentage of the datacenter with a single rack",
+			sdc:                    newSDC(6, &scyllav1alpha1.DisruptionToleranceOptions{MaxUnavailablePercent: 50}, "a"),
+			expectedMaxUnavailable: []apimachineryutilintstr.IntOrString{apimachineryutilintstr.FromInt32(3)},
+		},
+		{
+			name:                   "percentage of the datacenter with multiple racks",
+			sdc:                    newSDC(5, &scyllav1alpha1.DisruptionToleranceOptions{MaxUnavailablePercent: 50}, "a", "b"),
+			expectedMaxUnavailable: []apimachineryutilintstr.IntOrString{apimachineryutilintstr.FromInt32(5)},
+		},
+		{
+			name:                   "percentage of each rack with per-rack pdbs",
+			sdc:                    newSDC(5, &scyllav1alpha1.DisruptionToleranceOptions{MaxUnavailablePercent: 50, PerRack: true}, "a", "b"),
+			expectedMaxUnavailable: []apimachineryutilintstr.IntOrString{apimachineryutilintstr.FromInt32(2), apimachineryutilintstr.FromInt32(2)},
+		},
+		{
+			name:                   "at least one node",
+			sdc:                    newSDC(3, &scyllav1alpha1.DisruptionToleranceOptions{MaxUnavailablePercent: 0}, "a"),
+			expectedMaxUnavailable: []apimachineryutilintstr.IntOrString{apimachineryutilintstr.FromInt32(1)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pdbs, err := MakePodDisruptionBudgets(tc.sdc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotMaxUnavailable []apimachineryutilintstr.IntOrString
+			for _, pdb := range pdbs {
+				gotMaxUnavailable = append(gotMaxUnavailable, *pdb.Spec.MaxUnavailable)
+			}
+			if !reflect.DeepEqual(gotMaxUnavailable, tc.expectedMaxUnavailable) {
+				t.Errorf("expected and got maxUnavailable differ:\n%s", cmp.Diff(tc.expectedMaxUnavailable, gotMaxUnavailable))
+			}
+		})
+	}
+}
+
+func TestController_syncPodDisruptionBudgetsScaling(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	sdc := newScyllaDBDatacenterWithRacks("a")
+	sdc.Spec.DisruptionTolerance = &scyllav1alpha1.DisruptionToleranceOptions{
+		MaxUnavailablePercent: 50,
+	}
+
+	client := fake.NewSimpleClientset()
+
+	sync := func(nodes int32) []metav1.Condition {
+		t.Helper()
+
+		sdc.Spec.Racks[0].Nodes = pointer.Ptr(nodes)
+
+		sdcc, _ := newTestController(t, ctx, client)
+		pdbs, err := sdcc.pdbLister.PodDisruptionBudgets(sdc.Namespace).List(labels.Everything())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		progressingConditions, err := sdcc.syncPodDisruptionBudgets(ctx, sdc, mapByName(pdbs))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return progressingConditions
+	}
+
+	expectMaxUnavailable := func(expected apimachineryutilintstr.IntOrString) {
+		t.Helper()
+
+		pdb, err := client.PolicyV1().PodDisruptionBudgets(sdc.Namespace).Get(ctx, "basic", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*pdb.Spec.MaxUnavailable, expected) {
+			t.Errorf("expected maxUnavailable %v, got %v", expected, *pdb.Spec.MaxUnavailable)
+		}
+	}
+
+	if progressingConditions := sync(2); len(progressingConditions) == 0 {
+		t.Errorf("expected the pdb to be created")
+	}
+	expectMaxUnavailable(apimachineryutilintstr.FromInt32(1))
+
+	if progressingConditions := sync(2); len(progressingConditions) != 0 {
+		t.Errorf("expected no changes without scaling, got %v", progressingConditions)
+	}
+
+	if progressingConditions := sync(6); len(progressingConditions) == 0 {
+		t.Errorf("expected scaling out to update the pdb")
+	}
+	expectMaxUnavailable(apimachineryutilintstr.FromInt32(3))
+
+	if progressingConditions := sync(4); len(progressingConditions) == 0 {
+		t.Errorf("expected scaling in to update the pdb")
+	}
+	expectMaxUnavailable(apimachineryutilintstr.FromInt32(2))
+}
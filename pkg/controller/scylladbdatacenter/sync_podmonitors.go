@@ -0,0 +1,81 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func (sdcc *Controller) syncPodMonitors(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	podMonitors map[string]*monitoringv1.PodMonitor,
+) ([]metav1.Condition, error) {
+	var err error
+	var progressingConditions []metav1.Condition
+
+	if sdcc.podMonitorLister == nil {
+		if sdc.Spec.PodMonitor != nil {
+			return progressingConditions, fmt.Errorf("can't create pod monitor: CRD %q wasn't available when the operator started", "podmonitors.monitoring.coreos.com")
+		}
+
+		return progressingConditions, nil
+	}
+
+	var requiredPodMonitors []*monitoringv1.PodMonitor
+	if sdc.Spec.PodMonitor != nil {
+		requiredPodMonitors = append(requiredPodMonitors, MakePodMonitor(sdc))
+	}
+
+	requiredPodMonitorNames := sets.New[string]()
+	for _, requiredPodMonitor := range requiredPodMonitors {
+		requiredPodMonitorNames.Insert(requiredPodMonitor.Name)
+	}
+
+	// Delete any excessive PodMonitors.
+	// Delete has to be the fist action to avoid getting stuck on quota.
+	var deletionErrors []error
+	for _, podMonitor := range podMonitors {
+		if podMonitor.DeletionTimestamp != nil {
+			continue
+		}
+
+		if requiredPodMonitorNames.Has(podMonitor.Name) {
+			continue
+		}
+
+		propagationPolicy := metav1.DeletePropagationBackground
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, podMonitorControllerProgressingCondition, podMonitor, "delete", sdc.Generation)
+		err = sdcc.monitoringClient.PodMonitors(podMonitor.Namespace).Delete(ctx, podMonitor.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID: &podMonitor.UID,
+			},
+			PropagationPolicy: &propagationPolicy,
+		})
+		resourceapply.ReportDeleteEvent(sdcc.eventRecorder, podMonitor, err)
+		deletionErrors = append(deletionErrors, err)
+	}
+	err = apimachineryutilerrors.NewAggregate(deletionErrors)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't delete pod monitor(s): %w", err)
+	}
+
+	for _, requiredPodMonitor := range requiredPodMonitors {
+		_, changed, err := resourceapply.ApplyPodMonitor(ctx, sdcc.monitoringClient, sdcc.podMonitorLister, sdcc.eventRecorder, requiredPodMonitor, resourceapply.ApplyOptions{})
+		if changed {
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, podMonitorControllerProgressingCondition, requiredPodMonitor, "apply", sdc.Generation)
+		}
+		if err != nil {
+			return progressingConditions, fmt.Errorf("can't apply pod monitor: %w", err)
+		}
+	}
+
+	return progressingConditions, nil
+}
@@ -0,0 +1,201 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
+	monitoringfake "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/clientset/versioned/fake"
+	monitoringv1listers "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/listers/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncMonitorsSwitchingModes(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(serviceMonitor *scyllav1alpha1.ServiceMonitorOptions, podMonitor *scyllav1alpha1.PodMonitorOptions) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.Spec.ServiceMonitor = serviceMonitor
+		sdc.Spec.PodMonitor = podMonitor
+		return sdc
+	}
+
+	newServiceMonitor := func() *monitoringv1.ServiceMonitor {
+		sm := MakeServiceMonitor(newSDC(&scyllav1alpha1.ServiceMonitorOptions{}, nil))
+		sm.UID = "sm-uid"
+		return sm
+	}
+
+	newPodMonitor := func() *monitoringv1.PodMonitor {
+		pm := MakePodMonitor(newSDC(nil, &scyllav1alpha1.PodMonitorOptions{}))
+		pm.UID = "pm-uid"
+		return pm
+	}
+
+	tt := []struct {
+		name                    string
+		sdc                     *scyllav1alpha1.ScyllaDBDatacenter
+		existingServiceMonitors []*monitoringv1.ServiceMonitor
+		existingPodMonitors     []*monitoringv1.PodMonitor
+		expectedServiceMonitors []string
+		expectedPodMonitors     []string
+	}{
+		{
+			name:                    "creates a pod monitor when it's enabled",
+			sdc:                     newSDC(nil, &scyllav1alpha1.PodMonitorOptions{}),
+			existingServiceMonitors: nil,
+			existingPodMonitors:     nil,
+			expectedServiceMonitors: nil,
+			expectedPodMonitors:     []string{"basic"},
+		},
+		{
+			name:                    "switches from a service monitor to a pod monitor",
+			sdc:                     newSDC(nil, &scyllav1alpha1.PodMonitorOptions{}),
+			existingServiceMonitors: []*monitoringv1.ServiceMonitor{newServiceMonitor()},
+			existingPodMonitors:     nil,
+			expectedServiceMonitors: nil,
+			expectedPodMonitors:     []string{"basic"},
+		},
+		{
+			name:                    "switches from a pod monitor to a service monitor",
+			sdc:                     newSDC(&scyllav1alpha1.ServiceMonitorOptions{}, nil),
+			existingServiceMonitors: nil,
+			existingPodMonitors:     []*monitoringv1.PodMonitor{newPodMonitor()},
+			expectedServiceMonitors: []string{"basic"},
+			expectedPodMonitors:     nil,
+		},
+		{
+			name:                    "prunes the pod monitor when monitoring is disabled",
+			sdc:                     newSDC(nil, nil),
+			existingServiceMonitors: nil,
+			existingPodMonitors:     []*monitoringv1.PodMonitor{newPodMonitor()},
+			expectedServiceMonitors: nil,
+			expectedPodMonitors:     nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var existingObjects []runtime.Object
+			for _, sm := range tc.existingServiceMonitors {
+				existingObjects = append(existingObjects, sm)
+			}
+			for _, pm := range tc.existingPodMonitors {
+				existingObjects = append(existingObjects, pm)
+			}
+
+			monitoringClient := monitoringfake.NewSimpleClientset(existingObjects...)
+			sdcc, _ := newTestController(t, ctx, fake.NewSimpleClientset())
+			sdcc.monitoringClient = monitoringClient.MonitoringV1()
+			sdcc.serviceMonitorLister = monitoringv1listers.NewServiceMonitorLister(newIndexer(t, tc.existingServiceMonitors))
+			sdcc.podMonitorLister = monitoringv1listers.NewPodMonitorLister(newIndexer(t, tc.existingPodMonitors))
+
+			_, err := sdcc.syncServiceMonitors(ctx, tc.sdc, mapByName(tc.existingServiceMonitors))
+			if err != nil {
+				t.Fatalf("unexpected error syncing service monitors: %v", err)
+			}
+
+			_, err = sdcc.syncPodMonitors(ctx, tc.sdc, mapByName(tc.existingPodMonitors))
+			if err != nil {
+				t.Fatalf("unexpected error syncing pod monitors: %v", err)
+			}
+
+			serviceMonitors, err := monitoringClient.MonitoringV1().ServiceMonitors(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotServiceMonitors []string
+			for _, sm := range serviceMonitors.Items {
+				gotServiceMonitors = append(gotServiceMonitors, sm.Name)
+			}
+			if !reflect.DeepEqual(gotServiceMonitors, tc.expectedServiceMonitors) {
+				t.Errorf("expected and got service monitors differ:\n%s", cmp.Diff(tc.expectedServiceMonitors, gotServiceMonitors))
+			}
+
+			podMonitors, err := monitoringClient.MonitoringV1().PodMonitors(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotPodMonitors []string
+			for _, pm := range podMonitors.Items {
+				gotPodMonitors = append(gotPodMonitors, pm.Name)
+			}
+			if !reflect.DeepEqual(gotPodMonitors, tc.expectedPodMonitors) {
+				t.Errorf("expected and got pod monitors differ:\n%s", cmp.Diff(tc.expectedPodMonitors, gotPodMonitors))
+			}
+		})
+	}
+}
+
+func TestController_syncMonitorsWithoutCRDs(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                         string
+		serviceMonitor               *scyllav1alpha1.ServiceMonitorOptions
+		podMonitor                   *scyllav1alpha1.PodMonitorOptions
+		expectedServiceMonitorsError error
+		expectedPodMonitorsError     error
+	}{
+		{
+			name:                         "succeeds when monitoring isn't requested",
+			serviceMonitor:               nil,
+			podMonitor:                   nil,
+			expectedServiceMonitorsError: nil,
+			expectedPodMonitorsError:     nil,
+		},
+		{
+			name:                         "fails when a service monitor is requested",
+			serviceMonitor:               &scyllav1alpha1.ServiceMonitorOptions{},
+			podMonitor:                   nil,
+			expectedServiceMonitorsError: errors.New(`can't create service monitor: CRD "servicemonitors.monitoring.coreos.com" wasn't available when the operator started`),
+			expectedPodMonitorsError:     nil,
+		},
+		{
+			name:                         "fails when a pod monitor is requested",
+			serviceMonitor:               nil,
+			podMonitor:                   &scyllav1alpha1.PodMonitorOptions{},
+			expectedServiceMonitorsError: nil,
+			expectedPodMonitorsError:     errors.New(`can't create pod monitor: CRD "podmonitors.monitoring.coreos.com" wasn't available when the operator started`),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			sdc := newBasicScyllaDBDatacenter()
+			sdc.Spec.ServiceMonitor = tc.serviceMonitor
+			sdc.Spec.PodMonitor = tc.podMonitor
+
+			sdcc, _ := newTestController(t, ctx, fake.NewSimpleClientset())
+			sdcc.serviceMonitorLister = nil
+			sdcc.podMonitorLister = nil
+
+			_, err := sdcc.syncServiceMonitors(ctx, sdc, nil)
+			if !reflect.DeepEqual(err, tc.expectedServiceMonitorsError) {
+				t.Errorf("expected service monitors error %v, got %v", tc.expectedServiceMonitorsError, err)
+			}
+
+			_, err = sdcc.syncPodMonitors(ctx, sdc, nil)
+			if !reflect.DeepEqual(err, tc.expectedPodMonitorsError) {
+				t.Errorf("expected pod monitors error %v, got %v", tc.expectedPodMonitorsError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// syncOrphanedPVCs removes data PersistentVolumeClaims that are left behind for ordinals no longer covered
+// by the desired number of nodes, if the retention policy asks for it.
+func (sdcc *Controller) syncOrphanedPVCs(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	statefulSets map[string]*appsv1.StatefulSet,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	if sdc.Spec.OrphanedPersistentVolumeClaimRetentionPolicy == nil ||
+		*sdc.Spec.OrphanedPersistentVolumeClaimRetentionPolicy != scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete {
+		return progressingConditions, nil
+	}
+
+	var errs []error
+	for _, rack := range sdc.Spec.Racks {
+		stsName := naming.StatefulSetNameForRack(rack, sdc)
+		sts, ok := statefulSets[stsName]
+		if !ok {
+			// Without the StatefulSet we can't tell which ordinals are still in use.
+			continue
+		}
+
+		desiredNodes, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get rack %q node count: %w", rack.Name, err))
+			continue
+		}
+
+		// Ordinals may still be in use until the StatefulSet finishes scaling down.
+		replicas := *desiredNodes
+		if sts.Spec.Replicas != nil {
+			replicas = max(replicas, *sts.Spec.Replicas)
+		}
+		replicas = max(replicas, sts.Status.Replicas)
+
+		rackSelectorLabels, err := naming.RackSelectorLabels(rack, sdc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get rack %q selector labels: %w", rack.Name, err))
+			continue
+		}
+
+		pvcs, err := sdcc.pvcLister.PersistentVolumeClaims(sdc.Namespace).List(labels.SelectorFromSet(rackSelectorLabels))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't list pvcs for rack %q: %w", rack.Name, err))
+			continue
+		}
+
+		pvcNamePrefix := naming.PVCNameForPod(stsName) + "-"
+		for _, pvc := range pvcs {
+			if pvc.DeletionTimestamp != nil {
+				continue
+			}
+
+			if !isOrphanedPVCCandidate(sdc, sts, pvc) {
+				klog.V(4).InfoS("Skipping PVC not owned by the operator", "ScyllaDBDatacenter", klog.KObj(sdc), "PVC", klog.KObj(pvc))
+				continue
+			}
+
+			ordinalString, ok := strings.CutPrefix(pvc.Name, pvcNamePrefix)
+			if !ok {
+				continue
+			}
+
+			ordinal, err := strconv.ParseInt(ordinalString, 10, 32)
+			if err != nil {
+				klog.V(4).InfoS("Skipping PVC with an unparsable ordinal", "PVC", klog.KObj(pvc))
+				continue
+			}
+
+			if int32(ordinal) < replicas {
+				continue
+			}
+
+			podName := fmt.Sprintf("%s-%d", stsName, ordinal)
+			_, err = sdcc.podLister.Pods(sdc.Namespace).Get(podName)
+			if err == nil {
+				progressingConditions = append(progressingConditions, metav1.Condition{
+					Type:               pvcControllerProgressingCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "WaitingForPodDeletion",
+					Message:            fmt.Sprintf("Waiting for Pod %q to be deleted before removing PVC %q.", naming.ManualRef(sdc.Namespace, podName), naming.ObjRef(pvc)),
+					ObservedGeneration: sdc.Generation,
+				})
+				continue
+			}
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("can't get pod %q: %w", naming.ManualRef(sdc.Namespace, podName), err))
+				continue
+			}
+
+			klog.V(2).InfoS("Deleting orphaned PVC", "ScyllaDBDatacenter", klog.KObj(sdc), "PVC", klog.KObj(pvc))
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, pvcControllerProgressingCondition, pvc, "delete", sdc.Generation)
+			propagationPolicy := metav1.DeletePropagationBackground
+			err = sdcc.kubeClient.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{
+					UID: &pvc.UID,
+				},
+				PropagationPolicy: &propagationPolicy,
+			})
+			resourceapply.ReportDeleteEvent(sdcc.eventRecorder, pvc, err)
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("can't delete pvc %q: %w", naming.ObjRef(pvc), err))
+				continue
+			}
+		}
+	}
+
+	return progressingConditions, apimachineryutilerrors.NewAggregate(errs)
+}
+
+// isOrphanedPVCCandidate tells whether the PVC belongs to the operator and may be deleted as orphaned.
+// It has to carry the operator's managed-by labels, and if something controls it, that has to be the rack StatefulSet.
+func isOrphanedPVCCandidate(sdc *scyllav1alpha1.ScyllaDBDatacenter, sts *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim) bool {
+	if !naming.ManagedBySelector(sdc).Matches(labels.Set(pvc.Labels)) {
+		return false
+	}
+
+	controllerRef := metav1.GetControllerOfNoCopy(pvc)
+	if controllerRef != nil && controllerRef.UID != sts.UID {
+		return false
+	}
+
+	return true
+}
+
+// syncPVCStorage expands the data PersistentVolumeClaims of every rack that requests less storage than the desired capacity.
+// StatefulSets never update PVCs created from their volumeClaimTemplates, so the growth has to be applied to them directly.
+// Shrinking isn't supported by Kubernetes and is rejected by the validation.
+func (sdcc *Controller) syncPVCStorage(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	var errs []error
+	for _, rack := range sdc.Spec.Racks {
+		rackSpec := applyRackTemplateOnRackSpec(sdc.Spec.RackTemplate, rack)
+		if rackSpec.ScyllaDB == nil || rackSpec.ScyllaDB.Storage == nil || len(rackSpec.ScyllaDB.Storage.Capacity) == 0 {
+			continue
+		}
+
+		capacity, err := resource.ParseQuantity(rackSpec.ScyllaDB.Storage.Capacity)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't parse rack %q storage capacity %q: %w", rack.Name, rackSpec.ScyllaDB.Storage.Capacity, err))
+			continue
+		}
+
+		rackSelectorLabels, err := naming.RackSelectorLabels(rack, sdc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get rack %q selector labels: %w", rack.Name, err))
+			continue
+		}
+
+		pvcs, err := sdcc.pvcLister.PersistentVolumeClaims(sdc.Namespace).List(labels.SelectorFromSet(rackSelectorLabels))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't list pvcs for rack %q: %w", rack.Name, err))
+			continue
+		}
+
+		pvcNamePrefix := naming.PVCNameForPod(naming.StatefulSetNameForRack(rack, sdc)) + "-"
+		for _, pvc := range pvcs {
+			if pvc.DeletionTimestamp != nil {
+				continue
+			}
+
+			if !strings.HasPrefix(pvc.Name, pvcNamePrefix) {
+				continue
+			}
+
+			requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			if requested.Cmp(capacity) >= 0 {
+				continue
+			}
+
+			klog.V(2).InfoS("Expanding PVC", "ScyllaDBDatacenter", klog.KObj(sdc), "PVC", klog.KObj(pvc), "From", requested.String(), "To", capacity.String())
+			required := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: pvc.Namespace,
+					Name:      pvc.Name,
+					Labels:    maps.Clone(pvc.Labels),
+				},
+				Spec: *pvc.Spec.DeepCopy(),
+			}
+			if required.Spec.Resources.Requests == nil {
+				required.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			required.Spec.Resources.Requests[corev1.ResourceStorage] = capacity
+
+			// The PVCs are created by the StatefulSet controller, so they aren't controlled by the ScyllaDBDatacenter.
+			_, changed, err := resourceapply.ApplyPersistentVolumeClaim(ctx, sdcc.kubeClient.CoreV1(), sdcc.pvcLister, sdcc.eventRecorder, required, resourceapply.ApplyOptions{
+				SkipOwnershipCheck: true,
+			})
+			if changed {
+				controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, pvcControllerProgressingCondition, required, "expand", sdc.Generation)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("can't expand pvc %q: %w", naming.ObjRef(pvc), err))
+				continue
+			}
+		}
+	}
+
+	return progressingConditions, apimachineryutilerrors.NewAggregate(errs)
+}
+
+// syncPVCLabels makes sure the data PersistentVolumeClaims of every rack carry the labels backup tooling selects them by.
+// PersistentVolumeClaims created from volumeClaimTemplates are never updated by StatefulSets, so the labels are patched on them directly.
+// Only the labels are patched, the rest of the PersistentVolumeClaims is left as it is.
+func (sdcc *Controller) syncPVCLabels(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	var errs []error
+	for _, rack := range sdc.Spec.Racks {
+		rackSelectorLabels, err := naming.RackSelectorLabels(rack, sdc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get rack %q selector labels: %w", rack.Name, err))
+			continue
+		}
+
+		requiredLabels, err := naming.DataPersistentVolumeClaimLabels(rack, sdc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get rack %q data pvc labels: %w", rack.Name, err))
+			continue
+		}
+
+		pvcs, err := sdcc.pvcLister.PersistentVolumeClaims(sdc.Namespace).List(labels.SelectorFromSet(rackSelectorLabels))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't list pvcs for rack %q: %w", rack.Name, err))
+			continue
+		}
+
+		pvcNamePrefix := naming.PVCNameForPod(naming.StatefulSetNameForRack(rack, sdc)) + "-"
+		pvcs = slices.DeleteFunc(pvcs, func(pvc *corev1.PersistentVolumeClaim) bool {
+			return !strings.HasPrefix(pvc.Name, pvcNamePrefix)
+		})
+		slices.SortFunc(pvcs, func(a, b *corev1.PersistentVolumeClaim) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		_, err = controllerhelpers.Relabel(ctx, sdcc.kubeClient.CoreV1().PersistentVolumeClaims(sdc.Namespace), pvcs, requiredLabels, resourceapply.ApplyOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't relabel pvcs for rack %q: %w", rack.Name, err))
+			continue
+		}
+	}
+
+	return progressingConditions, apimachineryutilerrors.NewAggregate(errs)
+}
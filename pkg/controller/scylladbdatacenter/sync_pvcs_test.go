@@ -0,0 +1,544 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestController_syncOrphanedPVCs(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(policy *scyllav1alpha1.PersistentVolumeClaimRetentionPolicy) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newScyllaDBDatacenterWithRacks("a")
+		sdc.Spec.OrphanedPersistentVolumeClaimRetentionPolicy = policy
+		return sdc
+	}
+
+	newStatefulSet := func(specReplicas, statusReplicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic-dc-a",
+				Namespace: "default",
+				UID:       "basic-dc-a-uid",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Ptr(specReplicas),
+			},
+			Status: appsv1.StatefulSetStatus{
+				Replicas: statusReplicas,
+			},
+		}
+	}
+
+	newPVC := func(name string, managed bool) *corev1.PersistentVolumeClaim {
+		pvcLabels := map[string]string{
+			"scylla/cluster":    "basic",
+			"scylla/datacenter": "dc",
+			"scylla/rack":       "a",
+		}
+		if managed {
+			pvcLabels["app"] = "scylla"
+			pvcLabels["app.kubernetes.io/name"] = "scylla"
+			pvcLabels["app.kubernetes.io/managed-by"] = "scylla-operator"
+		}
+
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name + "-uid"),
+				Labels:    pvcLabels,
+			},
+		}
+	}
+
+	newControlledPVC := func(name string, controller metav1.OwnerReference) *corev1.PersistentVolumeClaim {
+		pvc := newPVC(name, true)
+		pvc.OwnerReferences = []metav1.OwnerReference{controller}
+		return pvc
+	}
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		sdc                    *scyllav1alpha1.ScyllaDBDatacenter
+		existingObjects        []runtime.Object
+		expectedPVCs           []string
+		expectedProgressingLen int
+	}{
+		{
+			name: "policy defaults to retain",
+			sdc:  newSDC(nil),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", true),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "retain policy keeps orphaned pvcs",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyRetain)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", true),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "delete policy prunes orphaned pvcs above desired nodes",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", true),
+				newPVC("data-basic-dc-a-2", true),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0"},
+			expectedProgressingLen: 2,
+		},
+		{
+			name: "delete policy keeps pvcs of a statefulset that is still scaling down",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 2),
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", true),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "delete policy keeps pvcs of existing pods",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPod("basic-dc-a-1"),
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", true),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "delete policy ignores pvcs not managed by the operator",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", false),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "delete policy ignores look-alike pvcs controlled by another object",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPVC("data-basic-dc-a-0", true),
+				newControlledPVC("data-basic-dc-a-1", metav1.OwnerReference{
+					APIVersion: "apps/v1",
+					Kind:       "StatefulSet",
+					Name:       "user-sts",
+					UID:        "user-sts-uid",
+					Controller: pointer.Ptr(true),
+				}),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "delete policy prunes orphaned pvcs controlled by the rack statefulset",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newStatefulSet(1, 1),
+				newPVC("data-basic-dc-a-0", true),
+				newControlledPVC("data-basic-dc-a-1", metav1.OwnerReference{
+					APIVersion: "apps/v1",
+					Kind:       "StatefulSet",
+					Name:       "basic-dc-a",
+					UID:        "basic-dc-a-uid",
+					Controller: pointer.Ptr(true),
+				}),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0"},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "delete policy waits for the statefulset to exist",
+			sdc:  newSDC(pointer.Ptr(scyllav1alpha1.PersistentVolumeClaimRetentionPolicyDelete)),
+			existingObjects: []runtime.Object{
+				newPVC("data-basic-dc-a-0", true),
+				newPVC("data-basic-dc-a-1", true),
+			},
+			expectedPVCs:           []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedProgressingLen: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existingObjects...)
+			sdcc, _ := newTestController(t, ctx, client)
+
+			statefulSets := map[string]*appsv1.StatefulSet{}
+			for _, obj := range tc.existingObjects {
+				sts, ok := obj.(*appsv1.StatefulSet)
+				if ok {
+					statefulSets[sts.Name] = sts
+				}
+			}
+
+			progressingConditions, err := sdcc.syncOrphanedPVCs(ctx, tc.sdc, statefulSets)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(progressingConditions) != tc.expectedProgressingLen {
+				t.Errorf("expected %d progressing conditions, got %d: %v", tc.expectedProgressingLen, len(progressingConditions), progressingConditions)
+			}
+
+			gotPVCs, err := client.CoreV1().PersistentVolumeClaims(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotNames []string
+			for _, pvc := range gotPVCs.Items {
+				gotNames = append(gotNames, pvc.Name)
+			}
+			if !reflect.DeepEqual(gotNames, tc.expectedPVCs) {
+				t.Errorf("expected and got pvcs differ:\n%s", cmp.Diff(tc.expectedPVCs, gotNames))
+			}
+		})
+	}
+}
+
+func TestController_syncPVCStorage(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(capacity string) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newScyllaDBDatacenterWithRacks("a")
+		sdc.Spec.Racks[0].ScyllaDB = &scyllav1alpha1.ScyllaDBTemplate{
+			Storage: &scyllav1alpha1.StorageOptions{
+				Capacity: capacity,
+			},
+		}
+		return sdc
+	}
+
+	newPVC := func(name, storage string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name + "-uid"),
+				Labels: map[string]string{
+					"app":                          "scylla",
+					"app.kubernetes.io/name":       "scylla",
+					"app.kubernetes.io/managed-by": "scylla-operator",
+					"scylla/cluster":               "basic",
+					"scylla/datacenter":            "dc",
+					"scylla/rack":                  "a",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(storage),
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		sdc                    *scyllav1alpha1.ScyllaDBDatacenter
+		existingObjects        []runtime.Object
+		expectedStorage        map[string]string
+		expectedProgressingLen int
+	}{
+		{
+			name: "expands pvcs requesting less than the desired capacity",
+			sdc:  newSDC("2Gi"),
+			existingObjects: []runtime.Object{
+				newPVC("data-basic-dc-a-0", "1Gi"),
+				newPVC("data-basic-dc-a-1", "2Gi"),
+			},
+			expectedStorage: map[string]string{
+				"data-basic-dc-a-0": "2Gi",
+				"data-basic-dc-a-1": "2Gi",
+			},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "expands pvcs controlled by the rack statefulset",
+			sdc:  newSDC("2Gi"),
+			existingObjects: []runtime.Object{
+				func() *corev1.PersistentVolumeClaim {
+					pvc := newPVC("data-basic-dc-a-0", "1Gi")
+					pvc.OwnerReferences = []metav1.OwnerReference{
+						{
+							APIVersion: "apps/v1",
+							Kind:       "StatefulSet",
+							Name:       "basic-dc-a",
+							UID:        "basic-dc-a-uid",
+							Controller: pointer.Ptr(true),
+						},
+					}
+					return pvc
+				}(),
+			},
+			expectedStorage: map[string]string{
+				"data-basic-dc-a-0": "2Gi",
+			},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "never shrinks pvcs",
+			sdc:  newSDC("1Gi"),
+			existingObjects: []runtime.Object{
+				newPVC("data-basic-dc-a-0", "2Gi"),
+			},
+			expectedStorage: map[string]string{
+				"data-basic-dc-a-0": "2Gi",
+			},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "ignores pvcs not created for the rack statefulset",
+			sdc:  newSDC("2Gi"),
+			existingObjects: []runtime.Object{
+				newPVC("other-basic-dc-a-0", "1Gi"),
+			},
+			expectedStorage: map[string]string{
+				"other-basic-dc-a-0": "1Gi",
+			},
+			expectedProgressingLen: 0,
+		},
+		{
+			name: "does nothing without a storage capacity",
+			sdc:  newScyllaDBDatacenterWithRacks("a"),
+			existingObjects: []runtime.Object{
+				newPVC("data-basic-dc-a-0", "1Gi"),
+			},
+			expectedStorage: map[string]string{
+				"data-basic-dc-a-0": "1Gi",
+			},
+			expectedProgressingLen: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existingObjects...)
+			sdcc, _ := newTestController(t, ctx, client)
+
+			progressingConditions, err := sdcc.syncPVCStorage(ctx, tc.sdc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(progressingConditions) != tc.expectedProgressingLen {
+				t.Errorf("expected %d progressing conditions, got %d: %v", tc.expectedProgressingLen, len(progressingConditions), progressingConditions)
+			}
+
+			gotPVCs, err := client.CoreV1().PersistentVolumeClaims(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotStorage := map[string]string{}
+			for _, pvc := range gotPVCs.Items {
+				storage := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+				gotStorage[pvc.Name] = storage.String()
+			}
+			if !reflect.DeepEqual(gotStorage, tc.expectedStorage) {
+				t.Errorf("expected and got pvc storage differ:\n%s", cmp.Diff(tc.expectedStorage, gotStorage))
+			}
+		})
+	}
+}
+
+func TestController_syncPVCLabels(t *testing.T) {
+	t.Parallel()
+
+	newPVC := func(name string, extraLabels map[string]string) *corev1.PersistentVolumeClaim {
+		pvcLabels := map[string]string{
+			"app":                          "scylla",
+			"app.kubernetes.io/name":       "scylla",
+			"app.kubernetes.io/managed-by": "scylla-operator",
+			"scylla/cluster":               "basic",
+			"scylla/datacenter":            "dc",
+			"scylla/rack":                  "a",
+		}
+		maps.Copy(pvcLabels, extraLabels)
+
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name + "-uid"),
+				Labels:    pvcLabels,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+	}
+
+	dataLabels := map[string]string{
+		"scylla-operator.scylladb.com/persistentvolumeclaim-type": "data",
+	}
+
+	tt := []struct {
+		name                string
+		existingObjects     []runtime.Object
+		expectedDataPVCs    []string
+		expectedPatchedPVCs []string
+	}{
+		{
+			name: "labels data pvcs missing the labels",
+			existingObjects: []runtime.Object{
+				newPVC("data-basic-dc-a-0", nil),
+				newPVC("data-basic-dc-a-1", nil),
+			},
+			expectedDataPVCs:    []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedPatchedPVCs: []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+		},
+		{
+			name: "doesn't patch pvcs that already carry the labels",
+			existingObjects: []runtime.Object{
+				newPVC("data-basic-dc-a-0", dataLabels),
+				newPVC("data-basic-dc-a-1", nil),
+			},
+			expectedDataPVCs:    []string{"data-basic-dc-a-0", "data-basic-dc-a-1"},
+			expectedPatchedPVCs: []string{"data-basic-dc-a-1"},
+		},
+		{
+			name: "ignores pvcs not created for the rack statefulset",
+			existingObjects: []runtime.Object{
+				newPVC("other-basic-dc-a-0", nil),
+			},
+			expectedDataPVCs:    nil,
+			expectedPatchedPVCs: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			sdc := newScyllaDBDatacenterWithRacks("a")
+
+			client := fake.NewSimpleClientset(tc.existingObjects...)
+			sdcc, _ := newTestController(t, ctx, client)
+			client.ClearActions()
+
+			_, err := sdcc.syncPVCLabels(ctx, sdc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var gotPatchedPVCs []string
+			for _, action := range client.Actions() {
+				if !action.Matches("patch", "persistentvolumeclaims") {
+					t.Errorf("expected only pvc patches, got %s on %s", action.GetVerb(), action.GetResource().Resource)
+					continue
+				}
+
+				patchAction := action.(clienttesting.PatchAction)
+				if patchAction.GetPatchType() != types.JSONPatchType {
+					t.Errorf("expected a JSON patch, got %q", patchAction.GetPatchType())
+				}
+
+				var ops []struct {
+					Path string `json:"path"`
+				}
+				err := json.Unmarshal(patchAction.GetPatch(), &ops)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, op := range ops {
+					if !strings.HasPrefix(op.Path, "/metadata/") {
+						t.Errorf("expected a metadata-only patch, got a patch of %q", op.Path)
+					}
+				}
+
+				gotPatchedPVCs = append(gotPatchedPVCs, patchAction.GetName())
+			}
+			if !reflect.DeepEqual(gotPatchedPVCs, tc.expectedPatchedPVCs) {
+				t.Errorf("expected and got patched pvcs differ:\n%s", cmp.Diff(tc.expectedPatchedPVCs, gotPatchedPVCs))
+			}
+
+			gotPVCs, err := client.CoreV1().PersistentVolumeClaims(sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotDataPVCs []string
+			for _, pvc := range gotPVCs.Items {
+				original := newPVC(pvc.Name, nil)
+				if !equality.Semantic.DeepEqual(pvc.Spec, original.Spec) {
+					t.Errorf("pvc %q spec changed:\n%s", pvc.Name, cmp.Diff(original.Spec, pvc.Spec))
+				}
+
+				if pvc.Labels["scylla-operator.scylladb.com/persistentvolumeclaim-type"] == "data" {
+					gotDataPVCs = append(gotDataPVCs, pvc.Name)
+				}
+			}
+			slices.Sort(gotDataPVCs)
+			if !reflect.DeepEqual(gotDataPVCs, tc.expectedDataPVCs) {
+				t.Errorf("expected and got labeled data pvcs differ:\n%s", cmp.Diff(tc.expectedDataPVCs, gotDataPVCs))
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func (sdcc *Controller) syncRBAC(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	roles map[string]*rbacv1.Role,
+	roleBindings map[string]*rbacv1.RoleBinding,
+) ([]metav1.Condition, error) {
+	var progressingConditions []metav1.Condition
+
+	requiredRoles := []*rbacv1.Role{
+		MakeAgentRole(sdc),
+	}
+	requiredRoleBindings := []*rbacv1.RoleBinding{
+		MakeRoleBinding(sdc),
+		MakeAgentRoleBinding(sdc),
+	}
+
+	// Delete any excessive RBAC objects.
+	// Delete has to be the fist action to avoid getting stuck on quota.
+	err := apimachineryutilerrors.NewAggregate([]error{
+		controllerhelpers.Prune(
+			ctx,
+			requiredRoleBindings,
+			roleBindings,
+			&controllerhelpers.PruneControlFuncs{
+				DeleteFunc: sdcc.kubeClient.RbacV1().RoleBindings(sdc.Namespace).Delete,
+			},
+			sdcc.eventRecorder,
+		),
+		controllerhelpers.Prune(
+			ctx,
+			requiredRoles,
+			roles,
+			&controllerhelpers.PruneControlFuncs{
+				DeleteFunc: sdcc.kubeClient.RbacV1().Roles(sdc.Namespace).Delete,
+			},
+			sdcc.eventRecorder,
+		),
+	})
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't prune RBAC object(s): %w", err)
+	}
+
+	var errs []error
+
+	// Roles have to be applied first so the bindings never reference a missing Role.
+	for _, role := range requiredRoles {
+		_, changed, err := resourceapply.ApplyRole(ctx, sdcc.kubeClient.RbacV1(), sdcc.roleLister, sdcc.eventRecorder, role, resourceapply.ApplyOptions{
+			ForceOwnership: true,
+		})
+		if changed {
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, roleBindingControllerProgressingCondition, role, "apply", sdc.Generation)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't apply role: %w", err))
+		}
+	}
+
+	for _, rb := range requiredRoleBindings {
+		_, changed, err := resourceapply.ApplyRoleBinding(ctx, sdcc.kubeClient.RbacV1(), sdcc.roleBindingLister, sdcc.eventRecorder, rb, resourceapply.ApplyOptions{
+			ForceOwnership: true,
+		})
+		if changed {
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, roleBindingControllerProgressingCondition, rb, "apply", sdc.Generation)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't apply role binding: %w", err))
+		}
+	}
+
+	return progressingConditions, apimachineryutilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,114 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMakeAgentRoleBinding(t *testing.T) {
+	sdc := newBasicScyllaDBDatacenter()
+
+	sa := MakeServiceAccount(sdc)
+	role := MakeAgentRole(sdc)
+	rb := MakeAgentRoleBinding(sdc)
+
+	expectedSubjects := []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: sa.Namespace,
+			Name:      sa.Name,
+		},
+	}
+	if !reflect.DeepEqual(rb.Subjects, expectedSubjects) {
+		t.Errorf("expected and got subjects differ:\n%s", cmp.Diff(expectedSubjects, rb.Subjects))
+	}
+
+	expectedRoleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	if !reflect.DeepEqual(rb.RoleRef, expectedRoleRef) {
+		t.Errorf("expected and got roleRef differ:\n%s", cmp.Diff(expectedRoleRef, rb.RoleRef))
+	}
+}
+
+func TestController_syncRBAC(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	sdc := newBasicScyllaDBDatacenter()
+
+	staleRoleBinding := MakeAgentRoleBinding(sdc)
+	staleRoleBinding.Name = "stale"
+	staleRoleBinding.UID = "stale-uid"
+
+	client := fake.NewSimpleClientset(staleRoleBinding)
+
+	// Reconciliation needs to be stable, so running the sync the second time must not make any changes.
+	for i := range 2 {
+		sdcc, _ := newTestController(t, ctx, client)
+
+		roles, err := sdcc.roleLister.Roles(sdc.Namespace).List(labels.Everything())
+		if err != nil {
+			t.Fatal(err)
+		}
+		roleBindings, err := sdcc.roleBindingLister.RoleBindings(sdc.Namespace).List(labels.Everything())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		progressingConditions, err := sdcc.syncRBAC(ctx, sdc, mapByName(roles), mapByName(roleBindings))
+		if err != nil {
+			t.Fatalf("iteration %d: unexpected error: %v", i, err)
+		}
+
+		if i == 0 && len(progressingConditions) == 0 {
+			t.Errorf("iteration %d: expected progressing conditions, got none", i)
+		}
+		if i > 0 && len(progressingConditions) != 0 {
+			t.Errorf("iteration %d: expected no progressing conditions, got %v", i, progressingConditions)
+		}
+	}
+
+	gotRoleBindings, err := client.RbacV1().RoleBindings(sdc.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotRoleBindingNames []string
+	for _, rb := range gotRoleBindings.Items {
+		gotRoleBindingNames = append(gotRoleBindingNames, rb.Name)
+	}
+	expectedRoleBindingNames := []string{"basic-agent", "basic-member"}
+	if !reflect.DeepEqual(gotRoleBindingNames, expectedRoleBindingNames) {
+		t.Errorf("expected and got role bindings differ:\n%s", cmp.Diff(expectedRoleBindingNames, gotRoleBindingNames))
+	}
+
+	agentRoleBinding, err := client.RbacV1().RoleBindings(sdc.Namespace).Get(ctx, "basic-agent", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedSubjects := []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: sdc.Namespace,
+			Name:      MakeServiceAccount(sdc).Name,
+		},
+	}
+	if !reflect.DeepEqual(agentRoleBinding.Subjects, expectedSubjects) {
+		t.Errorf("expected and got subjects differ:\n%s", cmp.Diff(expectedSubjects, agentRoleBinding.Subjects))
+	}
+
+	_, err = client.RbacV1().Roles(sdc.Namespace).Get(ctx, "basic-agent", metav1.GetOptions{})
+	if err != nil {
+		t.Errorf("expected agent Role to exist: %v", err)
+	}
+}
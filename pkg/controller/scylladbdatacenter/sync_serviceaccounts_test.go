@@ -0,0 +1,52 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncServiceAccounts(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	sdc := newBasicScyllaDBDatacenter()
+
+	staleServiceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sdc.Namespace,
+			Name:      "stale",
+			UID:       "stale-uid",
+		},
+	}
+
+	client := fake.NewSimpleClientset(staleServiceAccount)
+	sdcc, _ := newTestController(t, ctx, client)
+
+	progressingConditions, err := sdcc.syncServiceAccounts(ctx, sdc, mapByName([]*corev1.ServiceAccount{staleServiceAccount}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(progressingConditions) != 2 {
+		t.Errorf("expected 2 progressing conditions, got %v", progressingConditions)
+	}
+
+	_, err = client.CoreV1().ServiceAccounts(sdc.Namespace).Get(ctx, staleServiceAccount.Name, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the stale service account to be deleted, got %v", err)
+	}
+
+	requiredServiceAccount := MakeServiceAccount(sdc)
+	_, err = client.CoreV1().ServiceAccounts(sdc.Namespace).Get(ctx, requiredServiceAccount.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Errorf("expected the required service account to be created: %v", err)
+	}
+}
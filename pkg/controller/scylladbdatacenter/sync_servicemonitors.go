@@ -0,0 +1,81 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"fmt"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func (sdcc *Controller) syncServiceMonitors(
+	ctx context.Context,
+	sdc *scyllav1alpha1.ScyllaDBDatacenter,
+	serviceMonitors map[string]*monitoringv1.ServiceMonitor,
+) ([]metav1.Condition, error) {
+	var err error
+	var progressingConditions []metav1.Condition
+
+	if sdcc.serviceMonitorLister == nil {
+		if sdc.Spec.ServiceMonitor != nil {
+			return progressingConditions, fmt.Errorf("can't create service monitor: CRD %q wasn't available when the operator started", "servicemonitors.monitoring.coreos.com")
+		}
+
+		return progressingConditions, nil
+	}
+
+	var requiredServiceMonitors []*monitoringv1.ServiceMonitor
+	if sdc.Spec.ServiceMonitor != nil {
+		requiredServiceMonitors = append(requiredServiceMonitors, MakeServiceMonitor(sdc))
+	}
+
+	requiredServiceMonitorNames := sets.New[string]()
+	for _, requiredServiceMonitor := range requiredServiceMonitors {
+		requiredServiceMonitorNames.Insert(requiredServiceMonitor.Name)
+	}
+
+	// Delete any excessive ServiceMonitors.
+	// Delete has to be the fist action to avoid getting stuck on quota.
+	var deletionErrors []error
+	for _, serviceMonitor := range serviceMonitors {
+		if serviceMonitor.DeletionTimestamp != nil {
+			continue
+		}
+
+		if requiredServiceMonitorNames.Has(serviceMonitor.Name) {
+			continue
+		}
+
+		propagationPolicy := metav1.DeletePropagationBackground
+		controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, serviceMonitorControllerProgressingCondition, serviceMonitor, "delete", sdc.Generation)
+		err = sdcc.monitoringClient.ServiceMonitors(serviceMonitor.Namespace).Delete(ctx, serviceMonitor.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID: &serviceMonitor.UID,
+			},
+			PropagationPolicy: &propagationPolicy,
+		})
+		resourceapply.ReportDeleteEvent(sdcc.eventRecorder, serviceMonitor, err)
+		deletionErrors = append(deletionErrors, err)
+	}
+	err = apimachineryutilerrors.NewAggregate(deletionErrors)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't delete service monitor(s): %w", err)
+	}
+
+	for _, requiredServiceMonitor := range requiredServiceMonitors {
+		_, changed, err := resourceapply.ApplyServiceMonitor(ctx, sdcc.monitoringClient, sdcc.serviceMonitorLister, sdcc.eventRecorder, requiredServiceMonitor, resourceapply.ApplyOptions{})
+		if changed {
+			controllerhelpers.AddGenericProgressingStatusCondition(&progressingConditions, serviceMonitorControllerProgressingCondition, requiredServiceMonitor, "apply", sdc.Generation)
+		}
+		if err != nil {
+			return progressingConditions, fmt.Errorf("can't apply service monitor: %w", err)
+		}
+	}
+
+	return progressingConditions, nil
+}
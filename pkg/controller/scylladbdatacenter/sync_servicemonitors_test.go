@@ -0,0 +1,147 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
+	monitoringfake "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/clientset/versioned/fake"
+	monitoringv1listers "github.com/scylladb/scylla-operator/pkg/externalclient/monitoring/listers/monitoring/v1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestController_syncServiceMonitors(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(serviceMonitor *scyllav1alpha1.ServiceMonitorOptions) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.Spec.ServiceMonitor = serviceMonitor
+		return sdc
+	}
+
+	newServiceMonitor := func() *monitoringv1.ServiceMonitor {
+		sm := MakeServiceMonitor(newSDC(&scyllav1alpha1.ServiceMonitorOptions{}))
+		sm.UID = "sm-uid"
+		return sm
+	}
+
+	newOutdatedServiceMonitor := func() *monitoringv1.ServiceMonitor {
+		sm := newServiceMonitor()
+		sm.Spec.Endpoints[1].RelabelConfigs = sm.Spec.Endpoints[1].RelabelConfigs[:1]
+		return sm
+	}
+
+	tt := []struct {
+		name                    string
+		sdc                     *scyllav1alpha1.ScyllaDBDatacenter
+		existingServiceMonitors []*monitoringv1.ServiceMonitor
+		expectedServiceMonitors []*monitoringv1.ServiceMonitor
+		expectedProgressingLen  int
+	}{
+		{
+			name:                    "doesn't create a service monitor when it isn't enabled",
+			sdc:                     newSDC(nil),
+			existingServiceMonitors: nil,
+			expectedServiceMonitors: nil,
+			expectedProgressingLen:  0,
+		},
+		{
+			name:                    "creates a service monitor when it's enabled",
+			sdc:                     newSDC(&scyllav1alpha1.ServiceMonitorOptions{}),
+			existingServiceMonitors: nil,
+			expectedServiceMonitors: []*monitoringv1.ServiceMonitor{
+				MakeServiceMonitor(newSDC(&scyllav1alpha1.ServiceMonitorOptions{})),
+			},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "prunes the service monitor when it's disabled",
+			sdc:  newSDC(nil),
+			existingServiceMonitors: []*monitoringv1.ServiceMonitor{
+				newServiceMonitor(),
+			},
+			expectedServiceMonitors: nil,
+			expectedProgressingLen:  1,
+		},
+		{
+			name: "updates the service monitor when its relabeling changes",
+			sdc:  newSDC(&scyllav1alpha1.ServiceMonitorOptions{}),
+			existingServiceMonitors: []*monitoringv1.ServiceMonitor{
+				newOutdatedServiceMonitor(),
+			},
+			expectedServiceMonitors: []*monitoringv1.ServiceMonitor{
+				MakeServiceMonitor(newSDC(&scyllav1alpha1.ServiceMonitorOptions{})),
+			},
+			expectedProgressingLen: 1,
+		},
+		{
+			name: "merges custom labels into the service monitor labels",
+			sdc: newSDC(&scyllav1alpha1.ServiceMonitorOptions{
+				Labels: map[string]string{
+					"release": "prometheus",
+				},
+			}),
+			existingServiceMonitors: nil,
+			expectedServiceMonitors: []*monitoringv1.ServiceMonitor{
+				MakeServiceMonitor(newSDC(&scyllav1alpha1.ServiceMonitorOptions{
+					Labels: map[string]string{
+						"release": "prometheus",
+					},
+				})),
+			},
+			expectedProgressingLen: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var existingObjects []runtime.Object
+			for _, sm := range tc.existingServiceMonitors {
+				existingObjects = append(existingObjects, sm)
+			}
+
+			monitoringClient := monitoringfake.NewSimpleClientset(existingObjects...)
+			sdcc, _ := newTestController(t, ctx, fake.NewSimpleClientset())
+			sdcc.monitoringClient = monitoringClient.MonitoringV1()
+			sdcc.serviceMonitorLister = monitoringv1listers.NewServiceMonitorLister(newIndexer(t, tc.existingServiceMonitors))
+
+			progressingConditions, err := sdcc.syncServiceMonitors(ctx, tc.sdc, mapByName(tc.existingServiceMonitors))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(progressingConditions) != tc.expectedProgressingLen {
+				t.Errorf("expected %d progressing conditions, got %d: %v", tc.expectedProgressingLen, len(progressingConditions), progressingConditions)
+			}
+
+			gotServiceMonitorList, err := monitoringClient.MonitoringV1().ServiceMonitors(tc.sdc.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotServiceMonitors []*monitoringv1.ServiceMonitor
+			for i := range gotServiceMonitorList.Items {
+				sm := gotServiceMonitorList.Items[i].DeepCopy()
+				// Drop fields managed by the apply.
+				sm.UID = ""
+				delete(sm.Annotations, naming.ManagedHash)
+				gotServiceMonitors = append(gotServiceMonitors, sm)
+			}
+			if !reflect.DeepEqual(gotServiceMonitors, tc.expectedServiceMonitors) {
+				t.Errorf("expected and got service monitors differ:\n%s", cmp.Diff(tc.expectedServiceMonitors, gotServiceMonitors))
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMemberServiceNodeServiceAnnotationsAreReconciled(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	const (
+		hostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+		svcName            = "basic-dc-rack-0"
+	)
+
+	steps := []struct {
+		name                string
+		nodeServiceHostname *string
+		expectedHostname    *string
+	}{
+		{
+			name:                "hostname is added",
+			nodeServiceHostname: pointer.Ptr("a.example.com"),
+			expectedHostname:    pointer.Ptr("a.example.com"),
+		},
+		{
+			name:                "hostname is changed",
+			nodeServiceHostname: pointer.Ptr("b.example.com"),
+			expectedHostname:    pointer.Ptr("b.example.com"),
+		},
+		{
+			name:                "hostname is removed",
+			nodeServiceHostname: nil,
+			expectedHostname:    nil,
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+
+	for _, step := range steps {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+			NodeService: &scyllav1alpha1.NodeServiceTemplate{
+				Type: scyllav1alpha1.NodeServiceTypeClusterIP,
+			},
+		}
+		if step.nodeServiceHostname != nil {
+			sdc.Spec.ExposeOptions.NodeService.Annotations = map[string]string{
+				hostnameAnnotation: *step.nodeServiceHostname,
+			}
+		}
+
+		sdcc, _ := newTestController(t, ctx, client)
+
+		oldService, err := sdcc.serviceLister.Services(sdc.Namespace).Get(svcName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			oldService = nil
+		}
+
+		required, err := MemberService(sdc, "rack", svcName, oldService, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		_, _, err = resourceapply.ApplyService(ctx, client.CoreV1(), sdcc.serviceLister, sdcc.eventRecorder, required, resourceapply.ApplyOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		svc, err := client.CoreV1().Services(sdc.Namespace).Get(ctx, svcName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var gotHostname *string
+		if v, ok := svc.Annotations[hostnameAnnotation]; ok {
+			gotHostname = &v
+		}
+		if !reflect.DeepEqual(gotHostname, step.expectedHostname) {
+			t.Errorf("%s: expected and got hostname annotation differ:\n%s", step.name, cmp.Diff(step.expectedHostname, gotHostname))
+		}
+	}
+}
+
+func TestMemberServicePublishNotReadyAddressesIsReconciled(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	const svcName = "basic-dc-rack-0"
+
+	steps := []struct {
+		name                             string
+		publishNotReadyAddresses         *bool
+		expectedPublishNotReadyAddresses bool
+		expectedChanged                  bool
+	}{
+		{
+			name:                             "not ready addresses are published by default",
+			publishNotReadyAddresses:         nil,
+			expectedPublishNotReadyAddresses: true,
+			expectedChanged:                  true,
+		},
+		{
+			name:                             "not ready addresses are excluded",
+			publishNotReadyAddresses:         pointer.Ptr(false),
+			expectedPublishNotReadyAddresses: false,
+			expectedChanged:                  true,
+		},
+		{
+			name:                             "excluding not ready addresses again is a no-op",
+			publishNotReadyAddresses:         pointer.Ptr(false),
+			expectedPublishNotReadyAddresses: false,
+			expectedChanged:                  false,
+		},
+		{
+			name:                             "not ready addresses are published again",
+			publishNotReadyAddresses:         pointer.Ptr(true),
+			expectedPublishNotReadyAddresses: true,
+			expectedChanged:                  true,
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+
+	for _, step := range steps {
+		sdc := newBasicScyllaDBDatacenter()
+		sdc.Spec.ExposeOptions = &scyllav1alpha1.ExposeOptions{
+			NodeService: &scyllav1alpha1.NodeServiceTemplate{
+				Type:                     scyllav1alpha1.NodeServiceTypeHeadless,
+				PublishNotReadyAddresses: step.publishNotReadyAddresses,
+			},
+			BroadcastOptions: &scyllav1alpha1.NodeBroadcastOptions{
+				Nodes: scyllav1alpha1.BroadcastOptions{
+					Type: scyllav1alpha1.BroadcastAddressTypePodIP,
+				},
+				Clients: scyllav1alpha1.BroadcastOptions{
+					Type: scyllav1alpha1.BroadcastAddressTypePodIP,
+				},
+			},
+		}
+
+		sdcc, _ := newTestController(t, ctx, client)
+
+		oldService, err := sdcc.serviceLister.Services(sdc.Namespace).Get(svcName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			oldService = nil
+		}
+
+		required, err := MemberService(sdc, "rack", svcName, oldService, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		if required.Spec.PublishNotReadyAddresses != step.expectedPublishNotReadyAddresses {
+			t.Errorf("%s: expected required publishNotReadyAddresses %t, got %t", step.name, step.expectedPublishNotReadyAddresses, required.Spec.PublishNotReadyAddresses)
+		}
+
+		_, changed, err := resourceapply.ApplyService(ctx, client.CoreV1(), sdcc.serviceLister, sdcc.eventRecorder, required, resourceapply.ApplyOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if changed != step.expectedChanged {
+			t.Errorf("%s: expected changed %t, got %t", step.name, step.expectedChanged, changed)
+		}
+
+		svc, err := client.CoreV1().Services(sdc.Namespace).Get(ctx, svcName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if svc.Spec.PublishNotReadyAddresses != step.expectedPublishNotReadyAddresses {
+			t.Errorf("%s: expected publishNotReadyAddresses %t, got %t", step.name, step.expectedPublishNotReadyAddresses, svc.Spec.PublishNotReadyAddresses)
+		}
+	}
+}
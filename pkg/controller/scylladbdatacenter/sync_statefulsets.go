@@ -38,7 +38,7 @@ func snapshotTag(prefix string, t time.Time) string {
 	return fmt.Sprintf("so_%s_%sUTC", prefix, t.UTC().Format(time.RFC3339))
 }
 
-func (sdcc *Controller) makeRacks(sdc *scyllav1alpha1.ScyllaDBDatacenter, statefulSets map[string]*appsv1.StatefulSet, inputsHash string) ([]*appsv1.StatefulSet, error) {
+func (sdcc *Controller) makeRacks(sdc *scyllav1alpha1.ScyllaDBDatacenter, statefulSets map[string]*appsv1.StatefulSet, inputsHash string, rolloutSecretsHash string) ([]*appsv1.StatefulSet, error) {
 	sets := make([]*appsv1.StatefulSet, 0, len(sdc.Spec.Racks))
 	for i, rack := range sdc.Spec.Racks {
 		oldSts := statefulSets[naming.StatefulSetNameForRack(rack, sdc)]
@@ -47,11 +47,51 @@ func (sdcc *Controller) makeRacks(sdc *scyllav1alpha1.ScyllaDBDatacenter, statef
 			return nil, err
 		}
 
+		setRolloutSecretsHashAnnotations(sts, oldSts, rolloutSecretsHash)
+
 		sets = append(sets, sts)
 	}
 	return sets, nil
 }
 
+// setRolloutSecretsHashAnnotations stamps the rollout Secrets hash on the Pod template, so rotating a rollout Secret
+// makes the StatefulSet roll the Pods.
+// StatefulSets whose Pod template doesn't carry the hash yet, like the ones created by older operator versions,
+// only record it as a baseline on the StatefulSet and get it on the Pod template once the Secrets change.
+// This avoids restarting the Pods of every existing cluster on an upgrade.
+// An empty rolloutSecretsHash means the hash isn't known and the existing annotations are kept.
+func setRolloutSecretsHashAnnotations(sts *appsv1.StatefulSet, oldSts *appsv1.StatefulSet, rolloutSecretsHash string) {
+	var existingTemplateHash, existingBaselineHash string
+	if oldSts != nil {
+		existingTemplateHash = oldSts.Spec.Template.Annotations[naming.RolloutSecretsHashAnnotation]
+		existingBaselineHash = oldSts.Annotations[naming.RolloutSecretsBaselineHashAnnotation]
+	}
+
+	if len(rolloutSecretsHash) == 0 {
+		if len(existingTemplateHash) != 0 {
+			sts.Spec.Template.Annotations[naming.RolloutSecretsHashAnnotation] = existingTemplateHash
+		}
+		if len(existingBaselineHash) != 0 {
+			sts.Annotations[naming.RolloutSecretsBaselineHashAnnotation] = existingBaselineHash
+		}
+		return
+	}
+
+	switch {
+	case oldSts == nil, len(existingTemplateHash) != 0:
+		sts.Spec.Template.Annotations[naming.RolloutSecretsHashAnnotation] = rolloutSecretsHash
+
+	case len(existingBaselineHash) == 0:
+		sts.Annotations[naming.RolloutSecretsBaselineHashAnnotation] = rolloutSecretsHash
+
+	case existingBaselineHash != rolloutSecretsHash:
+		sts.Spec.Template.Annotations[naming.RolloutSecretsHashAnnotation] = rolloutSecretsHash
+
+	default:
+		sts.Annotations[naming.RolloutSecretsBaselineHashAnnotation] = existingBaselineHash
+	}
+}
+
 func (sdcc *Controller) getScyllaManagerAgentToken(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter) (string, error) {
 	secretName := naming.AgentAuthTokenSecretName(sdc)
 	secret, err := sdcc.secretLister.Secrets(sdc.Namespace).Get(secretName)
@@ -470,6 +510,40 @@ func (sdcc *Controller) createMissingStatefulSets(
 	return progressingConditions, apimachineryutilerrors.NewAggregate(errs)
 }
 
+// rolloutSecretNames returns the names of the Secrets the ScyllaDB Pods only read when they start,
+// so the Pods have to be restarted when the Secrets are rotated.
+func rolloutSecretNames(sdc *scyllav1alpha1.ScyllaDBDatacenter) []string {
+	return []string{
+		naming.AgentAuthTokenSecretName(sdc),
+	}
+}
+
+// makeRolloutSecretsHash hashes the data of the rollout Secrets, so the hash only changes when their content does.
+// It also returns the names of the rollout Secrets that don't exist yet, the hash isn't complete without them.
+func makeRolloutSecretsHash(sdc *scyllav1alpha1.ScyllaDBDatacenter, secrets map[string]*corev1.Secret) (string, []string, error) {
+	var missingSecretNames []string
+	var secretsData []any
+	for _, name := range rolloutSecretNames(sdc) {
+		secret, ok := secrets[name]
+		if !ok {
+			missingSecretNames = append(missingSecretNames, name)
+			continue
+		}
+		secretsData = append(secretsData, secret.Data)
+	}
+
+	if len(missingSecretNames) != 0 {
+		return "", missingSecretNames, nil
+	}
+
+	h, err := hash.HashObjects(secretsData...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return h, nil, nil
+}
+
 func (sdcc *Controller) syncStatefulSets(
 	ctx context.Context,
 	key string,
@@ -478,6 +552,7 @@ func (sdcc *Controller) syncStatefulSets(
 	statefulSets map[string]*appsv1.StatefulSet,
 	services map[string]*corev1.Service,
 	configMaps map[string]*corev1.ConfigMap,
+	secrets map[string]*corev1.Secret,
 ) ([]metav1.Condition, error) {
 	var err error
 	var progressingConditions []metav1.Condition
@@ -501,7 +576,23 @@ func (sdcc *Controller) syncStatefulSets(
 		return progressingConditions, fmt.Errorf("can't hash inputs: %w", err)
 	}
 
-	requiredStatefulSets, err := sdcc.makeRacks(sdc, statefulSets, inputsHash)
+	rolloutSecretsHash, missingSecretNames, err := makeRolloutSecretsHash(sdc, secrets)
+	if err != nil {
+		return progressingConditions, fmt.Errorf("can't hash rollout secrets: %w", err)
+	}
+	if len(missingSecretNames) != 0 {
+		// The rest of the StatefulSets is still reconciled, only the rollout Secrets hash is kept as it is.
+		klog.V(2).InfoS("Waiting for rollout secrets", "ScyllaDBDatacenter", klog.KObj(sdc), "SecretNames", missingSecretNames)
+		progressingConditions = append(progressingConditions, metav1.Condition{
+			Type:               statefulSetControllerProgressingCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "WaitingForRolloutSecrets",
+			Message:            fmt.Sprintf("Waiting for Secret(s) %q to be created.", strings.Join(missingSecretNames, ", ")),
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+
+	requiredStatefulSets, err := sdcc.makeRacks(sdc, statefulSets, inputsHash, rolloutSecretsHash)
 	if err != nil {
 		sdcc.eventRecorder.Eventf(
 			sdc,
@@ -814,6 +905,7 @@ func (sdcc *Controller) syncStatefulSets(
 			for _, sts := range requiredStatefulSets {
 				partition := *sts.Spec.UpdateStrategy.RollingUpdate.Partition
 
+				var nextPartition int32
 				// Isolate the live values in a block to prevent accidental use.
 				{
 					// TODO: Remove the live call when hooks are migrated into Jobs.
@@ -830,6 +922,18 @@ func (sdcc *Controller) syncStatefulSets(
 						klog.V(2).InfoS("Stale StatefulSet partition, waiting for requeue", "ScyllaDBDatacenter", klog.KObj(sdc), "StatefulSet", klog.KObj(sts))
 						return progressingConditions, nil
 					}
+
+					var ok bool
+					nextPartition, ok, err = controllerhelpers.GetNextStatefulSetPartition(freshSts, sdcc.podLister.Pods(sts.Namespace).Get)
+					if err != nil {
+						return progressingConditions, fmt.Errorf("can't get next partition of StatefulSet %q: %w", naming.ObjRef(sts), err)
+					}
+
+					if !ok {
+						klog.V(4).InfoS("Waiting for the updated node to become healthy", "ScyllaDBDatacenter", klog.KObj(sdc), "StatefulSet", klog.KObj(sts), "Partition", partition)
+						sdcc.queue.AddAfter(key, 5*time.Second)
+						return progressingConditions, nil
+					}
 				}
 
 				if partition < *sts.Spec.Replicas {
@@ -845,8 +949,6 @@ func (sdcc *Controller) syncStatefulSets(
 					continue
 				}
 
-				nextPartition := partition - 1
-
 				klog.V(4).InfoS("Upgrade is running a rollout", "Partition", partition, "NextPartition", nextPartition)
 
 				// TODO: Move the pre-node-upgrade hook into a Job.
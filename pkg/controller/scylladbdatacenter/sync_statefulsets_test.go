@@ -0,0 +1,587 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// newRackStatefulSets makes the StatefulSets the controller would create for the racks of sdc.
+func newRackStatefulSets(t *testing.T, sdc *scyllav1alpha1.ScyllaDBDatacenter) []*appsv1.StatefulSet {
+	t.Helper()
+
+	sdcc := &Controller{}
+	statefulSets, err := sdcc.makeRacks(sdc, map[string]*appsv1.StatefulSet{}, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return statefulSets
+}
+
+// newAgentTokenSecret makes the agent auth token Secret of sdc holding the token.
+func newAgentTokenSecret(t *testing.T, sdc *scyllav1alpha1.ScyllaDBDatacenter, token string) *corev1.Secret {
+	t.Helper()
+
+	secret, err := MakeAgentAuthTokenSecret(sdc, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return secret
+}
+
+func TestController_createMissingStatefulSets(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	sdc := newScyllaDBDatacenterWithRacks("a")
+
+	client := fake.NewSimpleClientset()
+	sdcc, _ := newTestController(t, ctx, client)
+
+	status := sdc.Status.DeepCopy()
+	progressingConditions, err := sdcc.createMissingStatefulSets(ctx, sdc, status, newRackStatefulSets(t, sdc), map[string]*appsv1.StatefulSet{}, map[string]*corev1.Service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.AppsV1().StatefulSets(sdc.Namespace).Get(ctx, "basic-dc-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the rack statefulset to be created: %v", err)
+	}
+
+	if len(status.Racks) != 1 || status.Racks[0].Name != "a" {
+		t.Errorf("expected status of rack %q, got %v", "a", status.Racks)
+	}
+
+	// The new StatefulSet isn't rolled out, so the following racks have to wait for it.
+	if len(progressingConditions) == 0 || progressingConditions[len(progressingConditions)-1].Reason != "WaitingForStatefulSetRollout" {
+		t.Errorf("expected to wait for the statefulset rollout, got %v", progressingConditions)
+	}
+}
+
+func TestController_pruneStatefulSets(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	existingStatefulSets := newRackStatefulSets(t, newScyllaDBDatacenterWithRacks("a", "b"))
+	sdc := newScyllaDBDatacenterWithRacks("a")
+	sdc.Status.Racks = []scyllav1alpha1.RackStatus{
+		{Name: "a"},
+		{Name: "b"},
+	}
+
+	var existingObjects []runtime.Object
+	for _, sts := range existingStatefulSets {
+		sts.UID = types.UID(sts.Name + "-uid")
+		existingObjects = append(existingObjects, sts)
+	}
+
+	client := fake.NewSimpleClientset(existingObjects...)
+	sdcc, _ := newTestController(t, ctx, client)
+
+	status := sdc.Status.DeepCopy()
+	progressingConditions, err := sdcc.pruneStatefulSets(ctx, sdc, status, newRackStatefulSets(t, sdc), mapByName(existingStatefulSets))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(progressingConditions) != 1 {
+		t.Errorf("expected 1 progressing condition, got %v", progressingConditions)
+	}
+
+	_, err = client.AppsV1().StatefulSets(sdc.Namespace).Get(ctx, "basic-dc-a", metav1.GetOptions{})
+	if err != nil {
+		t.Errorf("expected the statefulset of a remaining rack to be kept: %v", err)
+	}
+
+	_, err = client.AppsV1().StatefulSets(sdc.Namespace).Get(ctx, "basic-dc-b", metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the statefulset of a removed rack to be pruned, got %v", err)
+	}
+
+	expectedRacks := []scyllav1alpha1.RackStatus{{Name: "a"}}
+	if !reflect.DeepEqual(status.Racks, expectedRacks) {
+		t.Errorf("expected rack statuses %v, got %v", expectedRacks, status.Racks)
+	}
+}
+
+func TestController_syncStatefulSetsScalesRack(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	existingStatefulSets := newRackStatefulSets(t, newScyllaDBDatacenterWithRacks("a"))
+	sdc := newScyllaDBDatacenterWithRacks("a")
+	sdc.Spec.Racks[0].Nodes = pointer.Ptr[int32](3)
+
+	managedConfigCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sdc.Namespace,
+			Name:      naming.GetScyllaDBManagedConfigCMName(sdc.Name),
+		},
+	}
+
+	agentTokenSecret := newAgentTokenSecret(t, sdc, "token")
+
+	client := fake.NewSimpleClientset(existingStatefulSets[0])
+	sdcc, _ := newTestController(t, ctx, client)
+
+	status := sdc.Status.DeepCopy()
+	progressingConditions, err := sdcc.syncStatefulSets(
+		ctx,
+		naming.ObjRef(sdc),
+		sdc,
+		status,
+		mapByName(existingStatefulSets),
+		map[string]*corev1.Service{},
+		mapByName([]*corev1.ConfigMap{managedConfigCM}),
+		mapByName([]*corev1.Secret{agentTokenSecret}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(progressingConditions) != 1 {
+		t.Errorf("expected 1 progressing condition, got %v", progressingConditions)
+	}
+
+	var gotReplicas []int32
+	for _, action := range client.Actions() {
+		updateAction, ok := action.(clienttesting.UpdateAction)
+		if !ok || updateAction.GetSubresource() != "scale" {
+			continue
+		}
+		gotReplicas = append(gotReplicas, updateAction.GetObject().(*autoscalingv1.Scale).Spec.Replicas)
+	}
+
+	expectedReplicas := []int32{3}
+	if !reflect.DeepEqual(gotReplicas, expectedReplicas) {
+		t.Errorf("expected scale updates to replicas %v, got %v", expectedReplicas, gotReplicas)
+	}
+}
+
+func TestStatefulSetIsRecreatedOnStorageGrowth(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	newSDC := func(capacity string) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := newScyllaDBDatacenterWithRacks("a")
+		sdc.Spec.Racks[0].ScyllaDB = &scyllav1alpha1.ScyllaDBTemplate{
+			Storage: &scyllav1alpha1.StorageOptions{
+				Capacity: capacity,
+			},
+		}
+		return sdc
+	}
+
+	existingStatefulSets := newRackStatefulSets(t, newSDC("1Gi"))
+	err := resourceapply.SetHashAnnotation(existingStatefulSets[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := fake.NewSimpleClientset(existingStatefulSets[0])
+	sdcc, _ := newTestController(t, ctx, client)
+
+	sdc := newSDC("2Gi")
+	required, err := sdcc.makeRacks(sdc, mapByName(existingStatefulSets), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(required) != 1 {
+		t.Fatalf("expected 1 StatefulSet, got %d", len(required))
+	}
+
+	// The controller applies the StatefulSets with the same options.
+	sts, changed, err := resourceapply.ApplyStatefulSet(ctx, client.AppsV1(), sdcc.statefulSetLister, sdcc.eventRecorder, required[0], resourceapply.ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Errorf("expected the StatefulSet to be changed")
+	}
+
+	// VolumeClaimTemplates are immutable, so the StatefulSet has to be recreated while its Pods and PVCs are orphaned.
+	var gotVerbs []string
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource != "statefulsets" || action.GetVerb() == "list" || action.GetVerb() == "watch" {
+			continue
+		}
+		gotVerbs = append(gotVerbs, action.GetVerb())
+
+		deleteAction, ok := action.(clienttesting.DeleteAction)
+		if !ok {
+			continue
+		}
+		propagationPolicy := deleteAction.GetDeleteOptions().PropagationPolicy
+		if propagationPolicy == nil || *propagationPolicy != metav1.DeletePropagationOrphan {
+			t.Errorf("expected the StatefulSet to be deleted with the %q propagation policy, got %v", metav1.DeletePropagationOrphan, propagationPolicy)
+		}
+	}
+
+	expectedVerbs := []string{"delete", "create"}
+	if !reflect.DeepEqual(gotVerbs, expectedVerbs) {
+		t.Errorf("expected StatefulSet actions %v, got %v", expectedVerbs, gotVerbs)
+	}
+
+	var gotStorage []string
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		storage := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+		gotStorage = append(gotStorage, storage.String())
+	}
+	expectedStorage := []string{"2Gi"}
+	if !reflect.DeepEqual(gotStorage, expectedStorage) {
+		t.Errorf("expected volumeClaimTemplate storage %v, got %v", expectedStorage, gotStorage)
+	}
+}
+
+func TestRolloutSecretsHashIsReconciled(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	steps := []struct {
+		name                       string
+		agentToken                 *string
+		expectedMissingSecretNames []string
+		expectedChanged            bool
+		expectedHashChanged        bool
+	}{
+		{
+			name:                       "missing secret can't be hashed",
+			agentToken:                 nil,
+			expectedMissingSecretNames: []string{"basic-auth-token"},
+		},
+		{
+			name:                "secret stamps its hash on the Pod template",
+			agentToken:          pointer.Ptr("token-1"),
+			expectedChanged:     true,
+			expectedHashChanged: true,
+		},
+		{
+			name:                "steady secret doesn't change the Pod template",
+			agentToken:          pointer.Ptr("token-1"),
+			expectedChanged:     false,
+			expectedHashChanged: false,
+		},
+		{
+			name:                "rotated secret changes the Pod template",
+			agentToken:          pointer.Ptr("token-2"),
+			expectedChanged:     true,
+			expectedHashChanged: true,
+		},
+		{
+			name:                "secret rotated back changes the Pod template",
+			agentToken:          pointer.Ptr("token-1"),
+			expectedChanged:     true,
+			expectedHashChanged: true,
+		},
+	}
+
+	sdc := newScyllaDBDatacenterWithRacks("a")
+	client := fake.NewSimpleClientset()
+
+	var previousHash string
+	for _, step := range steps {
+		var secrets []*corev1.Secret
+		if step.agentToken != nil {
+			secrets = append(secrets, newAgentTokenSecret(t, sdc, *step.agentToken))
+		}
+
+		rolloutSecretsHash, missingSecretNames, err := makeRolloutSecretsHash(sdc, mapByName(secrets))
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if !reflect.DeepEqual(missingSecretNames, step.expectedMissingSecretNames) {
+			t.Errorf("%s: expected and got missing secret names differ:\n%s", step.name, cmp.Diff(step.expectedMissingSecretNames, missingSecretNames))
+		}
+		if len(missingSecretNames) != 0 {
+			continue
+		}
+
+		sdcc, _ := newTestController(t, ctx, client)
+
+		existingStatefulSets, err := sdcc.statefulSetLister.StatefulSets(sdc.Namespace).List(labels.Everything())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		required, err := sdcc.makeRacks(sdc, mapByName(existingStatefulSets), "", rolloutSecretsHash)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if len(required) != 1 {
+			t.Fatalf("%s: expected 1 StatefulSet, got %d", step.name, len(required))
+		}
+
+		sts, changed, err := resourceapply.ApplyStatefulSet(ctx, client.AppsV1(), sdcc.statefulSetLister, sdcc.eventRecorder, required[0], resourceapply.ApplyOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if changed != step.expectedChanged {
+			t.Errorf("%s: expected changed %t, got %t", step.name, step.expectedChanged, changed)
+		}
+
+		gotHash := sts.Spec.Template.Annotations[naming.RolloutSecretsHashAnnotation]
+		if len(gotHash) == 0 {
+			t.Errorf("%s: expected the Pod template to have the %q annotation", step.name, naming.RolloutSecretsHashAnnotation)
+		}
+		if (gotHash != previousHash) != step.expectedHashChanged {
+			t.Errorf("%s: expected hash changed %t, got %q after %q", step.name, step.expectedHashChanged, gotHash, previousHash)
+		}
+		previousHash = gotHash
+	}
+}
+
+func TestRolloutSecretsHashDoesNotRestartExistingStatefulSets(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	steps := []struct {
+		name                    string
+		agentToken              *string
+		expectedTemplateChanged bool
+		expectedTemplateHash    bool
+	}{
+		{
+			name:                    "upgrade doesn't change the Pod template",
+			agentToken:              pointer.Ptr("token-1"),
+			expectedTemplateChanged: false,
+			expectedTemplateHash:    false,
+		},
+		{
+			name:                    "steady secret doesn't change the Pod template",
+			agentToken:              pointer.Ptr("token-1"),
+			expectedTemplateChanged: false,
+			expectedTemplateHash:    false,
+		},
+		{
+			name:                    "missing secret doesn't change the Pod template",
+			agentToken:              nil,
+			expectedTemplateChanged: false,
+			expectedTemplateHash:    false,
+		},
+		{
+			name:                    "rotated secret changes the Pod template",
+			agentToken:              pointer.Ptr("token-2"),
+			expectedTemplateChanged: true,
+			expectedTemplateHash:    true,
+		},
+		{
+			name:                    "missing secret keeps the hash on the Pod template",
+			agentToken:              nil,
+			expectedTemplateChanged: false,
+			expectedTemplateHash:    true,
+		},
+	}
+
+	sdc := newScyllaDBDatacenterWithRacks("a")
+
+	// StatefulSets created by older operator versions don't have the rollout Secrets hash on the Pod template.
+	existingSts := newRackStatefulSets(t, sdc)[0]
+	delete(existingSts.Spec.Template.Annotations, naming.RolloutSecretsHashAnnotation)
+	client := fake.NewSimpleClientset()
+	{
+		sdcc, _ := newTestController(t, ctx, client)
+		_, _, err := resourceapply.ApplyStatefulSet(ctx, client.AppsV1(), sdcc.statefulSetLister, sdcc.eventRecorder, existingSts, resourceapply.ApplyOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, step := range steps {
+		var secrets []*corev1.Secret
+		if step.agentToken != nil {
+			secrets = append(secrets, newAgentTokenSecret(t, sdc, *step.agentToken))
+		}
+
+		rolloutSecretsHash, _, err := makeRolloutSecretsHash(sdc, mapByName(secrets))
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		sdcc, _ := newTestController(t, ctx, client)
+
+		existingStatefulSets, err := sdcc.statefulSetLister.StatefulSets(sdc.Namespace).List(labels.Everything())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(existingStatefulSets) != 1 {
+			t.Fatalf("%s: expected 1 existing StatefulSet, got %d", step.name, len(existingStatefulSets))
+		}
+		existingTemplate := existingStatefulSets[0].Spec.Template
+
+		required, err := sdcc.makeRacks(sdc, mapByName(existingStatefulSets), "", rolloutSecretsHash)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		sts, _, err := resourceapply.ApplyStatefulSet(ctx, client.AppsV1(), sdcc.statefulSetLister, sdcc.eventRecorder, required[0], resourceapply.ApplyOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		templateChanged := !equality.Semantic.DeepEqual(sts.Spec.Template, existingTemplate)
+		if templateChanged != step.expectedTemplateChanged {
+			t.Errorf("%s: expected Pod template changed %t, got %t:\n%s", step.name, step.expectedTemplateChanged, templateChanged, cmp.Diff(existingTemplate, sts.Spec.Template))
+		}
+
+		_, hasTemplateHash := sts.Spec.Template.Annotations[naming.RolloutSecretsHashAnnotation]
+		if hasTemplateHash != step.expectedTemplateHash {
+			t.Errorf("%s: expected the Pod template to have the %q annotation %t, got %t", step.name, naming.RolloutSecretsHashAnnotation, step.expectedTemplateHash, hasTemplateHash)
+		}
+	}
+}
+
+func TestStatefulSetEphemeralStorageLimitIsReconciled(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	steps := []struct {
+		name                  string
+		ephemeralStorageLimit *string
+		expectedLimit         *string
+		expectedChanged       bool
+	}{
+		{
+			name:                  "StatefulSet without a limit is created",
+			ephemeralStorageLimit: nil,
+			expectedLimit:         nil,
+			expectedChanged:       true,
+		},
+		{
+			name:                  "setting the limit updates the StatefulSet",
+			ephemeralStorageLimit: pointer.Ptr("1Gi"),
+			expectedLimit:         pointer.Ptr("1Gi"),
+			expectedChanged:       true,
+		},
+		{
+			name:                  "equal limit in bytes doesn't update the StatefulSet",
+			ephemeralStorageLimit: pointer.Ptr("1073741824"),
+			expectedLimit:         pointer.Ptr("1Gi"),
+			expectedChanged:       false,
+		},
+		{
+			name:                  "equal limit in a smaller unit doesn't update the StatefulSet",
+			ephemeralStorageLimit: pointer.Ptr("1024Mi"),
+			expectedLimit:         pointer.Ptr("1Gi"),
+			expectedChanged:       false,
+		},
+		{
+			name:                  "changing the limit updates the StatefulSet",
+			ephemeralStorageLimit: pointer.Ptr("2G"),
+			expectedLimit:         pointer.Ptr("2G"),
+			expectedChanged:       true,
+		},
+		{
+			name:                  "equal decimal limit in an exponent form doesn't update the StatefulSet",
+			ephemeralStorageLimit: pointer.Ptr("2e9"),
+			expectedLimit:         pointer.Ptr("2G"),
+			expectedChanged:       false,
+		},
+		{
+			name:                  "binary limit keeps its form",
+			ephemeralStorageLimit: pointer.Ptr("500Mi"),
+			expectedLimit:         pointer.Ptr("500Mi"),
+			expectedChanged:       true,
+		},
+		{
+			name:                  "existing binary limit is unchanged",
+			ephemeralStorageLimit: pointer.Ptr("500Mi"),
+			expectedLimit:         pointer.Ptr("500Mi"),
+			expectedChanged:       false,
+		},
+		{
+			name:                  "equal limit in a decimal form doesn't update the StatefulSet",
+			ephemeralStorageLimit: pointer.Ptr("524288k"),
+			expectedLimit:         pointer.Ptr("500Mi"),
+			expectedChanged:       false,
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+
+	for _, step := range steps {
+		sdc := newScyllaDBDatacenterWithRacks("a")
+		if step.ephemeralStorageLimit != nil {
+			sdc.Spec.Racks[0].ScyllaDB = &scyllav1alpha1.ScyllaDBTemplate{
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceEphemeralStorage: resource.MustParse(*step.ephemeralStorageLimit),
+					},
+				},
+			}
+		}
+
+		sdcc, _ := newTestController(t, ctx, client)
+
+		existingStatefulSets, err := sdcc.statefulSetLister.StatefulSets(sdc.Namespace).List(labels.Everything())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		required, err := sdcc.makeRacks(sdc, mapByName(existingStatefulSets), "", "")
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if len(required) != 1 {
+			t.Fatalf("%s: expected 1 StatefulSet, got %d", step.name, len(required))
+		}
+
+		sts, changed, err := resourceapply.ApplyStatefulSet(ctx, client.AppsV1(), sdcc.statefulSetLister, sdcc.eventRecorder, required[0], resourceapply.ApplyOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if changed != step.expectedChanged {
+			t.Errorf("%s: expected changed %t, got %t", step.name, step.expectedChanged, changed)
+		}
+
+		var gotLimit *string
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			if c.Name != naming.ScyllaContainerName {
+				continue
+			}
+
+			q, ok := c.Resources.Limits[corev1.ResourceEphemeralStorage]
+			if ok {
+				gotLimit = pointer.Ptr(q.String())
+			}
+		}
+		if !reflect.DeepEqual(gotLimit, step.expectedLimit) {
+			t.Errorf("%s: expected and got ephemeral storage limit differ:\n%s", step.name, cmp.Diff(step.expectedLimit, gotLimit))
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"testing"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newBasicScyllaDBDatacenter() *scyllav1alpha1.ScyllaDBDatacenter {
+	return &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+			UID:       "the-uid",
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			ScyllaDB: scyllav1alpha1.ScyllaDB{
+				Image: "scylladb/scylla:latest",
+			},
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "rack",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr[int32](3),
+					},
+				},
+			},
+		},
+	}
+}
+
+func newIndexer[T runtime.Object](t *testing.T, items []T) cache.Indexer {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, item := range items {
+		err := indexer.Add(item)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return indexer
+}
+
+func toPointers[T any](items []T) []*T {
+	res := make([]*T, 0, len(items))
+	for i := range items {
+		res = append(res, &items[i])
+	}
+	return res
+}
+
+// newTestController returns a Controller backed by the provided fake client.
+// Listers are filled with the current state of the client, simulating fully synced informers,
+// so the controller has to be recreated to observe changes made by a previous sync.
+func newTestController(t *testing.T, ctx context.Context, client *fake.Clientset) (*Controller, *record.FakeRecorder) {
+	t.Helper()
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	must(err)
+	services, err := client.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	must(err)
+	secrets, err := client.CoreV1().Secrets("").List(ctx, metav1.ListOptions{})
+	must(err)
+	configMaps, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+	must(err)
+	serviceAccounts, err := client.CoreV1().ServiceAccounts("").List(ctx, metav1.ListOptions{})
+	must(err)
+	roles, err := client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	must(err)
+	roleBindings, err := client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	must(err)
+	statefulSets, err := client.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+	must(err)
+	pdbs, err := client.PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	must(err)
+	ingresses, err := client.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	must(err)
+	jobs, err := client.BatchV1().Jobs("").List(ctx, metav1.ListOptions{})
+	must(err)
+	pvcs, err := client.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{})
+	must(err)
+	networkPolicies, err := client.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
+	must(err)
+
+	recorder := record.NewFakeRecorder(100)
+
+	return &Controller{
+		kubeClient: client,
+
+		podLister:            corev1listers.NewPodLister(newIndexer(t, toPointers[corev1.Pod](pods.Items))),
+		serviceLister:        corev1listers.NewServiceLister(newIndexer(t, toPointers[corev1.Service](services.Items))),
+		secretLister:         corev1listers.NewSecretLister(newIndexer(t, toPointers[corev1.Secret](secrets.Items))),
+		configMapLister:      corev1listers.NewConfigMapLister(newIndexer(t, toPointers[corev1.ConfigMap](configMaps.Items))),
+		serviceAccountLister: corev1listers.NewServiceAccountLister(newIndexer(t, toPointers[corev1.ServiceAccount](serviceAccounts.Items))),
+		roleLister:           rbacv1listers.NewRoleLister(newIndexer(t, toPointers[rbacv1.Role](roles.Items))),
+		roleBindingLister:    rbacv1listers.NewRoleBindingLister(newIndexer(t, toPointers[rbacv1.RoleBinding](roleBindings.Items))),
+		statefulSetLister:    appsv1listers.NewStatefulSetLister(newIndexer(t, toPointers[appsv1.StatefulSet](statefulSets.Items))),
+		pdbLister:            policyv1listers.NewPodDisruptionBudgetLister(newIndexer(t, toPointers[policyv1.PodDisruptionBudget](pdbs.Items))),
+		ingressLister:        networkingv1listers.NewIngressLister(newIndexer(t, toPointers[networkingv1.Ingress](ingresses.Items))),
+		jobLister:            batchv1listers.NewJobLister(newIndexer(t, toPointers[batchv1.Job](jobs.Items))),
+		pvcLister:            corev1listers.NewPersistentVolumeClaimLister(newIndexer(t, toPointers[corev1.PersistentVolumeClaim](pvcs.Items))),
+		networkPolicyLister:  networkingv1listers.NewNetworkPolicyLister(newIndexer(t, toPointers[networkingv1.NetworkPolicy](networkPolicies.Items))),
+
+		eventRecorder: recorder,
+	}, recorder
+}
+
+// mapByName converts a list of objects into a map keyed by their names, as returned by controllerhelpers.GetObjects.
+func mapByName[T metav1.Object](items []T) map[string]T {
+	res := make(map[string]T, len(items))
+	for _, item := range items {
+		res[item.GetName()] = item
+	}
+	return res
+}
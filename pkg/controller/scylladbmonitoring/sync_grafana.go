@@ -451,66 +451,31 @@ func (smc *Controller) syncGrafana(
 	applyConfigurations := []resourceapply.ApplyConfigUntyped{
 		resourceapply.ApplyConfig[*corev1.ServiceAccount]{
 			Required: requiredGrafanaSA,
-			Control: resourceapply.ApplyControlFuncs[*corev1.ServiceAccount]{
-				GetCachedFunc: smc.serviceAccountLister.ServiceAccounts(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*corev1.ServiceAccount](smc.serviceAccountLister.ServiceAccounts(sm.Namespace), smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*rbacv1.RoleBinding]{
 			Required: requiredGrafanaRoleBinding,
-			Control: resourceapply.ApplyControlFuncs[*rbacv1.RoleBinding]{
-				GetCachedFunc: smc.roleBindingLister.RoleBindings(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.RbacV1().RoleBindings(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.RbacV1().RoleBindings(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.RbacV1().RoleBindings(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*rbacv1.RoleBinding](smc.roleBindingLister.RoleBindings(sm.Namespace), smc.kubeClient.RbacV1().RoleBindings(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*corev1.ConfigMap]{
 			Required: requiredConfigsCM,
-			Control: resourceapply.ApplyControlFuncs[*corev1.ConfigMap]{
-				GetCachedFunc: smc.configMapLister.ConfigMaps(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*corev1.ConfigMap](smc.configMapLister.ConfigMaps(sm.Namespace), smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*corev1.ConfigMap]{
 			Required: requiredProvisioningsCM,
-			Control: resourceapply.ApplyControlFuncs[*corev1.ConfigMap]{
-				GetCachedFunc: smc.configMapLister.ConfigMaps(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*corev1.ConfigMap](smc.configMapLister.ConfigMaps(sm.Namespace), smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*corev1.Secret]{
 			Required: requiredAdminCredentialsSecret,
-			Control: resourceapply.ApplyControlFuncs[*corev1.Secret]{
-				GetCachedFunc: smc.secretLister.Secrets(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.CoreV1().Secrets(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.CoreV1().Secrets(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.CoreV1().Secrets(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*corev1.Secret](smc.secretLister.Secrets(sm.Namespace), smc.kubeClient.CoreV1().Secrets(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*appsv1.Deployment]{
 			Required: requiredDeployment,
-			Control: resourceapply.ApplyControlFuncs[*appsv1.Deployment]{
-				GetCachedFunc: smc.deploymentLister.Deployments(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.AppsV1().Deployments(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.AppsV1().Deployments(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.AppsV1().Deployments(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*appsv1.Deployment](smc.deploymentLister.Deployments(sm.Namespace), smc.kubeClient.AppsV1().Deployments(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*corev1.Service]{
 			Required: requiredService,
-			Control: resourceapply.ApplyControlFuncs[*corev1.Service]{
-				GetCachedFunc: smc.serviceLister.Services(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.CoreV1().Services(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.CoreV1().Services(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.CoreV1().Services(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*corev1.Service](smc.serviceLister.Services(sm.Namespace), smc.kubeClient.CoreV1().Services(sm.Namespace)),
 		}.ToUntyped(),
 	}
 	for _, cm := range requiredDahsboardsCMs {
@@ -518,12 +483,7 @@ func (smc *Controller) syncGrafana(
 			applyConfigurations,
 			resourceapply.ApplyConfig[*corev1.ConfigMap]{
 				Required: cm,
-				Control: resourceapply.ApplyControlFuncs[*corev1.ConfigMap]{
-					GetCachedFunc: smc.configMapLister.ConfigMaps(sm.Namespace).Get,
-					CreateFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Create,
-					UpdateFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Update,
-					DeleteFunc:    smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace).Delete,
-				},
+				Control:  resourceapply.NewApplyControlFuncs[*corev1.ConfigMap](smc.configMapLister.ConfigMaps(sm.Namespace), smc.kubeClient.CoreV1().ConfigMaps(sm.Namespace)),
 			}.ToUntyped(),
 		)
 	}
@@ -531,12 +491,7 @@ func (smc *Controller) syncGrafana(
 	if requiredIngress != nil {
 		applyConfigurations = append(applyConfigurations, resourceapply.ApplyConfig[*networkingv1.Ingress]{
 			Required: requiredIngress,
-			Control: resourceapply.ApplyControlFuncs[*networkingv1.Ingress]{
-				GetCachedFunc: smc.ingressLister.Ingresses(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*networkingv1.Ingress](smc.ingressLister.Ingresses(sm.Namespace), smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace)),
 		}.ToUntyped())
 	}
 
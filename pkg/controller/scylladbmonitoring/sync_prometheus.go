@@ -440,12 +440,7 @@ func (smc *Controller) syncPrometheus(
 	applyConfigurations := []resourceapply.ApplyConfigUntyped{
 		resourceapply.ApplyConfig[*corev1.ServiceAccount]{
 			Required: requiredPrometheusSA,
-			Control: resourceapply.ApplyControlFuncs[*corev1.ServiceAccount]{
-				GetCachedFunc: smc.serviceAccountLister.ServiceAccounts(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*corev1.ServiceAccount](smc.serviceAccountLister.ServiceAccounts(sm.Namespace), smc.kubeClient.CoreV1().ServiceAccounts(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*corev1.Service]{
 			Required: requiredPrometheusService,
@@ -457,69 +452,34 @@ func (smc *Controller) syncPrometheus(
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*rbacv1.RoleBinding]{
 			Required: requiredPrometheusRoleBinding,
-			Control: resourceapply.ApplyControlFuncs[*rbacv1.RoleBinding]{
-				GetCachedFunc: smc.roleBindingLister.RoleBindings(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.RbacV1().RoleBindings(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.RbacV1().RoleBindings(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.RbacV1().RoleBindings(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*rbacv1.RoleBinding](smc.roleBindingLister.RoleBindings(sm.Namespace), smc.kubeClient.RbacV1().RoleBindings(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*monitoringv1.Prometheus]{
 			Required: requiredPrometheus,
-			Control: resourceapply.ApplyControlFuncs[*monitoringv1.Prometheus]{
-				GetCachedFunc: smc.prometheusLister.Prometheuses(sm.Namespace).Get,
-				CreateFunc:    smc.monitoringClient.Prometheuses(sm.Namespace).Create,
-				UpdateFunc:    smc.monitoringClient.Prometheuses(sm.Namespace).Update,
-				DeleteFunc:    smc.monitoringClient.Prometheuses(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*monitoringv1.Prometheus](smc.prometheusLister.Prometheuses(sm.Namespace), smc.monitoringClient.Prometheuses(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*monitoringv1.ServiceMonitor]{
 			Required: requiredScyllaDBServiceMonitor,
-			Control: resourceapply.ApplyControlFuncs[*monitoringv1.ServiceMonitor]{
-				GetCachedFunc: smc.serviceMonitorLister.ServiceMonitors(sm.Namespace).Get,
-				CreateFunc:    smc.monitoringClient.ServiceMonitors(sm.Namespace).Create,
-				UpdateFunc:    smc.monitoringClient.ServiceMonitors(sm.Namespace).Update,
-				DeleteFunc:    smc.monitoringClient.ServiceMonitors(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*monitoringv1.ServiceMonitor](smc.serviceMonitorLister.ServiceMonitors(sm.Namespace), smc.monitoringClient.ServiceMonitors(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*monitoringv1.PrometheusRule]{
 			Required: requiredLatencyPrometheusRule,
-			Control: resourceapply.ApplyControlFuncs[*monitoringv1.PrometheusRule]{
-				GetCachedFunc: smc.prometheusRuleLister.PrometheusRules(sm.Namespace).Get,
-				CreateFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Create,
-				UpdateFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Update,
-				DeleteFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*monitoringv1.PrometheusRule](smc.prometheusRuleLister.PrometheusRules(sm.Namespace), smc.monitoringClient.PrometheusRules(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*monitoringv1.PrometheusRule]{
 			Required: requiredAlertsPrometheusRule,
-			Control: resourceapply.ApplyControlFuncs[*monitoringv1.PrometheusRule]{
-				GetCachedFunc: smc.prometheusRuleLister.PrometheusRules(sm.Namespace).Get,
-				CreateFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Create,
-				UpdateFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Update,
-				DeleteFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*monitoringv1.PrometheusRule](smc.prometheusRuleLister.PrometheusRules(sm.Namespace), smc.monitoringClient.PrometheusRules(sm.Namespace)),
 		}.ToUntyped(),
 		resourceapply.ApplyConfig[*monitoringv1.PrometheusRule]{
 			Required: requiredTablePrometheusRule,
-			Control: resourceapply.ApplyControlFuncs[*monitoringv1.PrometheusRule]{
-				GetCachedFunc: smc.prometheusRuleLister.PrometheusRules(sm.Namespace).Get,
-				CreateFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Create,
-				UpdateFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Update,
-				DeleteFunc:    smc.monitoringClient.PrometheusRules(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*monitoringv1.PrometheusRule](smc.prometheusRuleLister.PrometheusRules(sm.Namespace), smc.monitoringClient.PrometheusRules(sm.Namespace)),
 		}.ToUntyped(),
 	}
 
 	if requiredIngress != nil {
 		applyConfigurations = append(applyConfigurations, resourceapply.ApplyConfig[*networkingv1.Ingress]{
 			Required: requiredIngress,
-			Control: resourceapply.ApplyControlFuncs[*networkingv1.Ingress]{
-				GetCachedFunc: smc.ingressLister.Ingresses(sm.Namespace).Get,
-				CreateFunc:    smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace).Create,
-				UpdateFunc:    smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace).Update,
-				DeleteFunc:    smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace).Delete,
-			},
+			Control:  resourceapply.NewApplyControlFuncs[*networkingv1.Ingress](smc.ingressLister.Ingresses(sm.Namespace), smc.kubeClient.NetworkingV1().Ingresses(sm.Namespace)),
 		}.ToUntyped())
 	}
 
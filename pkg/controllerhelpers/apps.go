@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 )
 
@@ -40,6 +42,49 @@ func IsStatefulSetRolledOut(sts *appsv1.StatefulSet) (bool, error) {
 	}
 }
 
+// GetNextStatefulSetPartition returns the partition a staged rollout of the StatefulSet should advance to.
+// The rollout may proceed only once the Pod at the current partition ordinal, the last one updated,
+// runs the update revision and is ready. It returns false when the rollout has to wait for that Pod.
+// The next partition never drops below 0.
+func GetNextStatefulSetPartition(sts *appsv1.StatefulSet, getPod func(name string) (*corev1.Pod, error)) (int32, bool, error) {
+	if sts.Spec.UpdateStrategy.RollingUpdate == nil || sts.Spec.UpdateStrategy.RollingUpdate.Partition == nil {
+		return 0, false, fmt.Errorf("statefulset %q doesn't have a partition set", klog.KObj(sts))
+	}
+
+	if sts.Spec.Replicas == nil {
+		return 0, false, fmt.Errorf("statefulset.spec.replicas can't be nil")
+	}
+
+	partition := *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+	if partition < *sts.Spec.Replicas {
+		podName := fmt.Sprintf("%s-%d", sts.Name, partition)
+		pod, err := getPod(podName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				klog.V(4).InfoS("Waiting for the updated Pod to be created", "StatefulSet", klog.KObj(sts), "Pod", podName)
+				return partition, false, nil
+			}
+			return 0, false, fmt.Errorf("can't get pod %q: %w", podName, err)
+		}
+
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] != sts.Status.UpdateRevision {
+			klog.V(4).InfoS("Waiting for the Pod to be updated", "StatefulSet", klog.KObj(sts), "Pod", klog.KObj(pod))
+			return partition, false, nil
+		}
+
+		if !IsPodReady(pod) {
+			klog.V(4).InfoS("Waiting for the updated Pod to become ready", "StatefulSet", klog.KObj(sts), "Pod", klog.KObj(pod))
+			return partition, false, nil
+		}
+	}
+
+	if partition <= 0 {
+		return 0, true, nil
+	}
+
+	return partition - 1, true, nil
+}
+
 func IsDaemonSetRolledOut(ds *appsv1.DaemonSet) (bool, error) {
 	if ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
 		return false, fmt.Errorf("can't determine rollout status for %s strategy type", ds.Spec.UpdateStrategy.Type)
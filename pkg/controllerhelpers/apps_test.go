@@ -7,7 +7,10 @@ import (
 
 	"github.com/scylladb/scylla-operator/pkg/pointer"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestIsStatefulSetRolledOut(t *testing.T) {
@@ -275,3 +278,123 @@ func TestIsStatefulSetRolledOut(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNextStatefulSetPartitionStagedRollout(t *testing.T) {
+	t.Parallel()
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Ptr(int32(3)),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: pointer.Ptr(int32(3)),
+				},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			CurrentRevision: "old",
+			UpdateRevision:  "new",
+		},
+	}
+
+	pods := map[string]*corev1.Pod{}
+	for i := range 3 {
+		name := fmt.Sprintf("basic-%d", i)
+		pods[name] = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					appsv1.ControllerRevisionHashLabelKey: "old",
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	getPod := func(name string) (*corev1.Pod, error) {
+		pod, ok := pods[name]
+		if !ok {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, name)
+		}
+		return pod, nil
+	}
+
+	// reconcile advances the partition the same way the controller does across reconciles.
+	reconcile := func() bool {
+		t.Helper()
+
+		next, ok, err := GetNextStatefulSetPartition(sts, getPod)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			return false
+		}
+		sts.Spec.UpdateStrategy.RollingUpdate.Partition = pointer.Ptr(next)
+		return true
+	}
+
+	// updatePod simulates the StatefulSet controller recreating the Pod with the update revision.
+	updatePod := func(ordinal int, ready bool) {
+		t.Helper()
+
+		pod := pods[fmt.Sprintf("basic-%d", ordinal)]
+		pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "new"
+		pod.Status.Conditions[0].Status = corev1.ConditionFalse
+		if ready {
+			pod.Status.Conditions[0].Status = corev1.ConditionTrue
+		}
+	}
+
+	expectPartition := func(expected int32) {
+		t.Helper()
+
+		got := *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+		if got != expected {
+			t.Fatalf("expected partition %d, got %d", expected, got)
+		}
+	}
+
+	if !reconcile() {
+		t.Fatal("expected the rollout to start")
+	}
+	expectPartition(2)
+
+	for _, ordinal := range []int32{2, 1, 0} {
+		if reconcile() {
+			t.Fatalf("expected the rollout to wait for pod %d to be updated", ordinal)
+		}
+		expectPartition(ordinal)
+
+		updatePod(int(ordinal), false)
+		if reconcile() {
+			t.Fatalf("expected the rollout to wait for pod %d to become ready", ordinal)
+		}
+		expectPartition(ordinal)
+
+		updatePod(int(ordinal), true)
+		if !reconcile() {
+			t.Fatalf("expected the rollout to advance after pod %d became healthy", ordinal)
+		}
+		expectPartition(max(ordinal-1, 0))
+	}
+
+	delete(pods, "basic-0")
+	if reconcile() {
+		t.Fatal("expected the rollout to wait for a missing pod")
+	}
+	expectPartition(0)
+}
@@ -0,0 +1,58 @@
+package controllerhelpers
+
+import (
+	"fmt"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultJobBackoffLimit bounds the retries of utility Jobs, so a broken Job ends up failed
+// and gets reported by IsJobCompleted instead of being retried forever.
+const defaultJobBackoffLimit = 6
+
+// SetJobDefaults sets the fields of a utility Job that the apiserver either doesn't default or defaults unsuitably.
+// Fields that are already set are kept. It has to be called before the Job is applied, so the hash covers the defaults.
+// No ttlSecondsAfterFinished is set, controllers track the completion of their Jobs and would recreate the ones removed by the TTL.
+func SetJobDefaults(job *batchv1.Job) {
+	if job.Spec.BackoffLimit == nil {
+		job.Spec.BackoffLimit = pointer.Ptr(int32(defaultJobBackoffLimit))
+	}
+
+	// Pods of a Job can't use the apiserver default restart policy "Always".
+	if len(job.Spec.Template.Spec.RestartPolicy) == 0 {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	}
+}
+
+// MakeJob returns a utility Job with the given metadata and spec, with defaults set by SetJobDefaults.
+func MakeJob(objectMeta metav1.ObjectMeta, spec batchv1.JobSpec) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: objectMeta,
+		Spec:       spec,
+	}
+	SetJobDefaults(job)
+	return job
+}
+
+// IsJobCompleted returns whether the Job has succeeded. A failed Job is reported as an error,
+// as it won't make any more progress on its own.
+func IsJobCompleted(job *batchv1.Job) (bool, error) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("job %q has failed: %s: %s", naming.ObjRef(job), c.Reason, c.Message)
+		}
+	}
+
+	return false, nil
+}
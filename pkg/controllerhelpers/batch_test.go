@@ -0,0 +1,248 @@
+package controllerhelpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestSetJobDefaults(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		job      *batchv1.Job
+		expected *batchv1.Job
+	}{
+		{
+			name: "sets missing fields",
+			job:  &batchv1.Job{},
+			expected: &batchv1.Job{
+				Spec: batchv1.JobSpec{
+					BackoffLimit: pointer.Ptr(int32(6)),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyOnFailure,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "keeps fields that are set",
+			job: &batchv1.Job{
+				Spec: batchv1.JobSpec{
+					BackoffLimit:            pointer.Ptr(int32(3)),
+					TTLSecondsAfterFinished: pointer.Ptr(int32(0)),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+						},
+					},
+				},
+			},
+			expected: &batchv1.Job{
+				Spec: batchv1.JobSpec{
+					BackoffLimit:            pointer.Ptr(int32(3)),
+					TTLSecondsAfterFinished: pointer.Ptr(int32(0)),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			job := tc.job.DeepCopy()
+			SetJobDefaults(job)
+			if !equality.Semantic.DeepEqual(job, tc.expected) {
+				t.Errorf("expected and got jobs differ:\n%s", cmp.Diff(tc.expected, job))
+			}
+		})
+	}
+}
+
+func TestMakeJobReapplyIsNoop(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	makeRequired := func() *batchv1.Job {
+		return MakeJob(
+			metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "cleanup",
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         "scylla.scylladb.com/v1alpha1",
+						Kind:               "ScyllaDBDatacenter",
+						Name:               "basic",
+						UID:                "the-uid",
+						Controller:         pointer.Ptr(true),
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "cleanup",
+								Image: "scylladb/scylla-operator:latest",
+							},
+						},
+					},
+				},
+			},
+		)
+	}
+
+	client := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10)
+	newLister := func() batchv1listers.JobLister {
+		jobList, err := client.BatchV1().Jobs("default").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for i := range jobList.Items {
+			err = indexer.Add(&jobList.Items[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return batchv1listers.NewJobLister(indexer)
+	}
+
+	created, changed, err := resourceapply.ApplyJob(ctx, client.BatchV1(), newLister(), recorder, makeRequired(), resourceapply.ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatalf("expected the job to be created")
+	}
+
+	if created.Spec.BackoffLimit == nil || len(created.Spec.Template.Spec.RestartPolicy) == 0 {
+		t.Fatalf("expected the created job to have the defaults set, got %#v", created.Spec)
+	}
+
+	// Simulate the apiserver defaulting the rest of the Job.
+	defaulted := created.DeepCopy()
+	defaulted.Spec.Completions = pointer.Ptr(int32(1))
+	defaulted.Spec.Parallelism = pointer.Ptr(int32(1))
+	defaulted.Spec.CompletionMode = pointer.Ptr(batchv1.NonIndexedCompletion)
+	defaulted.Spec.Suspend = pointer.Ptr(false)
+	defaulted.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	defaulted.Spec.Template.Spec.SchedulerName = corev1.DefaultSchedulerName
+	_, err = client.BatchV1().Jobs("default").Update(ctx, defaulted, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, changed, err = resourceapply.ApplyJob(ctx, client.BatchV1(), newLister(), recorder, makeRequired(), resourceapply.ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Errorf("expected reapplying the job to be a no-op")
+	}
+}
+
+func TestIsJobCompleted(t *testing.T) {
+	t.Parallel()
+
+	newJob := func(conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "cleanup",
+			},
+			Status: batchv1.JobStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	tt := []struct {
+		name        string
+		job         *batchv1.Job
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name:        "running job isn't completed",
+			job:         newJob(),
+			expected:    false,
+			expectedErr: nil,
+		},
+		{
+			name: "succeeded job is completed",
+			job: newJob(batchv1.JobCondition{
+				Type:   batchv1.JobComplete,
+				Status: corev1.ConditionTrue,
+			}),
+			expected:    true,
+			expectedErr: nil,
+		},
+		{
+			name: "failed job returns an error",
+			job: newJob(batchv1.JobCondition{
+				Type:    batchv1.JobFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "BackoffLimitExceeded",
+				Message: "Job has reached the specified backoff limit",
+			}),
+			expected:    false,
+			expectedErr: fmt.Errorf(`job "default/cleanup" has failed: BackoffLimitExceeded: Job has reached the specified backoff limit`),
+		},
+		{
+			name: "conditions that aren't true are ignored",
+			job: newJob(batchv1.JobCondition{
+				Type:   batchv1.JobFailed,
+				Status: corev1.ConditionFalse,
+			}),
+			expected:    false,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := IsJobCompleted(tc.job)
+			if !reflect.DeepEqual(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/scylladb/scylla-operator/pkg/internalapi"
 	"github.com/scylladb/scylla-operator/pkg/naming"
 	"github.com/scylladb/scylla-operator/pkg/resource"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
 	outilerrors "github.com/scylladb/scylla-operator/pkg/util/errors"
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -190,12 +191,19 @@ func AggregateStatusConditions(conditions []metav1.Condition, condition metav1.C
 	return condition, nil
 }
 
+// SetStatusConditionFromError sets the condition to true with the error as its message, or to false if there is no error.
+// Failed applies get a dedicated reason so the degradation can be told apart from errors computing the required state.
 func SetStatusConditionFromError(conditions *[]metav1.Condition, err error, conditionType string, observedGeneration int64) {
 	if err != nil {
+		reason := internalapi.ErrorReason
+		if resourceapply.IsApplyFailure(err) {
+			reason = internalapi.DegradedDueToApplyFailureReason
+		}
+
 		apimeta.SetStatusCondition(conditions, metav1.Condition{
 			Type:               conditionType,
 			Status:             metav1.ConditionTrue,
-			Reason:             internalapi.ErrorReason,
+			Reason:             reason,
 			Message:            outilerrors.NewMultilineAggregate([]error{err}).Error(),
 			ObservedGeneration: observedGeneration,
 		})
@@ -210,14 +218,54 @@ func SetStatusConditionFromError(conditions *[]metav1.Condition, err error, cond
 	}
 }
 
-func AddGenericProgressingStatusCondition(conditions *[]metav1.Condition, conditionType string, obj runtime.Object, verb string, observedGeneration int64) {
-	*conditions = append(*conditions, metav1.Condition{
-		Type:               conditionType,
+// NewProgressingCondition returns the progressing condition of the controller condition type
+// reporting the action running on the object.
+func NewProgressingCondition(controller, obj, action string, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               controller,
 		Status:             metav1.ConditionTrue,
 		Reason:             internalapi.ProgressingReason,
-		Message:            fmt.Sprintf("Progressing: Running %q on %q", verb, resource.GetObjectGVKOrUnknown(obj)),
-		ObservedGeneration: observedGeneration,
-	})
+		Message:            fmt.Sprintf("Progressing: Running %q on %q", action, obj),
+		ObservedGeneration: generation,
+	}
+}
+
+func AddGenericProgressingStatusCondition(conditions *[]metav1.Condition, conditionType string, obj runtime.Object, verb string, observedGeneration int64) {
+	*conditions = append(*conditions, NewProgressingCondition(conditionType, resource.GetObjectGVKOrUnknown(obj).String(), verb, observedGeneration))
+}
+
+// ConditionsEqualIgnoringTime reports whether the conditions are equal, in order, when their LastTransitionTime is disregarded.
+func ConditionsEqualIgnoringTime(a, b []metav1.Condition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		ac, bc := a[i], b[i]
+		ac.LastTransitionTime = metav1.Time{}
+		bc.LastTransitionTime = metav1.Time{}
+		if ac != bc {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ProgressingEntry describes an operation on an object that makes a controller progress.
+type ProgressingEntry struct {
+	ConditionType string
+	Object        runtime.Object
+	Verb          string
+}
+
+// SetProgressingConditions adds a progressing condition for every entry, as AddGenericProgressingStatusCondition does.
+// All the conditions get the same observedGeneration. LastTransitionTime is left unset because it's only
+// managed once the conditions are aggregated and set on the status.
+func SetProgressingConditions(conditions *[]metav1.Condition, entries []ProgressingEntry, generation int64) {
+	for _, e := range entries {
+		AddGenericProgressingStatusCondition(conditions, e.ConditionType, e.Object, e.Verb, generation)
+	}
 }
 
 func IsPodReadyWithPositiveLiveCheck(ctx context.Context, client corev1client.PodsGetter, pod *corev1.Pod) (bool, *corev1.Pod, error) {
@@ -2,13 +2,20 @@ package controllerhelpers
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestFindStatusConditionsWithSuffix(t *testing.T) {
@@ -424,6 +431,242 @@ func TestAggregateStatusConditions(t *testing.T) {
 	}
 }
 
+func TestSetProgressingConditions(t *testing.T) {
+	tt := []struct {
+		name       string
+		conditions []metav1.Condition
+		entries    []ProgressingEntry
+		generation int64
+		expected   []metav1.Condition
+	}{
+		{
+			name:       "no entries leave conditions untouched",
+			conditions: nil,
+			entries:    nil,
+			generation: 42,
+			expected:   nil,
+		},
+		{
+			name: "conditions are added for all entries with the same generation",
+			conditions: []metav1.Condition{
+				{
+					Type:               "ExistingProgressing",
+					Status:             metav1.ConditionTrue,
+					Reason:             "Progressing",
+					Message:            "foo",
+					ObservedGeneration: 1,
+				},
+			},
+			entries: []ProgressingEntry{
+				{
+					ConditionType: "ConfigMapControllerProgressing",
+					Object:        &corev1.ConfigMap{},
+					Verb:          "apply",
+				},
+				{
+					ConditionType: "ServiceControllerProgressing",
+					Object:        &corev1.Service{},
+					Verb:          "delete",
+				},
+			},
+			generation: 42,
+			expected: []metav1.Condition{
+				{
+					Type:               "ExistingProgressing",
+					Status:             metav1.ConditionTrue,
+					Reason:             "Progressing",
+					Message:            "foo",
+					ObservedGeneration: 1,
+				},
+				{
+					Type:               "ConfigMapControllerProgressing",
+					Status:             metav1.ConditionTrue,
+					Reason:             "Progressing",
+					Message:            `Progressing: Running "apply" on "/v1, Kind=ConfigMap"`,
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "ServiceControllerProgressing",
+					Status:             metav1.ConditionTrue,
+					Reason:             "Progressing",
+					Message:            `Progressing: Running "delete" on "/v1, Kind=Service"`,
+					ObservedGeneration: 42,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := tc.conditions
+			SetProgressingConditions(&conditions, tc.entries, tc.generation)
+			if !apiequality.Semantic.DeepEqual(conditions, tc.expected) {
+				t.Errorf("expected and got conditions differ:\n%s", cmp.Diff(tc.expected, conditions))
+			}
+		})
+	}
+}
+
+func TestNewProgressingCondition(t *testing.T) {
+	got := NewProgressingCondition("ConfigMapControllerProgressing", "/v1, Kind=ConfigMap", "apply", 42)
+
+	var conditions []metav1.Condition
+	AddGenericProgressingStatusCondition(&conditions, "ConfigMapControllerProgressing", &corev1.ConfigMap{}, "apply", 42)
+	expected := conditions[0]
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected and got conditions differ:\n%s", cmp.Diff(expected, got))
+	}
+}
+
+func TestConditionsEqualIgnoringTime(t *testing.T) {
+	newConditions := func(transitionTime time.Time) []metav1.Condition {
+		return []metav1.Condition{
+			NewProgressingCondition("ConfigMapControllerProgressing", "/v1, Kind=ConfigMap", "apply", 42),
+			{
+				Type:               "Available",
+				Status:             metav1.ConditionTrue,
+				Reason:             "AsExpected",
+				ObservedGeneration: 42,
+				LastTransitionTime: metav1.NewTime(transitionTime),
+			},
+		}
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name     string
+		a        []metav1.Condition
+		b        []metav1.Condition
+		expected bool
+	}{
+		{
+			name:     "nil and empty conditions are equal",
+			a:        nil,
+			b:        []metav1.Condition{},
+			expected: true,
+		},
+		{
+			name:     "conditions differing only in transition time are equal",
+			a:        newConditions(now),
+			b:        newConditions(now.Add(time.Hour)),
+			expected: true,
+		},
+		{
+			name: "conditions with a different status aren't equal",
+			a:    newConditions(now),
+			b: func() []metav1.Condition {
+				conditions := newConditions(now)
+				conditions[1].Status = metav1.ConditionFalse
+				return conditions
+			}(),
+			expected: false,
+		},
+		{
+			name:     "conditions of a different length aren't equal",
+			a:        newConditions(now),
+			b:        newConditions(now)[:1],
+			expected: false,
+		},
+		{
+			name: "conditions in a different order aren't equal",
+			a:    newConditions(now),
+			b: func() []metav1.Condition {
+				conditions := newConditions(now)
+				conditions[0], conditions[1] = conditions[1], conditions[0]
+				return conditions
+			}(),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ConditionsEqualIgnoringTime(tc.a, tc.b)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunSyncDegradedDueToApplyFailure(t *testing.T) {
+	const generation = 42
+
+	applyErr := fmt.Errorf("can't update apps/v1, Kind=StatefulSet %q: %w", "default/basic", &resourceapply.APIError{
+		Class: resourceapply.APIErrorClassForbidden,
+		Err:   apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "basic", errors.New("quota exceeded")),
+	})
+
+	tt := []struct {
+		name            string
+		syncErr         error
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage []string
+	}{
+		{
+			name:            "failed apply degrades the object",
+			syncErr:         applyErr,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "DegradedDueToApplyFailure",
+			expectedMessage: []string{"StatefulSetControllerDegraded", "Kind=StatefulSet", "quota exceeded"},
+		},
+		{
+			name:            "other errors keep the generic reason",
+			syncErr:         errors.New("can't make statefulset"),
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "Error",
+			expectedMessage: []string{"StatefulSetControllerDegraded", "can't make statefulset"},
+		},
+		{
+			name:            "successful apply clears the degradation",
+			syncErr:         nil,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "AsExpected",
+			expectedMessage: nil,
+		},
+	}
+
+	// Conditions are shared so the cases run as a sequence of syncs.
+	var conditions []metav1.Condition
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RunSync(&conditions, "StatefulSetControllerProgressing", "StatefulSetControllerDegraded", generation, func() ([]metav1.Condition, error) {
+				return nil, tc.syncErr
+			})
+			if !errors.Is(err, tc.syncErr) {
+				t.Fatalf("expected error %v, got %v", tc.syncErr, err)
+			}
+
+			err = SetAggregatedWorkloadConditions(&conditions, generation)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			degradedCondition := apimeta.FindStatusCondition(conditions, "Degraded")
+			if degradedCondition == nil {
+				t.Fatalf("expected a Degraded condition, got %v", conditions)
+			}
+
+			if degradedCondition.Status != tc.expectedStatus {
+				t.Errorf("expected status %q, got %q", tc.expectedStatus, degradedCondition.Status)
+			}
+
+			if degradedCondition.Reason != tc.expectedReason {
+				t.Errorf("expected reason %q, got %q", tc.expectedReason, degradedCondition.Reason)
+			}
+
+			for _, s := range tc.expectedMessage {
+				if !strings.Contains(degradedCondition.Message, s) {
+					t.Errorf("expected message to contain %q, got %q", s, degradedCondition.Message)
+				}
+			}
+		})
+	}
+}
+
 func TestJoinWithLimit(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,115 @@
+// Copyright (C) 2025 ScyllaDB
+
+package controllerhelpers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// EventRetention holds the limits of PruneEvents. Zero values disable the respective limit.
+type EventRetention struct {
+	// MaxCount is the number of the most recent Events that are kept.
+	MaxCount int
+	// MaxAge is the age after which Events are deleted, regardless of MaxCount.
+	MaxAge time.Duration
+}
+
+// eventTime returns when the Event last occurred.
+func eventTime(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+
+	return event.CreationTimestamp.Time
+}
+
+// PruneEvents deletes the Events recorded by the component about the object that exceed the retention,
+// to keep the list of Events of long living objects readable. Events of other components are never deleted.
+// It returns the number of deleted Events.
+func PruneEvents(
+	ctx context.Context,
+	eventClient corev1client.EventsGetter,
+	obj metav1.Object,
+	component string,
+	retention EventRetention,
+	now time.Time,
+) (int, error) {
+	if retention.MaxCount <= 0 && retention.MaxAge <= 0 {
+		return 0, nil
+	}
+
+	eventList, err := eventClient.Events(obj.GetNamespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("involvedObject.uid", string(obj.GetUID())),
+			fields.OneTermEqualSelector("source", component),
+		).String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("can't list events of %q: %w", naming.ObjRef(obj), err)
+	}
+
+	var events []*corev1.Event
+	for i := range eventList.Items {
+		event := &eventList.Items[i]
+		// Field selectors aren't guaranteed to be honored by every client, make sure the Event is ours.
+		if event.InvolvedObject.UID != obj.GetUID() || event.Source.Component != component {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	// Sort from the newest to the oldest Event.
+	slices.SortFunc(events, func(a, b *corev1.Event) int {
+		c := eventTime(b).Compare(eventTime(a))
+		if c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var errs []error
+	deleted := 0
+	for i, event := range events {
+		tooMany := retention.MaxCount > 0 && i >= retention.MaxCount
+		tooOld := retention.MaxAge > 0 && now.Sub(eventTime(event)) > retention.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		klog.V(4).InfoS("Pruning Event", "Event", klog.KObj(event), "Object", klog.KObj(obj), "TooMany", tooMany, "TooOld", tooOld)
+		err = eventClient.Events(event.Namespace).Delete(ctx, event.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID: &event.UID,
+			},
+		})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't delete event %q: %w", naming.ObjRef(event), err))
+			continue
+		}
+
+		deleted++
+	}
+
+	return deleted, apimachineryutilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2025 ScyllaDB
+
+package controllerhelpers
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPruneEvents(t *testing.T) {
+	t.Parallel()
+
+	const component = "scylladbdatacenter-controller"
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	obj := &metav1.ObjectMeta{
+		Namespace: "scylla",
+		Name:      "basic",
+		UID:       "uid-basic",
+	}
+
+	newEvent := func(name string, age time.Duration, uid types.UID, component string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "scylla",
+				Name:      name,
+				UID:       types.UID("uid-" + name),
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Namespace: "scylla",
+				Name:      "basic",
+				UID:       uid,
+			},
+			Source: corev1.EventSource{
+				Component: component,
+			},
+			LastTimestamp: metav1.NewTime(now.Add(-age)),
+		}
+	}
+
+	tt := []struct {
+		name                    string
+		existingEvents          []runtime.Object
+		retention               EventRetention
+		expectedDeleted         int
+		expectedRemainingEvents []string
+	}{
+		{
+			name: "events older than max age are deleted and recent ones remain",
+			existingEvents: []runtime.Object{
+				newEvent("recent", time.Minute, "uid-basic", component),
+				newEvent("old", 2*time.Hour, "uid-basic", component),
+				newEvent("older", 3*time.Hour, "uid-basic", component),
+			},
+			retention: EventRetention{
+				MaxAge: time.Hour,
+			},
+			expectedDeleted:         2,
+			expectedRemainingEvents: []string{"recent"},
+		},
+		{
+			name: "events beyond max count are deleted from the oldest",
+			existingEvents: []runtime.Object{
+				newEvent("a", 1*time.Minute, "uid-basic", component),
+				newEvent("b", 2*time.Minute, "uid-basic", component),
+				newEvent("c", 3*time.Minute, "uid-basic", component),
+			},
+			retention: EventRetention{
+				MaxCount: 2,
+			},
+			expectedDeleted:         1,
+			expectedRemainingEvents: []string{"a", "b"},
+		},
+		{
+			name: "events of other objects and other components are kept",
+			existingEvents: []runtime.Object{
+				newEvent("other-object", 3*time.Hour, "uid-other", component),
+				newEvent("other-component", 3*time.Hour, "uid-basic", "kubelet"),
+				newEvent("old", 3*time.Hour, "uid-basic", component),
+			},
+			retention: EventRetention{
+				MaxAge: time.Hour,
+			},
+			expectedDeleted:         1,
+			expectedRemainingEvents: []string{"other-component", "other-object"},
+		},
+		{
+			name: "nothing is deleted without a retention",
+			existingEvents: []runtime.Object{
+				newEvent("old", 3*time.Hour, "uid-basic", component),
+			},
+			retention:               EventRetention{},
+			expectedDeleted:         0,
+			expectedRemainingEvents: []string{"old"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existingEvents...)
+
+			deleted, err := PruneEvents(ctx, client.CoreV1(), obj, component, tc.retention, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deleted != tc.expectedDeleted {
+				t.Errorf("expected %d deleted events, got %d", tc.expectedDeleted, deleted)
+			}
+
+			eventList, err := client.CoreV1().Events("scylla").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var remaining []string
+			for _, e := range eventList.Items {
+				remaining = append(remaining, e.Name)
+			}
+			slices.Sort(remaining)
+
+			if !reflect.DeepEqual(remaining, tc.expectedRemainingEvents) {
+				t.Errorf("expected and got remaining events differ:\n%s", cmp.Diff(tc.expectedRemainingEvents, remaining))
+			}
+		})
+	}
+}
@@ -3,10 +3,14 @@
 package controllerhelpers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/scylladb/scylla-operator/pkg/naming"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 )
 
 type objectForFinalizersPatch struct {
@@ -80,3 +84,30 @@ func HasFinalizer(obj metav1.Object, finalizer string) bool {
 	}
 	return found
 }
+
+// RemoveFinalizer removes the finalizer from the object after the caller is done with the cleanup it guards.
+// It's the delete path counterpart to resourceapply.ApplyOptions.EnsureFinalizers.
+// The patch is bound to the object's resource version, so it fails with a conflict if the object changed in the meantime.
+func RemoveFinalizer[T metav1.Object](
+	ctx context.Context,
+	obj T,
+	finalizer string,
+	patchFunc func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error),
+) error {
+	patch, err := RemoveFinalizerPatch(obj, finalizer)
+	if err != nil {
+		return fmt.Errorf("can't create remove finalizer patch: %w", err)
+	}
+
+	if patch == nil {
+		return nil
+	}
+
+	_, err = patchFunc(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("can't remove finalizer %q from %q: %w", finalizer, naming.ObjRef(obj), err)
+	}
+
+	klog.V(2).InfoS("Removed finalizer", "Finalizer", finalizer, "Object", klog.KObj(obj))
+	return nil
+}
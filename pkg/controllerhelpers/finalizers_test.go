@@ -3,10 +3,15 @@
 package controllerhelpers
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestAddFinalizerPatch(t *testing.T) {
@@ -135,3 +140,73 @@ func TestHasFinalizer(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveFinalizer(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name               string
+		finalizers         []string
+		expectedFinalizers []string
+		expectedPatches    int
+	}{
+		{
+			name:               "finalizer is removed",
+			finalizers:         []string{"a", "my-finalizer"},
+			expectedFinalizers: []string{"a"},
+			expectedPatches:    1,
+		},
+		{
+			name:               "missing finalizer isn't patched",
+			finalizers:         []string{"a"},
+			expectedFinalizers: []string{"a"},
+			expectedPatches:    0,
+		},
+	}
+
+	for i := range tt {
+		tc := tt[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "default",
+					Name:       "test",
+					Finalizers: tc.finalizers,
+				},
+			})
+
+			cm, err := client.CoreV1().ConfigMaps("default").Get(ctx, "test", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = RemoveFinalizer(ctx, cm, "my-finalizer", client.CoreV1().ConfigMaps("default").Patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			patches := 0
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "patch" {
+					patches++
+				}
+			}
+			if patches != tc.expectedPatches {
+				t.Errorf("expected %d patches, got %d", tc.expectedPatches, patches)
+			}
+
+			cm, err = client.CoreV1().ConfigMaps("default").Get(ctx, "test", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cm.Finalizers, tc.expectedFinalizers) {
+				t.Errorf("expected finalizers %v, got %v", tc.expectedFinalizers, cm.Finalizers)
+			}
+		})
+	}
+}
@@ -2,12 +2,20 @@ package controllerhelpers
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
 	"github.com/scylladb/scylla-operator/pkg/resource"
 	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
@@ -26,25 +34,148 @@ func (pcf *PruneControlFuncs) Delete(ctx context.Context, name string, opts meta
 
 var _ PruneControlInterface = &PruneControlFuncs{}
 
-func Prune[T kubeinterfaces.ObjectInterface](ctx context.Context, requiredObjects []T, existingObjects map[string]T, control PruneControlInterface, eventRecorder record.EventRecorder) error {
-	var errs []error
+// ObjectRef identifies an object that PlanPrune would delete.
+type ObjectRef struct {
+	Namespace string
+	Name      string
+	UID       types.UID
+}
+
+func (r ObjectRef) String() string {
+	return naming.ManualRef(r.Namespace, r.Name)
+}
+
+// PruneLister lists objects in the scope of a single owner, like a namespaced lister.
+type PruneLister[T kubeinterfaces.ObjectInterface] interface {
+	List(selector labels.Selector) ([]T, error)
+}
+
+// PlanPrune returns refs of the objects, managed and controlled by the owner, that a prune keeping only
+// the desired names would delete. The refs are sorted by name and nothing is deleted, so controllers
+// can log the plan or gate the deletion behind an approval.
+// Objects already being deleted aren't part of the plan.
+func PlanPrune[T kubeinterfaces.ObjectInterface](ctx context.Context, lister PruneLister[T], owner metav1.Object, desiredNames sets.Set[string]) ([]ObjectRef, error) {
+	existingObjects, err := ListManagedObjects(owner, lister.List)
+	if err != nil {
+		return nil, fmt.Errorf("can't list managed objects: %w", err)
+	}
 
+	var refs []ObjectRef
 	for _, existing := range existingObjects {
 		if existing.GetDeletionTimestamp() != nil {
 			continue
 		}
 
-		isRequired := false
-		for _, required := range requiredObjects {
-			if existing.GetName() == required.GetName() {
-				isRequired = true
-				break
-			}
+		if desiredNames.Has(existing.GetName()) {
+			continue
+		}
+
+		refs = append(refs, ObjectRef{
+			Namespace: existing.GetNamespace(),
+			Name:      existing.GetName(),
+			UID:       existing.GetUID(),
+		})
+	}
+
+	slices.SortFunc(refs, func(a, b ObjectRef) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return refs, nil
+}
+
+func getStaleObjects[T kubeinterfaces.ObjectInterface](requiredObjects []T, existingObjects map[string]T) []T {
+	var stale []T
+
+	for _, existing := range existingObjects {
+		if existing.GetDeletionTimestamp() != nil {
+			continue
 		}
+
+		isRequired := slices.ContainsFunc(requiredObjects, func(required T) bool {
+			return existing.GetName() == required.GetName()
+		})
 		if isRequired {
 			continue
 		}
 
+		stale = append(stale, existing)
+	}
+
+	slices.SortFunc(stale, func(a, b T) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return stale
+}
+
+// PruneOptions hold options of PruneWithOptions.
+type PruneOptions struct {
+	// SafetyThresholdPercent makes the prune abort when it would delete more than the given percentage
+	// of the existing objects, or any object while no object is required.
+	// This guards against a transiently empty or partial required set deleting everything.
+	// Nil disables the check.
+	SafetyThresholdPercent *int
+}
+
+// PruneSafetyAbortError is returned when a prune was aborted by PruneOptions.SafetyThresholdPercent.
+type PruneSafetyAbortError struct {
+	Stale            int
+	Existing         int
+	Required         int
+	ThresholdPercent int
+}
+
+var _ error = &PruneSafetyAbortError{}
+
+func (e *PruneSafetyAbortError) Error() string {
+	if e.Required == 0 {
+		return fmt.Sprintf("refusing to prune %d of %d object(s) because no object is required", e.Stale, e.Existing)
+	}
+
+	return fmt.Sprintf("refusing to prune %d of %d object(s) as it exceeds the safety threshold of %d%%", e.Stale, e.Existing, e.ThresholdPercent)
+}
+
+func checkPruneSafety[T kubeinterfaces.ObjectInterface](requiredObjects []T, existingObjects map[string]T, stale []T, thresholdPercent int) error {
+	if len(stale) == 0 {
+		return nil
+	}
+
+	if len(requiredObjects) != 0 && len(stale)*100 <= thresholdPercent*len(existingObjects) {
+		return nil
+	}
+
+	return &PruneSafetyAbortError{
+		Stale:            len(stale),
+		Existing:         len(existingObjects),
+		Required:         len(requiredObjects),
+		ThresholdPercent: thresholdPercent,
+	}
+}
+
+func Prune[T kubeinterfaces.ObjectInterface](ctx context.Context, requiredObjects []T, existingObjects map[string]T, control PruneControlInterface, eventRecorder record.EventRecorder) error {
+	return PruneWithOptions(ctx, requiredObjects, existingObjects, control, eventRecorder, PruneOptions{})
+}
+
+// PruneWithOptions is like Prune but applies the safety checks configured by options.
+// When a check fails, nothing is deleted, a warning event is emitted for every object that would have been,
+// and a PruneSafetyAbortError is returned.
+func PruneWithOptions[T kubeinterfaces.ObjectInterface](ctx context.Context, requiredObjects []T, existingObjects map[string]T, control PruneControlInterface, eventRecorder record.EventRecorder, options PruneOptions) error {
+	stale := getStaleObjects(requiredObjects, existingObjects)
+
+	if options.SafetyThresholdPercent != nil {
+		err := checkPruneSafety(requiredObjects, existingObjects, stale, *options.SafetyThresholdPercent)
+		if err != nil {
+			for _, obj := range stale {
+				eventRecorder.Eventf(obj, corev1.EventTypeWarning, "PruneAborted", "Not deleting %s: %v", naming.ObjRef(obj), err)
+			}
+			return err
+		}
+	}
+
+	var errs []error
+
+	for _, existing := range stale {
 		uid := existing.GetUID()
 		propagationPolicy := metav1.DeletePropagationBackground
 		klog.V(2).InfoS("Pruning resource", "GVK", resource.GetObjectGVKOrUnknown(existing), "Ref", klog.KObj(existing))
@@ -63,3 +194,97 @@ func Prune[T kubeinterfaces.ObjectInterface](ctx context.Context, requiredObject
 
 	return apimachineryutilerrors.NewAggregate(errs)
 }
+
+// PodSpecReferences holds names of ConfigMaps and Secrets referenced from Pod specs.
+type PodSpecReferences struct {
+	ConfigMaps sets.Set[string]
+	Secrets    sets.Set[string]
+}
+
+func collectContainerReferences(refs *PodSpecReferences, containers []corev1.Container) {
+	for _, c := range containers {
+		for _, envFrom := range c.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				refs.ConfigMaps.Insert(envFrom.ConfigMapRef.Name)
+			}
+			if envFrom.SecretRef != nil {
+				refs.Secrets.Insert(envFrom.SecretRef.Name)
+			}
+		}
+
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				refs.ConfigMaps.Insert(env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				refs.Secrets.Insert(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+}
+
+// GetPodSpecReferences returns names of all ConfigMaps and Secrets referenced by the Pod specs
+// through volumes, projected volumes, environment variables and image pull secrets.
+func GetPodSpecReferences(podSpecs ...*corev1.PodSpec) *PodSpecReferences {
+	refs := &PodSpecReferences{
+		ConfigMaps: sets.New[string](),
+		Secrets:    sets.New[string](),
+	}
+
+	for _, podSpec := range podSpecs {
+		for _, v := range podSpec.Volumes {
+			if v.ConfigMap != nil {
+				refs.ConfigMaps.Insert(v.ConfigMap.Name)
+			}
+			if v.Secret != nil {
+				refs.Secrets.Insert(v.Secret.SecretName)
+			}
+			if v.Projected != nil {
+				for _, source := range v.Projected.Sources {
+					if source.ConfigMap != nil {
+						refs.ConfigMaps.Insert(source.ConfigMap.Name)
+					}
+					if source.Secret != nil {
+						refs.Secrets.Insert(source.Secret.Name)
+					}
+				}
+			}
+		}
+
+		for _, ips := range podSpec.ImagePullSecrets {
+			refs.Secrets.Insert(ips.Name)
+		}
+
+		collectContainerReferences(refs, podSpec.InitContainers)
+		collectContainerReferences(refs, podSpec.Containers)
+	}
+
+	return refs
+}
+
+func pruneUnreferenced[T kubeinterfaces.ObjectInterface](ctx context.Context, referenced sets.Set[string], existingObjects map[string]T, control PruneControlInterface, eventRecorder record.EventRecorder, options PruneOptions) error {
+	var requiredObjects []T
+	for _, existing := range existingObjects {
+		if referenced.Has(existing.GetName()) {
+			requiredObjects = append(requiredObjects, existing)
+		}
+	}
+
+	return PruneWithOptions(ctx, requiredObjects, existingObjects, control, eventRecorder, options)
+}
+
+// PruneUnreferencedConfigMaps deletes the existing ConfigMaps that aren't referenced by any of the desired Pod specs.
+// It is meant for managed, versioned config objects that would otherwise accumulate with every config change.
+// Callers have to pass only the ConfigMaps they own, as anything unreferenced gets deleted.
+func PruneUnreferencedConfigMaps(ctx context.Context, podSpecs []*corev1.PodSpec, existingConfigMaps map[string]*corev1.ConfigMap, control PruneControlInterface, eventRecorder record.EventRecorder, options PruneOptions) error {
+	return pruneUnreferenced(ctx, GetPodSpecReferences(podSpecs...).ConfigMaps, existingConfigMaps, control, eventRecorder, options)
+}
+
+// PruneUnreferencedSecrets deletes the existing Secrets that aren't referenced by any of the desired Pod specs.
+// Callers have to pass only the Secrets they own, as anything unreferenced gets deleted.
+func PruneUnreferencedSecrets(ctx context.Context, podSpecs []*corev1.PodSpec, existingSecrets map[string]*corev1.Secret, control PruneControlInterface, eventRecorder record.EventRecorder, options PruneOptions) error {
+	return pruneUnreferenced(ctx, GetPodSpecReferences(podSpecs...).Secrets, existingSecrets, control, eventRecorder, options)
+}
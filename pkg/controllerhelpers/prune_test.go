@@ -0,0 +1,436 @@
+package controllerhelpers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestPlanPrune(t *testing.T) {
+	t.Parallel()
+
+	owner := &metav1.ObjectMeta{
+		Name:      "basic",
+		Namespace: "default",
+		UID:       "owner-uid",
+	}
+
+	managedLabels := map[string]string{
+		naming.KubernetesManagedByLabel: naming.OperatorAppName,
+		naming.ClusterNameLabel:         "basic",
+	}
+
+	newConfigMap := func(name string, labels map[string]string, controllerUID string, deleting bool) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       "uid-" + types.UID(name),
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "scylla.scylladb.com/v1alpha1",
+						Kind:       "ScyllaDBDatacenter",
+						Name:       "basic",
+						UID:        types.UID(controllerUID),
+						Controller: pointer.Ptr(true),
+					},
+				},
+			},
+		}
+		if deleting {
+			cm.DeletionTimestamp = &metav1.Time{}
+		}
+		return cm
+	}
+
+	tt := []struct {
+		name            string
+		existingObjects []*corev1.ConfigMap
+		desiredNames    sets.Set[string]
+		expectedRefs    []ObjectRef
+	}{
+		{
+			name:            "nothing is planned when no objects exist",
+			existingObjects: nil,
+			desiredNames:    sets.New("a"),
+			expectedRefs:    nil,
+		},
+		{
+			name: "nothing is planned when all existing objects are desired",
+			existingObjects: []*corev1.ConfigMap{
+				newConfigMap("a", managedLabels, "owner-uid", false),
+				newConfigMap("b", managedLabels, "owner-uid", false),
+			},
+			desiredNames: sets.New("a", "b"),
+			expectedRefs: nil,
+		},
+		{
+			name: "plans exactly the stale objects sorted by name",
+			existingObjects: []*corev1.ConfigMap{
+				newConfigMap("d", managedLabels, "owner-uid", false),
+				newConfigMap("a", managedLabels, "owner-uid", false),
+				newConfigMap("b", managedLabels, "owner-uid", false),
+				newConfigMap("c", managedLabels, "owner-uid", true),
+				newConfigMap("unlabeled", nil, "owner-uid", false),
+				newConfigMap("foreign", managedLabels, "other-uid", false),
+			},
+			desiredNames: sets.New("b"),
+			expectedRefs: []ObjectRef{
+				{
+					Namespace: "default",
+					Name:      "a",
+					UID:       "uid-a",
+				},
+				{
+					Namespace: "default",
+					Name:      "d",
+					UID:       "uid-d",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, obj := range tc.existingObjects {
+				err := indexer.Add(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			lister := corev1listers.NewConfigMapLister(indexer).ConfigMaps(owner.Namespace)
+
+			gotRefs, err := PlanPrune(context.Background(), lister, owner, tc.desiredNames)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(gotRefs, tc.expectedRefs) {
+				t.Errorf("expected and got refs differ:\n%s", cmp.Diff(tc.expectedRefs, gotRefs))
+			}
+		})
+	}
+}
+
+func TestPruneWithOptions(t *testing.T) {
+	t.Parallel()
+
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       "uid-" + types.UID(name),
+			},
+		}
+	}
+
+	existingObjects := map[string]*corev1.ConfigMap{
+		"a": newConfigMap("a"),
+		"b": newConfigMap("b"),
+		"c": newConfigMap("c"),
+		"d": newConfigMap("d"),
+	}
+
+	tt := []struct {
+		name              string
+		requiredObjects   []*corev1.ConfigMap
+		options           PruneOptions
+		expectedRemaining []string
+		expectedErr       error
+		expectedEvents    []string
+	}{
+		{
+			name:              "deletes everything for an empty required set without a safety threshold",
+			requiredObjects:   nil,
+			options:           PruneOptions{},
+			expectedRemaining: nil,
+			expectedErr:       nil,
+			expectedEvents: []string{
+				"Normal ConfigMapDeleted ConfigMap default/a deleted",
+				"Normal ConfigMapDeleted ConfigMap default/b deleted",
+				"Normal ConfigMapDeleted ConfigMap default/c deleted",
+				"Normal ConfigMapDeleted ConfigMap default/d deleted",
+			},
+		},
+		{
+			name:            "aborts for an empty required set with a safety threshold",
+			requiredObjects: nil,
+			options: PruneOptions{
+				SafetyThresholdPercent: pointer.Ptr(100),
+			},
+			expectedRemaining: []string{"a", "b", "c", "d"},
+			expectedErr: &PruneSafetyAbortError{
+				Stale:            4,
+				Existing:         4,
+				Required:         0,
+				ThresholdPercent: 100,
+			},
+			expectedEvents: []string{
+				"Warning PruneAborted Not deleting default/a: refusing to prune 4 of 4 object(s) because no object is required",
+				"Warning PruneAborted Not deleting default/b: refusing to prune 4 of 4 object(s) because no object is required",
+				"Warning PruneAborted Not deleting default/c: refusing to prune 4 of 4 object(s) because no object is required",
+				"Warning PruneAborted Not deleting default/d: refusing to prune 4 of 4 object(s) because no object is required",
+			},
+		},
+		{
+			name:            "aborts when exceeding the safety threshold",
+			requiredObjects: []*corev1.ConfigMap{newConfigMap("a")},
+			options: PruneOptions{
+				SafetyThresholdPercent: pointer.Ptr(50),
+			},
+			expectedRemaining: []string{"a", "b", "c", "d"},
+			expectedErr: &PruneSafetyAbortError{
+				Stale:            3,
+				Existing:         4,
+				Required:         1,
+				ThresholdPercent: 50,
+			},
+			expectedEvents: []string{
+				"Warning PruneAborted Not deleting default/b: refusing to prune 3 of 4 object(s) as it exceeds the safety threshold of 50%",
+				"Warning PruneAborted Not deleting default/c: refusing to prune 3 of 4 object(s) as it exceeds the safety threshold of 50%",
+				"Warning PruneAborted Not deleting default/d: refusing to prune 3 of 4 object(s) as it exceeds the safety threshold of 50%",
+			},
+		},
+		{
+			name:            "deletes within the safety threshold",
+			requiredObjects: []*corev1.ConfigMap{newConfigMap("a"), newConfigMap("b")},
+			options: PruneOptions{
+				SafetyThresholdPercent: pointer.Ptr(50),
+			},
+			expectedRemaining: []string{"a", "b"},
+			expectedErr:       nil,
+			expectedEvents: []string{
+				"Normal ConfigMapDeleted ConfigMap default/c deleted",
+				"Normal ConfigMapDeleted ConfigMap default/d deleted",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var objects []runtime.Object
+			for _, obj := range existingObjects {
+				objects = append(objects, obj.DeepCopy())
+			}
+			client := fake.NewSimpleClientset(objects...)
+			recorder := record.NewFakeRecorder(10)
+
+			err := PruneWithOptions(ctx, tc.requiredObjects, existingObjects, &PruneControlFuncs{
+				DeleteFunc: client.CoreV1().ConfigMaps("default").Delete,
+			}, recorder, tc.options)
+			if !reflect.DeepEqual(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			var abortErr *PruneSafetyAbortError
+			if tc.expectedErr != nil && !errors.As(err, &abortErr) {
+				t.Errorf("expected a PruneSafetyAbortError, got %T", err)
+			}
+
+			configMaps, err := client.CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotRemaining []string
+			for _, cm := range configMaps.Items {
+				gotRemaining = append(gotRemaining, cm.Name)
+			}
+			if !reflect.DeepEqual(gotRemaining, tc.expectedRemaining) {
+				t.Errorf("expected and got remaining objects differ:\n%s", cmp.Diff(tc.expectedRemaining, gotRemaining))
+			}
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+		})
+	}
+}
+
+func TestGetPodSpecReferences(t *testing.T) {
+	t.Parallel()
+
+	podSpec := &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "cm-volume"},
+					},
+				},
+			},
+			{
+				Name: "certs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "secret-volume",
+					},
+				},
+			},
+			{
+				Name: "projected",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{
+								ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "cm-projected"},
+								},
+							},
+							{
+								Secret: &corev1.SecretProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "secret-projected"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ImagePullSecrets: []corev1.LocalObjectReference{
+			{Name: "secret-pull"},
+		},
+		InitContainers: []corev1.Container{
+			{
+				Name: "init",
+				EnvFrom: []corev1.EnvFromSource{
+					{
+						ConfigMapRef: &corev1.ConfigMapEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "cm-envfrom"},
+						},
+					},
+				},
+			},
+		},
+		Containers: []corev1.Container{
+			{
+				Name: "main",
+				Env: []corev1.EnvVar{
+					{
+						Name: "TOKEN",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "secret-env"},
+								Key:                  "token",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	refs := GetPodSpecReferences(podSpec)
+
+	expectedConfigMaps := []string{"cm-envfrom", "cm-projected", "cm-volume"}
+	if got := sets.List(refs.ConfigMaps); !reflect.DeepEqual(got, expectedConfigMaps) {
+		t.Errorf("expected and got ConfigMaps differ:\n%s", cmp.Diff(expectedConfigMaps, got))
+	}
+
+	expectedSecrets := []string{"secret-env", "secret-projected", "secret-pull", "secret-volume"}
+	if got := sets.List(refs.Secrets); !reflect.DeepEqual(got, expectedSecrets) {
+		t.Errorf("expected and got Secrets differ:\n%s", cmp.Diff(expectedSecrets, got))
+	}
+}
+
+func TestPruneUnreferencedConfigMaps(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       "uid-" + types.UID(name),
+			},
+		}
+	}
+
+	existingConfigMaps := map[string]*corev1.ConfigMap{
+		"config-v1": newConfigMap("config-v1"),
+		"config-v2": newConfigMap("config-v2"),
+	}
+
+	podSpec := &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "config-v2"},
+					},
+				},
+			},
+		},
+	}
+
+	var objects []runtime.Object
+	for _, obj := range existingConfigMaps {
+		objects = append(objects, obj.DeepCopy())
+	}
+	client := fake.NewSimpleClientset(objects...)
+	recorder := record.NewFakeRecorder(10)
+
+	err := PruneUnreferencedConfigMaps(ctx, []*corev1.PodSpec{podSpec}, existingConfigMaps, &PruneControlFuncs{
+		DeleteFunc: client.CoreV1().ConfigMaps("default").Delete,
+	}, recorder, PruneOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configMaps, err := client.CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotRemaining []string
+	for _, cm := range configMaps.Items {
+		gotRemaining = append(gotRemaining, cm.Name)
+	}
+	expectedRemaining := []string{"config-v2"}
+	if !reflect.DeepEqual(gotRemaining, expectedRemaining) {
+		t.Errorf("expected and got remaining objects differ:\n%s", cmp.Diff(expectedRemaining, gotRemaining))
+	}
+
+	close(recorder.Events)
+	var gotEvents []string
+	for e := range recorder.Events {
+		gotEvents = append(gotEvents, e)
+	}
+	expectedEvents := []string{"Normal ConfigMapDeleted ConfigMap default/config-v1 deleted"}
+	if !reflect.DeepEqual(gotEvents, expectedEvents) {
+		t.Errorf("expected and got events differ:\n%s", cmp.Diff(expectedEvents, gotEvents))
+	}
+}
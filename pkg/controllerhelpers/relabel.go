@@ -0,0 +1,155 @@
+package controllerhelpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resource"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+type labelPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func makeRelabelPatch(obj metav1.Object, missing map[string]string) ([]byte, error) {
+	ops := []labelPatchOperation{
+		{
+			Op:    "test",
+			Path:  "/metadata/uid",
+			Value: obj.GetUID(),
+		},
+	}
+
+	if obj.GetLabels() == nil {
+		ops = append(ops, labelPatchOperation{
+			Op:    "add",
+			Path:  "/metadata/labels",
+			Value: missing,
+		})
+	} else {
+		keys := make([]string, 0, len(missing))
+		for k := range missing {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			ops = append(ops, labelPatchOperation{
+				// Add replaces the value if it's already present.
+				Op:    "add",
+				Path:  "/metadata/labels/" + jsonPointerEscaper.Replace(k),
+				Value: missing[k],
+			})
+		}
+	}
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal relabel patch: %w", err)
+	}
+
+	return patch, nil
+}
+
+// RelabelManaged sets the labels on all objects managed by the owner, see ListManagedObjects and Relabel.
+func RelabelManaged[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	client resourceapply.PatchClient[T],
+	listFunc func(labels.Selector) ([]T, error),
+	owner metav1.Object,
+	requiredLabels map[string]string,
+	options resourceapply.ApplyOptions,
+) (int, error) {
+	objects, err := ListManagedObjects(owner, listFunc)
+	if err != nil {
+		return 0, fmt.Errorf("can't list managed objects: %w", err)
+	}
+
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	sortedObjects := make([]T, 0, len(names))
+	for _, name := range names {
+		sortedObjects = append(sortedObjects, objects[name])
+	}
+
+	return Relabel(ctx, client, sortedObjects, requiredLabels, options)
+}
+
+// Relabel sets the labels on the given objects. It suits objects that are managed without a controllerRef,
+// like PersistentVolumeClaims created from StatefulSet volumeClaimTemplates, which RelabelManaged wouldn't find.
+// Only the label map is JSON-patched, so objects keep their hash and no full update is needed for a metadata-only change.
+// Objects that already carry the labels and objects being deleted are skipped.
+// Of the options, only AllowedNamespaces is honored. It returns the number of patched objects.
+func Relabel[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	client resourceapply.PatchClient[T],
+	objects []T,
+	requiredLabels map[string]string,
+	options resourceapply.ApplyOptions,
+) (int, error) {
+	var errs []error
+	patched := 0
+	for _, obj := range objects {
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		missing := map[string]string{}
+		for k, v := range requiredLabels {
+			existing, ok := obj.GetLabels()[k]
+			if !ok || existing != v {
+				missing[k] = v
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		gvk := resource.GetObjectGVKOrUnknown(obj)
+
+		if options.AllowedNamespaces != nil && !options.AllowedNamespaces.Has(obj.GetNamespace()) {
+			errs = append(errs, &resourceapply.NamespaceNotAllowedError{
+				GVK:       *gvk,
+				Ref:       naming.ObjRef(obj),
+				Namespace: obj.GetNamespace(),
+			})
+			continue
+		}
+
+		patch, err := makeRelabelPatch(obj, missing)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		_, err = client.Patch(ctx, obj.GetName(), types.JSONPatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't relabel %s %q: %w", gvk, naming.ObjRef(obj), err))
+			continue
+		}
+
+		klog.V(2).InfoS("Relabeled object", "GVK", gvk, "Ref", naming.ObjRef(obj))
+		patched++
+	}
+
+	return patched, apimachineryutilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,125 @@
+package controllerhelpers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRelabelManaged(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	owner := &metav1.ObjectMeta{
+		Name:      "basic",
+		Namespace: "default",
+		UID:       "owner-uid",
+	}
+
+	newConfigMap := func(name string, extraLabels map[string]string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID("uid-" + name),
+				Labels: map[string]string{
+					naming.KubernetesManagedByLabel: naming.OperatorAppName,
+					naming.ClusterNameLabel:         "basic",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "scylla.scylladb.com/v1alpha1",
+						Kind:       "ScyllaDBDatacenter",
+						Name:       "basic",
+						UID:        "owner-uid",
+						Controller: pointer.Ptr(true),
+					},
+				},
+			},
+		}
+		for k, v := range extraLabels {
+			cm.Labels[k] = v
+		}
+		return cm
+	}
+
+	client := fake.NewSimpleClientset([]runtime.Object{
+		newConfigMap("a", nil),
+		newConfigMap("b", map[string]string{"app.kubernetes.io/part-of": "outdated"}),
+		newConfigMap("c", map[string]string{"app.kubernetes.io/part-of": "scylla"}),
+	}...)
+
+	listFunc := func(selector labels.Selector) ([]*corev1.ConfigMap, error) {
+		list, err := client.CoreV1().ConfigMaps(owner.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]*corev1.ConfigMap, 0, len(list.Items))
+		for i := range list.Items {
+			res = append(res, &list.Items[i])
+		}
+		return res, nil
+	}
+
+	requiredLabels := map[string]string{
+		"app.kubernetes.io/part-of":         "scylla",
+		"scylla-operator.scylladb.com/tier": "data",
+	}
+
+	patched, err := RelabelManaged(ctx, client.CoreV1().ConfigMaps(owner.Namespace), listFunc, owner, requiredLabels, resourceapply.ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched != 3 {
+		t.Errorf("expected 3 patched objects, got %d", patched)
+	}
+
+	for _, name := range []string{"a", "b", "c"} {
+		cm, err := client.CoreV1().ConfigMaps(owner.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedLabels := newConfigMap(name, requiredLabels).Labels
+		if !reflect.DeepEqual(cm.Labels, expectedLabels) {
+			t.Errorf("expected and got labels of %q differ:\n%s", name, cmp.Diff(expectedLabels, cm.Labels))
+		}
+	}
+
+	for _, a := range client.Actions() {
+		if a.GetVerb() == "update" {
+			t.Errorf("expected no full updates, got %v", a)
+		}
+	}
+
+	client.ClearActions()
+	patched, err = RelabelManaged(ctx, client.CoreV1().ConfigMaps(owner.Namespace), listFunc, owner, requiredLabels, resourceapply.ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched != 0 {
+		t.Errorf("expected no patched objects on a second run, got %d", patched)
+	}
+	for _, a := range client.Actions() {
+		if a.GetVerb() != "list" {
+			t.Errorf("expected only list actions on a second run, got %v", a)
+		}
+	}
+}
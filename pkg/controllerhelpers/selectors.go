@@ -1,6 +1,8 @@
 package controllerhelpers
 
 import (
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -16,3 +18,26 @@ func FilterObjectMapByLabel[T metav1.Object](objects map[string]T, selector labe
 
 	return res
 }
+
+// ListManagedObjects lists only the objects matching naming.ManagedBySelector for the owner,
+// instead of reading the whole namespace. Objects controlled by anyone else are left out.
+// Unlike GetObjects, it never adopts or orphans objects, so it's only suitable for kinds
+// that the operator has always labeled.
+func ListManagedObjects[T kubeinterfaces.ObjectInterface](owner metav1.Object, listFunc func(labels.Selector) ([]T, error)) (map[string]T, error) {
+	objects, err := listFunc(naming.ManagedBySelector(owner))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]T, len(objects))
+	for _, obj := range objects {
+		controllerRef := metav1.GetControllerOfNoCopy(obj)
+		if controllerRef == nil || controllerRef.UID != owner.GetUID() {
+			continue
+		}
+
+		res[obj.GetName()] = obj
+	}
+
+	return res, nil
+}
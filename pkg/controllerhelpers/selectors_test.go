@@ -0,0 +1,86 @@
+package controllerhelpers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestListManagedObjects(t *testing.T) {
+	t.Parallel()
+
+	owner := &metav1.ObjectMeta{
+		Name:      "basic",
+		Namespace: "default",
+		UID:       "owner-uid",
+	}
+
+	newConfigMap := func(name string, labels map[string]string, controllerUID string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    labels,
+			},
+		}
+		if len(controllerUID) != 0 {
+			cm.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: "scylla.scylladb.com/v1alpha1",
+					Kind:       "ScyllaDBDatacenter",
+					Name:       "basic",
+					UID:        types.UID(controllerUID),
+					Controller: pointer.Ptr(true),
+				},
+			}
+		}
+		return cm
+	}
+
+	managedLabels := map[string]string{
+		naming.KubernetesManagedByLabel: naming.OperatorAppName,
+		naming.ClusterNameLabel:         "basic",
+	}
+
+	existing := []*corev1.ConfigMap{
+		newConfigMap("managed", managedLabels, "owner-uid"),
+		newConfigMap("unlabeled", nil, "owner-uid"),
+		newConfigMap("other-cluster", map[string]string{
+			naming.KubernetesManagedByLabel: naming.OperatorAppName,
+			naming.ClusterNameLabel:         "other",
+		}, "owner-uid"),
+		newConfigMap("not-managed-by-operator", map[string]string{
+			naming.ClusterNameLabel: "basic",
+		}, "owner-uid"),
+		newConfigMap("other-controller", managedLabels, "other-uid"),
+		newConfigMap("orphan", managedLabels, ""),
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cm := range existing {
+		err := indexer.Add(cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := ListManagedObjects(owner, corev1listers.NewConfigMapLister(indexer).ConfigMaps(owner.Namespace).List)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedNames := []string{"managed"}
+	gotNames := sets.List(sets.KeySet(got))
+	if !reflect.DeepEqual(gotNames, expectedNames) {
+		t.Errorf("expected and got objects differ:\n%s", cmp.Diff(expectedNames, gotNames))
+	}
+}
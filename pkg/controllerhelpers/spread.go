@@ -0,0 +1,185 @@
+// Copyright (C) 2025 ScyllaDB
+
+package controllerhelpers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// FindSpreadViolatingPods returns the Pods that have to move for the Pods to be spread across the topology domains
+// of the nodes with at most maxSkew Pods of difference between any two domains, like a topology spread constraint would.
+// The domains are the values of the topologyKey label of the nodes, nodes without it aren't part of any domain.
+// Only Pods scheduled to a node of a domain are counted. The youngest Pods of the most crowded domains are picked first,
+// and every picked Pod is assumed to reschedule to the least crowded domain.
+func FindSpreadViolatingPods(pods []*corev1.Pod, nodes []*corev1.Node, topologyKey string, maxSkew int) []*corev1.Pod {
+	nodeDomains := make(map[string]string, len(nodes))
+	domainPods := map[string][]*corev1.Pod{}
+	for _, node := range nodes {
+		domain, ok := node.Labels[topologyKey]
+		if !ok {
+			continue
+		}
+
+		nodeDomains[node.Name] = domain
+		// Domains without any Pods count as well.
+		if _, ok := domainPods[domain]; !ok {
+			domainPods[domain] = nil
+		}
+	}
+
+	if len(domainPods) < 2 {
+		return nil
+	}
+
+	for _, pod := range pods {
+		domain, ok := nodeDomains[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+
+		domainPods[domain] = append(domainPods[domain], pod)
+	}
+
+	counts := make(map[string]int, len(domainPods))
+	for domain, pods := range domainPods {
+		counts[domain] = len(pods)
+
+		// Sort from the oldest to the youngest Pod, so the youngest are at the end and picked first.
+		slices.SortFunc(pods, func(a, b *corev1.Pod) int {
+			c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+			if c != 0 {
+				return c
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	domains := make([]string, 0, len(domainPods))
+	for domain := range domainPods {
+		domains = append(domains, domain)
+	}
+	slices.Sort(domains)
+
+	var violating []*corev1.Pod
+	for {
+		maxDomain, minDomain := domains[0], domains[0]
+		for _, domain := range domains[1:] {
+			if counts[domain] > counts[maxDomain] {
+				maxDomain = domain
+			}
+			if counts[domain] < counts[minDomain] {
+				minDomain = domain
+			}
+		}
+
+		if counts[maxDomain]-counts[minDomain] <= maxSkew {
+			return violating
+		}
+
+		candidates := domainPods[maxDomain]
+		violating = append(violating, candidates[len(candidates)-1])
+		domainPods[maxDomain] = candidates[:len(candidates)-1]
+		counts[maxDomain]--
+		counts[minDomain]++
+	}
+}
+
+// isDisruptionAllowed returns false when any PodDisruptionBudget selecting the Pod doesn't allow a disruption.
+func isDisruptionAllowed(pod *corev1.Pod, pdbs []*policyv1.PodDisruptionBudget) (bool, error) {
+	for _, pdb := range pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return false, fmt.Errorf("can't convert selector of PodDisruptionBudget %q: %w", naming.ObjRef(pdb), err)
+		}
+
+		if selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		if pdb.Status.DisruptionsAllowed < 1 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// EvictSpreadViolatingPod evicts one of the Pods found by FindSpreadViolatingPods, so it gets rescheduled.
+// Only one Pod is evicted per call, which gives the scheduler a chance to place it before the spread is evaluated again.
+// Nothing is evicted while any of the Pods is unscheduled or being deleted, or when the PodDisruptionBudgets selecting
+// the Pods don't allow a disruption. The eviction API enforces the budgets as well, a refused eviction isn't an error.
+// It returns the evicted Pod, or nil when no Pod was evicted.
+func EvictSpreadViolatingPod(
+	ctx context.Context,
+	podClient corev1client.PodsGetter,
+	recorder record.EventRecorder,
+	pods []*corev1.Pod,
+	nodes []*corev1.Node,
+	pdbs []*policyv1.PodDisruptionBudget,
+	topologyKey string,
+	maxSkew int,
+) (*corev1.Pod, error) {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || len(pod.Spec.NodeName) == 0 {
+			klog.V(4).InfoS("Waiting for Pods to settle before evaluating their spread", "Pod", klog.KObj(pod))
+			return nil, nil
+		}
+	}
+
+	for _, pod := range FindSpreadViolatingPods(pods, nodes, topologyKey, maxSkew) {
+		allowed, err := isDisruptionAllowed(pod, pdbs)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			klog.V(2).InfoS("Pod violates the topology spread but its disruption isn't allowed", "Pod", klog.KObj(pod))
+			continue
+		}
+
+		klog.V(2).InfoS("Evicting Pod violating the topology spread", "Pod", klog.KObj(pod), "Node", pod.Spec.NodeName, "TopologyKey", topologyKey)
+		err = podClient.Pods(pod.Namespace).EvictV1(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+			},
+			DeleteOptions: &metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{
+					UID: &pod.UID,
+				},
+			},
+		})
+		if apierrors.IsTooManyRequests(err) {
+			klog.V(2).InfoS("Eviction was refused by a PodDisruptionBudget", "Pod", klog.KObj(pod))
+			continue
+		}
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		resourceapply.ReportDeleteEvent(recorder, pod, err)
+		if err != nil {
+			return nil, fmt.Errorf("can't evict Pod %q: %w", naming.ObjRef(pod), err)
+		}
+
+		return pod, nil
+	}
+
+	return nil, nil
+}
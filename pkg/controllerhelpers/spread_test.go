@@ -0,0 +1,275 @@
+package controllerhelpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+)
+
+func newSpreadTestNodes() []*corev1.Node {
+	var nodes []*corev1.Node
+	for _, zone := range []string{"a", "b", "c"} {
+		nodes = append(nodes, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-" + zone,
+				Labels: map[string]string{
+					corev1.LabelTopologyZone: zone,
+				},
+			},
+		})
+	}
+	nodes = append(nodes, &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-unlabeled",
+		},
+	})
+	return nodes
+}
+
+func newSpreadTestPod(name, zone string, age time.Duration) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			UID:               types.UID(name + "-uid"),
+			CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age)),
+			Labels: map[string]string{
+				"app": "scylla",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-" + zone,
+		},
+	}
+}
+
+func TestFindSpreadViolatingPods(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		pods          []*corev1.Pod
+		maxSkew       int
+		expectedNames []string
+	}{
+		{
+			name: "balanced pods",
+			pods: []*corev1.Pod{
+				newSpreadTestPod("pod-0", "a", 3*time.Hour),
+				newSpreadTestPod("pod-1", "b", 2*time.Hour),
+				newSpreadTestPod("pod-2", "c", time.Hour),
+			},
+			maxSkew:       1,
+			expectedNames: nil,
+		},
+		{
+			name: "skew within the limit",
+			pods: []*corev1.Pod{
+				newSpreadTestPod("pod-0", "a", 3*time.Hour),
+				newSpreadTestPod("pod-1", "a", 2*time.Hour),
+			},
+			maxSkew:       2,
+			expectedNames: nil,
+		},
+		{
+			name: "picks the youngest pods of the crowded zone",
+			pods: []*corev1.Pod{
+				newSpreadTestPod("pod-0", "a", 4*time.Hour),
+				newSpreadTestPod("pod-1", "a", time.Hour),
+				newSpreadTestPod("pod-2", "a", 3*time.Hour),
+				newSpreadTestPod("pod-3", "a", 2*time.Hour),
+				newSpreadTestPod("pod-4", "b", 2*time.Hour),
+				newSpreadTestPod("pod-5", "c", 2*time.Hour),
+			},
+			maxSkew:       1,
+			expectedNames: []string{"pod-1", "pod-3"},
+		},
+		{
+			name: "counts empty zones",
+			pods: []*corev1.Pod{
+				newSpreadTestPod("pod-0", "a", 2*time.Hour),
+				newSpreadTestPod("pod-1", "a", time.Hour),
+			},
+			maxSkew:       1,
+			expectedNames: []string{"pod-1"},
+		},
+		{
+			name: "ignores pods outside of the zones",
+			pods: []*corev1.Pod{
+				newSpreadTestPod("pod-0", "a", time.Hour),
+				newSpreadTestPod("pod-1", "unlabeled", time.Hour),
+				newSpreadTestPod("pod-2", "unlabeled", time.Hour),
+			},
+			maxSkew:       1,
+			expectedNames: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotNames []string
+			for _, pod := range FindSpreadViolatingPods(tc.pods, newSpreadTestNodes(), corev1.LabelTopologyZone, tc.maxSkew) {
+				gotNames = append(gotNames, pod.Name)
+			}
+			if !reflect.DeepEqual(gotNames, tc.expectedNames) {
+				t.Errorf("expected and got pods differ:\n%s", cmp.Diff(tc.expectedNames, gotNames))
+			}
+		})
+	}
+}
+
+func TestEvictSpreadViolatingPodConverges(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	nodes := newSpreadTestNodes()
+	pods := map[string]*corev1.Pod{}
+	for i := 0; i < 6; i++ {
+		pod := newSpreadTestPod(fmt.Sprintf("pod-%d", i), "a", time.Duration(i)*time.Hour)
+		pods[pod.Name] = pod
+	}
+
+	client := fake.NewSimpleClientset()
+	var evictions []string
+	client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(clienttesting.CreateAction).GetObject().(*policyv1.Eviction)
+		evictions = append(evictions, eviction.Name)
+		return true, nil, nil
+	})
+
+	podList := func() []*corev1.Pod {
+		var res []*corev1.Pod
+		for _, pod := range pods {
+			res = append(res, pod)
+		}
+		return res
+	}
+
+	pdbs := []*policyv1.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "scylla",
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "scylla"},
+				},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: 0,
+			},
+		},
+	}
+
+	evicted, err := EvictSpreadViolatingPod(ctx, client.CoreV1(), record.NewFakeRecorder(10), podList(), nodes, pdbs, corev1.LabelTopologyZone, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted != nil || len(evictions) != 0 {
+		t.Fatalf("expected no eviction while the PodDisruptionBudget doesn't allow it, got %v", evictions)
+	}
+	pdbs[0].Status.DisruptionsAllowed = 1
+
+	for iteration := 0; ; iteration++ {
+		if iteration > 10 {
+			t.Fatalf("pods didn't converge, evictions: %v", evictions)
+		}
+
+		evicted, err := EvictSpreadViolatingPod(ctx, client.CoreV1(), record.NewFakeRecorder(10), podList(), nodes, pdbs, corev1.LabelTopologyZone, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if evicted == nil {
+			break
+		}
+
+		// The evicted Pod is recreated, the scheduler places it into the emptiest zone.
+		counts := map[string]int{}
+		for _, pod := range pods {
+			counts[pod.Spec.NodeName]++
+		}
+		counts[evicted.Spec.NodeName]--
+		target := "node-a"
+		for _, node := range []string{"node-b", "node-c"} {
+			if counts[node] < counts[target] {
+				target = node
+			}
+		}
+		rescheduled := evicted.DeepCopy()
+		rescheduled.UID = types.UID(fmt.Sprintf("%s-uid-%d", evicted.Name, iteration))
+		rescheduled.Spec.NodeName = target
+		pods[evicted.Name] = rescheduled
+	}
+
+	counts := map[string]int{}
+	for _, pod := range pods {
+		counts[pod.Spec.NodeName]++
+	}
+	expectedCounts := map[string]int{"node-a": 2, "node-b": 2, "node-c": 2}
+	if !reflect.DeepEqual(counts, expectedCounts) {
+		t.Errorf("expected and got pod counts differ:\n%s", cmp.Diff(expectedCounts, counts))
+	}
+	expectedEvictions := []string{"pod-0", "pod-1", "pod-2", "pod-3"}
+	if !reflect.DeepEqual(evictions, expectedEvictions) {
+		t.Errorf("expected and got evictions differ:\n%s", cmp.Diff(expectedEvictions, evictions))
+	}
+}
+
+func TestEvictSpreadViolatingPodRefusedEviction(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	pods := []*corev1.Pod{
+		newSpreadTestPod("pod-0", "a", 2*time.Hour),
+		newSpreadTestPod("pod-1", "a", time.Hour),
+	}
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+	})
+
+	evicted, err := EvictSpreadViolatingPod(ctx, client.CoreV1(), record.NewFakeRecorder(10), pods, newSpreadTestNodes(), nil, corev1.LabelTopologyZone, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted != nil {
+		t.Errorf("expected no pod to be evicted, got %q", evicted.Name)
+	}
+
+	pending := newSpreadTestPod("pod-2", "a", 0)
+	pending.Spec.NodeName = ""
+	client.ClearActions()
+	evicted, err = EvictSpreadViolatingPod(ctx, client.CoreV1(), record.NewFakeRecorder(10), append(pods, pending), newSpreadTestNodes(), nil, corev1.LabelTopologyZone, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted != nil || len(client.Actions()) != 0 {
+		t.Errorf("expected nothing to be evicted while a pod is unscheduled, got %v", client.Actions())
+	}
+}
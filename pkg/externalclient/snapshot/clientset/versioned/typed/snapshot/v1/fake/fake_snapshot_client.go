@@ -0,0 +1,24 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned/typed/snapshot/v1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeSnapshotV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeSnapshotV1) VolumeSnapshots(namespace string) v1.VolumeSnapshotInterface {
+	return newFakeVolumeSnapshots(c, namespace)
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeSnapshotV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
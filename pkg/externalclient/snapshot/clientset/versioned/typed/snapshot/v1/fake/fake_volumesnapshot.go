@@ -0,0 +1,34 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned/typed/snapshot/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeVolumeSnapshots implements VolumeSnapshotInterface
+type fakeVolumeSnapshots struct {
+	*gentype.FakeClientWithList[*v1.VolumeSnapshot, *v1.VolumeSnapshotList]
+	Fake *FakeSnapshotV1
+}
+
+func newFakeVolumeSnapshots(fake *FakeSnapshotV1, namespace string) snapshotv1.VolumeSnapshotInterface {
+	return &fakeVolumeSnapshots{
+		gentype.NewFakeClientWithList[*v1.VolumeSnapshot, *v1.VolumeSnapshotList](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("volumesnapshots"),
+			v1.SchemeGroupVersion.WithKind("VolumeSnapshot"),
+			func() *v1.VolumeSnapshot { return &v1.VolumeSnapshot{} },
+			func() *v1.VolumeSnapshotList { return &v1.VolumeSnapshotList{} },
+			func(dst, src *v1.VolumeSnapshotList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.VolumeSnapshotList) []*v1.VolumeSnapshot { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.VolumeSnapshotList, items []*v1.VolumeSnapshot) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
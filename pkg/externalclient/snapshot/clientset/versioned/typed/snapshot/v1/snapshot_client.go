@@ -0,0 +1,85 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	http "net/http"
+
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	scheme "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type SnapshotV1Interface interface {
+	RESTClient() rest.Interface
+	VolumeSnapshotsGetter
+}
+
+// SnapshotV1Client is used to interact with features provided by the snapshot.storage.k8s.io group.
+type SnapshotV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *SnapshotV1Client) VolumeSnapshots(namespace string) VolumeSnapshotInterface {
+	return newVolumeSnapshots(c, namespace)
+}
+
+// NewForConfig creates a new SnapshotV1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*SnapshotV1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new SnapshotV1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*SnapshotV1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new SnapshotV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *SnapshotV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new SnapshotV1Client for the given RESTClient.
+func New(c rest.Interface) *SnapshotV1Client {
+	return &SnapshotV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) {
+	gv := snapshotv1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = rest.CodecFactoryForGeneratedClient(scheme.Scheme, scheme.Codecs).WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *SnapshotV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
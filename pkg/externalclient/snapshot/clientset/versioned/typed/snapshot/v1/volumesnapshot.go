@@ -0,0 +1,54 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	scheme "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// VolumeSnapshotsGetter has a method to return a VolumeSnapshotInterface.
+// A group's client should implement this interface.
+type VolumeSnapshotsGetter interface {
+	VolumeSnapshots(namespace string) VolumeSnapshotInterface
+}
+
+// VolumeSnapshotInterface has methods to work with VolumeSnapshot resources.
+type VolumeSnapshotInterface interface {
+	Create(ctx context.Context, volumeSnapshot *snapshotv1.VolumeSnapshot, opts metav1.CreateOptions) (*snapshotv1.VolumeSnapshot, error)
+	Update(ctx context.Context, volumeSnapshot *snapshotv1.VolumeSnapshot, opts metav1.UpdateOptions) (*snapshotv1.VolumeSnapshot, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, volumeSnapshot *snapshotv1.VolumeSnapshot, opts metav1.UpdateOptions) (*snapshotv1.VolumeSnapshot, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*snapshotv1.VolumeSnapshot, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*snapshotv1.VolumeSnapshotList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *snapshotv1.VolumeSnapshot, err error)
+	VolumeSnapshotExpansion
+}
+
+// volumeSnapshots implements VolumeSnapshotInterface
+type volumeSnapshots struct {
+	*gentype.ClientWithList[*snapshotv1.VolumeSnapshot, *snapshotv1.VolumeSnapshotList]
+}
+
+// newVolumeSnapshots returns a VolumeSnapshots
+func newVolumeSnapshots(c *SnapshotV1Client, namespace string) *volumeSnapshots {
+	return &volumeSnapshots{
+		gentype.NewClientWithList[*snapshotv1.VolumeSnapshot, *snapshotv1.VolumeSnapshotList](
+			"volumesnapshots",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *snapshotv1.VolumeSnapshot { return &snapshotv1.VolumeSnapshot{} },
+			func() *snapshotv1.VolumeSnapshotList { return &snapshotv1.VolumeSnapshotList{} },
+		),
+	}
+}
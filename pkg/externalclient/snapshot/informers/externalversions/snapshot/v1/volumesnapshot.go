@@ -0,0 +1,86 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+	time "time"
+
+	externalapisnapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	versioned "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned"
+	internalinterfaces "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/informers/externalversions/internalinterfaces"
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/listers/snapshot/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VolumeSnapshotInformer provides access to a shared informer and lister for
+// VolumeSnapshots.
+type VolumeSnapshotInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() snapshotv1.VolumeSnapshotLister
+}
+
+type volumeSnapshotInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewVolumeSnapshotInformer constructs a new informer for VolumeSnapshot type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewVolumeSnapshotInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredVolumeSnapshotInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredVolumeSnapshotInformer constructs a new informer for VolumeSnapshot type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredVolumeSnapshotInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SnapshotV1().VolumeSnapshots(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SnapshotV1().VolumeSnapshots(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SnapshotV1().VolumeSnapshots(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SnapshotV1().VolumeSnapshots(namespace).Watch(ctx, options)
+			},
+		},
+		&externalapisnapshotv1.VolumeSnapshot{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *volumeSnapshotInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredVolumeSnapshotInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *volumeSnapshotInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&externalapisnapshotv1.VolumeSnapshot{}, f.defaultInformer)
+}
+
+func (f *volumeSnapshotInformer) Lister() snapshotv1.VolumeSnapshotLister {
+	return snapshotv1.NewVolumeSnapshotLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VolumeSnapshotLister helps list VolumeSnapshots.
+// All objects returned here must be treated as read-only.
+type VolumeSnapshotLister interface {
+	// List lists all VolumeSnapshots in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*snapshotv1.VolumeSnapshot, err error)
+	// VolumeSnapshots returns an object that can list and get VolumeSnapshots.
+	VolumeSnapshots(namespace string) VolumeSnapshotNamespaceLister
+	VolumeSnapshotListerExpansion
+}
+
+// volumeSnapshotLister implements the VolumeSnapshotLister interface.
+type volumeSnapshotLister struct {
+	listers.ResourceIndexer[*snapshotv1.VolumeSnapshot]
+}
+
+// NewVolumeSnapshotLister returns a new VolumeSnapshotLister.
+func NewVolumeSnapshotLister(indexer cache.Indexer) VolumeSnapshotLister {
+	return &volumeSnapshotLister{listers.New[*snapshotv1.VolumeSnapshot](indexer, snapshotv1.Resource("volumesnapshot"))}
+}
+
+// VolumeSnapshots returns an object that can list and get VolumeSnapshots.
+func (s *volumeSnapshotLister) VolumeSnapshots(namespace string) VolumeSnapshotNamespaceLister {
+	return volumeSnapshotNamespaceLister{listers.NewNamespaced[*snapshotv1.VolumeSnapshot](s.ResourceIndexer, namespace)}
+}
+
+// VolumeSnapshotNamespaceLister helps list and get VolumeSnapshots.
+// All objects returned here must be treated as read-only.
+type VolumeSnapshotNamespaceLister interface {
+	// List lists all VolumeSnapshots in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*snapshotv1.VolumeSnapshot, err error)
+	// Get retrieves the VolumeSnapshot from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*snapshotv1.VolumeSnapshot, error)
+	VolumeSnapshotNamespaceListerExpansion
+}
+
+// volumeSnapshotNamespaceLister implements the VolumeSnapshotNamespaceLister
+// interface.
+type volumeSnapshotNamespaceLister struct {
+	listers.ResourceIndexer[*snapshotv1.VolumeSnapshot]
+}
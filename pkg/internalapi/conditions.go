@@ -23,6 +23,8 @@ const (
 	ErrorReason             = "Error"
 	ProgressingReason       = "Progressing"
 	AwaitingConditionReason = "AwaitingCondition"
+
+	DegradedDueToApplyFailureReason = "DegradedDueToApplyFailure"
 )
 
 var (
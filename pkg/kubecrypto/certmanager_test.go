@@ -0,0 +1,212 @@
+package kubecrypto
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	ocrypto "github.com/scylladb/scylla-operator/pkg/crypto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestCertificateManager_ManageCertificates(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	keygen, err := ocrypto.NewRSAKeyGenerator(1, 1, 2048, 42*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keygen.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	keygenCtx, keygenCtxCancel := context.WithCancel(ctx)
+	defer keygenCtxCancel()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		keygen.Run(keygenCtx)
+	}()
+
+	controller := &metav1.ObjectMeta{
+		Namespace: "foo",
+		Name:      "sdc",
+		UID:       "42",
+	}
+	controllerGVK := schema.GroupVersionKind{
+		Group:   "scylla.scylladb.com",
+		Version: "v1alpha1",
+		Kind:    "ScyllaDBDatacenter",
+	}
+
+	caConfig := &CAConfig{
+		MetaConfig: MetaConfig{
+			Name: "serving-ca",
+		},
+		Validity: 10 * 365 * 24 * time.Hour,
+		Refresh:  8 * 365 * 24 * time.Hour,
+	}
+	caBundleConfig := &CABundleConfig{
+		MetaConfig: MetaConfig{
+			Name: "serving-ca",
+		},
+	}
+	certConfigs := []*CertificateConfig{
+		{
+			MetaConfig: MetaConfig{
+				Name: "serving-cert",
+			},
+			Validity: 30 * 24 * time.Hour,
+			Refresh:  20 * 24 * time.Hour,
+			CertCreator: (&ocrypto.ServingCertCreatorConfig{
+				Subject: pkix.Name{
+					CommonName: "",
+				},
+				DNSNames: []string{"sdc.foo.svc"},
+			}).ToCreator(),
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(100)
+
+	manage := func(t *testing.T, now time.Time) {
+		t.Helper()
+
+		secretCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		existingSecrets := map[string]*corev1.Secret{}
+		secretList, err := client.CoreV1().Secrets(controller.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range secretList.Items {
+			secret := &secretList.Items[i]
+			existingSecrets[secret.Name] = secret
+			err = secretCache.Add(secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		configMapCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		existingConfigMaps := map[string]*corev1.ConfigMap{}
+		configMapList, err := client.CoreV1().ConfigMaps(controller.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range configMapList.Items {
+			cm := &configMapList.Items[i]
+			existingConfigMaps[cm.Name] = cm
+			err = configMapCache.Add(cm)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		client.ClearActions()
+
+		cm := NewCertificateManager(
+			keygen,
+			client.CoreV1(),
+			corev1listers.NewSecretLister(secretCache),
+			client.CoreV1(),
+			corev1listers.NewConfigMapLister(configMapCache),
+			recorder,
+		)
+		err = cm.ManageCertificates(
+			ctx,
+			func() time.Time { return now },
+			controller,
+			controllerGVK,
+			caConfig,
+			caBundleConfig,
+			certConfigs,
+			existingSecrets,
+			existingConfigMaps,
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	getWrites := func() []string {
+		var writes []string
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "list" || action.GetVerb() == "get" {
+				continue
+			}
+			writes = append(writes, action.GetVerb()+" "+action.GetResource().Resource)
+		}
+		return writes
+	}
+
+	getSecretData := func(t *testing.T, name string) map[string][]byte {
+		t.Helper()
+
+		secret, err := client.CoreV1().Secrets(controller.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return secret.Data
+	}
+
+	issuanceTime := now()
+
+	manage(t, issuanceTime)
+	expectedWrites := []string{"create secrets", "create configmaps", "create secrets"}
+	if gotWrites := getWrites(); !reflect.DeepEqual(gotWrites, expectedWrites) {
+		t.Fatalf("expected and got writes on initial issuance differ:\n%s", cmp.Diff(expectedWrites, gotWrites))
+	}
+	issuedCAData := getSecretData(t, "serving-ca")
+	issuedCertData := getSecretData(t, "serving-cert")
+
+	// Nothing changes until the certificate needs a refresh.
+	manage(t, issuanceTime.Add(time.Hour))
+	if gotWrites := getWrites(); len(gotWrites) != 0 {
+		t.Fatalf("expected no writes in steady state, got %v", gotWrites)
+	}
+
+	// Refreshing the certificate is a legitimate data change that has to be applied.
+	manage(t, issuanceTime.Add(21*24*time.Hour))
+	expectedWrites = []string{"update secrets"}
+	if gotWrites := getWrites(); !reflect.DeepEqual(gotWrites, expectedWrites) {
+		t.Fatalf("expected and got writes on rotation differ:\n%s", cmp.Diff(expectedWrites, gotWrites))
+	}
+	if !reflect.DeepEqual(getSecretData(t, "serving-ca"), issuedCAData) {
+		t.Errorf("expected the CA to be kept on the certificate rotation")
+	}
+	rotatedCertData := getSecretData(t, "serving-cert")
+	if reflect.DeepEqual(rotatedCertData, issuedCertData) {
+		t.Errorf("expected the certificate to be rotated")
+	}
+
+	close(recorder.Events)
+	for e := range recorder.Events {
+		for _, data := range []map[string][]byte{issuedCAData, issuedCertData, rotatedCertData} {
+			for k, v := range data {
+				if strings.Contains(e, string(v)) {
+					t.Errorf("event %q contains the value of %q", e, k)
+				}
+			}
+		}
+		if strings.Contains(e, "-----BEGIN") {
+			t.Errorf("event %q contains PEM data", e)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package kubecrypto
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sort"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type bundleEntry struct {
+	secret *corev1.Secret
+	cert   *x509.Certificate
+}
+
+func parseSecretBundle(secrets []*corev1.Secret) ([]bundleEntry, error) {
+	var errs []error
+	entries := make([]bundleEntry, 0, len(secrets))
+	for _, secret := range secrets {
+		cert, key, err := GetCertKeyFromSecret(secret)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get cert key from secret %q: %w", naming.ObjRef(secret), err))
+			continue
+		}
+
+		if !key.PublicKey.Equal(cert.PublicKey) {
+			errs = append(errs, fmt.Errorf("private key in secret %q doesn't match its certificate", naming.ObjRef(secret)))
+			continue
+		}
+
+		entries = append(entries, bundleEntry{
+			secret: secret,
+			cert:   cert,
+		})
+	}
+
+	err := apimachineryutilerrors.NewAggregate(errs)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ValidateSecretBundle checks that the TLS secrets form a consistent cert chain:
+// every secret holds a certificate with a matching key, the bundle contains at least one CA
+// and every non-CA certificate is signed by a CA from the bundle.
+func ValidateSecretBundle(secrets []*corev1.Secret) error {
+	entries, err := parseSecretBundle(secrets)
+	if err != nil {
+		return err
+	}
+
+	return validateBundleEntries(entries)
+}
+
+func validateBundleEntries(entries []bundleEntry) error {
+	var cas []*x509.Certificate
+	for _, e := range entries {
+		if e.cert.IsCA {
+			cas = append(cas, e.cert)
+		}
+	}
+
+	if len(cas) == 0 {
+		return fmt.Errorf("secret bundle doesn't contain any CA certificate")
+	}
+
+	var errs []error
+	for _, e := range entries {
+		if e.cert.IsCA {
+			continue
+		}
+
+		signed := false
+		for _, ca := range cas {
+			if e.cert.CheckSignatureFrom(ca) == nil {
+				signed = true
+				break
+			}
+		}
+
+		if !signed {
+			errs = append(errs, fmt.Errorf("certificate in secret %q isn't signed by any CA in the bundle", naming.ObjRef(e.secret)))
+		}
+	}
+
+	return apimachineryutilerrors.NewAggregate(errs)
+}
+
+// ApplySecretBundle validates that the secrets form a consistent cert chain and only then applies them.
+// CA secrets are applied before the leaves so a reader never observes a leaf without its issuer.
+// Applying stops at the first failure and the secrets applied so far are returned along with the error.
+func ApplySecretBundle(
+	ctx context.Context,
+	client corev1client.SecretsGetter,
+	lister corev1listers.SecretLister,
+	recorder record.EventRecorder,
+	secrets []*corev1.Secret,
+	options resourceapply.ApplyOptions,
+) ([]*corev1.Secret, error) {
+	entries, err := parseSecretBundle(secrets)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse secret bundle: %w", err)
+	}
+
+	err = validateBundleEntries(entries)
+	if err != nil {
+		return nil, fmt.Errorf("can't validate secret bundle: %w", err)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].cert.IsCA && !entries[j].cert.IsCA
+	})
+
+	applied := make([]*corev1.Secret, 0, len(entries))
+	for _, e := range entries {
+		updated, _, err := resourceapply.ApplySecret(ctx, client, lister, recorder, e.secret, options)
+		if err != nil {
+			appliedRefs := make([]string, 0, len(applied))
+			for _, s := range applied {
+				appliedRefs = append(appliedRefs, naming.ObjRef(s))
+			}
+			return applied, fmt.Errorf("can't apply secret %q (applied %q): %w", naming.ObjRef(e.secret), appliedRefs, err)
+		}
+
+		applied = append(applied, updated)
+	}
+
+	return applied, nil
+}
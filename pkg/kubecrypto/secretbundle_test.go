@@ -0,0 +1,174 @@
+package kubecrypto
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	ocrypto "github.com/scylladb/scylla-operator/pkg/crypto"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/resourceapply"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTestTLSSecret(t *testing.T, name string, isCA bool, issuer *x509.Certificate, issuerKey *rsa.PrivateKey) (*corev1.Secret, *x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	if issuer == nil {
+		issuer, issuerKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certBytes, err := ocrypto.EncodeCertificates(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyBytes, err := ocrypto.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "scylla.scylladb.com/v1alpha1",
+					Kind:               "ScyllaDBDatacenter",
+					Name:               "sdc",
+					UID:                "42",
+					Controller:         pointer.Ptr(true),
+					BlockOwnerDeletion: pointer.Ptr(true),
+				},
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certBytes,
+			corev1.TLSPrivateKeyKey: keyBytes,
+		},
+	}, cert, key
+}
+
+func TestApplySecretBundle(t *testing.T) {
+	t.Parallel()
+
+	caSecret, caCert, caKey := newTestTLSSecret(t, "ca", true, nil, nil)
+	leafSecret, _, _ := newTestTLSSecret(t, "leaf", false, caCert, caKey)
+
+	_, otherCACert, otherCAKey := newTestTLSSecret(t, "other-ca", true, nil, nil)
+	foreignLeafSecret, _, _ := newTestTLSSecret(t, "foreign-leaf", false, otherCACert, otherCAKey)
+
+	tt := []struct {
+		name                string
+		secrets             []*corev1.Secret
+		expectedAppliedRefs []string
+		expectedErr         string
+	}{
+		{
+			name:                "consistent bundle is applied with CA first",
+			secrets:             []*corev1.Secret{leafSecret, caSecret},
+			expectedAppliedRefs: []string{"ca", "leaf"},
+			expectedErr:         "",
+		},
+		{
+			name:                "leaf signed by a CA outside of the bundle is rejected before any write",
+			secrets:             []*corev1.Secret{caSecret, foreignLeafSecret},
+			expectedAppliedRefs: nil,
+			expectedErr:         `can't validate secret bundle: certificate in secret "default/foreign-leaf" isn't signed by any CA in the bundle`,
+		},
+		{
+			name:                "bundle without a CA is rejected before any write",
+			secrets:             []*corev1.Secret{leafSecret},
+			expectedAppliedRefs: nil,
+			expectedErr:         "can't validate secret bundle: secret bundle doesn't contain any CA certificate",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithCancel(context.Background())
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset()
+			lister := corev1listers.NewSecretLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}))
+			recorder := record.NewFakeRecorder(10)
+
+			secrets := make([]*corev1.Secret, 0, len(tc.secrets))
+			for _, s := range tc.secrets {
+				secrets = append(secrets, s.DeepCopy())
+			}
+
+			applied, err := ApplySecretBundle(ctx, client.CoreV1(), lister, recorder, secrets, resourceapply.ApplyOptions{})
+			if len(tc.expectedErr) != 0 {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			var appliedNames []string
+			for _, s := range applied {
+				appliedNames = append(appliedNames, s.Name)
+			}
+			if strings.Join(appliedNames, ",") != strings.Join(tc.expectedAppliedRefs, ",") {
+				t.Errorf("expected applied secrets %q, got %q", tc.expectedAppliedRefs, appliedNames)
+			}
+
+			var createdNames []string
+			for _, a := range client.Actions() {
+				if a.GetVerb() == "create" {
+					createdNames = append(createdNames, a.(clienttesting.CreateAction).GetObject().(*corev1.Secret).Name)
+				}
+			}
+			if strings.Join(createdNames, ",") != strings.Join(tc.expectedAppliedRefs, ",") {
+				t.Errorf("expected created secrets %q, got %q", tc.expectedAppliedRefs, createdNames)
+			}
+		})
+	}
+}
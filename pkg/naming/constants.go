@@ -46,6 +46,10 @@ const (
 
 	// CleanupJobTokenRingHashAnnotation reflects which version of token ring cleanup Job is cleaning.
 	CleanupJobTokenRingHashAnnotation = "internal.scylla-operator.scylladb.com/cleanup-token-ring-hash"
+
+	// ManagedNodeServiceAnnotationKeysAnnotation lists the annotation keys that were propagated to a member Service
+	// from the node service templates, so they can be removed once they are dropped from the spec.
+	ManagedNodeServiceAnnotationKeysAnnotation = "internal.scylla-operator.scylladb.com/managed-node-service-annotation-keys"
 )
 
 // Annotations used for feature backward compatibility between v1.ScyllaCluster and v1alpha1.ScyllaDBDatacenter
@@ -64,11 +68,20 @@ const (
 	ScyllaServiceTypeMember   ScyllaServiceType = "member"
 )
 
+type PersistentVolumeClaimType string
+
+const (
+	// PersistentVolumeClaimTypeData marks the PersistentVolumeClaims holding ScyllaDB data, e.g. for backup tooling selecting volumes to snapshot.
+	PersistentVolumeClaimTypeData PersistentVolumeClaimType = "data"
+)
+
 type ScyllaIngressType string
 
 const (
 	ScyllaIngressTypeNode    ScyllaIngressType = "Node"
 	ScyllaIngressTypeAnyNode ScyllaIngressType = "AnyNode"
+
+	ScyllaIngressTypeManagementAPI ScyllaIngressType = "ManagementAPI"
 )
 
 // Generic Labels used on objects created by the operator.
@@ -84,6 +97,11 @@ const (
 	ScyllaServiceTypeLabel       = "scylla-operator.scylladb.com/scylla-service-type"
 	ScyllaIngressTypeLabel       = "scylla-operator.scylladb.com/scylla-ingress-type"
 	ManagedHash                  = "scylla-operator.scylladb.com/managed-hash"
+	ManagedHashVersion           = "scylla-operator.scylladb.com/managed-hash-version"
+	LastAppliedTimeAnnotation    = "scylla-operator.scylladb.com/last-applied-time"
+	ReconcileTokenAnnotation     = "scylla-operator.scylladb.com/reconcile-token"
+	ReconciledByAnnotation       = "scylla-operator.scylladb.com/reconciled-by"
+	ApplyErrorClassAnnotation    = "scylla-operator.scylladb.com/apply-error-class"
 	NodeConfigJobForNodeUIDLabel = "scylla-operator.scylladb.com/node-config-job-for-node-uid"
 	NodeConfigJobTypeLabel       = "scylla-operator.scylladb.com/node-config-job-type"
 	NodeConfigJobData            = "scylla-operator.scylladb.com/node-config-job-data"
@@ -95,6 +113,9 @@ const (
 	NodeJobLabel                 = "scylla-operator.scylladb.com/node-job"
 	NodeJobTypeLabel             = "scylla-operator.scylladb.com/node-job-type"
 
+	// PersistentVolumeClaimTypeLabel specifies the PersistentVolumeClaimType of a PersistentVolumeClaim.
+	PersistentVolumeClaimTypeLabel = "scylla-operator.scylladb.com/persistentvolumeclaim-type"
+
 	AppName           = "scylla"
 	OperatorAppName   = "scylla-operator"
 	ManagerAppName    = "scylla-manager"
@@ -103,8 +124,10 @@ const (
 	PrometheusScrapeAnnotation = "prometheus.io/scrape"
 	PrometheusPortAnnotation   = "prometheus.io/port"
 
-	ForceRedeploymentReasonAnnotation = "scylla-operator.scylladb.com/force-redeployment-reason"
-	InputsHashAnnotation              = "scylla-operator.scylladb.com/inputs-hash"
+	ForceRedeploymentReasonAnnotation    = "scylla-operator.scylladb.com/force-redeployment-reason"
+	InputsHashAnnotation                 = "scylla-operator.scylladb.com/inputs-hash"
+	RolloutSecretsHashAnnotation         = "scylla-operator.scylladb.com/rollout-secrets-hash"
+	RolloutSecretsBaselineHashAnnotation = "scylla-operator.scylladb.com/rollout-secrets-baseline-hash"
 )
 
 const (
@@ -120,6 +143,7 @@ const (
 	PerftuneContainerName           = "perftune"
 	CleanupContainerName            = "cleanup"
 	RLimitsContainerName            = "rlimits"
+	DataDirPreparationContainerName = "data-dir-preparation"
 
 	PVCTemplateName = "data"
 
@@ -142,6 +166,11 @@ const (
 	ScyllaDBIgnitionDonePath = SharedDirName + "/ignition.done"
 
 	DataDir = "/var/lib/scylla"
+	// DataDirFSGroup is the group of the scylla user in ScyllaDB images, used to give it access to the data dir.
+	DataDirFSGroup = 999
+	// DataDirOwnershipMarkerPath records the ownership the data dir was last recursively given to,
+	// so the recursive walk doesn't have to be repeated on every start.
+	DataDirOwnershipMarkerPath = DataDir + "/.scylla-operator-ownership"
 
 	ReadinessProbePath         = "/readyz"
 	LivenessProbePath          = "/healthz"
@@ -180,6 +209,13 @@ type ConfigMapType string
 
 const (
 	NodeConfigDataConfigMapType ConfigMapType = "NodeConfigData"
+	ExporterConfigConfigMapType ConfigMapType = "ExporterConfig"
+)
+
+const (
+	// ExporterScrapeTargetsKey is the key of the exporter ConfigMap holding the scrape targets
+	// in the Prometheus file based service discovery format.
+	ExporterScrapeTargetsKey = "scrape-targets.json"
 )
 
 const (
@@ -239,6 +275,8 @@ const (
 const (
 	RemoteKubernetesClusterFinalizer = "scylla-operator.scylladb.com/remotekubernetescluster-protection"
 	ScyllaDBClusterFinalizer         = "scylla-operator.scylladb.com/scylladbcluster-protection"
+
+	ScyllaDBDatacenterDeletionCleanupFinalizer = "scylla-operator.scylladb.com/scylladbdatacenter-deletion-cleanup"
 )
 
 const (
@@ -31,6 +31,14 @@ func ClusterSelector(sdc *scyllav1alpha1.ScyllaDBDatacenter) labels.Selector {
 	return labels.SelectorFromSet(ClusterLabels(sdc))
 }
 
+// ManagedBySelector returns a labels selector matching the objects the operator manages for the given owner.
+func ManagedBySelector(owner metav1.Object) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		KubernetesManagedByLabel: OperatorAppName,
+		ClusterNameLabel:         owner.GetName(),
+	})
+}
+
 // DatacenterLabels returns a map of label keys and values
 // for the given Datacenter.
 func DatacenterLabels(sdc *scyllav1alpha1.ScyllaDBDatacenter) map[string]string {
@@ -52,6 +60,18 @@ func RackSelectorLabels(r scyllav1alpha1.RackSpec, sdc *scyllav1alpha1.ScyllaDBD
 	return mergeLabels(rackLabels, recLabels), nil
 }
 
+// DataPersistentVolumeClaimLabels returns a map of label keys and values
+// that the data PersistentVolumeClaims of the given Rack carry, so backup tooling can select them for snapshots.
+func DataPersistentVolumeClaimLabels(r scyllav1alpha1.RackSpec, sdc *scyllav1alpha1.ScyllaDBDatacenter) (map[string]string, error) {
+	pvcLabels, err := RackSelectorLabels(r, sdc)
+	if err != nil {
+		return nil, err
+	}
+	pvcLabels[PersistentVolumeClaimTypeLabel] = string(PersistentVolumeClaimTypeData)
+
+	return pvcLabels, nil
+}
+
 // StatefulSetPodLabel returns a map of labels to uniquely
 // identify a StatefulSet Pod with the given name
 func StatefulSetPodLabel(name string) map[string]string {
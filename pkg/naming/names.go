@@ -51,6 +51,10 @@ func AgentAuthTokenSecretName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
 	return fmt.Sprintf("%s-auth-token", sdc.Name)
 }
 
+func ImagePullSecretName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return fmt.Sprintf("%s-image-pull", sdc.Name)
+}
+
 func AgentAuthTokenSecretNameForScyllaCluster(sc *scyllav1.ScyllaCluster) string {
 	return AgentAuthTokenSecretName(&scyllav1alpha1.ScyllaDBDatacenter{
 		ObjectMeta: metav1.ObjectMeta{
@@ -83,10 +87,38 @@ func PodDisruptionBudgetName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
 	return sdc.Name
 }
 
+func PodDisruptionBudgetNameForRack(r scyllav1alpha1.RackSpec, sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return StatefulSetNameForRack(r, sdc)
+}
+
 func PodDisruptionBudgetNameForScyllaCluster(sc *scyllav1.ScyllaCluster) string {
 	return sc.Name
 }
 
+func NetworkPolicyName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return sdc.Name
+}
+
+func DefaultDenyNetworkPolicyName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return fmt.Sprintf("%s-default-deny", sdc.Name)
+}
+
+func AllowOverlayNetworkPolicyName(sdc *scyllav1alpha1.ScyllaDBDatacenter, overlayName string) string {
+	return fmt.Sprintf("%s-allow-%s", sdc.Name, overlayName)
+}
+
+func ServiceMonitorName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return sdc.Name
+}
+
+func PodMonitorName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return sdc.Name
+}
+
+func ManagementAPIIngressName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return fmt.Sprintf("%s-management-api", sdc.Name)
+}
+
 func CrossNamespaceServiceName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
 	return fmt.Sprintf("%s.%s.svc", IdentityServiceName(sdc), sdc.Namespace)
 }
@@ -202,6 +234,10 @@ func MemberServiceAccountNameForScyllaDBDatacenter(sdcName string) string {
 	return fmt.Sprintf("%s-member", sdcName)
 }
 
+func AgentRoleNameForScyllaDBDatacenter(sdcName string) string {
+	return fmt.Sprintf("%s-agent", sdcName)
+}
+
 func GetScyllaClusterLocalClientCAName(scName string) string {
 	return fmt.Sprintf("%s-local-client-ca", scName)
 }
@@ -246,6 +282,10 @@ func CleanupJobForService(svcName string) string {
 	return fmt.Sprintf("cleanup-%s", svcName)
 }
 
+func DeletionCleanupJobName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return fmt.Sprintf("%s-deletion-cleanup", sdc.Name)
+}
+
 func GetScyllaDBManagedConfigCMName(clusterName string) string {
 	return fmt.Sprintf("%s-managed-config", clusterName)
 }
@@ -265,6 +305,10 @@ func UpgradeContextConfigMapName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string
 	return fmt.Sprintf("%s-upgrade-context", sdc.Name)
 }
 
+func ExporterConfigMapName(sdc *scyllav1alpha1.ScyllaDBDatacenter) string {
+	return fmt.Sprintf("%s-exporter-config", sdc.Name)
+}
+
 func DCNameFromSeedServiceAddress(sc *scyllav1alpha1.ScyllaDBCluster, seedServiceAddress, namespace string) string {
 	dcName := strings.TrimPrefix(seedServiceAddress, fmt.Sprintf("%s-", sc.Name))
 	dcName = strings.TrimSuffix(dcName, fmt.Sprintf("-seed.%s.svc", namespace))
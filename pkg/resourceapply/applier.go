@@ -0,0 +1,252 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	coordinationv1listers "k8s.io/client-go/listers/coordination/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// ApplierListers hold the listers used by an Applier.
+// Only listers of the kinds that are going to be applied have to be set.
+type ApplierListers struct {
+	ConfigMaps               corev1listers.ConfigMapLister
+	Secrets                  corev1listers.SecretLister
+	Services                 corev1listers.ServiceLister
+	ServiceAccounts          corev1listers.ServiceAccountLister
+	Namespaces               corev1listers.NamespaceLister
+	Endpoints                corev1listers.EndpointsLister
+	Pods                     corev1listers.PodLister
+	PersistentVolumeClaims   corev1listers.PersistentVolumeClaimLister
+	EndpointSlices           discoveryv1listers.EndpointSliceLister
+	StatefulSets             appsv1listers.StatefulSetLister
+	DaemonSets               appsv1listers.DaemonSetLister
+	Deployments              appsv1listers.DeploymentLister
+	Jobs                     batchv1listers.JobLister
+	Leases                   coordinationv1listers.LeaseLister
+	PodDisruptionBudgets     policyv1listers.PodDisruptionBudgetLister
+	Ingresses                networkingv1listers.IngressLister
+	NetworkPolicies          networkingv1listers.NetworkPolicyLister
+	ClusterRoles             rbacv1listers.ClusterRoleLister
+	Roles                    rbacv1listers.RoleLister
+	RoleBindings             rbacv1listers.RoleBindingLister
+	ClusterRoleBindings      rbacv1listers.ClusterRoleBindingLister
+	HorizontalPodAutoscalers autoscalingv2listers.HorizontalPodAutoscalerLister
+}
+
+// Applier applies Kubernetes objects with the client, listers and event recorder it was constructed with,
+// so they don't have to be passed to every call. Its methods are equivalent to the corresponding ApplyX functions.
+type Applier struct {
+	kubeClient kubernetes.Interface
+	listers    ApplierListers
+	recorder   record.EventRecorder
+}
+
+func NewApplier(kubeClient kubernetes.Interface, listers ApplierListers, recorder record.EventRecorder) *Applier {
+	return &Applier{
+		kubeClient: kubeClient,
+		listers:    listers,
+		recorder:   recorder,
+	}
+}
+
+func missingListerError(kind string) error {
+	return fmt.Errorf("applier doesn't have a lister for %s", kind)
+}
+
+func (a *Applier) ApplyConfigMap(ctx context.Context, required *corev1.ConfigMap, options ApplyOptions) (*corev1.ConfigMap, bool, error) {
+	if a.listers.ConfigMaps == nil {
+		return nil, false, missingListerError("ConfigMap")
+	}
+
+	return ApplyConfigMap(ctx, a.kubeClient.CoreV1(), a.listers.ConfigMaps, a.recorder, required, options)
+}
+
+func (a *Applier) ApplySecret(ctx context.Context, required *corev1.Secret, options ApplyOptions) (*corev1.Secret, bool, error) {
+	if a.listers.Secrets == nil {
+		return nil, false, missingListerError("Secret")
+	}
+
+	return ApplySecret(ctx, a.kubeClient.CoreV1(), a.listers.Secrets, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyService(ctx context.Context, required *corev1.Service, options ApplyOptions) (*corev1.Service, bool, error) {
+	if a.listers.Services == nil {
+		return nil, false, missingListerError("Service")
+	}
+
+	return ApplyService(ctx, a.kubeClient.CoreV1(), a.listers.Services, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyServiceAccount(ctx context.Context, required *corev1.ServiceAccount, options ApplyOptions) (*corev1.ServiceAccount, bool, error) {
+	if a.listers.ServiceAccounts == nil {
+		return nil, false, missingListerError("ServiceAccount")
+	}
+
+	return ApplyServiceAccount(ctx, a.kubeClient.CoreV1(), a.listers.ServiceAccounts, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyNamespace(ctx context.Context, required *corev1.Namespace, options ApplyOptions) (*corev1.Namespace, bool, error) {
+	if a.listers.Namespaces == nil {
+		return nil, false, missingListerError("Namespace")
+	}
+
+	return ApplyNamespace(ctx, a.kubeClient.CoreV1(), a.listers.Namespaces, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyEndpoints(ctx context.Context, required *corev1.Endpoints, options ApplyOptions) (*corev1.Endpoints, bool, error) {
+	if a.listers.Endpoints == nil {
+		return nil, false, missingListerError("Endpoints")
+	}
+
+	return ApplyEndpoints(ctx, a.kubeClient.CoreV1(), a.listers.Endpoints, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyPod(ctx context.Context, required *corev1.Pod, options ApplyOptions) (*corev1.Pod, bool, error) {
+	if a.listers.Pods == nil {
+		return nil, false, missingListerError("Pod")
+	}
+
+	return ApplyPod(ctx, a.kubeClient.CoreV1(), a.listers.Pods, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyPersistentVolumeClaim(ctx context.Context, required *corev1.PersistentVolumeClaim, options ApplyOptions) (*corev1.PersistentVolumeClaim, bool, error) {
+	if a.listers.PersistentVolumeClaims == nil {
+		return nil, false, missingListerError("PersistentVolumeClaim")
+	}
+
+	return ApplyPersistentVolumeClaim(ctx, a.kubeClient.CoreV1(), a.listers.PersistentVolumeClaims, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyEndpointSlice(ctx context.Context, required *discoveryv1.EndpointSlice, options ApplyOptions) (*discoveryv1.EndpointSlice, bool, error) {
+	if a.listers.EndpointSlices == nil {
+		return nil, false, missingListerError("EndpointSlice")
+	}
+
+	return ApplyEndpointSlice(ctx, a.kubeClient.DiscoveryV1(), a.listers.EndpointSlices, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyStatefulSet(ctx context.Context, required *appsv1.StatefulSet, options ApplyOptions) (*appsv1.StatefulSet, bool, error) {
+	if a.listers.StatefulSets == nil {
+		return nil, false, missingListerError("StatefulSet")
+	}
+
+	return ApplyStatefulSet(ctx, a.kubeClient.AppsV1(), a.listers.StatefulSets, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyDaemonSet(ctx context.Context, required *appsv1.DaemonSet, options ApplyOptions) (*appsv1.DaemonSet, bool, error) {
+	if a.listers.DaemonSets == nil {
+		return nil, false, missingListerError("DaemonSet")
+	}
+
+	return ApplyDaemonSet(ctx, a.kubeClient.AppsV1(), a.listers.DaemonSets, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyDeployment(ctx context.Context, required *appsv1.Deployment, options ApplyOptions) (*appsv1.Deployment, bool, error) {
+	if a.listers.Deployments == nil {
+		return nil, false, missingListerError("Deployment")
+	}
+
+	return ApplyDeployment(ctx, a.kubeClient.AppsV1(), a.listers.Deployments, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyJob(ctx context.Context, required *batchv1.Job, options ApplyOptions) (*batchv1.Job, bool, error) {
+	if a.listers.Jobs == nil {
+		return nil, false, missingListerError("Job")
+	}
+
+	return ApplyJob(ctx, a.kubeClient.BatchV1(), a.listers.Jobs, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyLease(ctx context.Context, required *coordinationv1.Lease, options ApplyOptions) (*coordinationv1.Lease, bool, error) {
+	if a.listers.Leases == nil {
+		return nil, false, missingListerError("Lease")
+	}
+
+	return ApplyLease(ctx, a.kubeClient.CoordinationV1(), a.listers.Leases, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyPodDisruptionBudget(ctx context.Context, required *policyv1.PodDisruptionBudget, options ApplyOptions) (*policyv1.PodDisruptionBudget, bool, error) {
+	if a.listers.PodDisruptionBudgets == nil {
+		return nil, false, missingListerError("PodDisruptionBudget")
+	}
+
+	return ApplyPodDisruptionBudget(ctx, a.kubeClient.PolicyV1(), a.listers.PodDisruptionBudgets, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyIngress(ctx context.Context, required *networkingv1.Ingress, options ApplyOptions) (*networkingv1.Ingress, bool, error) {
+	if a.listers.Ingresses == nil {
+		return nil, false, missingListerError("Ingress")
+	}
+
+	return ApplyIngress(ctx, a.kubeClient.NetworkingV1(), a.listers.Ingresses, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyNetworkPolicy(ctx context.Context, required *networkingv1.NetworkPolicy, options ApplyOptions) (*networkingv1.NetworkPolicy, bool, error) {
+	if a.listers.NetworkPolicies == nil {
+		return nil, false, missingListerError("NetworkPolicy")
+	}
+
+	return ApplyNetworkPolicy(ctx, a.kubeClient.NetworkingV1(), a.listers.NetworkPolicies, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyClusterRole(ctx context.Context, required *rbacv1.ClusterRole, options ApplyOptions) (*rbacv1.ClusterRole, bool, error) {
+	if a.listers.ClusterRoles == nil {
+		return nil, false, missingListerError("ClusterRole")
+	}
+
+	return ApplyClusterRole(ctx, a.kubeClient.RbacV1(), a.listers.ClusterRoles, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyRole(ctx context.Context, required *rbacv1.Role, options ApplyOptions) (*rbacv1.Role, bool, error) {
+	if a.listers.Roles == nil {
+		return nil, false, missingListerError("Role")
+	}
+
+	return ApplyRole(ctx, a.kubeClient.RbacV1(), a.listers.Roles, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyRoleBinding(ctx context.Context, required *rbacv1.RoleBinding, options ApplyOptions) (*rbacv1.RoleBinding, bool, error) {
+	if a.listers.RoleBindings == nil {
+		return nil, false, missingListerError("RoleBinding")
+	}
+
+	return ApplyRoleBinding(ctx, a.kubeClient.RbacV1(), a.listers.RoleBindings, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyClusterRoleBinding(ctx context.Context, required *rbacv1.ClusterRoleBinding, options ApplyOptions) (*rbacv1.ClusterRoleBinding, bool, error) {
+	if a.listers.ClusterRoleBindings == nil {
+		return nil, false, missingListerError("ClusterRoleBinding")
+	}
+
+	return ApplyClusterRoleBinding(ctx, a.kubeClient.RbacV1(), a.listers.ClusterRoleBindings, a.recorder, required, options)
+}
+
+func (a *Applier) ApplyHorizontalPodAutoscaler(ctx context.Context, required *autoscalingv2.HorizontalPodAutoscaler, options ApplyOptions) (*autoscalingv2.HorizontalPodAutoscaler, bool, error) {
+	if a.listers.HorizontalPodAutoscalers == nil {
+		return nil, false, missingListerError("HorizontalPodAutoscaler")
+	}
+
+	return ApplyHorizontalPodAutoscaler(ctx, a.kubeClient.AutoscalingV2(), a.listers.HorizontalPodAutoscalers, a.recorder, required, options)
+}
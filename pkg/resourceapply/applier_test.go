@@ -0,0 +1,189 @@
+package resourceapply
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplier(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	newConfigMapWithHash := func() *corev1.ConfigMap {
+		cm := newConfigMap()
+		err := SetHashAnnotation(cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cm
+	}
+
+	tt := []struct {
+		name              string
+		existing          []runtime.Object
+		required          *corev1.ConfigMap
+		expectedConfigMap *corev1.ConfigMap
+		expectedChanged   bool
+		expectedEvents    []string
+	}{
+		{
+			name:              "creates a missing configmap",
+			existing:          nil,
+			required:          newConfigMap(),
+			expectedConfigMap: newConfigMapWithHash(),
+			expectedChanged:   true,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+		},
+		{
+			name:              "leaves an up to date configmap alone",
+			existing:          []runtime.Object{newConfigMapWithHash()},
+			required:          newConfigMap(),
+			expectedConfigMap: newConfigMapWithHash(),
+			expectedChanged:   false,
+			expectedEvents:    nil,
+		},
+		{
+			name:     "updates a changed configmap",
+			existing: []runtime.Object{newConfigMapWithHash()},
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Data["foo"] = "bar"
+				return cm
+			}(),
+			expectedConfigMap: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Data["foo"] = "bar"
+				err := SetHashAnnotation(cm)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return cm
+			}(),
+			expectedChanged: true,
+			expectedEvents:  []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existing...)
+
+			cmCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, obj := range tc.existing {
+				err := cmCache.Add(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			applier := NewApplier(
+				client,
+				ApplierListers{
+					ConfigMaps: corev1listers.NewConfigMapLister(cmCache),
+				},
+				recorder,
+			)
+
+			got, gotChanged, err := applier.ApplyConfigMap(ctx, tc.required, ApplyOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(got, tc.expectedConfigMap) {
+				t.Errorf("expected and got configmaps differ:\n%s", cmp.Diff(tc.expectedConfigMap, got))
+			}
+
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected changed %t, got %t", tc.expectedChanged, gotChanged)
+			}
+
+			stored, err := client.CoreV1().ConfigMaps(tc.required.Namespace).Get(ctx, tc.required.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(stored, got) {
+				t.Errorf("stored and returned configmaps differ:\n%s", cmp.Diff(stored, got))
+			}
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+		})
+	}
+}
+
+func TestApplierMissingLister(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	client := fake.NewSimpleClientset()
+	applier := NewApplier(client, ApplierListers{}, record.NewFakeRecorder(10))
+
+	_, _, err := applier.ApplyConfigMap(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+	}, ApplyOptions{})
+	expectedErr := errors.New("applier doesn't have a lister for ConfigMap")
+	if !reflect.DeepEqual(err, expectedErr) {
+		t.Errorf("expected error %v, got %v", expectedErr, err)
+	}
+
+	list, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("expected no configmaps to be created, got %d", len(list.Items))
+	}
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/scylladb/scylla-operator/pkg/pointer"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
@@ -21,7 +23,7 @@ func ApplyStatefulSetWithControl(
 	required *appsv1.StatefulSet,
 	options ApplyOptions,
 ) (*appsv1.StatefulSet, bool, error) {
-	return ApplyGenericWithHandlers[*appsv1.StatefulSet](
+	return applyGenericWithHandlers[*appsv1.StatefulSet](
 		ctx,
 		control,
 		recorder,
@@ -43,11 +45,59 @@ func ApplyStatefulSetWithControl(
 				return "spec.selector is immutable", pointer.Ptr(metav1.DeletePropagationOrphan), nil
 			}
 
+			if volumeClaimTemplatesStorageDiffer(existing, required) {
+				// PVCs aren't owned by the StatefulSet and are expanded separately, so orphaning keeps both Pods and data.
+				return "spec.volumeClaimTemplates is immutable", pointer.Ptr(metav1.DeletePropagationOrphan), nil
+			}
+
 			return "", nil, nil
 		},
+		getStatefulSetDriftReason,
 	)
 }
 
+// getStatefulSetDriftReason detects StatefulSets that were scaled manually, e.g. with kubectl scale.
+// Scaling doesn't touch the hash annotation, so without it the manual change would stick until the next spec change.
+func getStatefulSetDriftReason(required *appsv1.StatefulSet, existing *appsv1.StatefulSet) string {
+	if required.Spec.Replicas == nil {
+		return ""
+	}
+
+	existingReplicas := int32(1)
+	if existing.Spec.Replicas != nil {
+		existingReplicas = *existing.Spec.Replicas
+	}
+
+	if existingReplicas != *required.Spec.Replicas {
+		return fmt.Sprintf("spec.replicas was changed to %d, reverting it to %d", existingReplicas, *required.Spec.Replicas)
+	}
+
+	return ""
+}
+
+// volumeClaimTemplatesStorageDiffer reports whether a volumeClaimTemplate present in both StatefulSets
+// requests a different amount of storage.
+// Only the storage requests are compared so server defaulting of the templates doesn't cause a recreation.
+func volumeClaimTemplatesStorageDiffer(existing, required *appsv1.StatefulSet) bool {
+	existingStorage := make(map[string]resource.Quantity, len(existing.Spec.VolumeClaimTemplates))
+	for _, vct := range existing.Spec.VolumeClaimTemplates {
+		existingStorage[vct.Name] = vct.Spec.Resources.Requests[corev1.ResourceStorage]
+	}
+
+	for _, vct := range required.Spec.VolumeClaimTemplates {
+		existingQuantity, ok := existingStorage[vct.Name]
+		if !ok {
+			continue
+		}
+
+		if existingQuantity.Cmp(vct.Spec.Resources.Requests[corev1.ResourceStorage]) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func ApplyStatefulSet(
 	ctx context.Context,
 	client appsv1client.StatefulSetsGetter,
@@ -58,12 +108,7 @@ func ApplyStatefulSet(
 ) (*appsv1.StatefulSet, bool, error) {
 	return ApplyStatefulSetWithControl(
 		ctx,
-		ApplyControlFuncs[*appsv1.StatefulSet]{
-			GetCachedFunc: lister.StatefulSets(required.Namespace).Get,
-			CreateFunc:    client.StatefulSets(required.Namespace).Create,
-			UpdateFunc:    client.StatefulSets(required.Namespace).Update,
-			DeleteFunc:    client.StatefulSets(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*appsv1.StatefulSet](lister.StatefulSets(required.Namespace), client.StatefulSets(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -103,12 +148,7 @@ func ApplyDaemonSet(
 ) (*appsv1.DaemonSet, bool, error) {
 	return ApplyDaemonSetWithControl(
 		ctx,
-		ApplyControlFuncs[*appsv1.DaemonSet]{
-			GetCachedFunc: lister.DaemonSets(required.Namespace).Get,
-			CreateFunc:    client.DaemonSets(required.Namespace).Create,
-			UpdateFunc:    client.DaemonSets(required.Namespace).Update,
-			DeleteFunc:    client.DaemonSets(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*appsv1.DaemonSet](lister.DaemonSets(required.Namespace), client.DaemonSets(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -135,12 +175,7 @@ func ApplyDeployment(
 ) (*appsv1.Deployment, bool, error) {
 	return ApplyDeploymentWithControl(
 		ctx,
-		ApplyControlFuncs[*appsv1.Deployment]{
-			GetCachedFunc: lister.Deployments(required.Namespace).Get,
-			CreateFunc:    client.Deployments(required.Namespace).Create,
-			UpdateFunc:    client.Deployments(required.Namespace).Update,
-			DeleteFunc:    client.Deployments(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*appsv1.Deployment](lister.Deployments(required.Namespace), client.Deployments(required.Namespace)),
 		recorder,
 		required,
 		options,
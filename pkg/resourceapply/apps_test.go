@@ -13,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +25,22 @@ import (
 )
 
 func TestApplyStatefulSet(t *testing.T) {
+	newVolumeClaimTemplate := func(name, storage string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(storage),
+					},
+				},
+			},
+		}
+	}
+
 	// Using a generating function prevents unwanted mutations.
 	newSts := func() *appsv1.StatefulSet {
 		return &appsv1.StatefulSet{
@@ -162,6 +179,25 @@ func TestApplyStatefulSet(t *testing.T) {
 			expectedErr:     nil,
 			expectedEvents:  []string{"Normal StatefulSetUpdated StatefulSet default/test updated"},
 		},
+		{
+			name: "reverts a manual scale of the sts",
+			existing: []runtime.Object{
+				func() *appsv1.StatefulSet {
+					sts := newStsWithHash()
+					// Simulate a manual scale, which keeps the hash annotation in place.
+					sts.Spec.Replicas = pointer.Ptr(int32(5))
+					return sts
+				}(),
+			},
+			required:        newSts(),
+			expectedSts:     newStsWithHash(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents: []string{
+				"Normal StatefulSetUpdated StatefulSet default/test updated",
+				"Normal StatefulSetDriftCorrected StatefulSet default/test drifted from the required state and was corrected: spec.replicas was changed to 5, reverting it to 3",
+			},
+		},
 		{
 			name: "updates the sts if labels differ",
 			existing: []runtime.Object{
@@ -263,8 +299,8 @@ func TestApplyStatefulSet(t *testing.T) {
 			}(),
 			expectedSts:     nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update apps/v1, Kind=StatefulSet "default/test": %w`, apierrors.NewNotFound(appsv1.Resource("statefulsets"), "test")),
-			expectedEvents:  []string{`Warning UpdateStatefulSetFailed Failed to update StatefulSet default/test: statefulsets.apps "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update apps/v1, Kind=StatefulSet "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(appsv1.Resource("statefulsets"), "test")}),
+			expectedEvents:  []string{`Warning UpdateStatefulSetFailed Failed to update StatefulSet default/test: statefulsets.apps "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -554,6 +590,69 @@ func TestApplyStatefulSet(t *testing.T) {
 				"Normal StatefulSetCreated StatefulSet default/test created",
 			},
 		},
+		{
+			name: "deletes and creates the StatefulSet when volumeClaimTemplate storage is increased",
+			existing: []runtime.Object{
+				func() *appsv1.StatefulSet {
+					sts := newSts()
+					sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+						newVolumeClaimTemplate("data", "1Gi"),
+					}
+					return sts
+				}(),
+			},
+			required: func() *appsv1.StatefulSet {
+				sts := newSts()
+				sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+					newVolumeClaimTemplate("data", "2Gi"),
+				}
+				return sts
+			}(),
+			expectedSts: func() *appsv1.StatefulSet {
+				sts := newSts()
+				sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+					newVolumeClaimTemplate("data", "2Gi"),
+				}
+				apimachineryutilruntime.Must(SetHashAnnotation(sts))
+				return sts
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents: []string{
+				"Normal StatefulSetDeleted StatefulSet default/test deleted",
+				"Normal StatefulSetCreated StatefulSet default/test created",
+			},
+		},
+		{
+			name: "updates the StatefulSet in place when volumeClaimTemplate storage is equal but differently formatted",
+			existing: []runtime.Object{
+				func() *appsv1.StatefulSet {
+					sts := newSts()
+					sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+						newVolumeClaimTemplate("data", "1Gi"),
+					}
+					return sts
+				}(),
+			},
+			required: func() *appsv1.StatefulSet {
+				sts := newSts()
+				sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+					newVolumeClaimTemplate("data", "1024Mi"),
+				}
+				return sts
+			}(),
+			expectedSts: func() *appsv1.StatefulSet {
+				sts := newSts()
+				sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+					newVolumeClaimTemplate("data", "1024Mi"),
+				}
+				apimachineryutilruntime.Must(SetHashAnnotation(sts))
+				return sts
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal StatefulSetUpdated StatefulSet default/test updated"},
+		},
 		{
 			name: "apply fails when StatefulSet selector differs and existing Pod labels doesn't match new selector",
 			existing: []runtime.Object{
@@ -899,8 +998,8 @@ func TestApplyDaemonSet(t *testing.T) {
 			}(),
 			expectedDaemonSet: nil,
 			expectedChanged:   false,
-			expectedErr:       fmt.Errorf(`can't update apps/v1, Kind=DaemonSet "default/test": %w`, apierrors.NewNotFound(appsv1.Resource("daemonsets"), "test")),
-			expectedEvents:    []string{`Warning UpdateDaemonSetFailed Failed to update DaemonSet default/test: daemonsets.apps "test" not found`},
+			expectedErr:       fmt.Errorf(`can't update apps/v1, Kind=DaemonSet "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(appsv1.Resource("daemonsets"), "test")}),
+			expectedEvents:    []string{`Warning UpdateDaemonSetFailed Failed to update DaemonSet default/test: daemonsets.apps "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
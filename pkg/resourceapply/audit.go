@@ -0,0 +1,194 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+type AuditOperation string
+
+const (
+	AuditOperationCreate AuditOperation = "Create"
+	AuditOperationUpdate AuditOperation = "Update"
+	AuditOperationPatch  AuditOperation = "Patch"
+	AuditOperationDelete AuditOperation = "Delete"
+)
+
+// AuditEntry describes a single mutating API call made by an apply, see ApplyOptions.AuditSink.
+type AuditEntry struct {
+	// Time is when the call finished.
+	Time time.Time `json:"time"`
+	// Controller is the ApplyOptions.ControllerName of the apply, if set.
+	Controller string         `json:"controller,omitempty"`
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Namespace  string         `json:"namespace,omitempty"`
+	Name       string         `json:"name"`
+	Operation  AuditOperation `json:"operation"`
+	// ResourceVersionBefore is the resource version of the object the call was based on, if it was known.
+	ResourceVersionBefore string `json:"resourceVersionBefore,omitempty"`
+	// ResourceVersionAfter is the resource version of the object returned by the call, if any.
+	ResourceVersionAfter string `json:"resourceVersionAfter,omitempty"`
+	// Error is the error the call failed with. Failed calls are audited as well.
+	Error string `json:"error,omitempty"`
+}
+
+// NewJSONLinesAuditSink returns an audit sink that writes every entry to w as a single line of JSON.
+// It's safe for concurrent use. Entries that can't be written are logged and dropped, so auditing never fails an apply.
+func NewJSONLinesAuditSink(w io.Writer) func(AuditEntry) {
+	var lock sync.Mutex
+	encoder := json.NewEncoder(w)
+
+	return func(entry AuditEntry) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		err := encoder.Encode(entry)
+		if err != nil {
+			klog.ErrorS(err, "Can't write audit entry", "Kind", entry.Kind, "Namespace", entry.Namespace, "Name", entry.Name, "Operation", entry.Operation)
+		}
+	}
+}
+
+// auditingApplyControl reports every mutating call made through the wrapped control to an audit sink.
+// Resource versions of the objects read through it are remembered, so calls addressing objects by name,
+// like patches and deletes, can be audited with the resource version they were based on.
+type auditingApplyControl[T kubeinterfaces.ObjectInterface] struct {
+	control   ApplyControlInterface[T]
+	options   *ApplyOptions
+	gvk       schema.GroupVersionKind
+	namespace string
+	lock      sync.Mutex
+	seenRVs   map[string]string
+}
+
+var _ ApplyControlInterface[kubeinterfaces.ObjectInterface] = &auditingApplyControl[kubeinterfaces.ObjectInterface]{}
+var _ ApplyControlPatcher[kubeinterfaces.ObjectInterface] = &auditingApplyControl[kubeinterfaces.ObjectInterface]{}
+
+func newAuditingApplyControl[T kubeinterfaces.ObjectInterface](control ApplyControlInterface[T], options *ApplyOptions, gvk schema.GroupVersionKind, namespace string) *auditingApplyControl[T] {
+	return &auditingApplyControl[T]{
+		control:   control,
+		options:   options,
+		gvk:       gvk,
+		namespace: namespace,
+		seenRVs:   map[string]string{},
+	}
+}
+
+func (c *auditingApplyControl[T]) remember(name string, obj T, err error) {
+	if err != nil || len(name) == 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.seenRVs[name] = obj.GetResourceVersion()
+}
+
+func (c *auditingApplyControl[T]) seenRV(name string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.seenRVs[name]
+}
+
+func (c *auditingApplyControl[T]) audit(operation AuditOperation, name string, rvBefore string, obj *T, err error) {
+	entry := AuditEntry{
+		Time:                  c.options.now(),
+		Controller:            c.options.ControllerName,
+		APIVersion:            c.gvk.GroupVersion().String(),
+		Kind:                  c.gvk.Kind,
+		Namespace:             c.namespace,
+		Name:                  name,
+		Operation:             operation,
+		ResourceVersionBefore: rvBefore,
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else if obj != nil {
+		entry.ResourceVersionAfter = (*obj).GetResourceVersion()
+	}
+
+	c.options.AuditSink(entry)
+}
+
+func (c *auditingApplyControl[T]) GetCached(name string) (T, error) {
+	obj, err := c.control.GetCached(name)
+	c.remember(name, obj, err)
+	return obj, err
+}
+
+func (c *auditingApplyControl[T]) ListCached(selector labels.Selector) ([]T, error) {
+	objs, err := c.control.ListCached(selector)
+	if err == nil {
+		for _, obj := range objs {
+			c.remember(obj.GetName(), obj, nil)
+		}
+	}
+	return objs, err
+}
+
+func (c *auditingApplyControl[T]) Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+	obj, err := c.control.Get(ctx, name, opts)
+	c.remember(name, obj, err)
+	return obj, err
+}
+
+func (c *auditingApplyControl[T]) Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error) {
+	created, err := c.control.Create(ctx, obj, opts)
+
+	// Created objects may have a server generated name.
+	name := obj.GetName()
+	if err == nil {
+		name = created.GetName()
+	}
+	c.audit(AuditOperationCreate, name, "", &created, err)
+	c.remember(name, created, err)
+
+	return created, err
+}
+
+func (c *auditingApplyControl[T]) Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error) {
+	updated, err := c.control.Update(ctx, obj, opts)
+	c.audit(AuditOperationUpdate, obj.GetName(), obj.GetResourceVersion(), &updated, err)
+	c.remember(obj.GetName(), updated, err)
+	return updated, err
+}
+
+func (c *auditingApplyControl[T]) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+	patcher, ok := c.control.(ApplyControlPatcher[T])
+	if !ok {
+		return *new(T), fmt.Errorf("patching isn't supported by this control")
+	}
+
+	patched, err := patcher.Patch(ctx, name, pt, data, opts)
+	c.audit(AuditOperationPatch, name, c.seenRV(name), &patched, err)
+	c.remember(name, patched, err)
+	return patched, err
+}
+
+func (c *auditingApplyControl[T]) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	rvBefore := c.seenRV(name)
+	if opts.Preconditions != nil && opts.Preconditions.ResourceVersion != nil {
+		rvBefore = *opts.Preconditions.ResourceVersion
+	}
+
+	err := c.control.Delete(ctx, name, opts)
+	c.audit(AuditOperationDelete, name, rvBefore, nil, err)
+	return err
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestApplyGenericAuditSink(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	client := fake.NewSimpleClientset()
+	// The fake tracker doesn't maintain resource versions, bump them on every write like the apiserver would.
+	rv := 0
+	bumpResourceVersion := func(action clienttesting.Action) (bool, runtime.Object, error) {
+		rv++
+		action.(clienttesting.CreateAction).GetObject().(metav1.Object).SetResourceVersion(strconv.Itoa(rv))
+		return false, nil, nil
+	}
+	client.PrependReactor("create", "configmaps", bumpResourceVersion)
+	client.PrependReactor("update", "configmaps", bumpResourceVersion)
+
+	var lock sync.Mutex
+	var entries []AuditEntry
+	options := ApplyOptions{
+		ControllerName: "test-controller",
+		Clock:          testingclock.NewFakePassiveClock(now),
+		AuditSink: func(entry AuditEntry) {
+			lock.Lock()
+			defer lock.Unlock()
+			entries = append(entries, entry)
+		},
+	}
+
+	newEntry := func(operation AuditOperation, rvBefore, rvAfter string) AuditEntry {
+		return AuditEntry{
+			Time:                  now,
+			Controller:            "test-controller",
+			APIVersion:            "v1",
+			Kind:                  "ConfigMap",
+			Namespace:             "default",
+			Name:                  "test",
+			Operation:             operation,
+			ResourceVersionBefore: rvBefore,
+			ResourceVersionAfter:  rvAfter,
+		}
+	}
+
+	steps := []struct {
+		name            string
+		data            string
+		expectedChanged bool
+		expectedEntries []AuditEntry
+	}{
+		{
+			name:            "create is audited",
+			data:            "foo",
+			expectedChanged: true,
+			expectedEntries: []AuditEntry{newEntry(AuditOperationCreate, "", "1")},
+		},
+		{
+			name:            "no-op isn't audited",
+			data:            "foo",
+			expectedChanged: false,
+			expectedEntries: nil,
+		},
+		{
+			name:            "update is audited",
+			data:            "bar",
+			expectedChanged: true,
+			expectedEntries: []AuditEntry{newEntry(AuditOperationUpdate, "1", "2")},
+		},
+		{
+			name:            "another no-op isn't audited",
+			data:            "bar",
+			expectedChanged: false,
+			expectedEntries: nil,
+		},
+	}
+
+	for _, step := range steps {
+		entries = nil
+
+		required := newConfigMap()
+		required.Data["foo"] = step.data
+
+		_, gotChanged, err := ApplyConfigMap(ctx, client.CoreV1(), newConfigMapListerForTest(t, ctx, client), record.NewFakeRecorder(10), required, options)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if gotChanged != step.expectedChanged {
+			t.Errorf("%s: expected changed %t, got %t", step.name, step.expectedChanged, gotChanged)
+		}
+
+		if !reflect.DeepEqual(entries, step.expectedEntries) {
+			t.Errorf("%s: expected and got audit entries differ:\n%s", step.name, cmp.Diff(step.expectedEntries, entries))
+		}
+	}
+}
+
+func TestNewJSONLinesAuditSink(t *testing.T) {
+	t.Parallel()
+
+	entries := []AuditEntry{
+		{
+			Time:                 time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Controller:           "test-controller",
+			APIVersion:           "v1",
+			Kind:                 "ConfigMap",
+			Namespace:            "default",
+			Name:                 "test",
+			Operation:            AuditOperationCreate,
+			ResourceVersionAfter: "1",
+		},
+		{
+			Time:                  time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+			APIVersion:            "apps/v1",
+			Kind:                  "StatefulSet",
+			Namespace:             "default",
+			Name:                  "test",
+			Operation:             AuditOperationDelete,
+			ResourceVersionBefore: "2",
+			Error:                 "boom",
+		},
+	}
+
+	var buf bytes.Buffer
+	sink := NewJSONLinesAuditSink(&buf)
+	for _, entry := range entries {
+		sink(entry)
+	}
+
+	expectedOutput := strings.Join([]string{
+		`{"time":"2025-01-01T00:00:00Z","controller":"test-controller","apiVersion":"v1","kind":"ConfigMap","namespace":"default","name":"test","operation":"Create","resourceVersionAfter":"1"}`,
+		`{"time":"2025-01-01T00:00:01Z","apiVersion":"apps/v1","kind":"StatefulSet","namespace":"default","name":"test","operation":"Delete","resourceVersionBefore":"2","error":"boom"}`,
+		"",
+	}, "\n")
+	if buf.String() != expectedOutput {
+		t.Errorf("expected and got output differ:\n%s", cmp.Diff(expectedOutput, buf.String()))
+	}
+
+	var got []AuditEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry AuditEntry
+		err := json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, entry)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("expected and got decoded entries differ:\n%s", cmp.Diff(entries, got))
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2client "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
+	"k8s.io/client-go/tools/record"
+)
+
+func ApplyHorizontalPodAutoscalerWithControl(
+	ctx context.Context,
+	control ApplyControlInterface[*autoscalingv2.HorizontalPodAutoscaler],
+	recorder record.EventRecorder,
+	required *autoscalingv2.HorizontalPodAutoscaler,
+	options ApplyOptions,
+) (*autoscalingv2.HorizontalPodAutoscaler, bool, error) {
+	return ApplyGeneric[*autoscalingv2.HorizontalPodAutoscaler](ctx, control, recorder, required, options)
+}
+
+func ApplyHorizontalPodAutoscaler(
+	ctx context.Context,
+	client autoscalingv2client.HorizontalPodAutoscalersGetter,
+	lister autoscalingv2listers.HorizontalPodAutoscalerLister,
+	recorder record.EventRecorder,
+	required *autoscalingv2.HorizontalPodAutoscaler,
+	options ApplyOptions,
+) (*autoscalingv2.HorizontalPodAutoscaler, bool, error) {
+	return ApplyHorizontalPodAutoscalerWithControl(
+		ctx,
+		NewApplyControlFuncs[*autoscalingv2.HorizontalPodAutoscaler](lister.HorizontalPodAutoscalers(required.Namespace), client.HorizontalPodAutoscalers(required.Namespace)),
+		recorder,
+		required,
+		options,
+	)
+}
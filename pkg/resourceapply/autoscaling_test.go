@@ -0,0 +1,150 @@
+package resourceapply
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyHorizontalPodAutoscaler(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newHPA := func(targetUtilization int32) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "test",
+				},
+				MinReplicas: pointer.Ptr[int32](1),
+				MaxReplicas: 3,
+				Metrics: []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name: corev1.ResourceCPU,
+							Target: autoscalingv2.MetricTarget{
+								Type:               autoscalingv2.UtilizationMetricType,
+								AverageUtilization: pointer.Ptr(targetUtilization),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newHPAWithHash := func(targetUtilization int32) *autoscalingv2.HorizontalPodAutoscaler {
+		hpa := newHPA(targetUtilization)
+		apimachineryutilruntime.Must(SetHashAnnotation(hpa))
+		return hpa
+	}
+
+	tt := []struct {
+		name            string
+		existing        []runtime.Object
+		required        *autoscalingv2.HorizontalPodAutoscaler
+		expectedHPA     *autoscalingv2.HorizontalPodAutoscaler
+		expectedChanged bool
+		expectedEvents  []string
+	}{
+		{
+			name:            "creates a new hpa when there is none",
+			existing:        nil,
+			required:        newHPA(80),
+			expectedHPA:     newHPAWithHash(80),
+			expectedChanged: true,
+			expectedEvents:  []string{"Normal HorizontalPodAutoscalerCreated HorizontalPodAutoscaler default/test created"},
+		},
+		{
+			name:            "does nothing if the same hpa already exists",
+			existing:        []runtime.Object{newHPAWithHash(80)},
+			required:        newHPA(80),
+			expectedHPA:     newHPAWithHash(80),
+			expectedChanged: false,
+			expectedEvents:  nil,
+		},
+		{
+			name:            "updates the hpa when the target utilization changes",
+			existing:        []runtime.Object{newHPAWithHash(80)},
+			required:        newHPA(60),
+			expectedHPA:     newHPAWithHash(60),
+			expectedChanged: true,
+			expectedEvents:  []string{"Normal HorizontalPodAutoscalerUpdated HorizontalPodAutoscaler default/test updated"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existing...)
+
+			hpaCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, obj := range tc.existing {
+				err := hpaCache.Add(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			hpaLister := autoscalingv2listers.NewHorizontalPodAutoscalerLister(hpaCache)
+
+			recorder := record.NewFakeRecorder(10)
+			gotObj, gotChanged, err := ApplyHorizontalPodAutoscaler(ctx, client.AutoscalingV2(), hpaLister, recorder, tc.required, ApplyOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(gotObj, tc.expectedHPA) {
+				t.Errorf("expected and got hpas differ:\n%s", cmp.Diff(tc.expectedHPA, gotObj))
+			}
+
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected %t, got %t", tc.expectedChanged, gotChanged)
+			}
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+		})
+	}
+}
@@ -56,12 +56,7 @@ func ApplyJob(
 ) (*batchv1.Job, bool, error) {
 	return ApplyJobWithControl(
 		ctx,
-		ApplyControlFuncs[*batchv1.Job]{
-			GetCachedFunc: lister.Jobs(required.Namespace).Get,
-			CreateFunc:    client.Jobs(required.Namespace).Create,
-			UpdateFunc:    client.Jobs(required.Namespace).Update,
-			DeleteFunc:    client.Jobs(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*batchv1.Job](lister.Jobs(required.Namespace), client.Jobs(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	remoteclient "github.com/scylladb/scylla-operator/pkg/remoteclient/client"
+	remotelister "github.com/scylladb/scylla-operator/pkg/remoteclient/lister"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+)
+
+// ClusterApplyControlProvider resolves the control applying objects into the named cluster.
+type ClusterApplyControlProvider[T kubeinterfaces.ObjectInterface] interface {
+	Cluster(name string) (ApplyControlInterface[T], error)
+}
+
+type ClusterApplyControlProviderFunc[T kubeinterfaces.ObjectInterface] func(name string) (ApplyControlInterface[T], error)
+
+func (f ClusterApplyControlProviderFunc[T]) Cluster(name string) (ApplyControlInterface[T], error) {
+	return f(name)
+}
+
+var _ ClusterApplyControlProvider[kubeinterfaces.ObjectInterface] = ClusterApplyControlProviderFunc[kubeinterfaces.ObjectInterface](nil)
+
+// NewClusterApplyControlProvider wires the remote cluster clients and listers into a ClusterApplyControlProvider.
+// makeControl gets the typed client and lister of the requested cluster, e.g. to scope them with NewApplyControlFuncs.
+func NewClusterApplyControlProvider[T kubeinterfaces.ObjectInterface, CT, LT any](
+	clusterClient remoteclient.ClusterClientInterface[CT],
+	clusterLister remotelister.GenericClusterLister[LT],
+	makeControl func(client CT, lister LT) ApplyControlInterface[T],
+) ClusterApplyControlProviderFunc[T] {
+	return func(name string) (ApplyControlInterface[T], error) {
+		client, err := clusterClient.Cluster(name)
+		if err != nil {
+			return nil, fmt.Errorf("can't get cluster %q client: %w", name, err)
+		}
+
+		return makeControl(client, clusterLister.Cluster(name)), nil
+	}
+}
+
+// ApplyToClusters applies the same required object into each of the clusters, using applyFunc with the control
+// the provider resolves for the cluster, e.g. ApplyConfigMapWithControl.
+// A failure in one cluster doesn't stop the apply into the others, the errors are aggregated.
+// The applied objects are returned by cluster name, the bool is true if the object changed in any cluster.
+func ApplyToClusters[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	provider ClusterApplyControlProvider[T],
+	clusters []string,
+	applyFunc func(context.Context, ApplyControlInterface[T], record.EventRecorder, T, ApplyOptions) (T, bool, error),
+	recorder record.EventRecorder,
+	required T,
+	options ApplyOptions,
+) (map[string]T, bool, error) {
+	applied := make(map[string]T, len(clusters))
+	anyChanged := false
+	var errs []error
+	for _, cluster := range clusters {
+		control, err := provider.Cluster(cluster)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get cluster %q control: %w", cluster, err))
+			continue
+		}
+
+		obj, changed, err := applyFunc(ctx, control, recorder, required, options)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't apply into cluster %q: %w", cluster, err))
+			continue
+		}
+
+		applied[cluster] = obj
+		anyChanged = anyChanged || changed
+	}
+
+	return applied, anyChanged, apimachineryutilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,140 @@
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	remotelister "github.com/scylladb/scylla-operator/pkg/remoteclient/lister"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+type testClusterClient map[string]kubernetes.Interface
+
+func (c testClusterClient) Cluster(name string) (kubernetes.Interface, error) {
+	client, ok := c[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q", name)
+	}
+	return client, nil
+}
+
+func TestApplyToClusters(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	clusterClients := map[string]*fake.Clientset{
+		"dc1": fake.NewSimpleClientset(),
+		"dc2": fake.NewSimpleClientset(),
+	}
+
+	clusterClient := testClusterClient{}
+	for name, client := range clusterClients {
+		clusterClient[name] = client
+	}
+
+	// Caches are filled from the clients on every lookup, so the second apply sees the created objects.
+	clusterLister := remotelister.NewClusterLister(corev1listers.NewConfigMapLister, func(name string) cache.Indexer {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		client, ok := clusterClients[name]
+		if !ok {
+			return indexer
+		}
+
+		cmList, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range cmList.Items {
+			err = indexer.Add(&cmList.Items[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return indexer
+	})
+
+	required := newConfigMap()
+	required.Data["foo"] = "bar"
+
+	provider := NewClusterApplyControlProvider(clusterClient, clusterLister, func(client kubernetes.Interface, lister corev1listers.ConfigMapLister) ApplyControlInterface[*corev1.ConfigMap] {
+		return NewApplyControlFuncs[*corev1.ConfigMap](lister.ConfigMaps(required.Namespace), client.CoreV1().ConfigMaps(required.Namespace))
+	})
+
+	applied, changed, err := ApplyToClusters(ctx, provider, []string{"dc1", "dc2"}, ApplyConfigMapWithControl, record.NewFakeRecorder(10), required, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Errorf("expected the objects to be created")
+	}
+
+	expectedClusters := []string{"dc1", "dc2"}
+	if gotClusters := sets.List(sets.KeySet(applied)); !reflect.DeepEqual(gotClusters, expectedClusters) {
+		t.Errorf("expected and got applied clusters differ:\n%s", cmp.Diff(expectedClusters, gotClusters))
+	}
+
+	for name, client := range clusterClients {
+		cm, err := client.CoreV1().ConfigMaps(required.Namespace).Get(ctx, required.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected the configmap in cluster %q: %v", name, err)
+		}
+		if !reflect.DeepEqual(cm.Data, required.Data) {
+			t.Errorf("expected and got data in cluster %q differ:\n%s", name, cmp.Diff(required.Data, cm.Data))
+		}
+	}
+
+	_, changed, err = ApplyToClusters(ctx, provider, []string{"dc1", "dc2"}, ApplyConfigMapWithControl, record.NewFakeRecorder(10), required, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed {
+		t.Errorf("expected no change when the objects are up to date")
+	}
+
+	// A cluster that can't be reached doesn't block the others.
+	applied, _, err = ApplyToClusters(ctx, provider, []string{"unknown", "dc1"}, ApplyConfigMapWithControl, record.NewFakeRecorder(10), required, ApplyOptions{})
+	if err == nil {
+		t.Errorf("expected an error for the unknown cluster")
+	}
+	if _, ok := applied["dc1"]; !ok {
+		t.Errorf("expected the apply into cluster %q to succeed, got %v", "dc1", applied)
+	}
+}
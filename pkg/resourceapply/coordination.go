@@ -0,0 +1,130 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	coordinationv1listers "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+func ApplyLeaseWithControl(
+	ctx context.Context,
+	control ApplyControlInterface[*coordinationv1.Lease],
+	recorder record.EventRecorder,
+	required *coordinationv1.Lease,
+	options ApplyOptions,
+) (*coordinationv1.Lease, bool, error) {
+	return ApplyGeneric[*coordinationv1.Lease](ctx, control, recorder, required, options)
+}
+
+func ApplyLease(
+	ctx context.Context,
+	client coordinationv1client.LeasesGetter,
+	lister coordinationv1listers.LeaseLister,
+	recorder record.EventRecorder,
+	required *coordinationv1.Lease,
+	options ApplyOptions,
+) (*coordinationv1.Lease, bool, error) {
+	return ApplyLeaseWithControl(
+		ctx,
+		NewApplyControlFuncs[*coordinationv1.Lease](lister.Leases(required.Namespace), client.Leases(required.Namespace)),
+		recorder,
+		required,
+		options,
+	)
+}
+
+const (
+	nodeMaintenanceLeaseDurationSeconds = 60
+)
+
+func nodeMaintenanceLeaseName(nodeName string) string {
+	return fmt.Sprintf("node-maintenance-%s", nodeName)
+}
+
+func isLeaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.HolderIdentity == nil || len(*lease.Spec.HolderIdentity) == 0 {
+		return true
+	}
+
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+
+	return lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second).Before(now)
+}
+
+// EnsureNodeMaintenanceLease acquires or renews the Lease marking the node as being under maintenance by holder.
+// It returns false when the Lease is held by someone else or when holder lost a race for it.
+// The Lease is always read live and written with optimistic concurrency, so at most one holder can succeed.
+func EnsureNodeMaintenanceLease(ctx context.Context, client coordinationv1client.LeaseInterface, nodeName string, holder string) (bool, error) {
+	name := nodeMaintenanceLeaseName(nodeName)
+	now := metav1.NowMicro()
+
+	lease, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("can't get lease %q: %w", name, err)
+		}
+
+		_, err = client.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       pointer.Ptr(holder),
+				LeaseDurationSeconds: pointer.Ptr[int32](nodeMaintenanceLeaseDurationSeconds),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			klog.V(2).InfoS("Lost node maintenance lease race on create", "Node", nodeName, "Holder", holder)
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("can't create lease %q: %w", name, err)
+		}
+
+		return true, nil
+	}
+
+	isHeldByUs := lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == holder
+	if !isHeldByUs && !isLeaseExpired(lease, now.Time) {
+		return false, nil
+	}
+
+	lease = lease.DeepCopy()
+	if !isHeldByUs {
+		lease.Spec.HolderIdentity = pointer.Ptr(holder)
+		lease.Spec.AcquireTime = &now
+		var transitions int32
+		if lease.Spec.LeaseTransitions != nil {
+			transitions = *lease.Spec.LeaseTransitions
+		}
+		lease.Spec.LeaseTransitions = pointer.Ptr(transitions + 1)
+	}
+	lease.Spec.RenewTime = &now
+	lease.Spec.LeaseDurationSeconds = pointer.Ptr[int32](nodeMaintenanceLeaseDurationSeconds)
+
+	_, err = client.Update(ctx, lease, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		klog.V(2).InfoS("Lost node maintenance lease race on update", "Node", nodeName, "Holder", holder)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't update lease %q: %w", name, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestEnsureNodeMaintenanceLease(t *testing.T) {
+	t.Parallel()
+
+	newLease := func(holder string, renewTime time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "scylla-operator",
+				Name:      "node-maintenance-node-1",
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       pointer.Ptr(holder),
+				LeaseDurationSeconds: pointer.Ptr[int32](nodeMaintenanceLeaseDurationSeconds),
+				AcquireTime:          pointer.Ptr(metav1.NewMicroTime(renewTime)),
+				RenewTime:            pointer.Ptr(metav1.NewMicroTime(renewTime)),
+			},
+		}
+	}
+
+	tt := []struct {
+		name           string
+		existing       []runtime.Object
+		reactor        clienttesting.ReactionFunc
+		holder         string
+		expected       bool
+		expectedHolder string
+	}{
+		{
+			name:           "missing lease is acquired",
+			existing:       nil,
+			holder:         "a",
+			expected:       true,
+			expectedHolder: "a",
+		},
+		{
+			name:           "lease held by us is renewed",
+			existing:       []runtime.Object{newLease("a", time.Now())},
+			holder:         "a",
+			expected:       true,
+			expectedHolder: "a",
+		},
+		{
+			name:           "lease held by someone else isn't acquired",
+			existing:       []runtime.Object{newLease("a", time.Now())},
+			holder:         "b",
+			expected:       false,
+			expectedHolder: "a",
+		},
+		{
+			name:           "expired lease held by someone else is taken over",
+			existing:       []runtime.Object{newLease("a", time.Now().Add(-time.Hour))},
+			holder:         "b",
+			expected:       true,
+			expectedHolder: "b",
+		},
+		{
+			name:     "losing a create race doesn't acquire the lease",
+			existing: nil,
+			reactor: func(action clienttesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewAlreadyExists(coordinationv1.Resource("leases"), "node-maintenance-node-1")
+			},
+			holder:         "b",
+			expected:       false,
+			expectedHolder: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existing...)
+			if tc.reactor != nil {
+				client.PrependReactor("create", "leases", tc.reactor)
+			}
+			leaseClient := client.CoordinationV1().Leases("scylla-operator")
+
+			got, err := EnsureNodeMaintenanceLease(ctx, leaseClient, "node-1", tc.holder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected acquired %t, got %t", tc.expected, got)
+			}
+
+			lease, err := leaseClient.Get(ctx, "node-maintenance-node-1", metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				if len(tc.expectedHolder) != 0 {
+					t.Errorf("expected lease held by %q, got none", tc.expectedHolder)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *lease.Spec.HolderIdentity != tc.expectedHolder {
+				t.Errorf("expected holder %q, got %q", tc.expectedHolder, *lease.Spec.HolderIdentity)
+			}
+		})
+	}
+}
+
+func TestEnsureNodeMaintenanceLeaseContention(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	client := fake.NewSimpleClientset()
+	leaseClient := client.CoordinationV1().Leases("scylla-operator")
+
+	acquired, err := EnsureNodeMaintenanceLease(ctx, leaseClient, "node-1", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acquired {
+		t.Fatal("expected the first holder to acquire the lease")
+	}
+
+	acquired, err = EnsureNodeMaintenanceLease(ctx, leaseClient, "node-1", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acquired {
+		t.Error("expected the second holder to fail acquiring a held lease")
+	}
+
+	acquired, err = EnsureNodeMaintenanceLease(ctx, leaseClient, "node-2", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acquired {
+		t.Error("expected leases for different nodes to be independent")
+	}
+}
@@ -2,8 +2,16 @@ package resourceapply
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -29,12 +37,7 @@ func ApplyConfigMap(
 ) (*corev1.ConfigMap, bool, error) {
 	return ApplyConfigMapWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.ConfigMap]{
-			GetCachedFunc: lister.ConfigMaps(required.Namespace).Get,
-			CreateFunc:    client.ConfigMaps(required.Namespace).Create,
-			UpdateFunc:    client.ConfigMaps(required.Namespace).Update,
-			DeleteFunc:    client.ConfigMaps(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.ConfigMap](lister.ConfigMaps(required.Namespace), client.ConfigMaps(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -61,12 +64,7 @@ func ApplySecret(
 ) (*corev1.Secret, bool, error) {
 	return ApplySecretWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.Secret]{
-			GetCachedFunc: lister.Secrets(required.Namespace).Get,
-			CreateFunc:    client.Secrets(required.Namespace).Create,
-			UpdateFunc:    client.Secrets(required.Namespace).Update,
-			DeleteFunc:    client.Secrets(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.Secret](lister.Secrets(required.Namespace), client.Secrets(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -80,6 +78,24 @@ func ApplyServiceWithControl(
 	required *corev1.Service,
 	options ApplyOptions,
 ) (*corev1.Service, bool, error) {
+	return applyServiceWithControl(ctx, control, recorder, required, options, nil)
+}
+
+func applyServiceWithControl(
+	ctx context.Context,
+	control ApplyControlInterface[*corev1.Service],
+	recorder record.EventRecorder,
+	required *corev1.Service,
+	options ApplyOptions,
+	transform func(required *corev1.Service, existing *corev1.Service),
+) (*corev1.Service, bool, error) {
+	if options.CanonicalizeServicePorts {
+		required = required.DeepCopy()
+		slices.SortStableFunc(required.Spec.Ports, func(a, b corev1.ServicePort) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
 	return ApplyGenericWithHandlers[*corev1.Service](
 		ctx,
 		control,
@@ -87,13 +103,53 @@ func ApplyServiceWithControl(
 		required,
 		options,
 		func(required **corev1.Service, existing *corev1.Service) {
-			(*required).Spec.ClusterIP = existing.Spec.ClusterIP
-			(*required).Spec.ClusterIPs = existing.Spec.ClusterIPs
+			// The recreated Service gets new ClusterIPs from the new families.
+			if !options.RecreateOnImmutable || !serviceIPFamiliesDiffer(*required, existing) {
+				(*required).Spec.ClusterIP = existing.Spec.ClusterIP
+				(*required).Spec.ClusterIPs = existing.Spec.ClusterIPs
+			}
+
+			// Traffic policies left unset are defaulted by the apiserver or set by an admin, keep them.
+			// The external one is only valid for some types, so it's kept only when the type doesn't change.
+			if len((*required).Spec.ExternalTrafficPolicy) == 0 && (*required).Spec.Type == existing.Spec.Type {
+				(*required).Spec.ExternalTrafficPolicy = existing.Spec.ExternalTrafficPolicy
+			}
+			if (*required).Spec.InternalTrafficPolicy == nil {
+				(*required).Spec.InternalTrafficPolicy = existing.Spec.InternalTrafficPolicy
+			}
+
+			if transform != nil {
+				transform(*required, existing)
+			}
+		},
+		func(required *corev1.Service, existing *corev1.Service) (string, *metav1.DeletionPropagation, error) {
+			if !options.RecreateOnImmutable || !serviceIPFamiliesDiffer(required, existing) {
+				return "", nil, nil
+			}
+
+			if len(existing.Spec.ClusterIP) == 0 || existing.Spec.ClusterIP == corev1.ClusterIPNone {
+				return "spec.ipFamilyPolicy and spec.ipFamilies changed", nil, nil
+			}
+
+			return fmt.Sprintf("spec.ipFamilyPolicy and spec.ipFamilies changed, its ClusterIP %q is released", existing.Spec.ClusterIP), nil, nil
 		},
-		nil,
 	)
 }
 
+// serviceIPFamiliesDiffer reports whether the required Service sets IP family fields that differ from the existing ones.
+// Fields left empty in the required Service are defaulted by the server, so they are not compared.
+func serviceIPFamiliesDiffer(required *corev1.Service, existing *corev1.Service) bool {
+	if required.Spec.IPFamilyPolicy != nil && !equality.Semantic.DeepEqual(required.Spec.IPFamilyPolicy, existing.Spec.IPFamilyPolicy) {
+		return true
+	}
+
+	if len(required.Spec.IPFamilies) != 0 && !equality.Semantic.DeepEqual(required.Spec.IPFamilies, existing.Spec.IPFamilies) {
+		return true
+	}
+
+	return false
+}
+
 func ApplyService(
 	ctx context.Context,
 	client corev1client.ServicesGetter,
@@ -104,15 +160,32 @@ func ApplyService(
 ) (*corev1.Service, bool, error) {
 	return ApplyServiceWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.Service]{
-			GetCachedFunc: lister.Services(required.Namespace).Get,
-			CreateFunc:    client.Services(required.Namespace).Create,
-			UpdateFunc:    client.Services(required.Namespace).Update,
-			DeleteFunc:    client.Services(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.Service](lister.Services(required.Namespace), client.Services(required.Namespace)),
+		recorder,
+		required,
+		options,
+	)
+}
+
+// ApplyServiceWithTransform is like ApplyService but calls transform on the object to be updated,
+// after the ClusterIPs were carried forward, so callers can preserve fields the apply doesn't know about.
+// The transform isn't part of the hash, so fields it sets don't trigger updates on their own.
+func ApplyServiceWithTransform(
+	ctx context.Context,
+	client corev1client.ServicesGetter,
+	lister corev1listers.ServiceLister,
+	recorder record.EventRecorder,
+	required *corev1.Service,
+	options ApplyOptions,
+	transform func(required *corev1.Service, existing *corev1.Service),
+) (*corev1.Service, bool, error) {
+	return applyServiceWithControl(
+		ctx,
+		NewApplyControlFuncs[*corev1.Service](lister.Services(required.Namespace), client.Services(required.Namespace)),
 		recorder,
 		required,
 		options,
+		transform,
 	)
 }
 
@@ -136,12 +209,7 @@ func ApplyServiceAccount(
 ) (*corev1.ServiceAccount, bool, error) {
 	return ApplyServiceAccountWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.ServiceAccount]{
-			GetCachedFunc: lister.ServiceAccounts(required.Namespace).Get,
-			CreateFunc:    client.ServiceAccounts(required.Namespace).Create,
-			UpdateFunc:    client.ServiceAccounts(required.Namespace).Update,
-			DeleteFunc:    client.ServiceAccounts(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.ServiceAccount](lister.ServiceAccounts(required.Namespace), client.ServiceAccounts(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -168,12 +236,7 @@ func ApplyNamespace(
 ) (*corev1.Namespace, bool, error) {
 	return ApplyNamespaceWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.Namespace]{
-			GetCachedFunc: lister.Get,
-			CreateFunc:    client.Namespaces().Create,
-			UpdateFunc:    client.Namespaces().Update,
-			DeleteFunc:    client.Namespaces().Delete,
-		},
+		NewApplyControlFuncs[*corev1.Namespace](lister, client.Namespaces()),
 		recorder,
 		required,
 		options,
@@ -200,12 +263,7 @@ func ApplyEndpoints(
 ) (*corev1.Endpoints, bool, error) {
 	return ApplyEndpointsWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.Endpoints]{
-			GetCachedFunc: lister.Endpoints(required.Namespace).Get,
-			CreateFunc:    client.Endpoints(required.Namespace).Create,
-			UpdateFunc:    client.Endpoints(required.Namespace).Update,
-			DeleteFunc:    client.Endpoints(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.Endpoints](lister.Endpoints(required.Namespace), client.Endpoints(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -219,9 +277,86 @@ func ApplyPodWithControl(
 	required *corev1.Pod,
 	options ApplyOptions,
 ) (*corev1.Pod, bool, error) {
+	if options.InjectDefaultPodSpread {
+		clusterName, ok := required.Labels[naming.ClusterNameLabel]
+		if ok {
+			required = required.DeepCopy()
+			injectDefaultPodSpread(&required.Spec, map[string]string{
+				naming.ClusterNameLabel: clusterName,
+			})
+		}
+	}
+
+	if options.InjectDefaultPodSecurityContext {
+		required = required.DeepCopy()
+		injectDefaultPodSecurityContext(&required.Spec)
+	}
+
 	return ApplyGeneric[*corev1.Pod](ctx, control, recorder, required, options)
 }
 
+// injectDefaultPodSecurityContext sets runAsNonRoot and the data dir fsGroup on the pod securityContext
+// when they aren't set already, which keeps the injection idempotent.
+// runAsNonRoot isn't injected for Pods explicitly running as root, as they couldn't start with it.
+func injectDefaultPodSecurityContext(podSpec *corev1.PodSpec) {
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	sc := podSpec.SecurityContext
+
+	if sc.RunAsNonRoot == nil && (sc.RunAsUser == nil || *sc.RunAsUser != 0) {
+		sc.RunAsNonRoot = pointer.Ptr(true)
+	}
+
+	if sc.FSGroup == nil {
+		sc.FSGroup = pointer.Ptr[int64](naming.DataDirFSGroup)
+	}
+}
+
+// injectDefaultPodSpread spreads the Pods matching selectorLabels across zones and nodes.
+// Constraints and anti-affinity terms already present for the same topology key take precedence,
+// which keeps the injection idempotent.
+func injectDefaultPodSpread(podSpec *corev1.PodSpec, selectorLabels map[string]string) {
+	hasZoneSpread := slices.ContainsFunc(podSpec.TopologySpreadConstraints, func(tsc corev1.TopologySpreadConstraint) bool {
+		return tsc.TopologyKey == corev1.LabelTopologyZone
+	})
+	if !hasZoneSpread {
+		podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: maps.Clone(selectorLabels),
+			},
+		})
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.PodAntiAffinity == nil {
+		podSpec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	antiAffinity := podSpec.Affinity.PodAntiAffinity
+
+	hasHostnameAntiAffinity := slices.ContainsFunc(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, func(term corev1.PodAffinityTerm) bool {
+		return term.TopologyKey == corev1.LabelHostname
+	}) || slices.ContainsFunc(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, func(term corev1.WeightedPodAffinityTerm) bool {
+		return term.PodAffinityTerm.TopologyKey == corev1.LabelHostname
+	})
+	if !hasHostnameAntiAffinity {
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				TopologyKey: corev1.LabelHostname,
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: maps.Clone(selectorLabels),
+				},
+			},
+		})
+	}
+}
+
 func ApplyPod(
 	ctx context.Context,
 	client corev1client.PodsGetter,
@@ -232,18 +367,16 @@ func ApplyPod(
 ) (*corev1.Pod, bool, error) {
 	return ApplyPodWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.Pod]{
-			GetCachedFunc: lister.Pods(required.Namespace).Get,
-			CreateFunc:    client.Pods(required.Namespace).Create,
-			UpdateFunc:    client.Pods(required.Namespace).Update,
-			DeleteFunc:    client.Pods(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.Pod](lister.Pods(required.Namespace), client.Pods(required.Namespace)),
 		recorder,
 		required,
 		options,
 	)
 }
 
+// ApplyPersistentVolumeClaimWithControl applies a PersistentVolumeClaim. The fields set when the claim is bound
+// or defaulted are immutable, so they are carried over from the existing claim unless the required one sets them.
+// Claims can only be expanded, a required storage request smaller than the existing one keeps the existing request.
 func ApplyPersistentVolumeClaimWithControl(
 	ctx context.Context,
 	control ApplyControlInterface[*corev1.PersistentVolumeClaim],
@@ -251,7 +384,36 @@ func ApplyPersistentVolumeClaimWithControl(
 	required *corev1.PersistentVolumeClaim,
 	options ApplyOptions,
 ) (*corev1.PersistentVolumeClaim, bool, error) {
-	return ApplyGeneric[*corev1.PersistentVolumeClaim](ctx, control, recorder, required, options)
+	return ApplyGenericWithHandlers[*corev1.PersistentVolumeClaim](
+		ctx,
+		control,
+		recorder,
+		required,
+		options,
+		func(required **corev1.PersistentVolumeClaim, existing *corev1.PersistentVolumeClaim) {
+			if len((*required).Spec.VolumeName) == 0 {
+				(*required).Spec.VolumeName = existing.Spec.VolumeName
+			}
+
+			if (*required).Spec.StorageClassName == nil {
+				(*required).Spec.StorageClassName = existing.Spec.StorageClassName
+			}
+
+			if (*required).Spec.VolumeMode == nil {
+				(*required).Spec.VolumeMode = existing.Spec.VolumeMode
+			}
+
+			requiredStorage, ok := (*required).Spec.Resources.Requests[corev1.ResourceStorage]
+			if !ok {
+				return
+			}
+			existingStorage, ok := existing.Spec.Resources.Requests[corev1.ResourceStorage]
+			if ok && requiredStorage.Cmp(existingStorage) < 0 {
+				(*required).Spec.Resources.Requests[corev1.ResourceStorage] = existingStorage
+			}
+		},
+		nil,
+	)
 }
 
 func ApplyPersistentVolumeClaim(
@@ -264,12 +426,7 @@ func ApplyPersistentVolumeClaim(
 ) (*corev1.PersistentVolumeClaim, bool, error) {
 	return ApplyPersistentVolumeClaimWithControl(
 		ctx,
-		ApplyControlFuncs[*corev1.PersistentVolumeClaim]{
-			GetCachedFunc: lister.PersistentVolumeClaims(required.Namespace).Get,
-			CreateFunc:    client.PersistentVolumeClaims(required.Namespace).Create,
-			UpdateFunc:    client.PersistentVolumeClaims(required.Namespace).Update,
-			DeleteFunc:    client.PersistentVolumeClaims(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*corev1.PersistentVolumeClaim](lister.PersistentVolumeClaims(required.Namespace), client.PersistentVolumeClaims(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -2,8 +2,10 @@ package resourceapply
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 )
@@ -227,8 +230,8 @@ func TestApplyService(t *testing.T) {
 			}(),
 			expectedService: nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Service "default/test": %w`, apierrors.NewNotFound(corev1.Resource("services"), "test")),
-			expectedEvents:  []string{`Warning UpdateServiceFailed Failed to update Service default/test: services "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Service "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("services"), "test")}),
+			expectedEvents:  []string{`Warning UpdateServiceFailed Failed to update Service default/test: services "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -566,6 +569,485 @@ func TestApplyService(t *testing.T) {
 	}
 }
 
+func TestApplyServiceCanonicalizePorts(t *testing.T) {
+	t.Parallel()
+
+	newService := func(portNames ...string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+		}
+		for i, name := range portNames {
+			svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+				Name: name,
+				Port: int32(9000 + i),
+			})
+		}
+		return svc
+	}
+
+	reversed := func(svc *corev1.Service) *corev1.Service {
+		slices.Reverse(svc.Spec.Ports)
+		return svc
+	}
+
+	tt := []struct {
+		name              string
+		existing          *corev1.Service
+		required          *corev1.Service
+		canonicalize      bool
+		expectedChanged   bool
+		expectedPortNames []string
+	}{
+		{
+			name:              "reordered ports cause an update by default",
+			existing:          newService("a", "b"),
+			required:          reversed(newService("a", "b")),
+			canonicalize:      false,
+			expectedChanged:   true,
+			expectedPortNames: []string{"b", "a"},
+		},
+		{
+			name:              "reordered ports are a no-op when canonicalized",
+			existing:          newService("a", "b"),
+			required:          reversed(newService("a", "b")),
+			canonicalize:      true,
+			expectedChanged:   false,
+			expectedPortNames: []string{"a", "b"},
+		},
+		{
+			name:              "added port is applied when canonicalized",
+			existing:          newService("a", "b"),
+			required:          reversed(newService("a", "b", "c")),
+			canonicalize:      true,
+			expectedChanged:   true,
+			expectedPortNames: []string{"a", "b", "c"},
+		},
+		{
+			name:              "removed port is applied when canonicalized",
+			existing:          newService("a", "b"),
+			required:          newService("b"),
+			canonicalize:      true,
+			expectedChanged:   true,
+			expectedPortNames: []string{"b"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			existing := tc.existing.DeepCopy()
+			apimachineryutilruntime.Must(SetHashAnnotation(existing))
+
+			client := fake.NewSimpleClientset(existing)
+
+			serviceCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := serviceCache.Add(existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			required := tc.required.DeepCopy()
+			got, gotChanged, err := ApplyService(ctx, client.CoreV1(), corev1listers.NewServiceLister(serviceCache), record.NewFakeRecorder(10), required, ApplyOptions{
+				CanonicalizeServicePorts: tc.canonicalize,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected changed %t, got %t", tc.expectedChanged, gotChanged)
+			}
+
+			var gotPortNames []string
+			for _, port := range got.Spec.Ports {
+				gotPortNames = append(gotPortNames, port.Name)
+			}
+			if !reflect.DeepEqual(gotPortNames, tc.expectedPortNames) {
+				t.Errorf("expected and got port names differ:\n%s", cmp.Diff(tc.expectedPortNames, gotPortNames))
+			}
+
+			if !equality.Semantic.DeepEqual(required, tc.required) {
+				t.Errorf("required object was mutated:\n%s", cmp.Diff(tc.required, required))
+			}
+		})
+	}
+}
+
+func TestApplyServiceRecreateOnImmutable(t *testing.T) {
+	t.Parallel()
+
+	newService := func(ipFamilyPolicy corev1.IPFamilyPolicy, ipFamilies ...corev1.IPFamily) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				IPFamilyPolicy: pointer.Ptr(ipFamilyPolicy),
+				IPFamilies:     ipFamilies,
+			},
+		}
+	}
+
+	newExistingService := func() *corev1.Service {
+		svc := newService(corev1.IPFamilyPolicySingleStack, corev1.IPv4Protocol)
+		apimachineryutilruntime.Must(SetHashAnnotation(svc))
+		svc.Spec.ClusterIP = "10.0.0.1"
+		svc.Spec.ClusterIPs = []string{"10.0.0.1"}
+		return svc
+	}
+
+	tt := []struct {
+		name                string
+		required            *corev1.Service
+		recreateOnImmutable bool
+		deleteErr           error
+		expectedClusterIP   string
+		expectedEvents      []string
+	}{
+		{
+			name:                "updates the service in place by default",
+			required:            newService(corev1.IPFamilyPolicyPreferDualStack, corev1.IPv4Protocol, corev1.IPv6Protocol),
+			recreateOnImmutable: false,
+			expectedClusterIP:   "10.0.0.1",
+			expectedEvents:      []string{"Normal ServiceUpdated Service default/test updated"},
+		},
+		{
+			name:                "recreates the service when switching from SingleStack to PreferDualStack",
+			required:            newService(corev1.IPFamilyPolicyPreferDualStack, corev1.IPv4Protocol, corev1.IPv6Protocol),
+			recreateOnImmutable: true,
+			expectedClusterIP:   "",
+			expectedEvents: []string{
+				"Normal ServiceDeleted Service default/test deleted",
+				`Warning ServiceRecreating Recreating Service default/test because spec.ipFamilyPolicy and spec.ipFamilies changed, its ClusterIP "10.0.0.1" is released`,
+				"Normal ServiceCreated Service default/test created",
+			},
+		},
+		{
+			name:                "doesn't report the recreation when the delete fails",
+			required:            newService(corev1.IPFamilyPolicyPreferDualStack, corev1.IPv4Protocol, corev1.IPv6Protocol),
+			recreateOnImmutable: true,
+			deleteErr:           apierrors.NewInternalError(errors.New("boom")),
+			expectedEvents: []string{
+				"Warning DeleteServiceFailed Failed to delete Service default/test: Internal error occurred: boom",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			existing := newExistingService()
+			client := fake.NewSimpleClientset(existing)
+			if tc.deleteErr != nil {
+				client.PrependReactor("delete", "services", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tc.deleteErr
+				})
+			}
+
+			serviceCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := serviceCache.Add(existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			got, gotChanged, err := ApplyService(ctx, client.CoreV1(), corev1listers.NewServiceLister(serviceCache), recorder, tc.required, ApplyOptions{
+				RecreateOnImmutable: tc.recreateOnImmutable,
+			})
+			if tc.deleteErr != nil {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !gotChanged {
+					t.Errorf("expected the service to be changed")
+				}
+
+				if !equality.Semantic.DeepEqual(got.Spec.IPFamilyPolicy, tc.required.Spec.IPFamilyPolicy) {
+					t.Errorf("expected ipFamilyPolicy %v, got %v", *tc.required.Spec.IPFamilyPolicy, got.Spec.IPFamilyPolicy)
+				}
+
+				if got.Spec.ClusterIP != tc.expectedClusterIP {
+					t.Errorf("expected clusterIP %q, got %q", tc.expectedClusterIP, got.Spec.ClusterIP)
+				}
+			}
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+		})
+	}
+}
+
+func TestApplyServiceWithTransform(t *testing.T) {
+	t.Parallel()
+
+	newService := func(selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeLoadBalancer,
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+				Selector:              selector,
+			},
+		}
+	}
+
+	newExistingService := func() *corev1.Service {
+		svc := newService(map[string]string{"app": "old"})
+		apimachineryutilruntime.Must(SetHashAnnotation(svc))
+		svc.Spec.ClusterIP = "10.0.0.1"
+		svc.Spec.ClusterIPs = []string{"10.0.0.1"}
+		svc.Spec.HealthCheckNodePort = 30000
+		return svc
+	}
+
+	preserveHealthCheckNodePort := func(required *corev1.Service, existing *corev1.Service) {
+		required.Spec.HealthCheckNodePort = existing.Spec.HealthCheckNodePort
+	}
+
+	tt := []struct {
+		name                        string
+		transform                   func(required *corev1.Service, existing *corev1.Service)
+		expectedHealthCheckNodePort int32
+	}{
+		{
+			name:                        "drops fields unknown to the apply without a transform",
+			transform:                   nil,
+			expectedHealthCheckNodePort: 0,
+		},
+		{
+			name:                        "preserves fields carried forward by the transform",
+			transform:                   preserveHealthCheckNodePort,
+			expectedHealthCheckNodePort: 30000,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			existing := newExistingService()
+			client := fake.NewSimpleClientset(existing)
+
+			serviceCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := serviceCache.Add(existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			required := newService(map[string]string{"app": "new"})
+			got, gotChanged, err := ApplyServiceWithTransform(ctx, client.CoreV1(), corev1listers.NewServiceLister(serviceCache), record.NewFakeRecorder(10), required, ApplyOptions{}, tc.transform)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !gotChanged {
+				t.Errorf("expected the service to be changed")
+			}
+
+			if !reflect.DeepEqual(got.Spec.Selector, required.Spec.Selector) {
+				t.Errorf("expected selector %v, got %v", required.Spec.Selector, got.Spec.Selector)
+			}
+
+			// The transform runs after the ClusterIPs were carried forward.
+			if got.Spec.ClusterIP != existing.Spec.ClusterIP {
+				t.Errorf("expected clusterIP %q, got %q", existing.Spec.ClusterIP, got.Spec.ClusterIP)
+			}
+
+			if got.Spec.HealthCheckNodePort != tc.expectedHealthCheckNodePort {
+				t.Errorf("expected healthCheckNodePort %d, got %d", tc.expectedHealthCheckNodePort, got.Spec.HealthCheckNodePort)
+			}
+
+			if required.Spec.HealthCheckNodePort != 0 {
+				t.Errorf("expected the required object not to be mutated")
+			}
+		})
+	}
+}
+
+func TestApplyServiceTrafficPolicies(t *testing.T) {
+	t.Parallel()
+
+	newService := func(serviceType corev1.ServiceType, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:     serviceType,
+				Selector: selector,
+			},
+		}
+	}
+
+	// The existing service has the traffic policies set by an admin after it was created.
+	newExistingService := func() *corev1.Service {
+		svc := newService(corev1.ServiceTypeLoadBalancer, map[string]string{"app": "old"})
+		apimachineryutilruntime.Must(SetHashAnnotation(svc))
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyLocal
+		svc.Spec.InternalTrafficPolicy = pointer.Ptr(corev1.ServiceInternalTrafficPolicyLocal)
+		return svc
+	}
+
+	tt := []struct {
+		name                          string
+		required                      *corev1.Service
+		expectedChanged               bool
+		expectedExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy
+		expectedInternalTrafficPolicy *corev1.ServiceInternalTrafficPolicy
+	}{
+		{
+			name:                          "keeps the traffic policies when required leaves them unset",
+			required:                      newService(corev1.ServiceTypeLoadBalancer, map[string]string{"app": "new"}),
+			expectedChanged:               true,
+			expectedExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			expectedInternalTrafficPolicy: pointer.Ptr(corev1.ServiceInternalTrafficPolicyLocal),
+		},
+		{
+			name:                          "doesn't update the service when only the unset traffic policies differ",
+			required:                      newService(corev1.ServiceTypeLoadBalancer, map[string]string{"app": "old"}),
+			expectedChanged:               false,
+			expectedExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			expectedInternalTrafficPolicy: pointer.Ptr(corev1.ServiceInternalTrafficPolicyLocal),
+		},
+		{
+			name: "sets the traffic policies when required specifies them",
+			required: func() *corev1.Service {
+				svc := newService(corev1.ServiceTypeLoadBalancer, map[string]string{"app": "old"})
+				svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+				svc.Spec.InternalTrafficPolicy = pointer.Ptr(corev1.ServiceInternalTrafficPolicyCluster)
+				return svc
+			}(),
+			expectedChanged:               true,
+			expectedExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyCluster,
+			expectedInternalTrafficPolicy: pointer.Ptr(corev1.ServiceInternalTrafficPolicyCluster),
+		},
+		{
+			name:                          "drops the external traffic policy when the type changes",
+			required:                      newService(corev1.ServiceTypeClusterIP, map[string]string{"app": "old"}),
+			expectedChanged:               true,
+			expectedExternalTrafficPolicy: "",
+			expectedInternalTrafficPolicy: pointer.Ptr(corev1.ServiceInternalTrafficPolicyLocal),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			existing := newExistingService()
+			client := fake.NewSimpleClientset(existing)
+
+			serviceCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := serviceCache.Add(existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, gotChanged, err := ApplyService(ctx, client.CoreV1(), corev1listers.NewServiceLister(serviceCache), record.NewFakeRecorder(10), tc.required, ApplyOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected changed %t, got %t", tc.expectedChanged, gotChanged)
+			}
+
+			if got.Spec.ExternalTrafficPolicy != tc.expectedExternalTrafficPolicy {
+				t.Errorf("expected externalTrafficPolicy %q, got %q", tc.expectedExternalTrafficPolicy, got.Spec.ExternalTrafficPolicy)
+			}
+
+			if !equality.Semantic.DeepEqual(got.Spec.InternalTrafficPolicy, tc.expectedInternalTrafficPolicy) {
+				t.Errorf("expected and got internalTrafficPolicy differ:\n%s", cmp.Diff(tc.expectedInternalTrafficPolicy, got.Spec.InternalTrafficPolicy))
+			}
+
+			live, err := client.CoreV1().Services(existing.Namespace).Get(ctx, existing.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(live, got) {
+				t.Errorf("live and returned services differ:\n%s", cmp.Diff(live, got))
+			}
+		})
+	}
+}
+
 func TestApplySecret(t *testing.T) {
 	// Using a generating function prevents unwanted mutations.
 	newSecret := func() *corev1.Secret {
@@ -762,8 +1244,8 @@ func TestApplySecret(t *testing.T) {
 			}(),
 			expectedSecret:  nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Secret "default/test": %w`, apierrors.NewNotFound(corev1.Resource("secrets"), "test")),
-			expectedEvents:  []string{`Warning UpdateSecretFailed Failed to update Secret default/test: secrets "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Secret "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("secrets"), "test")}),
+			expectedEvents:  []string{`Warning UpdateSecretFailed Failed to update Secret default/test: secrets "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -1313,8 +1795,8 @@ func TestApplyServiceAccount(t *testing.T) {
 			}(),
 			expectedSA:      nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update /v1, Kind=ServiceAccount "default/test": %w`, apierrors.NewNotFound(corev1.Resource("serviceaccounts"), "test")),
-			expectedEvents:  []string{`Warning UpdateServiceAccountFailed Failed to update ServiceAccount default/test: serviceaccounts "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update /v1, Kind=ServiceAccount "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("serviceaccounts"), "test")}),
+			expectedEvents:  []string{`Warning UpdateServiceAccountFailed Failed to update ServiceAccount default/test: serviceaccounts "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has ownerRef and required hasn't",
@@ -1843,8 +2325,8 @@ func TestApplyConfigMap(t *testing.T) {
 			}(),
 			expectedCM:      nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update /v1, Kind=ConfigMap "default/test": %w`, apierrors.NewNotFound(corev1.Resource("configmaps"), "test")),
-			expectedEvents:  []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: configmaps "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update /v1, Kind=ConfigMap "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("configmaps"), "test")}),
+			expectedEvents:  []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: configmaps "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -2327,8 +2809,8 @@ func TestApplyNamespace(t *testing.T) {
 			}(),
 			expectedNS:      nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Namespace "test": %w`, apierrors.NewNotFound(corev1.Resource("namespaces"), "test")),
-			expectedEvents:  []string{`Warning UpdateNamespaceFailed Failed to update Namespace test: namespaces "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Namespace "test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("namespaces"), "test")}),
+			expectedEvents:  []string{`Warning UpdateNamespaceFailed Failed to update Namespace test: namespaces "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has ownerRef and required hasn't",
@@ -2802,8 +3284,8 @@ func TestApplyEndpoints(t *testing.T) {
 			}(),
 			expectedEndpoints: nil,
 			expectedChanged:   false,
-			expectedErr:       fmt.Errorf(`can't update /v1, Kind=Endpoints "default/test": %w`, apierrors.NewNotFound(corev1.Resource("endpoints"), "test")),
-			expectedEvents:    []string{`Warning UpdateEndpointsFailed Failed to update Endpoints default/test: endpoints "test" not found`},
+			expectedErr:       fmt.Errorf(`can't update /v1, Kind=Endpoints "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("endpoints"), "test")}),
+			expectedEvents:    []string{`Warning UpdateEndpointsFailed Failed to update Endpoints default/test: endpoints "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -3277,8 +3759,8 @@ func TestApplyPod(t *testing.T) {
 			}(),
 			expectedPod:     nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Pod "default/test": %w`, apierrors.NewNotFound(corev1.Resource("pods"), "test")),
-			expectedEvents:  []string{`Warning UpdatePodFailed Failed to update Pod default/test: pods "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update /v1, Kind=Pod "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("pods"), "test")}),
+			expectedEvents:  []string{`Warning UpdatePodFailed Failed to update Pod default/test: pods "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -3616,6 +4098,417 @@ func TestApplyPod(t *testing.T) {
 	}
 }
 
+func TestInjectDefaultPodSpread(t *testing.T) {
+	t.Parallel()
+
+	selectorLabels := map[string]string{
+		"scylla/cluster": "basic",
+	}
+
+	newZoneSpread := func() corev1.TopologySpreadConstraint {
+		return corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"scylla/cluster": "basic",
+				},
+			},
+		}
+	}
+
+	newHostnameAntiAffinity := func() corev1.WeightedPodAffinityTerm {
+		return corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				TopologyKey: "kubernetes.io/hostname",
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"scylla/cluster": "basic",
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		podSpec  *corev1.PodSpec
+		expected *corev1.PodSpec
+	}{
+		{
+			name:    "injects spread into an empty spec",
+			podSpec: &corev1.PodSpec{},
+			expected: &corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					newZoneSpread(),
+				},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							newHostnameAntiAffinity(),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "keeps user provided constraints for the same topology keys",
+			podSpec: &corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           2,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+							{
+								TopologyKey: "kubernetes.io/hostname",
+							},
+						},
+					},
+				},
+			},
+			expected: &corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           2,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+							{
+								TopologyKey: "kubernetes.io/hostname",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "adds missing constraints next to unrelated ones",
+			podSpec: &corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "example.com/rack",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{},
+				},
+			},
+			expected: &corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "example.com/rack",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+					},
+					newZoneSpread(),
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{},
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							newHostnameAntiAffinity(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The injection has to be idempotent, so running it again mustn't make any changes.
+			for i := range 2 {
+				injectDefaultPodSpread(tc.podSpec, selectorLabels)
+				if !equality.Semantic.DeepEqual(tc.podSpec, tc.expected) {
+					t.Errorf("iteration %d: expected and got pod specs differ:\n%s", i, cmp.Diff(tc.expected, tc.podSpec))
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPodInjectDefaultPodSpread(t *testing.T) {
+	t.Parallel()
+
+	newPod := func(podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    podLabels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name             string
+		required         *corev1.Pod
+		expectedInjected bool
+	}{
+		{
+			name:             "injects spread into pods of a cluster",
+			required:         newPod(map[string]string{"scylla/cluster": "basic"}),
+			expectedInjected: true,
+		},
+		{
+			name:             "leaves pods without a cluster label alone",
+			required:         newPod(map[string]string{}),
+			expectedInjected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset()
+
+			// Reconciliation needs to be stable, so applying the second time must not make any changes.
+			for i := range 2 {
+				podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+				podList, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range podList.Items {
+					err = podCache.Add(&podList.Items[i])
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				required := tc.required.DeepCopy()
+				got, gotChanged, err := ApplyPod(ctx, client.CoreV1(), corev1listers.NewPodLister(podCache), record.NewFakeRecorder(10), required, ApplyOptions{
+					InjectDefaultPodSpread: true,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expectedChanged := i == 0
+				if gotChanged != expectedChanged {
+					t.Errorf("iteration %d: expected changed %t, got %t", i, expectedChanged, gotChanged)
+				}
+
+				gotInjected := len(got.Spec.TopologySpreadConstraints) != 0 && got.Spec.Affinity != nil
+				if gotInjected != tc.expectedInjected {
+					t.Errorf("iteration %d: expected injected %t, got %t", i, tc.expectedInjected, gotInjected)
+				}
+
+				if !equality.Semantic.DeepEqual(required, tc.required) {
+					t.Errorf("iteration %d: required object was mutated:\n%s", i, cmp.Diff(tc.required, required))
+				}
+			}
+		})
+	}
+}
+
+func TestInjectDefaultPodSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		podSpec  *corev1.PodSpec
+		expected *corev1.PodSpec
+	}{
+		{
+			name:    "injects the baseline securityContext into an empty spec",
+			podSpec: &corev1.PodSpec{},
+			expected: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: pointer.Ptr(true),
+					FSGroup:      pointer.Ptr[int64](999),
+				},
+			},
+		},
+		{
+			name: "keeps user provided overrides",
+			podSpec: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: pointer.Ptr(false),
+					FSGroup:      pointer.Ptr[int64](2000),
+				},
+			},
+			expected: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: pointer.Ptr(false),
+					FSGroup:      pointer.Ptr[int64](2000),
+				},
+			},
+		},
+		{
+			name: "fills in missing fields next to the user provided ones",
+			podSpec: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsUser: pointer.Ptr[int64](1000),
+				},
+			},
+			expected: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsUser:    pointer.Ptr[int64](1000),
+					RunAsNonRoot: pointer.Ptr(true),
+					FSGroup:      pointer.Ptr[int64](999),
+				},
+			},
+		},
+		{
+			name: "doesn't require non-root for pods explicitly running as root",
+			podSpec: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsUser: pointer.Ptr[int64](0),
+				},
+			},
+			expected: &corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsUser: pointer.Ptr[int64](0),
+					FSGroup:   pointer.Ptr[int64](999),
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The injection has to be idempotent, so running it again mustn't make any changes.
+			for i := range 2 {
+				injectDefaultPodSecurityContext(tc.podSpec)
+				if !equality.Semantic.DeepEqual(tc.podSpec, tc.expected) {
+					t.Errorf("iteration %d: expected and got pod specs differ:\n%s", i, cmp.Diff(tc.expected, tc.podSpec))
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPodInjectDefaultPodSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	newPod := func(securityContext *corev1.PodSecurityContext) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: securityContext,
+			},
+		}
+	}
+
+	tt := []struct {
+		name                    string
+		required                *corev1.Pod
+		expectedSecurityContext *corev1.PodSecurityContext
+	}{
+		{
+			name:     "injects the baseline securityContext when absent",
+			required: newPod(nil),
+			expectedSecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.Ptr(true),
+				FSGroup:      pointer.Ptr[int64](999),
+			},
+		},
+		{
+			name: "preserves user provided overrides",
+			required: newPod(&corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.Ptr(false),
+				FSGroup:      pointer.Ptr[int64](2000),
+			}),
+			expectedSecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.Ptr(false),
+				FSGroup:      pointer.Ptr[int64](2000),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset()
+
+			// Reconciliation needs to be stable, so applying the second time must not make any changes.
+			for i := range 2 {
+				podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+				podList, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range podList.Items {
+					err = podCache.Add(&podList.Items[i])
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				required := tc.required.DeepCopy()
+				got, gotChanged, err := ApplyPod(ctx, client.CoreV1(), corev1listers.NewPodLister(podCache), record.NewFakeRecorder(10), required, ApplyOptions{
+					InjectDefaultPodSecurityContext: true,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expectedChanged := i == 0
+				if gotChanged != expectedChanged {
+					t.Errorf("iteration %d: expected changed %t, got %t", i, expectedChanged, gotChanged)
+				}
+
+				if !equality.Semantic.DeepEqual(got.Spec.SecurityContext, tc.expectedSecurityContext) {
+					t.Errorf("iteration %d: expected and got securityContexts differ:\n%s", i, cmp.Diff(tc.expectedSecurityContext, got.Spec.SecurityContext))
+				}
+
+				if !equality.Semantic.DeepEqual(required, tc.required) {
+					t.Errorf("iteration %d: required object was mutated:\n%s", i, cmp.Diff(tc.required, required))
+				}
+			}
+		})
+	}
+}
+
 func TestApplyPersistentVolumeClaim(t *testing.T) {
 	// Using a generating function prevents unwanted mutations.
 	newPersistentVolumeClaim := func() *corev1.PersistentVolumeClaim {
@@ -3764,6 +4657,61 @@ func TestApplyPersistentVolumeClaim(t *testing.T) {
 			expectedErr:     nil,
 			expectedEvents:  []string{"Normal PersistentVolumeClaimUpdated PersistentVolumeClaim default/test updated"},
 		},
+		{
+			name: "expands the pvc and keeps the fields set when it was bound",
+			existing: []runtime.Object{
+				func() *corev1.PersistentVolumeClaim {
+					pvc := newPersistentVolumeClaimWithHash()
+					pvc.Spec.VolumeName = "pv-test"
+					pvc.Spec.StorageClassName = pointer.Ptr("standard")
+					pvc.Spec.VolumeMode = pointer.Ptr(corev1.PersistentVolumeFilesystem)
+					return pvc
+				}(),
+			},
+			required: func() *corev1.PersistentVolumeClaim {
+				pvc := newPersistentVolumeClaim()
+				pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("2Gi")
+				return pvc
+			}(),
+			expectedPersistentVolumeClaim: func() *corev1.PersistentVolumeClaim {
+				pvc := newPersistentVolumeClaim()
+				pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("2Gi")
+				apimachineryutilruntime.Must(SetHashAnnotation(pvc))
+				pvc.Spec.VolumeName = "pv-test"
+				pvc.Spec.StorageClassName = pointer.Ptr("standard")
+				pvc.Spec.VolumeMode = pointer.Ptr(corev1.PersistentVolumeFilesystem)
+				return pvc
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal PersistentVolumeClaimUpdated PersistentVolumeClaim default/test updated"},
+		},
+		{
+			name: "never shrinks the pvc",
+			existing: []runtime.Object{
+				func() *corev1.PersistentVolumeClaim {
+					pvc := newPersistentVolumeClaim()
+					pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("2Gi")
+					apimachineryutilruntime.Must(SetHashAnnotation(pvc))
+					return pvc
+				}(),
+			},
+			required: func() *corev1.PersistentVolumeClaim {
+				pvc := newPersistentVolumeClaim()
+				pvc.Labels["foo"] = "bar"
+				return pvc
+			}(),
+			expectedPersistentVolumeClaim: func() *corev1.PersistentVolumeClaim {
+				pvc := newPersistentVolumeClaim()
+				pvc.Labels["foo"] = "bar"
+				apimachineryutilruntime.Must(SetHashAnnotation(pvc))
+				pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("2Gi")
+				return pvc
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal PersistentVolumeClaimUpdated PersistentVolumeClaim default/test updated"},
+		},
 		{
 			name: "won't update the pvc if an admission changes it",
 			existing: []runtime.Object{
@@ -3825,8 +4773,8 @@ func TestApplyPersistentVolumeClaim(t *testing.T) {
 			}(),
 			expectedPersistentVolumeClaim: nil,
 			expectedChanged:               false,
-			expectedErr:                   fmt.Errorf(`can't update /v1, Kind=PersistentVolumeClaim "default/test": %w`, apierrors.NewNotFound(corev1.Resource("persistentvolumeclaims"), "test")),
-			expectedEvents:                []string{`Warning UpdatePersistentVolumeClaimFailed Failed to update PersistentVolumeClaim default/test: persistentvolumeclaims "test" not found`},
+			expectedErr:                   fmt.Errorf(`can't update /v1, Kind=PersistentVolumeClaim "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(corev1.Resource("persistentvolumeclaims"), "test")}),
+			expectedEvents:                []string{`Warning UpdatePersistentVolumeClaimFailed Failed to update PersistentVolumeClaim default/test: persistentvolumeclaims "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
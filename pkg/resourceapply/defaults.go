@@ -0,0 +1,172 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+// fieldPath addresses a value in an unstructured object, its elements are map keys or list indices.
+type fieldPath []any
+
+func getFieldPath(obj any, path fieldPath) (any, bool) {
+	current := obj
+	for _, element := range path {
+		switch e := element.(type) {
+		case string:
+			m, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			current, ok = m[e]
+			if !ok {
+				return nil, false
+			}
+
+		case int:
+			l, ok := current.([]any)
+			if !ok || e >= len(l) {
+				return nil, false
+			}
+			current = l[e]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// removeFieldPath removes the map key the path ends with. Paths ending with a list index are left alone,
+// removing list items would shift the following ones.
+func removeFieldPath(obj map[string]any, path fieldPath) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	key, ok := path[len(path)-1].(string)
+	if !ok {
+		return false
+	}
+
+	parent, ok := getFieldPath(obj, path[:len(path)-1])
+	if !ok {
+		return false
+	}
+
+	m, ok := parent.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	delete(m, key)
+	return true
+}
+
+// collectLeafFieldPaths returns the paths of all scalar values set in the object, in a stable order.
+func collectLeafFieldPaths(obj any, path fieldPath, paths []fieldPath) []fieldPath {
+	switch v := obj.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			paths = collectLeafFieldPaths(v[k], append(slices.Clone(path), k), paths)
+		}
+
+	case []any:
+		for i := range v {
+			paths = collectLeafFieldPaths(v[i], append(slices.Clone(path), i), paths)
+		}
+
+	default:
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// defaultUnstructured converts the unstructured content into a new object of the same type as obj,
+// defaults it and returns it as unstructured again.
+func defaultUnstructured(obj runtime.Object, u map[string]any, defaulter runtime.ObjectDefaulter) (map[string]any, error) {
+	typed := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(runtime.DeepCopyJSON(u), typed)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert from unstructured: %w", err)
+	}
+
+	defaulter.Default(typed)
+
+	defaulted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert to unstructured: %w", err)
+	}
+
+	return defaulted, nil
+}
+
+// stripDefaultedFields returns a copy of obj without the fields that are set to the value the defaulter would give them,
+// so the object written by the apply matches what the apiserver stores after defaulting it.
+// Fields are stripped one by one, keeping only removals that defaulting restores. Metadata is never stripped.
+// If the stripped object wouldn't default to the same object as obj, obj is returned as it is.
+func stripDefaultedFields(obj runtime.Object, defaulter runtime.ObjectDefaulter) (runtime.Object, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert to unstructured: %w", err)
+	}
+
+	stripped := runtime.DeepCopyJSON(u)
+	for _, path := range collectLeafFieldPaths(u, fieldPath{}, nil) {
+		if path[0] == "metadata" || path[0] == "apiVersion" || path[0] == "kind" {
+			continue
+		}
+
+		value, _ := getFieldPath(stripped, path)
+
+		candidate := runtime.DeepCopyJSON(stripped)
+		if !removeFieldPath(candidate, path) {
+			continue
+		}
+
+		defaulted, err := defaultUnstructured(obj, candidate, defaulter)
+		if err != nil {
+			return nil, err
+		}
+
+		defaultedValue, found := getFieldPath(defaulted, path)
+		if found && reflect.DeepEqual(defaultedValue, value) {
+			stripped = candidate
+		}
+	}
+
+	// Defaults can depend on each other, make sure the removals didn't change the meaning of the object.
+	defaultedStripped, err := defaultUnstructured(obj, stripped, defaulter)
+	if err != nil {
+		return nil, err
+	}
+	defaultedOriginal, err := defaultUnstructured(obj, u, defaulter)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(defaultedStripped, defaultedOriginal) {
+		klog.V(4).InfoS("Stripping defaulted fields changed the defaulted object, keeping it as it is", "Type", reflect.TypeOf(obj))
+		return obj, nil
+	}
+
+	res := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(stripped, res)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert from unstructured: %w", err)
+	}
+
+	return res, nil
+}
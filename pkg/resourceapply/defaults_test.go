@@ -0,0 +1,133 @@
+package resourceapply
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newPodDefaultingScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	apimachineryutilruntime.Must(corev1.AddToScheme(scheme))
+	scheme.AddTypeDefaultingFunc(&corev1.Pod{}, func(obj interface{}) {
+		pod := obj.(*corev1.Pod)
+		if len(pod.Spec.RestartPolicy) == 0 {
+			pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+		}
+		if len(pod.Spec.DNSPolicy) == 0 {
+			pod.Spec.DNSPolicy = corev1.DNSClusterFirst
+		}
+		for i := range pod.Spec.Containers {
+			if len(pod.Spec.Containers[i].TerminationMessagePath) == 0 {
+				pod.Spec.Containers[i].TerminationMessagePath = corev1.TerminationMessagePathDefault
+			}
+			if len(pod.Spec.Containers[i].ImagePullPolicy) == 0 {
+				pod.Spec.Containers[i].ImagePullPolicy = corev1.PullIfNotPresent
+			}
+		}
+	})
+	return scheme
+}
+
+func TestApplyGenericStripDefaults(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	required := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Controller:         pointer.Ptr(true),
+					UID:                "abcdefgh",
+					APIVersion:         "scylla.scylladb.com/v1",
+					Kind:               "ScyllaCluster",
+					Name:               "basic",
+					BlockOwnerDeletion: pointer.Ptr(true),
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			// Explicit defaults.
+			RestartPolicy: corev1.RestartPolicyAlways,
+			DNSPolicy:     corev1.DNSClusterFirst,
+			Containers: []corev1.Container{
+				{
+					Name:                   "scylla",
+					Image:                  "scylladb/scylla:latest",
+					TerminationMessagePath: corev1.TerminationMessagePathDefault,
+					// Not a default.
+					ImagePullPolicy: corev1.PullAlways,
+				},
+			},
+		},
+	}
+	originalRequired := required.DeepCopy()
+
+	expectedSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:            "scylla",
+				Image:           "scylladb/scylla:latest",
+				ImagePullPolicy: corev1.PullAlways,
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	options := ApplyOptions{
+		StripDefaults: newPodDefaultingScheme(),
+	}
+
+	// Reconciliation needs to be stable, so applying the second time must not make any changes.
+	for i := range 2 {
+		podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		podList, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range podList.Items {
+			err = podCache.Add(&podList.Items[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, changed, err := ApplyPod(ctx, client.CoreV1(), corev1listers.NewPodLister(podCache), record.NewFakeRecorder(10), required, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedChanged := i == 0
+		if changed != expectedChanged {
+			t.Errorf("iteration %d: expected changed %t, got %t", i, expectedChanged, changed)
+		}
+
+		got, err := client.CoreV1().Pods("default").Get(ctx, "test", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equality.Semantic.DeepEqual(got.Spec, expectedSpec) {
+			t.Errorf("iteration %d: expected and got specs differ:\n%s", i, cmp.Diff(expectedSpec, got.Spec))
+		}
+
+		if !equality.Semantic.DeepEqual(required, originalRequired) {
+			t.Errorf("iteration %d: required object was mutated:\n%s", i, cmp.Diff(originalRequired, required))
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Diff reports whether applying required over existing would change anything, using the same hash comparison
+// as the apply functions, and returns a human-readable diff of the two objects when it would.
+// Server populated fields, status and metadata keys not set by the required object aren't part of the diff.
+// Secret values, like keys and certificates, are replaced by their digests, so they never end up in logs.
+// It never calls the API, so it can be used to validate generated manifests, e.g. in CI.
+func Diff(existing, required runtime.Object) (string, bool, error) {
+	if reflect.TypeOf(existing) != reflect.TypeOf(required) {
+		return "", false, fmt.Errorf("can't diff %T with %T", existing, required)
+	}
+
+	existingObj, ok := existing.(kubeinterfaces.ObjectInterface)
+	if !ok {
+		return "", false, fmt.Errorf("existing object of type %T doesn't have metadata", existing)
+	}
+
+	requiredObj, ok := required.(kubeinterfaces.ObjectInterface)
+	if !ok {
+		return "", false, fmt.Errorf("required object of type %T doesn't have metadata", required)
+	}
+
+	requiredCopy := requiredObj.DeepCopyObject().(kubeinterfaces.ObjectInterface)
+	err := SetHashAnnotation(requiredCopy)
+	if err != nil {
+		return "", false, err
+	}
+
+	upToDate, err := isHashUpToDate(requiredCopy, existingObj)
+	if err != nil {
+		return "", false, fmt.Errorf("can't compare hashes: %w", err)
+	}
+	if upToDate {
+		return "", false, nil
+	}
+
+	existingCopy := makeComparableCopy(existingObj, requiredCopy)
+
+	// Hashes always differ at this point, they would only obscure the actual change.
+	for _, obj := range []kubeinterfaces.ObjectInterface{existingCopy, requiredCopy} {
+		annotations := maps.Clone(obj.GetAnnotations())
+		delete(annotations, naming.ManagedHash)
+		delete(annotations, naming.ManagedHashVersion)
+		obj.SetAnnotations(annotations)
+	}
+
+	redactSecretData(existingCopy)
+	redactSecretData(requiredCopy)
+
+	return cmp.Diff(existingCopy, requiredCopy), true, nil
+}
+
+// redactSecretData replaces the values of a Secret with their digests.
+// The diff still shows which keys changed, e.g. on a certificate rotation, without revealing the data.
+func redactSecretData(obj kubeinterfaces.ObjectInterface) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+
+	for k, v := range secret.Data {
+		secret.Data[k] = []byte(fmt.Sprintf("<redacted sha256:%x>", sha256.Sum256(v)))
+	}
+
+	for k, v := range secret.StringData {
+		secret.StringData[k] = fmt.Sprintf("<redacted sha256:%x>", sha256.Sum256([]byte(v)))
+	}
+}
+
+// DataKeysDiff lists the data keys an apply would add, remove or change, sorted by name.
+type DataKeysDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+func (d DataKeysDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String renders the diff with the keys only, so it's safe to be used in events even for Secrets.
+func (d DataKeysDiff) String() string {
+	var parts []string
+	for _, p := range []struct {
+		verb string
+		keys []string
+	}{
+		{verb: "added", keys: d.Added},
+		{verb: "removed", keys: d.Removed},
+		{verb: "changed", keys: d.Changed},
+	} {
+		if len(p.keys) != 0 {
+			parts = append(parts, fmt.Sprintf("%s keys: %s", p.verb, strings.Join(p.keys, ", ")))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DiffDataKeys compares the data of two ConfigMaps or two Secrets key by key, without looking at their ordering.
+// Secret stringData is merged into data the same way the server does it.
+func DiffDataKeys(existing, required runtime.Object) (DataKeysDiff, error) {
+	if reflect.TypeOf(existing) != reflect.TypeOf(required) {
+		return DataKeysDiff{}, fmt.Errorf("can't diff %T with %T", existing, required)
+	}
+
+	var existingData, requiredData map[string][]byte
+	switch e := existing.(type) {
+	case *corev1.ConfigMap:
+		existingData = configMapData(e)
+		requiredData = configMapData(required.(*corev1.ConfigMap))
+	case *corev1.Secret:
+		existingData = secretData(e)
+		requiredData = secretData(required.(*corev1.Secret))
+	default:
+		return DataKeysDiff{}, fmt.Errorf("can't diff data keys of unsupported type %T", existing)
+	}
+
+	var d DataKeysDiff
+	for k, requiredValue := range requiredData {
+		existingValue, ok := existingData[k]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, k)
+		case !bytes.Equal(existingValue, requiredValue):
+			d.Changed = append(d.Changed, k)
+		}
+	}
+	for k := range existingData {
+		if _, ok := requiredData[k]; !ok {
+			d.Removed = append(d.Removed, k)
+		}
+	}
+
+	slices.Sort(d.Added)
+	slices.Sort(d.Removed)
+	slices.Sort(d.Changed)
+
+	return d, nil
+}
+
+func configMapData(cm *corev1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+	maps.Copy(data, cm.BinaryData)
+	return data
+}
+
+func secretData(secret *corev1.Secret) map[string][]byte {
+	data := maps.Clone(secret.Data)
+	if data == nil {
+		data = make(map[string][]byte, len(secret.StringData))
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	return data
+}
@@ -0,0 +1,300 @@
+package resourceapply
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	newAppliedConfigMap := func() *corev1.ConfigMap {
+		cm := newConfigMap()
+		err := SetHashAnnotation(cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cm.UID = "cm-uid"
+		cm.ResourceVersion = "42"
+		return cm
+	}
+
+	tt := []struct {
+		name                  string
+		existing              runtime.Object
+		required              runtime.Object
+		expectedChanged       bool
+		expectedDiffContent   []string
+		unexpectedDiffContent []string
+		expectedErr           bool
+	}{
+		{
+			name:            "identical objects don't differ",
+			existing:        newAppliedConfigMap(),
+			required:        newConfigMap(),
+			expectedChanged: false,
+			expectedErr:     false,
+		},
+		{
+			name: "server populated fields and foreign metadata are ignored",
+			existing: func() *corev1.ConfigMap {
+				cm := newAppliedConfigMap()
+				cm.Labels["other-actor"] = "value"
+				return cm
+			}(),
+			required:        newConfigMap(),
+			expectedChanged: false,
+			expectedErr:     false,
+		},
+		{
+			name:     "objects with different data differ",
+			existing: newAppliedConfigMap(),
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Data["foo"] = "bar"
+				return cm
+			}(),
+			expectedChanged:     true,
+			expectedDiffContent: []string{`"foo": "bar"`},
+			expectedErr:         false,
+		},
+		{
+			name: "object without a hash differs",
+			existing: func() *corev1.ConfigMap {
+				cm := newAppliedConfigMap()
+				cm.Annotations = nil
+				return cm
+			}(),
+			required:        newConfigMap(),
+			expectedChanged: true,
+			expectedErr:     false,
+		},
+		{
+			name: "secret values are redacted",
+			existing: func() *corev1.Secret {
+				secret := &corev1.Secret{
+					ObjectMeta: newConfigMap().ObjectMeta,
+					Data: map[string][]byte{
+						"tls.key": []byte("old-private-key"),
+					},
+				}
+				apimachineryutilruntime.Must(SetHashAnnotation(secret))
+				return secret
+			}(),
+			required: &corev1.Secret{
+				ObjectMeta: newConfigMap().ObjectMeta,
+				Data: map[string][]byte{
+					"tls.key": []byte("new-private-key"),
+				},
+			},
+			expectedChanged:       true,
+			expectedDiffContent:   []string{"tls.key", "redacted sha256:"},
+			unexpectedDiffContent: []string{"old-private-key", "new-private-key"},
+			expectedErr:           false,
+		},
+		{
+			name:            "objects of different types can't be compared",
+			existing:        newAppliedConfigMap(),
+			required:        &corev1.Secret{},
+			expectedChanged: false,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			required := tc.required.DeepCopyObject()
+
+			gotDiff, gotChanged, err := Diff(tc.existing, required)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected changed %t, got %t, diff:\n%s", tc.expectedChanged, gotChanged, gotDiff)
+			}
+
+			if !gotChanged && len(gotDiff) != 0 {
+				t.Errorf("expected no diff, got:\n%s", gotDiff)
+			}
+
+			normalizedDiff := strings.ReplaceAll(gotDiff, " ", " ")
+			for _, s := range tc.expectedDiffContent {
+				if !strings.Contains(normalizedDiff, s) {
+					t.Errorf("expected diff to contain %q, got:\n%s", s, gotDiff)
+				}
+			}
+
+			for _, s := range tc.unexpectedDiffContent {
+				if strings.Contains(gotDiff, s) {
+					t.Errorf("expected diff not to contain %q, got:\n%s", s, gotDiff)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffDataKeys(t *testing.T) {
+	t.Parallel()
+
+	newSecret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+			},
+			Data: data,
+		}
+	}
+
+	tt := []struct {
+		name           string
+		existing       runtime.Object
+		required       runtime.Object
+		expectedDiff   DataKeysDiff
+		expectedString string
+		expectedErr    bool
+	}{
+		{
+			name: "identical secrets don't differ",
+			existing: newSecret(map[string][]byte{
+				"tls.crt": []byte("cert"),
+				"tls.key": []byte("key"),
+			}),
+			required: newSecret(map[string][]byte{
+				"tls.key": []byte("key"),
+				"tls.crt": []byte("cert"),
+			}),
+			expectedDiff:   DataKeysDiff{},
+			expectedString: "",
+			expectedErr:    false,
+		},
+		{
+			name: "secret keys are reported without values",
+			existing: newSecret(map[string][]byte{
+				"tls.crt": []byte("old-cert"),
+				"tls.key": []byte("key"),
+				"ca.crt":  []byte("ca"),
+			}),
+			required: newSecret(map[string][]byte{
+				"tls.crt":  []byte("new-cert"),
+				"tls.key":  []byte("key"),
+				"password": []byte("hunter2"),
+			}),
+			expectedDiff: DataKeysDiff{
+				Added:   []string{"password"},
+				Removed: []string{"ca.crt"},
+				Changed: []string{"tls.crt"},
+			},
+			expectedString: "added keys: password; removed keys: ca.crt; changed keys: tls.crt",
+			expectedErr:    false,
+		},
+		{
+			name: "secret stringData is merged into data",
+			existing: newSecret(map[string][]byte{
+				"user": []byte("admin"),
+			}),
+			required: func() *corev1.Secret {
+				s := newSecret(nil)
+				s.StringData = map[string]string{
+					"user": "root",
+				}
+				return s
+			}(),
+			expectedDiff: DataKeysDiff{
+				Changed: []string{"user"},
+			},
+			expectedString: "changed keys: user",
+			expectedErr:    false,
+		},
+		{
+			name: "configmap binaryData is compared next to data",
+			existing: &corev1.ConfigMap{
+				Data: map[string]string{
+					"a": "1",
+				},
+			},
+			required: &corev1.ConfigMap{
+				Data: map[string]string{
+					"a": "1",
+				},
+				BinaryData: map[string][]byte{
+					"b": []byte("2"),
+				},
+			},
+			expectedDiff: DataKeysDiff{
+				Added: []string{"b"},
+			},
+			expectedString: "added keys: b",
+			expectedErr:    false,
+		},
+		{
+			name:        "objects of different types can't be diffed",
+			existing:    &corev1.ConfigMap{},
+			required:    &corev1.Secret{},
+			expectedErr: true,
+		},
+		{
+			name:        "unsupported types can't be diffed",
+			existing:    &corev1.Service{},
+			required:    &corev1.Service{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DiffDataKeys(tc.existing, tc.required)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expectedDiff) {
+				t.Errorf("expected and got diffs differ:\n%s", cmp.Diff(tc.expectedDiff, got))
+			}
+
+			if got.String() != tc.expectedString {
+				t.Errorf("expected string %q, got %q", tc.expectedString, got.String())
+			}
+
+			if got.IsEmpty() != (len(tc.expectedString) == 0) {
+				t.Errorf("expected empty %t, got %t", len(tc.expectedString) == 0, got.IsEmpty())
+			}
+		})
+	}
+}
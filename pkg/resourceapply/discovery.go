@@ -31,12 +31,7 @@ func ApplyEndpointSlice(
 ) (*discoveryv1.EndpointSlice, bool, error) {
 	return ApplyEndpointSliceWithControl(
 		ctx,
-		ApplyControlFuncs[*discoveryv1.EndpointSlice]{
-			GetCachedFunc: lister.EndpointSlices(required.Namespace).Get,
-			CreateFunc:    client.EndpointSlices(required.Namespace).Create,
-			UpdateFunc:    client.EndpointSlices(required.Namespace).Update,
-			DeleteFunc:    client.EndpointSlices(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*discoveryv1.EndpointSlice](lister.EndpointSlices(required.Namespace), client.EndpointSlices(required.Namespace)),
 		recorder,
 		required,
 		options,
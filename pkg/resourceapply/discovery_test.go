@@ -239,8 +239,8 @@ func TestApplyEndpointSlice(t *testing.T) {
 			}(),
 			expectedEndpointSlice: nil,
 			expectedChanged:       false,
-			expectedErr:           fmt.Errorf(`can't update discovery.k8s.io/v1, Kind=EndpointSlice "default/test": %w`, apierrors.NewNotFound(discoveryv1.Resource("endpointslices"), "test")),
-			expectedEvents:        []string{`Warning UpdateEndpointSliceFailed Failed to update EndpointSlice default/test: endpointslices.discovery.k8s.io "test" not found`},
+			expectedErr:           fmt.Errorf(`can't update discovery.k8s.io/v1, Kind=EndpointSlice "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(discoveryv1.Resource("endpointslices"), "test")}),
+			expectedEvents:        []string{`Warning UpdateEndpointSliceFailed Failed to update EndpointSlice default/test: endpointslices.discovery.k8s.io "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -0,0 +1,279 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NamespaceTerminatingError is returned when an object can't be created because its namespace is being terminated.
+// The condition is transient from the controller's point of view, the namespace is either going away
+// (and so will the controller's object) or it's going to be recreated.
+type NamespaceTerminatingError struct {
+	Namespace string
+	Err       error
+}
+
+var _ error = &NamespaceTerminatingError{}
+
+func (e *NamespaceTerminatingError) Error() string {
+	return fmt.Sprintf("namespace %q is being terminated: %v", e.Namespace, e.Err)
+}
+
+func (e *NamespaceTerminatingError) Unwrap() error {
+	return e.Err
+}
+
+func IsNamespaceTerminatingError(err error) bool {
+	var nte *NamespaceTerminatingError
+	return errors.As(err, &nte)
+}
+
+func isNamespaceTerminatingAPIError(err error) bool {
+	return apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause)
+}
+
+// MissingRequiredLabelError is returned when the required object is missing a label the apply was asked to enforce.
+type MissingRequiredLabelError struct {
+	GVK   schema.GroupVersionKind
+	Ref   string
+	Label string
+}
+
+var _ error = &MissingRequiredLabelError{}
+
+func (e *MissingRequiredLabelError) Error() string {
+	return fmt.Sprintf("%s %q is missing required label %q", e.GVK, e.Ref, e.Label)
+}
+
+// NamespaceNotAllowedError is returned when the required object lives in a namespace the apply isn't allowed to write into.
+type NamespaceNotAllowedError struct {
+	GVK       schema.GroupVersionKind
+	Ref       string
+	Namespace string
+}
+
+var _ error = &NamespaceNotAllowedError{}
+
+func (e *NamespaceNotAllowedError) Error() string {
+	return fmt.Sprintf("%s %q can't be applied because namespace %q isn't allowed", e.GVK, e.Ref, e.Namespace)
+}
+
+// ReconcileTokenConflictError is returned when the object was last written by another operator instance
+// holding a newer ReconcileToken, which means this instance should no longer be reconciling it.
+type ReconcileTokenConflictError struct {
+	GVK           schema.GroupVersionKind
+	Ref           string
+	Token         ReconcileToken
+	ExistingToken ReconcileToken
+}
+
+var _ error = &ReconcileTokenConflictError{}
+
+func (e *ReconcileTokenConflictError) Error() string {
+	return fmt.Sprintf("%s %q is reconciled by a newer operator instance with token %q, refusing to write it with token %q", e.GVK, e.Ref, e.ExistingToken, e.Token)
+}
+
+// ProtectedOwnerError is returned when ForceOwnership would adopt an object owned by a higher-precedence controller,
+// see ApplyOptions.ProtectedOwnerGVKs.
+type ProtectedOwnerError struct {
+	GVK      schema.GroupVersionKind
+	Ref      string
+	OwnerRef metav1.OwnerReference
+}
+
+var _ error = &ProtectedOwnerError{}
+
+func (e *ProtectedOwnerError) Error() string {
+	return fmt.Sprintf("%s %q is owned by %s %q which takes precedence, refusing to adopt it", e.GVK, e.Ref, schema.FromAPIVersionAndKind(e.OwnerRef.APIVersion, e.OwnerRef.Kind), e.OwnerRef.Name)
+}
+
+// ImmutableFieldError is returned when an update would change a field that a CEL transition rule forbids changing,
+// see ApplyOptions.ImmutableFields.
+type ImmutableFieldError struct {
+	GVK   schema.GroupVersionKind
+	Ref   string
+	Field ImmutableField
+}
+
+var _ error = &ImmutableFieldError{}
+
+func (e *ImmutableFieldError) Error() string {
+	message := e.Field.Message
+	if len(message) == 0 {
+		message = fmt.Sprintf("failed rule: %s", e.Field.Rule)
+	}
+
+	return fmt.Sprintf("%s %q can't be updated because field %s is immutable: %s", e.GVK, e.Ref, e.Field.String(), message)
+}
+
+// ResourceVersionConflictError is returned when an update conditional on ApplyOptions.ExpectedResourceVersion
+// is rejected because the object was modified since. It isn't retried, the caller has to read the object again.
+// It unwraps to the APIError of the conflict.
+type ResourceVersionConflictError struct {
+	GVK                     schema.GroupVersionKind
+	Ref                     string
+	ExpectedResourceVersion string
+	Err                     error
+}
+
+var _ error = &ResourceVersionConflictError{}
+
+func (e *ResourceVersionConflictError) Error() string {
+	return fmt.Sprintf("%s %q was modified since resource version %q: %v", e.GVK, e.Ref, e.ExpectedResourceVersion, e.Err)
+}
+
+func (e *ResourceVersionConflictError) Unwrap() error {
+	return e.Err
+}
+
+// ErrObjectTooLarge is matched by every ObjectTooLargeError using errors.Is.
+var ErrObjectTooLarge = errors.New("object is too large")
+
+// ObjectTooLargeError is returned when the serialized required object exceeds ApplyOptions.MaxObjectSizeBytes.
+type ObjectTooLargeError struct {
+	GVK          schema.GroupVersionKind
+	Ref          string
+	SizeBytes    int
+	MaxSizeBytes int
+}
+
+var _ error = &ObjectTooLargeError{}
+
+func (e *ObjectTooLargeError) Error() string {
+	return fmt.Sprintf("%s %q is too large: its serialized size is %d bytes which exceeds the limit of %d bytes", e.GVK, e.Ref, e.SizeBytes, e.MaxSizeBytes)
+}
+
+func (e *ObjectTooLargeError) Is(target error) bool {
+	return target == ErrObjectTooLarge
+}
+
+// CallTimeoutError is returned when a single API call exceeded ApplyOptions.TimeoutPerCall.
+// It's retryable, the apiserver is likely just slow.
+type CallTimeoutError struct {
+	Verb    string
+	Timeout time.Duration
+	Err     error
+}
+
+var _ error = &CallTimeoutError{}
+
+func (e *CallTimeoutError) Error() string {
+	return fmt.Sprintf("%s call didn't finish within %v: %v", e.Verb, e.Timeout, e.Err)
+}
+
+func (e *CallTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func IsCallTimeoutError(err error) bool {
+	var cte *CallTimeoutError
+	return errors.As(err, &cte)
+}
+
+// APIErrorClass groups apiserver errors that are handled the same way, regardless of the kind being applied.
+type APIErrorClass string
+
+const (
+	APIErrorClassNotFound      APIErrorClass = "NotFound"
+	APIErrorClassConflict      APIErrorClass = "Conflict"
+	APIErrorClassAlreadyExists APIErrorClass = "AlreadyExists"
+	APIErrorClassForbidden     APIErrorClass = "Forbidden"
+	APIErrorClassInvalid       APIErrorClass = "Invalid"
+	APIErrorClassServerTimeout APIErrorClass = "ServerTimeout"
+)
+
+// APIError is returned when a write done by an apply fails with a classified apiserver error.
+// It keeps the original message and unwraps to the apiserver error, so apierrors helpers keep working.
+type APIError struct {
+	Class APIErrorClass
+	Err   error
+}
+
+var _ error = &APIError{}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// classifyApplyError maps an error returned by a write to a typed error and the name of its class,
+// which is reported in the ApplyErrorClassAnnotation of the events of failed apply writes.
+// Errors that don't fall into any class are returned as they are with an empty class name.
+func classifyApplyError(err error) (error, string) {
+	if err == nil {
+		return nil, ""
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err, string(apiErr.Class)
+	}
+
+	if IsCallTimeoutError(err) {
+		return err, "CallTimeout"
+	}
+
+	var class APIErrorClass
+	switch {
+	case apierrors.IsNotFound(err):
+		class = APIErrorClassNotFound
+	case apierrors.IsConflict(err):
+		class = APIErrorClassConflict
+	case apierrors.IsAlreadyExists(err):
+		class = APIErrorClassAlreadyExists
+	case apierrors.IsForbidden(err):
+		class = APIErrorClassForbidden
+	case apierrors.IsInvalid(err):
+		class = APIErrorClassInvalid
+	case apierrors.IsServerTimeout(err):
+		class = APIErrorClassServerTimeout
+	default:
+		return err, ""
+	}
+
+	return &APIError{
+		Class: class,
+		Err:   err,
+	}, string(class)
+}
+
+// IsApplyFailure reports whether the error comes from a write done by an apply that was rejected by the apiserver
+// or didn't finish in time, as opposed to errors computing the required state.
+func IsApplyFailure(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) || IsCallTimeoutError(err)
+}
+
+// IsRetryable reports whether the error returned by an apply is expected to go away on its own,
+// so the caller should requeue with a backoff rather than treat it as a terminal failure.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch {
+	case IsNamespaceTerminatingError(err),
+		IsCallTimeoutError(err),
+		apierrors.IsConflict(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsTooManyRequests(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsInternalError(err):
+		return true
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	gr := corev1.Resource("configmaps")
+
+	tt := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "generic error",
+			err:      errors.New("foo"),
+			expected: false,
+		},
+		{
+			name:     "conflict",
+			err:      apierrors.NewConflict(gr, "test", errors.New("foo")),
+			expected: true,
+		},
+		{
+			name:     "wrapped conflict",
+			err:      fmt.Errorf("can't update: %w", apierrors.NewConflict(gr, "test", errors.New("foo"))),
+			expected: true,
+		},
+		{
+			name: "call timeout",
+			err: &CallTimeoutError{
+				Verb:    "create",
+				Timeout: time.Second,
+				Err:     context.DeadlineExceeded,
+			},
+			expected: true,
+		},
+		{
+			name:     "server timeout",
+			err:      apierrors.NewServerTimeout(gr, "create", 1),
+			expected: true,
+		},
+		{
+			name:     "timeout",
+			err:      apierrors.NewTimeoutError("foo", 1),
+			expected: true,
+		},
+		{
+			name:     "too many requests",
+			err:      apierrors.NewTooManyRequests("foo", 1),
+			expected: true,
+		},
+		{
+			name:     "service unavailable",
+			err:      apierrors.NewServiceUnavailable("foo"),
+			expected: true,
+		},
+		{
+			name:     "internal error",
+			err:      apierrors.NewInternalError(errors.New("foo")),
+			expected: true,
+		},
+		{
+			name: "namespace terminating",
+			err: &NamespaceTerminatingError{
+				Namespace: "default",
+				Err:       newNamespaceTerminatingError("default"),
+			},
+			expected: true,
+		},
+		{
+			name:     "not found",
+			err:      apierrors.NewNotFound(gr, "test"),
+			expected: false,
+		},
+		{
+			name:     "already exists",
+			err:      apierrors.NewAlreadyExists(gr, "test"),
+			expected: false,
+		},
+		{
+			name:     "forbidden",
+			err:      apierrors.NewForbidden(gr, "test", errors.New("foo")),
+			expected: false,
+		},
+		{
+			name:     "invalid",
+			err:      apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("ConfigMap").GroupKind(), "test", nil),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := IsRetryable(tc.err)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClassifyApplyError(t *testing.T) {
+	t.Parallel()
+
+	gr := corev1.Resource("configmaps")
+	genericErr := errors.New("foo")
+
+	tt := []struct {
+		name              string
+		err               error
+		expectedClass     APIErrorClass
+		expectedClassName string
+	}{
+		{
+			name:              "nil error",
+			err:               nil,
+			expectedClass:     "",
+			expectedClassName: "",
+		},
+		{
+			name:              "generic error",
+			err:               genericErr,
+			expectedClass:     "",
+			expectedClassName: "",
+		},
+		{
+			name:              "unclassified apiserver error",
+			err:               apierrors.NewInternalError(errors.New("foo")),
+			expectedClass:     "",
+			expectedClassName: "",
+		},
+		{
+			name:              "not found",
+			err:               apierrors.NewNotFound(gr, "test"),
+			expectedClass:     APIErrorClassNotFound,
+			expectedClassName: "NotFound",
+		},
+		{
+			name:              "conflict",
+			err:               apierrors.NewConflict(gr, "test", errors.New("foo")),
+			expectedClass:     APIErrorClassConflict,
+			expectedClassName: "Conflict",
+		},
+		{
+			name:              "already exists",
+			err:               apierrors.NewAlreadyExists(gr, "test"),
+			expectedClass:     APIErrorClassAlreadyExists,
+			expectedClassName: "AlreadyExists",
+		},
+		{
+			name:              "forbidden",
+			err:               apierrors.NewForbidden(gr, "test", errors.New("foo")),
+			expectedClass:     APIErrorClassForbidden,
+			expectedClassName: "Forbidden",
+		},
+		{
+			name:              "invalid",
+			err:               apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("ConfigMap").GroupKind(), "test", nil),
+			expectedClass:     APIErrorClassInvalid,
+			expectedClassName: "Invalid",
+		},
+		{
+			name:              "server timeout",
+			err:               apierrors.NewServerTimeout(gr, "create", 1),
+			expectedClass:     APIErrorClassServerTimeout,
+			expectedClassName: "ServerTimeout",
+		},
+		{
+			name:              "wrapped apiserver error",
+			err:               fmt.Errorf("can't create: %w", apierrors.NewConflict(gr, "test", errors.New("foo"))),
+			expectedClass:     APIErrorClassConflict,
+			expectedClassName: "Conflict",
+		},
+		{
+			name: "already classified error keeps its class",
+			err: &APIError{
+				Class: APIErrorClassForbidden,
+				Err:   apierrors.NewForbidden(gr, "test", errors.New("foo")),
+			},
+			expectedClass:     APIErrorClassForbidden,
+			expectedClassName: "Forbidden",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotErr, gotClassName := classifyApplyError(tc.err)
+			if gotClassName != tc.expectedClassName {
+				t.Errorf("expected class name %q, got %q", tc.expectedClassName, gotClassName)
+			}
+
+			if tc.err == nil {
+				if gotErr != nil {
+					t.Errorf("expected nil error, got %v", gotErr)
+				}
+				return
+			}
+
+			if !errors.Is(gotErr, tc.err) {
+				t.Errorf("expected the typed error to wrap %v, got %v", tc.err, gotErr)
+			}
+			if gotErr.Error() != tc.err.Error() {
+				t.Errorf("expected the message to be kept as %q, got %q", tc.err.Error(), gotErr.Error())
+			}
+
+			var apiErr *APIError
+			if errors.As(gotErr, &apiErr) {
+				if apiErr.Class != tc.expectedClass {
+					t.Errorf("expected class %q, got %q", tc.expectedClass, apiErr.Class)
+				}
+			} else if len(tc.expectedClass) != 0 {
+				t.Errorf("expected an APIError with class %q, got %T", tc.expectedClass, gotErr)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	monitoringv1 "github.com/scylladb/scylla-operator/pkg/externalapi/monitoring/v1"
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
 	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -119,6 +120,15 @@ func Apply(
 			options,
 		)
 
+	case *networkingv1.NetworkPolicy:
+		return ApplyNetworkPolicyWithControl(
+			ctx,
+			TypeApplyControlInterface[*networkingv1.NetworkPolicy](control),
+			recorder,
+			required.(*networkingv1.NetworkPolicy),
+			options,
+		)
+
 	case *monitoringv1.Prometheus:
 		return ApplyPrometheusWithControl(
 			ctx,
@@ -146,6 +156,15 @@ func Apply(
 			options,
 		)
 
+	case *snapshotv1.VolumeSnapshot:
+		return ApplyVolumeSnapshotWithControl(
+			ctx,
+			TypeApplyControlInterface[*snapshotv1.VolumeSnapshot](control),
+			recorder,
+			required.(*snapshotv1.VolumeSnapshot),
+			options,
+		)
+
 	default:
 		return nil, false, fmt.Errorf("no apply method matched for type %T", required)
 	}
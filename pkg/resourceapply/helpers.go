@@ -2,8 +2,13 @@ package resourceapply
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
 	"github.com/scylladb/scylla-operator/pkg/naming"
@@ -15,10 +20,15 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apimachineryutilwait "k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 )
 
 func verifyDesiredObject(obj metav1.Object) error {
@@ -45,10 +55,43 @@ func verifyDesiredObject(obj metav1.Object) error {
 	return nil
 }
 
-func SetHashAnnotation(obj metav1.Object) error {
-	err := verifyDesiredObject(obj)
-	if err != nil {
-		return fmt.Errorf("invalid desider object %q: %w", naming.ObjRef(obj), err)
+// hashFunc computes the managed hash of an object that has its managed hash annotations and resource version cleared.
+type hashFunc func(obj metav1.Object) (string, error)
+
+const (
+	// hashVersionV1 is the initial hash version.
+	// Objects hashed with it don't carry the version annotation.
+	hashVersionV1 = "v1"
+)
+
+var (
+	// currentHashVersion is the hash version used for newly applied objects.
+	currentHashVersion = hashVersionV1
+
+	// hashFuncs holds all hash versions that can be found on existing objects.
+	// Versions can only be added, so objects written by any previous release can still be compared.
+	hashFuncs = map[string]hashFunc{
+		hashVersionV1: func(obj metav1.Object) (string, error) {
+			return hashutil.HashObjects(obj)
+		},
+	}
+)
+
+func getHashVersion(obj metav1.Object) string {
+	version, ok := obj.GetAnnotations()[naming.ManagedHashVersion]
+	if !ok {
+		return hashVersionV1
+	}
+
+	return version
+}
+
+// computeHash computes the hash of obj using the given hash version.
+// The object is restored to its original state before returning.
+func computeHash(obj metav1.Object, version string) (string, error) {
+	hf, ok := hashFuncs[version]
+	if !ok {
+		return "", fmt.Errorf("unknown hash version %q", version)
 	}
 
 	// Do not hash ResourceVersion.
@@ -56,26 +99,161 @@ func SetHashAnnotation(obj metav1.Object) error {
 	obj.SetResourceVersion("")
 	defer obj.SetResourceVersion(rv)
 
-	annotations := obj.GetAnnotations()
-	if annotations == nil {
-		annotations = map[string]string{}
+	// Clear hash annotations to have consistent hashing for the same objects.
+	originalAnnotations := obj.GetAnnotations()
+	annotations := make(map[string]string, len(originalAnnotations))
+	for k, v := range originalAnnotations {
+		annotations[k] = v
 	}
-
-	// Clear annotation to have consistent hashing for the same objects.
 	delete(annotations, naming.ManagedHash)
+	delete(annotations, naming.ManagedHashVersion)
+	// The reconcile time, token and controller name describe the writer rather than the object, they must never make the objects differ.
+	delete(annotations, naming.LastAppliedTimeAnnotation)
+	delete(annotations, naming.ReconcileTokenAnnotation)
+	delete(annotations, naming.ReconciledByAnnotation)
+	obj.SetAnnotations(annotations)
+	defer obj.SetAnnotations(originalAnnotations)
+
+	return hf(obj)
+}
+
+func SetHashAnnotation(obj metav1.Object) error {
+	err := verifyDesiredObject(obj)
+	if err != nil {
+		return fmt.Errorf("invalid desider object %q: %w", naming.ObjRef(obj), err)
+	}
 
-	hash, err := hashutil.HashObjects(obj)
+	hash, err := computeHash(obj, currentHashVersion)
 	if err != nil {
 		return err
 	}
 
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	} else {
+		delete(annotations, naming.ManagedHashVersion)
+	}
+
 	annotations[naming.ManagedHash] = hash
+	if currentHashVersion != hashVersionV1 {
+		annotations[naming.ManagedHashVersion] = currentHashVersion
+	}
 	obj.SetAnnotations(annotations)
 
 	return nil
 }
 
+// isHashIntact recomputes the hash of the existing object and reports whether it matches its hash annotation.
+// Server populated fields, status and metadata keys not set by the required object are ignored.
+func isHashIntact[T kubeinterfaces.ObjectInterface](existing T, required T) (bool, error) {
+	existingHash, ok := existing.GetAnnotations()[naming.ManagedHash]
+	if !ok {
+		return false, nil
+	}
+
+	existingCopy := makeComparableCopy(existing, required)
+	recomputedHash, err := computeHash(existingCopy, getHashVersion(existing))
+	if err != nil {
+		return false, err
+	}
+
+	return recomputedHash == existingHash, nil
+}
+
+// makeComparableCopy returns a copy of the existing object stripped down to the state a required object can express.
+// Server populated fields, status and metadata keys not set by the required object are dropped.
+func makeComparableCopy[T kubeinterfaces.ObjectInterface](existing T, required T) T {
+	existingCopy := existing.DeepCopyObject().(T)
+	existingCopy.GetObjectKind().SetGroupVersionKind(required.GetObjectKind().GroupVersionKind())
+	existingCopy.SetUID("")
+	existingCopy.SetResourceVersion("")
+	existingCopy.SetGeneration(0)
+	existingCopy.SetCreationTimestamp(metav1.Time{})
+	existingCopy.SetDeletionTimestamp(nil)
+	existingCopy.SetDeletionGracePeriodSeconds(nil)
+	existingCopy.SetManagedFields(nil)
+	existingCopy.SetSelfLink("")
+	existingCopy.SetLabels(filterKeys(existingCopy.GetLabels(), required.GetLabels()))
+	existingCopy.SetAnnotations(filterKeys(existingCopy.GetAnnotations(), required.GetAnnotations()))
+
+	// Status is never part of the required state.
+	status := reflect.ValueOf(existingCopy).Elem().FieldByName("Status")
+	if status.IsValid() && status.CanSet() {
+		status.Set(reflect.Zero(status.Type()))
+	}
+
+	return existingCopy
+}
+
+// filterKeys returns the entries of m that have their key present in keys.
+// Removal keys from keys are carried over as they never exist on a persisted object but are part of the required hash.
+func filterKeys(m map[string]string, keys map[string]string) map[string]string {
+	res := make(map[string]string, len(keys))
+	for k, v := range m {
+		_, ok := keys[k]
+		if ok {
+			res[k] = v
+		}
+	}
+
+	for k, v := range keys {
+		if resourcemerge.IsRemovalKey(k) {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+// isHashUpToDate reports whether the existing object was applied from the same required state.
+// The required object needs to have the hash annotation set.
+// When the existing object was hashed with a different hash version, the required object is rehashed with it,
+// so a change of the hashing scheme alone doesn't make every object look changed.
+func isHashUpToDate(required, existing metav1.Object) (bool, error) {
+	existingHash, ok := existing.GetAnnotations()[naming.ManagedHash]
+	if !ok {
+		return false, nil
+	}
+
+	existingHashVersion := getHashVersion(existing)
+	if existingHashVersion == getHashVersion(required) {
+		return existingHash == required.GetAnnotations()[naming.ManagedHash], nil
+	}
+
+	_, ok = hashFuncs[existingHashVersion]
+	if !ok {
+		// We can't compare objects written by an unknown (e.g. newer) version, so they're reconciled.
+		return false, nil
+	}
+
+	requiredHash, err := computeHash(required, existingHashVersion)
+	if err != nil {
+		return false, fmt.Errorf("can't compute hash version %q: %w", existingHashVersion, err)
+	}
+
+	return existingHash == requiredHash, nil
+}
+
 func reportEvent(recorder record.EventRecorder, obj runtime.Object, operationErr error, verb string) {
+	reportEventWithAnnotations(recorder, obj, operationErr, verb, nil)
+}
+
+// reportApplyEvent is like reportEvent, but it's meant for writes made by an apply. Their failures carry
+// the class of the apiserver error in the ApplyErrorClassAnnotation, see classifyApplyError.
+func reportApplyEvent(recorder record.EventRecorder, obj runtime.Object, operationErr error, verb string) {
+	var annotations map[string]string
+	_, errorClass := classifyApplyError(operationErr)
+	if len(errorClass) != 0 {
+		annotations = map[string]string{
+			naming.ApplyErrorClassAnnotation: errorClass,
+		}
+	}
+
+	reportEventWithAnnotations(recorder, obj, operationErr, verb, annotations)
+}
+
+func reportEventWithAnnotations(recorder record.EventRecorder, obj runtime.Object, operationErr error, verb string, annotations map[string]string) {
 	objMeta, err := meta.Accessor(obj)
 	if err != nil {
 		klog.ErrorS(err, "can't get object metadata")
@@ -88,10 +266,22 @@ func reportEvent(recorder record.EventRecorder, obj runtime.Object, operationErr
 	}
 
 	if operationErr != nil {
+		reason := fmt.Sprintf("%s%sFailed", strings.Title(verb), gvk.Kind)
+		if len(annotations) != 0 {
+			recorder.AnnotatedEventf(
+				obj,
+				annotations,
+				corev1.EventTypeWarning,
+				reason,
+				"Failed to %s %s %s: %v",
+				strings.ToLower(verb), gvk.Kind, naming.ObjRef(objMeta), operationErr,
+			)
+			return
+		}
 		recorder.Eventf(
 			obj,
 			corev1.EventTypeWarning,
-			fmt.Sprintf("%s%sFailed", strings.Title(verb), gvk.Kind),
+			reason,
 			"Failed to %s %s %s: %v",
 			strings.ToLower(verb), gvk.Kind, naming.ObjRef(objMeta), operationErr,
 		)
@@ -106,16 +296,79 @@ func reportEvent(recorder record.EventRecorder, obj runtime.Object, operationErr
 	)
 }
 
+// eventObjectRecorder records all events against a fixed object, see ApplyOptions.EventObject.
+type eventObjectRecorder struct {
+	record.EventRecorder
+	object runtime.Object
+}
+
+var _ record.EventRecorder = &eventObjectRecorder{}
+
+func (r *eventObjectRecorder) Event(_ runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(r.object, eventtype, reason, message)
+}
+
+func (r *eventObjectRecorder) Eventf(_ runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(r.object, eventtype, reason, messageFmt, args...)
+}
+
+func (r *eventObjectRecorder) AnnotatedEventf(_ runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(r.object, annotations, eventtype, reason, messageFmt, args...)
+}
+
+func eventRecorderForOptions(recorder record.EventRecorder, options ApplyOptions) record.EventRecorder {
+	if options.EventObject == nil {
+		return recorder
+	}
+
+	return &eventObjectRecorder{
+		EventRecorder: recorder,
+		object:        options.EventObject,
+	}
+}
+
 func ReportCreateEvent(recorder record.EventRecorder, obj runtime.Object, operationErr error) {
-	if apierrors.HasStatusCause(operationErr, corev1.NamespaceTerminatingCause) {
-		// If the namespace is being terminated, we don't have to do
-		// anything because any creation will fail.
+	if isNamespaceTerminatingAPIError(operationErr) {
+		// If the namespace is being terminated, any creation will fail.
+		// Report it distinctly so it's not mistaken for a generic failure.
+		reportNamespaceTerminatingEvent(recorder, obj)
 		return
 	}
 
 	reportEvent(recorder, obj, operationErr, "create")
 }
 
+// reportApplyCreateEvent is like ReportCreateEvent, but it's meant for creates made by an apply, see reportApplyEvent.
+func reportApplyCreateEvent(recorder record.EventRecorder, obj runtime.Object, operationErr error) {
+	if isNamespaceTerminatingAPIError(operationErr) {
+		reportNamespaceTerminatingEvent(recorder, obj)
+		return
+	}
+
+	reportApplyEvent(recorder, obj, operationErr, "create")
+}
+
+func reportNamespaceTerminatingEvent(recorder record.EventRecorder, obj runtime.Object) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't get object metadata")
+		return
+	}
+	gvk, err := resource.GetObjectGVK(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't determine object GVK", "Object", klog.KObj(objMeta))
+		return
+	}
+
+	recorder.Eventf(
+		obj,
+		corev1.EventTypeWarning,
+		"NamespaceTerminating",
+		"Can't create %s %s because namespace %q is being terminated",
+		gvk.Kind, naming.ObjRef(objMeta), objMeta.GetNamespace(),
+	)
+}
+
 func ReportUpdateEvent(recorder record.EventRecorder, obj runtime.Object, operationErr error) {
 	reportEvent(recorder, obj, operationErr, "update")
 }
@@ -124,8 +377,52 @@ func ReportDeleteEvent(recorder record.EventRecorder, obj runtime.Object, operat
 	reportEvent(recorder, obj, operationErr, "delete")
 }
 
+func reportDriftCorrectedEvent(recorder record.EventRecorder, obj runtime.Object, reason string) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't get object metadata")
+		return
+	}
+	gvk, err := resource.GetObjectGVK(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't determine object GVK", "Object", klog.KObj(objMeta))
+		return
+	}
+
+	recorder.Eventf(
+		obj,
+		corev1.EventTypeNormal,
+		fmt.Sprintf("%sDriftCorrected", gvk.Kind),
+		"%s %s drifted from the required state and was corrected: %s",
+		gvk.Kind, naming.ObjRef(objMeta), reason,
+	)
+}
+
+func reportUnchangedEvent(recorder record.EventRecorder, obj runtime.Object) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't get object metadata")
+		return
+	}
+	gvk, err := resource.GetObjectGVK(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't determine object GVK", "Object", klog.KObj(objMeta))
+		return
+	}
+
+	recorder.Eventf(
+		obj,
+		corev1.EventTypeNormal,
+		fmt.Sprintf("%sUnchanged", gvk.Kind),
+		"%s %s unchanged",
+		gvk.Kind, naming.ObjRef(objMeta),
+	)
+}
+
 type ApplyControlUntypedInterface interface {
 	GetCached(name string) (kubeinterfaces.ObjectInterface, error)
+	ListCached(selector labels.Selector) ([]kubeinterfaces.ObjectInterface, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (kubeinterfaces.ObjectInterface, error)
 	Create(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.CreateOptions) (kubeinterfaces.ObjectInterface, error)
 	Update(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.UpdateOptions) (kubeinterfaces.ObjectInterface, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
@@ -133,15 +430,35 @@ type ApplyControlUntypedInterface interface {
 
 type ApplyControlUntypedFuncs struct {
 	GetCachedFunc func(name string) (kubeinterfaces.ObjectInterface, error)
-	CreateFunc    func(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.CreateOptions) (kubeinterfaces.ObjectInterface, error)
-	UpdateFunc    func(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.UpdateOptions) (kubeinterfaces.ObjectInterface, error)
-	DeleteFunc    func(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	// ListCachedFunc is optional, it's only required for objects applied with a generated name.
+	ListCachedFunc func(selector labels.Selector) ([]kubeinterfaces.ObjectInterface, error)
+	// GetFunc is optional, it's used to recover from creates that raced with another actor.
+	GetFunc    func(ctx context.Context, name string, opts metav1.GetOptions) (kubeinterfaces.ObjectInterface, error)
+	CreateFunc func(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.CreateOptions) (kubeinterfaces.ObjectInterface, error)
+	UpdateFunc func(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.UpdateOptions) (kubeinterfaces.ObjectInterface, error)
+	DeleteFunc func(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	// PatchFunc is optional, it's only required for update strategies using patches, see ApplyOptions.UpdateStrategy.
+	PatchFunc func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (kubeinterfaces.ObjectInterface, error)
 }
 
 func (acf ApplyControlUntypedFuncs) GetCached(name string) (kubeinterfaces.ObjectInterface, error) {
 	return acf.GetCachedFunc(name)
 }
 
+func (acf ApplyControlUntypedFuncs) ListCached(selector labels.Selector) ([]kubeinterfaces.ObjectInterface, error) {
+	if acf.ListCachedFunc == nil {
+		return nil, fmt.Errorf("listing isn't supported by this control")
+	}
+	return acf.ListCachedFunc(selector)
+}
+
+func (acf ApplyControlUntypedFuncs) Get(ctx context.Context, name string, opts metav1.GetOptions) (kubeinterfaces.ObjectInterface, error) {
+	if acf.GetFunc == nil {
+		return nil, fmt.Errorf("live get isn't supported by this control")
+	}
+	return acf.GetFunc(ctx, name, opts)
+}
+
 func (acf ApplyControlUntypedFuncs) Create(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.CreateOptions) (kubeinterfaces.ObjectInterface, error) {
 	return acf.CreateFunc(ctx, obj, opts)
 }
@@ -154,10 +471,19 @@ func (acf ApplyControlUntypedFuncs) Delete(ctx context.Context, name string, opt
 	return acf.DeleteFunc(ctx, name, opts)
 }
 
+func (acf ApplyControlUntypedFuncs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (kubeinterfaces.ObjectInterface, error) {
+	if acf.PatchFunc == nil {
+		return nil, fmt.Errorf("patching isn't supported by this control")
+	}
+	return acf.PatchFunc(ctx, name, pt, data, opts)
+}
+
 var _ ApplyControlUntypedInterface = ApplyControlUntypedFuncs{}
 
 type ApplyControlInterface[T kubeinterfaces.ObjectInterface] interface {
 	GetCached(name string) (T, error)
+	ListCached(selector labels.Selector) ([]T, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
 	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
 	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
@@ -165,15 +491,35 @@ type ApplyControlInterface[T kubeinterfaces.ObjectInterface] interface {
 
 type ApplyControlFuncs[T kubeinterfaces.ObjectInterface] struct {
 	GetCachedFunc func(name string) (T, error)
-	CreateFunc    func(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
-	UpdateFunc    func(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
-	DeleteFunc    func(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	// ListCachedFunc is optional, it's only required for objects applied with a generated name.
+	ListCachedFunc func(selector labels.Selector) ([]T, error)
+	// GetFunc is optional, it's used to recover from creates that raced with another actor.
+	GetFunc    func(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	CreateFunc func(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	UpdateFunc func(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+	DeleteFunc func(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	// PatchFunc is optional, it's only required for update strategies using patches, see ApplyOptions.UpdateStrategy.
+	PatchFunc func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error)
 }
 
 func (acf ApplyControlFuncs[T]) GetCached(name string) (T, error) {
 	return acf.GetCachedFunc(name)
 }
 
+func (acf ApplyControlFuncs[T]) ListCached(selector labels.Selector) ([]T, error) {
+	if acf.ListCachedFunc == nil {
+		return nil, fmt.Errorf("listing isn't supported by this control")
+	}
+	return acf.ListCachedFunc(selector)
+}
+
+func (acf ApplyControlFuncs[T]) Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+	if acf.GetFunc == nil {
+		return *new(T), fmt.Errorf("live get isn't supported by this control")
+	}
+	return acf.GetFunc(ctx, name, opts)
+}
+
 func (acf ApplyControlFuncs[T]) Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error) {
 	return acf.CreateFunc(ctx, obj, opts)
 }
@@ -186,11 +532,32 @@ func (acf ApplyControlFuncs[T]) Delete(ctx context.Context, name string, opts me
 	return acf.DeleteFunc(ctx, name, opts)
 }
 
+func (acf ApplyControlFuncs[T]) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+	if acf.PatchFunc == nil {
+		return *new(T), fmt.Errorf("patching isn't supported by this control")
+	}
+	return acf.PatchFunc(ctx, name, pt, data, opts)
+}
+
 func (acf ApplyControlFuncs[T]) ToUntyped() ApplyControlUntypedFuncs {
 	return ApplyControlUntypedFuncs{
 		GetCachedFunc: func(name string) (kubeinterfaces.ObjectInterface, error) {
 			return acf.GetCached(name)
 		},
+		ListCachedFunc: func(selector labels.Selector) ([]kubeinterfaces.ObjectInterface, error) {
+			objs, err := acf.ListCached(selector)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]kubeinterfaces.ObjectInterface, 0, len(objs))
+			for _, obj := range objs {
+				res = append(res, obj)
+			}
+			return res, nil
+		},
+		GetFunc: func(ctx context.Context, name string, opts metav1.GetOptions) (kubeinterfaces.ObjectInterface, error) {
+			return acf.Get(ctx, name, opts)
+		},
 		CreateFunc: func(ctx context.Context, obj kubeinterfaces.ObjectInterface, opts metav1.CreateOptions) (kubeinterfaces.ObjectInterface, error) {
 			return acf.Create(ctx, obj.(T), opts)
 		},
@@ -198,10 +565,50 @@ func (acf ApplyControlFuncs[T]) ToUntyped() ApplyControlUntypedFuncs {
 			return acf.Update(ctx, obj.(T), opts)
 		},
 		DeleteFunc: acf.DeleteFunc,
+		PatchFunc: func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (kubeinterfaces.ObjectInterface, error) {
+			return acf.Patch(ctx, name, pt, data, opts)
+		},
 	}
 }
 
 var _ ApplyControlInterface[*corev1.Service] = ApplyControlFuncs[*corev1.Service]{}
+var _ ApplyControlPatcher[*corev1.Service] = ApplyControlFuncs[*corev1.Service]{}
+
+// CachedGetLister is implemented by the typed listers, both namespaced and cluster-scoped.
+type CachedGetLister[T kubeinterfaces.ObjectInterface] interface {
+	Get(name string) (T, error)
+	List(selector labels.Selector) ([]T, error)
+}
+
+// ApplyClient is implemented by the typed clients, both namespaced and cluster-scoped.
+type ApplyClient[T kubeinterfaces.ObjectInterface] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// NewApplyControlFuncs wires a typed lister and client, already scoped to the namespace of the applied objects,
+// into ApplyControlFuncs. Patching is wired in when the client supports it.
+func NewApplyControlFuncs[T kubeinterfaces.ObjectInterface](lister CachedGetLister[T], client ApplyClient[T]) ApplyControlFuncs[T] {
+	acf := ApplyControlFuncs[T]{
+		GetCachedFunc:  lister.Get,
+		ListCachedFunc: lister.List,
+		GetFunc:        client.Get,
+		CreateFunc:     client.Create,
+		UpdateFunc:     client.Update,
+		DeleteFunc:     client.Delete,
+	}
+
+	patchClient, ok := client.(PatchClient[T])
+	if ok {
+		acf.PatchFunc = func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+			return patchClient.Patch(ctx, name, pt, data, opts)
+		}
+	}
+
+	return acf
+}
 
 func TypeApplyControlInterface[T kubeinterfaces.ObjectInterface](untyped ApplyControlUntypedInterface) ApplyControlInterface[T] {
 	return ApplyControlFuncs[T]{
@@ -212,6 +619,24 @@ func TypeApplyControlInterface[T kubeinterfaces.ObjectInterface](untyped ApplyCo
 			}
 			return res.(T), err
 		},
+		ListCachedFunc: func(selector labels.Selector) ([]T, error) {
+			objs, err := untyped.ListCached(selector)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]T, 0, len(objs))
+			for _, obj := range objs {
+				res = append(res, obj.(T))
+			}
+			return res, nil
+		},
+		GetFunc: func(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+			res, err := untyped.Get(ctx, name, opts)
+			if res == nil {
+				return *new(T), err
+			}
+			return res.(T), err
+		},
 		CreateFunc: func(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error) {
 			res, err := untyped.Create(ctx, obj, opts)
 			if res == nil {
@@ -229,97 +654,776 @@ func TypeApplyControlInterface[T kubeinterfaces.ObjectInterface](untyped ApplyCo
 		DeleteFunc: func(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 			return untyped.Delete(ctx, name, opts)
 		},
+		PatchFunc: func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+			patcher, ok := untyped.(ApplyControlPatcher[kubeinterfaces.ObjectInterface])
+			if !ok {
+				return *new(T), fmt.Errorf("patching isn't supported by this control")
+			}
+			res, err := patcher.Patch(ctx, name, pt, data, opts)
+			if res == nil {
+				return *new(T), err
+			}
+			return res.(T), err
+		},
 	}
 }
 
 type ApplyOptions struct {
 	ForceOwnership            bool
 	AllowMissingControllerRef bool
+	// PreserveKeyPrefixes lists label and annotation key prefixes that are owned by other actors.
+	// Keys matching any of the prefixes are never set, removed or hashed by the apply,
+	// their values on the existing object are always carried over.
+	PreserveKeyPrefixes []string
+	// RequireManagedByLabel, when set, names a label key that has to be present on the required object.
+	// Objects missing it are rejected before any API call, because pruning, which selects by labels,
+	// wouldn't be able to find them later.
+	RequireManagedByLabel string
+	// DowngradeBlockOwnerDeletion makes the apply retry a create that was refused because the caller isn't allowed
+	// to set blockOwnerDeletion, with blockOwnerDeletion unset on all ownerReferences.
+	// This allows running with restricted RBAC at the cost of the garbage collector not waiting on the dependents.
+	DowngradeBlockOwnerDeletion bool
+	// AllowedNamespaces, when set, restricts the namespaces the apply is allowed to write into.
+	// Objects in any other namespace are rejected before any API call.
+	AllowedNamespaces sets.Set[string]
+	// GenerateName allows applying objects that have no name but have metadata.generateName set.
+	// The existing instance is looked up by the required object's labels, which have to identify it uniquely,
+	// and the object is created with a server generated name when there is none.
+	GenerateName bool
+	// DeduplicateGeneratedByHash changes how GenerateName looks up the existing instance.
+	// Instead of requiring the labels to match a single object, the instance whose hash annotation matches
+	// the required object is used, so there is one instance per distinct content.
+	// When no instance matches, a new one is created and the others are left for the caller to prune.
+	DeduplicateGeneratedByHash bool
+	// VerifyHashIntegrity makes the apply recompute the hash of the existing object instead of trusting its hash annotation,
+	// so changes made by other actors that left the annotation in place get reconciled.
+	// Only metadata keys set by the required object are taken into account, but any field defaulted by the server
+	// makes the hashes differ, so it's only suitable for kinds without defaulting, like ConfigMaps or Secrets.
+	VerifyHashIntegrity bool
+	// EnsureFinalizers lists finalizers that the apply keeps present on the object, each of them only once.
+	// They aren't hashed, so an update is only made when any of them is missing on the existing object.
+	// Finalizers added by other actors are carried over on updates and once the object is being deleted
+	// the apply leaves it alone, so the finalizers can be removed after cleanup, see controllerhelpers.RemoveFinalizer.
+	EnsureFinalizers []string
+	// CanonicalizeServicePorts makes the Service apply sort the ports by name before hashing,
+	// so callers generating the ports in a nondeterministic order don't cause updates.
+	// It has no effect on other kinds.
+	CanonicalizeServicePorts bool
+	// InjectDefaultPodSpread makes the Pod apply add a zone topology spread constraint and a hostname anti-affinity
+	// for Pods of the same cluster, unless constraints for those topology keys are already present.
+	// The cluster is identified by the Pod's cluster name label, Pods without it are left as they are.
+	// Pod placement is immutable, so it has to be enabled from the Pod's creation.
+	// It has no effect on other kinds.
+	InjectDefaultPodSpread bool
+	// InjectDefaultPodSecurityContext makes the Pod apply ensure a baseline pod securityContext
+	// running as non-root with the data dir fsGroup, so the Pods meet the restricted Pod Security Standard.
+	// Fields set by the caller always take precedence.
+	// It has no effect on other kinds.
+	InjectDefaultPodSecurityContext bool
+	// MaxObjectSizeBytes, when positive, limits the serialized size of the written object.
+	// Larger objects are rejected with an ObjectTooLargeError before any write, instead of an opaque API error
+	// when they hit the storage limits. The size is estimated from the JSON encoding.
+	MaxObjectSizeBytes int
+	// RecreatePropagationPolicy sets the propagation policy of the delete issued when an object has to be recreated
+	// because of a change to an immutable field, or deleted because its RequiredFeatureGate is disabled. Defaults to Background.
+	// Kinds that need a specific policy to recreate safely, like StatefulSets orphaning their Pods, keep using it.
+	RecreatePropagationPolicy *metav1.DeletionPropagation
+	// IgnoreNotFoundOnUpdate makes the apply treat an object that disappeared between the cache read and the update
+	// as unchanged, without an error or an event. The returned object is empty in that case.
+	// It suits controllers that get requeued by the delete and re-reconcile anyway.
+	IgnoreNotFoundOnUpdate bool
+	// TimeoutPerCall, when positive, bounds each API call made by the apply with a context derived from the parent one,
+	// so a slow apiserver can't block the reconcile until the parent context expires.
+	// Calls exceeding it fail with a retryable CallTimeoutError. Zero means the calls inherit the parent context.
+	TimeoutPerCall time.Duration
+	// EventObject, when set, is the object the apply events are recorded against instead of the applied object,
+	// e.g. the parent custom resource. The event messages keep referring to the applied object.
+	EventObject runtime.Object
+	// EmitUnchangedEvents makes the apply emit a Normal "<Kind>Unchanged" event when the object is already up to date.
+	// It's meant for debugging why an apply does nothing, as in normal operation it only adds noise.
+	EmitUnchangedEvents bool
+	// SkipOwnershipCheck makes the apply create or update the object regardless of its controllerRef,
+	// and of the controllerRef of the required object, while leaving the ownerReferences of an existing object as they are.
+	// Unlike ForceOwnership it never takes the object over. It's dangerous, the apply can overwrite objects
+	// managed by another controller, so it's only meant for bootstrapping objects the operator doesn't own yet.
+	SkipOwnershipCheck bool
+	// RecreateOnImmutable makes the Service apply delete and recreate a Service whose spec.ipFamilyPolicy
+	// or spec.ipFamilies differ, because the server may refuse to change them in place.
+	// The recreated Service gets a new ClusterIP, so clients using the old one lose connectivity.
+	// Likewise, the VolumeSnapshot apply recreates a VolumeSnapshot whose source differs instead of refusing the change,
+	// which deletes the existing snapshot according to its deletion policy.
+	// These recreations are reported with a Warning event once the existing object is deleted.
+	// It has no effect on other kinds.
+	RecreateOnImmutable bool
+	// UpdateStrategy selects how changes are written to an existing object. Empty means UpdateStrategyFullUpdate.
+	// Strategies using patches need a control supporting them and are validated against the kind before any API call.
+	// Creates and recreations aren't affected.
+	UpdateStrategy UpdateStrategy
+	// StampReconcileTime makes the apply set the LastAppliedTimeAnnotation to the current time whenever it creates
+	// or updates the object, to help debugging when the operator last wrote it.
+	// The annotation is never hashed, so it can't cause an update by itself, and it's kept as it is when nothing changes.
+	StampReconcileTime bool
+	// ReconcileToken, when set, guards against several operator instances writing the same objects,
+	// e.g. with a misconfigured leader election. Every write stores the token in the ReconcileTokenAnnotation
+	// and updates are refused with a ReconcileTokenConflictError when the object carries a newer token
+	// of another instance. The token isn't hashed, so it never causes an update by itself.
+	ReconcileToken *ReconcileToken
+	// ConflictRetryBudget, when set, makes updates failing on a conflict retry right away with a live object,
+	// taking one retry from the budget each time. Sharing one budget across a reconcile caps the total
+	// number of retries, so a storm of conflicts doesn't multiply the API calls. Once it's exhausted,
+	// conflicts are returned as they are and the object is retried with the next reconcile.
+	// Retries read the object with a live get, so the control has to support it.
+	ConflictRetryBudget *ConflictRetryBudget
+	// Clock is used wherever the apply writes a time, like with StampReconcileTime.
+	// Nil means the real clock. Tests can inject a fake one to assert on the written times.
+	Clock clock.PassiveClock
+	// FeatureGates holds the enabled state of feature gates, gates that are missing are disabled.
+	// Make functions can consult it through FeatureEnabled to include or exclude fields, the apply
+	// always hashes the object it's given, so the hash reflects the effective object.
+	FeatureGates map[string]bool
+	// RequiredFeatureGate, when set, conditions the whole apply on the gate being enabled in FeatureGates.
+	// When it's disabled, nothing is created or updated and an existing object controlled by the same controller
+	// is deleted instead, so turning a feature off prunes its objects.
+	RequiredFeatureGate string
+	// ControllerName, when set, is written into the ReconciledByAnnotation whenever the apply creates or updates the object,
+	// to help debugging which controller last touched it in environments running several operators.
+	// The annotation isn't hashed, so it never causes an update by itself, and it's kept as it is when nothing changes.
+	ControllerName string
+	// ProtectedOwnerGVKs lists the kinds of higher-precedence controllers whose objects ForceOwnership must never adopt.
+	// Adopting an object with an ownerReference to any of them is refused with a ProtectedOwnerError,
+	// so operators don't keep taking the objects over from each other. Owners match on their group and kind, any version.
+	ProtectedOwnerGVKs []schema.GroupVersionKind
+	// SpecOnly hands the ownership of labels and annotations to users. The required labels and annotations
+	// are only used when the object is created, updates keep the ones on the existing object as they are.
+	// The hash then covers only the rest of the object, so metadata changes neither cause nor get reverted by an update.
+	// It can't be combined with GenerateName, which looks objects up by their labels.
+	SpecOnly bool
+	// ImmutableFields lists fields that updates must not change, usually derived from the CEL transition rules
+	// of a CRD with ImmutableFieldsFromCRD. Updates changing any of them are refused with an ImmutableFieldError
+	// before any API call, instead of being sent only to be rejected by the apiserver.
+	// Recreations aren't affected, they don't update the object.
+	ImmutableFields []ImmutableField
+	// ReconcileCache, when set, is consulted for the existing object before the lister and updated with every write,
+	// so applying many objects, or the same object several times, within one reconcile avoids redundant reads.
+	// It has to be created anew for every reconcile with NewReconcileCache, it's never invalidated otherwise.
+	ReconcileCache *ReconcileCache
+	// ResourceVersionMatch sets how the live get made by a conflict retry, see ConflictRetryBudget, relates
+	// to the resource version of the existing object the conflicting update was based on.
+	// Only ResourceVersionMatchNotOlderThan is supported. It lets the apiserver serve the get from its watch cache,
+	// as long as the read is at least as new as what the apply last saw. Reads that are older anyway are rejected
+	// and retried once as a quorum read. Empty means the get is always a quorum read.
+	ResourceVersionMatch metav1.ResourceVersionMatch
+	// StripDefaults, when set, is used to recognize fields of the required object that are set to their default values,
+	// e.g. the defaulter of a scheme with the defaulting functions of the kind registered. Those fields are left unset,
+	// so the written object matches what the apiserver stores after defaulting it and spurious diffs are avoided.
+	// Stripping defaults every field one by one, so it's only meant for kinds where the churn outweighs the cost.
+	StripDefaults runtime.ObjectDefaulter
+	// AuditSink, when set, is called with an AuditEntry for every create, update, patch and delete the apply makes,
+	// including the failed ones, to keep a record of the changes made by the operator, e.g. for compliance.
+	// Applies that leave the object unchanged make no entries. NewJSONLinesAuditSink provides a sink writing JSON lines.
+	// The sink is called synchronously and has to be safe for concurrent use.
+	AuditSink func(AuditEntry)
+	// ComparisonIgnorePaths lists JSONPath expressions, e.g. "{.spec.template.spec.containers[*].image}", of fields
+	// written by other actors. The matched values are zeroed before hashing, so they neither cause nor get reverted
+	// by an update, and the existing values are carried over when the object is updated for other reasons.
+	// Only struct fields and list items can be matched, see ApplyOptions.PreserveKeyPrefixes for labels and annotations.
+	ComparisonIgnorePaths []string
+	// PostCreateWaitForCache makes the apply wait, after a successful create, until the created object is visible
+	// in the lister, bounded by the context. Multi-step syncs can otherwise see the object missing from a lagging
+	// informer cache in a later step and try to create it again.
+	PostCreateWaitForCache bool
+	// ExpectedResourceVersion, when set, makes updates conditional on the object still being at this resource version,
+	// for callers that computed the required object from a version they read themselves. A mismatch fails the update
+	// with a ResourceVersionConflictError and it's never retried, regardless of ConflictRetryBudget.
+	// Creates aren't affected.
+	ExpectedResourceVersion string
+	// FieldValidation sets how the apiserver handles unknown and duplicate fields in creates and updates,
+	// metav1.FieldValidationStrict when empty. With metav1.FieldValidationWarn, the warnings the apiserver returns,
+	// e.g. for deprecated fields, are reported as ApplyWarning events on the object, as long as the clients
+	// are built from a rest config using a WarningHandler.
+	FieldValidation string
 }
 
-func ApplyGenericWithHandlers[T kubeinterfaces.ObjectInterface](
-	ctx context.Context,
-	control ApplyControlInterface[T],
-	recorder record.EventRecorder,
-	required T,
-	options ApplyOptions,
-	projectFunc func(required *T, existing T),
-	getRecreateReasonFunc func(required T, existing T) (string, *metav1.DeletionPropagation, error),
-) (T, bool, error) {
-	gvk := resource.GetObjectGVKOrUnknown(required)
+// FeatureEnabled returns whether the feature gate is enabled in FeatureGates.
+func (o *ApplyOptions) FeatureEnabled(name string) bool {
+	return o.FeatureGates[name]
+}
 
-	requiredControllerRef := metav1.GetControllerOfNoCopy(required)
-	if !options.AllowMissingControllerRef && requiredControllerRef == nil {
-		return *new(T), false, fmt.Errorf("%s %q is missing controllerRef", gvk, naming.ObjRef(required))
+func (o *ApplyOptions) fieldValidation() string {
+	if len(o.FieldValidation) == 0 {
+		return metav1.FieldValidationStrict
 	}
+	return o.FieldValidation
+}
 
-	requiredCopy := required.DeepCopyObject().(T)
-	err := SetHashAnnotation(requiredCopy)
-	if err != nil {
-		return *new(T), false, err
+func (o *ApplyOptions) now() time.Time {
+	if o.Clock == nil {
+		return time.Now()
 	}
 
-	createOptions := metav1.CreateOptions{
-		FieldValidation: metav1.FieldValidationStrict,
-	}
+	return o.Clock.Now()
+}
 
-	existing, err := control.GetCached(requiredCopy.GetName())
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			return *new(T), false, err
-		}
+// ApplyOperation describes which branch the apply took.
+type ApplyOperation string
 
-		resourcemerge.SanitizeObject(requiredCopy)
-		actual, err := control.Create(ctx, requiredCopy, createOptions)
-		if apierrors.IsAlreadyExists(err) {
-			klog.V(2).InfoS("Already exists (stale cache)", "Service", klog.KObj(requiredCopy))
-		} else {
-			ReportCreateEvent(recorder, requiredCopy, err)
+const (
+	// ApplyOperationCreated means the object didn't exist and was created.
+	ApplyOperationCreated ApplyOperation = "Created"
+	// ApplyOperationRecreated means the object couldn't be updated in place, so it was deleted and created again.
+	ApplyOperationRecreated ApplyOperation = "Recreated"
+	// ApplyOperationAdoptedAndUpdated means the object had no controller and was claimed by the update, see ApplyOptions.ForceOwnership.
+	ApplyOperationAdoptedAndUpdated ApplyOperation = "AdoptedAndUpdated"
+	// ApplyOperationUpdated means the object existed with a different hash and was updated.
+	ApplyOperationUpdated ApplyOperation = "Updated"
+	// ApplyOperationUnchanged means the object already matched the required state.
+	ApplyOperationUnchanged ApplyOperation = "Unchanged"
+	// ApplyOperationRejected means the apply refused to act on the object, e.g. because it's controlled by someone else.
+	ApplyOperationRejected ApplyOperation = "Rejected"
+	// ApplyOperationDeleted means the object was deleted because its ApplyOptions.RequiredFeatureGate is disabled.
+	ApplyOperationDeleted ApplyOperation = "Deleted"
+)
+
+type ApplyResult[T kubeinterfaces.ObjectInterface] struct {
+	Object    T
+	Changed   bool
+	Operation ApplyOperation
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
 		}
-		return actual, err == nil, err
 	}
 
-	existingControllerRef := metav1.GetControllerOfNoCopy(existing)
+	return false
+}
 
-	existingControllerRefUID := types.UID("")
-	if existingControllerRef != nil {
-		existingControllerRefUID = existingControllerRef.UID
+// deleteKeysWithPrefixes removes keys matching any of the prefixes from the map, including their removal keys.
+func deleteKeysWithPrefixes(m map[string]string, prefixes []string) {
+	if len(prefixes) == 0 {
+		return
 	}
-	requiredControllerRefUID := types.UID("")
-	if requiredControllerRef != nil {
-		requiredControllerRefUID = requiredControllerRef.UID
+
+	for k := range m {
+		if hasAnyPrefix(k, prefixes) {
+			delete(m, k)
+		}
 	}
+}
 
-	if existingControllerRef == nil && requiredControllerRef != nil && options.ForceOwnership {
-		klog.V(2).InfoS("Forcing apply to claim the the object", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy))
-	} else if existingControllerRefUID != requiredControllerRefUID {
-		// This is not the place to handle adoption.
-		err := fmt.Errorf("%s %q isn't controlled by us", gvk, naming.ObjRef(requiredCopy))
-		ReportUpdateEvent(recorder, requiredCopy, err)
-		return *new(T), false, err
+// addMissingFinalizers appends the finalizers that obj doesn't have yet, keeping the existing order.
+func addMissingFinalizers(obj metav1.Object, finalizers []string) {
+	for _, f := range finalizers {
+		if !slices.Contains(obj.GetFinalizers(), f) {
+			obj.SetFinalizers(append(obj.GetFinalizers(), f))
+		}
+	}
+}
+
+// hasFinalizers returns true when obj has all the finalizers.
+func hasFinalizers(obj metav1.Object, finalizers []string) bool {
+	for _, f := range finalizers {
+		if !slices.Contains(obj.GetFinalizers(), f) {
+			return false
+		}
 	}
 
-	existingHash := existing.GetAnnotations()[naming.ManagedHash]
-	requiredHash := requiredCopy.GetAnnotations()[naming.ManagedHash]
+	return true
+}
 
-	// If they are the same do nothing.
-	if existingHash == requiredHash {
-		return existing, false, nil
+// withoutFinalizers returns obj, or its copy without the finalizers when it has any of them.
+func withoutFinalizers[T kubeinterfaces.ObjectInterface](obj T, finalizers []string) T {
+	if !slices.ContainsFunc(obj.GetFinalizers(), func(f string) bool { return slices.Contains(finalizers, f) }) {
+		return obj
 	}
 
-	resourcemerge.MergeMetadataInPlace(requiredCopy, existing)
+	objCopy := obj.DeepCopyObject().(T)
+	objCopy.SetFinalizers(slices.DeleteFunc(slices.Clone(objCopy.GetFinalizers()), func(f string) bool {
+		return slices.Contains(finalizers, f)
+	}))
 
-	// Project allocated fields, like spec.clusterIP for services.
-	if projectFunc != nil {
-		projectFunc(&requiredCopy, existing)
+	return objCopy
+}
+
+// verifyObjectSize estimates the serialized size of obj and fails if it exceeds maxSizeBytes.
+// Non-positive maxSizeBytes disables the check.
+func verifyObjectSize(obj runtime.Object, gvk schema.GroupVersionKind, maxSizeBytes int) error {
+	if maxSizeBytes <= 0 {
+		return nil
 	}
 
-	var recreateReason string
-	var propagationPolicy *metav1.DeletionPropagation
-	if getRecreateReasonFunc != nil {
-		recreateReason, propagationPolicy, err = getRecreateReasonFunc(requiredCopy, existing)
-		if err != nil {
-			return *new(T), false, fmt.Errorf("can't get recreate reason: %w", err)
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("can't serialize %s %q to estimate its size: %w", gvk, naming.ObjRef(obj.(metav1.Object)), err)
+	}
+
+	if len(data) > maxSizeBytes {
+		return &ObjectTooLargeError{
+			GVK:          gvk,
+			Ref:          naming.ObjRef(obj.(metav1.Object)),
+			SizeBytes:    len(data),
+			MaxSizeBytes: maxSizeBytes,
+		}
+	}
+
+	return nil
+}
+
+// deleteFeatureGatedObject deletes the existing instance of the required object when it's controlled
+// by the same controller, because the feature gate guarding it is disabled.
+func deleteFeatureGatedObject[T kubeinterfaces.ObjectInterface](ctx context.Context, control ApplyControlInterface[T], recorder record.EventRecorder, required T, options ApplyOptions) (ApplyResult[T], error) {
+	unchanged := ApplyResult[T]{
+		Operation: ApplyOperationUnchanged,
+	}
+
+	if len(required.GetName()) == 0 {
+		return unchanged, nil
+	}
+
+	existing, err := control.GetCached(required.GetName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return unchanged, nil
+		}
+		return ApplyResult[T]{}, err
+	}
+
+	if existing.GetDeletionTimestamp() != nil {
+		return unchanged, nil
+	}
+
+	existingControllerRef := metav1.GetControllerOfNoCopy(existing)
+	requiredControllerRef := metav1.GetControllerOfNoCopy(required)
+	if existingControllerRef == nil || requiredControllerRef == nil || existingControllerRef.UID != requiredControllerRef.UID {
+		// Objects we don't control aren't ours to prune.
+		return unchanged, nil
+	}
+
+	propagationPolicy := options.RecreatePropagationPolicy
+	if propagationPolicy == nil {
+		propagationPolicy = pointer.Ptr(metav1.DeletePropagationBackground)
+	}
+
+	klog.V(2).InfoS("Deleting object of a disabled feature", "FeatureGate", options.RequiredFeatureGate, "GVK", resource.GetObjectGVKOrUnknown(existing), "Ref", naming.ObjRefWithUID(existing))
+	uid := existing.GetUID()
+	err = control.Delete(ctx, existing.GetName(), metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{
+			UID: &uid,
+		},
+		PropagationPolicy: propagationPolicy,
+	})
+	reportApplyEvent(recorder, existing, err, "delete")
+	if err != nil {
+		typedErr, _ := classifyApplyError(err)
+		return ApplyResult[T]{Operation: ApplyOperationDeleted}, typedErr
+	}
+
+	return ApplyResult[T]{
+		Changed:   true,
+		Operation: ApplyOperationDeleted,
+	}, nil
+}
+
+// ApplyGenericWithResult applies the required object and reports which operation was taken to get there.
+// When an API call fails, the result carries the operation that was attempted.
+// The required object is never mutated, all hashing, defaulting and carrying over of existing fields
+// happens on a deep copy, so callers can safely reuse it, e.g. from a cache.
+func ApplyGenericWithResult[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	control ApplyControlInterface[T],
+	recorder record.EventRecorder,
+	required T,
+	options ApplyOptions,
+	projectFunc func(required *T, existing T),
+	getRecreateReasonFunc func(required T, existing T) (string, *metav1.DeletionPropagation, error),
+) (ApplyResult[T], error) {
+	return applyGenericWithResult(ctx, control, recorder, required, options, projectFunc, getRecreateReasonFunc, nil)
+}
+
+// applyGenericWithResult implements ApplyGenericWithResult. Kinds that have fields other actors are known to change
+// behind the operator's back can pass getDriftReasonFunc, which is called for existing objects with an up-to-date hash.
+// A non-empty reason makes the apply update the object anyway and report the correction with an event.
+func applyGenericWithResult[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	control ApplyControlInterface[T],
+	recorder record.EventRecorder,
+	required T,
+	options ApplyOptions,
+	projectFunc func(required *T, existing T),
+	getRecreateReasonFunc func(required T, existing T) (string, *metav1.DeletionPropagation, error),
+	getDriftReasonFunc func(required T, existing T) string,
+) (ApplyResult[T], error) {
+	gvk := resource.GetObjectGVKOrUnknown(required)
+
+	rejected := ApplyResult[T]{
+		Operation: ApplyOperationRejected,
+	}
+
+	requiredControllerRef := metav1.GetControllerOfNoCopy(required)
+	if !options.AllowMissingControllerRef && !options.SkipOwnershipCheck && requiredControllerRef == nil {
+		return rejected, fmt.Errorf("%s %q is missing controllerRef", gvk, naming.ObjRef(required))
+	}
+
+	if options.AllowedNamespaces != nil && !options.AllowedNamespaces.Has(required.GetNamespace()) {
+		return rejected, &NamespaceNotAllowedError{
+			GVK:       *gvk,
+			Ref:       naming.ObjRef(required),
+			Namespace: required.GetNamespace(),
+		}
+	}
+
+	if len(options.RequireManagedByLabel) != 0 {
+		_, ok := required.GetLabels()[options.RequireManagedByLabel]
+		if !ok {
+			return rejected, &MissingRequiredLabelError{
+				GVK:   *gvk,
+				Ref:   naming.ObjRef(required),
+				Label: options.RequireManagedByLabel,
+			}
+		}
+	}
+
+	err := validateUpdateStrategy(options.UpdateStrategy, *gvk)
+	if err != nil {
+		return rejected, err
+	}
+
+	err = validateResourceVersionMatch(options.ResourceVersionMatch)
+	if err != nil {
+		return rejected, err
+	}
+
+	if options.SpecOnly && options.GenerateName {
+		return rejected, fmt.Errorf("%s %q can't be applied with both SpecOnly and GenerateName", gvk, naming.ObjRef(required))
+	}
+
+	switch options.FieldValidation {
+	case "", metav1.FieldValidationStrict, metav1.FieldValidationWarn, metav1.FieldValidationIgnore:
+	default:
+		return rejected, fmt.Errorf("%s %q can't be applied with unknown field validation %q", gvk, naming.ObjRef(required), options.FieldValidation)
+	}
+
+	recorder = eventRecorderForOptions(recorder, options)
+
+	// Retries wrap the original control, the options are applied to it again.
+	unwrappedControl := control
+
+	if options.AuditSink != nil {
+		control = newAuditingApplyControl[T](control, &options, *gvk, required.GetNamespace())
+	}
+
+	if options.TimeoutPerCall > 0 {
+		control = &timeoutApplyControl[T]{
+			control: control,
+			timeout: options.TimeoutPerCall,
+		}
+	}
+
+	if options.ReconcileCache != nil {
+		control = &reconcileCachedApplyControl[T]{
+			control:   control,
+			cache:     options.ReconcileCache,
+			gvk:       *gvk,
+			namespace: required.GetNamespace(),
+		}
+	}
+
+	if len(options.RequiredFeatureGate) != 0 && !options.FeatureEnabled(options.RequiredFeatureGate) {
+		return deleteFeatureGatedObject(ctx, control, recorder, required, options)
+	}
+
+	ignorePaths, err := parseComparisonIgnorePaths(options.ComparisonIgnorePaths)
+	if err != nil {
+		return rejected, err
+	}
+
+	requiredCopy := required.DeepCopyObject().(T)
+
+	if options.FieldValidation == metav1.FieldValidationWarn {
+		var warnings *warningCollector
+		ctx, warnings = withWarningCollector(ctx)
+		defer func() {
+			reportWarningEvents(recorder, requiredCopy, warnings.take())
+		}()
+	}
+
+	if options.StripDefaults != nil {
+		stripped, err := stripDefaultedFields(requiredCopy, options.StripDefaults)
+		if err != nil {
+			return ApplyResult[T]{}, fmt.Errorf("can't strip defaulted fields of %s %q: %w", gvk, naming.ObjRef(required), err)
+		}
+		requiredCopy = stripped.(T)
+	}
+
+	// Drop any keys we are not supposed to manage so they are neither hashed nor overwritten.
+	// Existing values are carried over when merging metadata.
+	deleteKeysWithPrefixes(requiredCopy.GetLabels(), options.PreserveKeyPrefixes)
+	deleteKeysWithPrefixes(requiredCopy.GetAnnotations(), options.PreserveKeyPrefixes)
+
+	// Labels and annotations of spec-only applies are set aside for the create, so they are neither hashed
+	// nor merged into an existing object.
+	var specOnlyLabels, specOnlyAnnotations map[string]string
+	if options.SpecOnly {
+		specOnlyLabels = requiredCopy.GetLabels()
+		specOnlyAnnotations = requiredCopy.GetAnnotations()
+		requiredCopy.SetLabels(map[string]string{})
+		requiredCopy.SetAnnotations(map[string]string{})
+	}
+
+	if len(ignorePaths) == 0 {
+		err = SetHashAnnotation(requiredCopy)
+		if err != nil {
+			return rejected, err
+		}
+	} else {
+		hashCopy := requiredCopy.DeepCopyObject().(T)
+		err = zeroIgnoredPaths(hashCopy, ignorePaths)
+		if err != nil {
+			return rejected, err
+		}
+
+		err = SetHashAnnotation(hashCopy)
+		if err != nil {
+			return rejected, err
+		}
+		requiredCopy.SetAnnotations(hashCopy.GetAnnotations())
+	}
+
+	// Ensured finalizers are added only after hashing, so their presence on the existing object doesn't cause updates.
+	addMissingFinalizers(requiredCopy, options.EnsureFinalizers)
+
+	err = verifyObjectSize(requiredCopy, *gvk, options.MaxObjectSizeBytes)
+	if err != nil {
+		return rejected, err
+	}
+
+	// The name has to be filled in only after hashing, otherwise the hash would differ from the one computed on create.
+	if len(requiredCopy.GetName()) == 0 && len(requiredCopy.GetGenerateName()) != 0 && options.GenerateName {
+		var name string
+		if options.DeduplicateGeneratedByHash {
+			name, err = findGeneratedNameByHash(control, requiredCopy)
+		} else {
+			name, err = findGeneratedName(control, requiredCopy)
+		}
+		if err != nil {
+			return ApplyResult[T]{}, fmt.Errorf("can't find existing %s %q: %w", gvk, naming.ObjRef(required), err)
+		}
+		requiredCopy.SetName(name)
+	}
+
+	createOptions := metav1.CreateOptions{
+		FieldValidation: options.fieldValidation(),
+	}
+
+	var existing T
+	if len(requiredCopy.GetName()) != 0 {
+		existing, err = control.GetCached(requiredCopy.GetName())
+	} else {
+		err = apierrors.NewNotFound(schema.GroupResource{}, "")
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ApplyResult[T]{}, err
+		}
+
+		resourcemerge.SanitizeObject(requiredCopy)
+		toCreate := requiredCopy
+		if options.SpecOnly {
+			toCreate = requiredCopy.DeepCopyObject().(T)
+			toCreate.SetLabels(specOnlyLabels)
+			annotations := maps.Clone(specOnlyAnnotations)
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			maps.Copy(annotations, requiredCopy.GetAnnotations())
+			toCreate.SetAnnotations(annotations)
+		}
+		actual, createErr := createWithOwnerReferenceFallback(ctx, control, toCreate, createOptions, options)
+		if createErr == nil && len(requiredCopy.GetName()) == 0 {
+			// Report the name generated by the server.
+			requiredCopy.SetName(actual.GetName())
+		}
+
+		if !apierrors.IsAlreadyExists(createErr) || len(requiredCopy.GetName()) == 0 {
+			reportApplyCreateEvent(recorder, requiredCopy, createErr)
+			if isNamespaceTerminatingAPIError(createErr) {
+				return ApplyResult[T]{Operation: ApplyOperationCreated}, &NamespaceTerminatingError{
+					Namespace: requiredCopy.GetNamespace(),
+					Err:       createErr,
+				}
+			}
+			typedErr, _ := classifyApplyError(createErr)
+			if createErr == nil && options.PostCreateWaitForCache {
+				typedErr = waitForCreatedObjectInCache(ctx, unwrappedControl, actual)
+			}
+			return ApplyResult[T]{
+				Object:    actual,
+				Changed:   createErr == nil,
+				Operation: ApplyOperationCreated,
+			}, typedErr
+		}
+
+		// The object was created by someone else after our cache was populated.
+		// Read it from the apiserver and let the update path decide whether we can take it over.
+		klog.V(2).InfoS("Already exists (stale cache), reading the live object", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy))
+		existing, err = control.Get(ctx, requiredCopy.GetName(), metav1.GetOptions{})
+		if err != nil {
+			klog.V(2).InfoS("Can't read the live object after a create conflict", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy), "Error", err)
+			typedErr, _ := classifyApplyError(createErr)
+			return ApplyResult[T]{Operation: ApplyOperationCreated}, typedErr
+		}
+	}
+
+	existingControllerRef := metav1.GetControllerOfNoCopy(existing)
+
+	existingControllerRefUID := types.UID("")
+	if existingControllerRef != nil {
+		existingControllerRefUID = existingControllerRef.UID
+	}
+	requiredControllerRefUID := types.UID("")
+	if requiredControllerRef != nil {
+		requiredControllerRefUID = requiredControllerRef.UID
+	}
+
+	updateOperation := ApplyOperationUpdated
+	if options.SkipOwnershipCheck {
+		klog.V(2).InfoS("Skipping ownership check", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy))
+	} else if existingControllerRef == nil && requiredControllerRef != nil && options.ForceOwnership {
+		protectedOwnerRef := findProtectedOwnerReference(existing, options.ProtectedOwnerGVKs)
+		if protectedOwnerRef != nil {
+			err := &ProtectedOwnerError{
+				GVK:      *gvk,
+				Ref:      naming.ObjRef(requiredCopy),
+				OwnerRef: *protectedOwnerRef,
+			}
+			reportApplyEvent(recorder, requiredCopy, err, "update")
+			return rejected, err
+		}
+
+		klog.V(2).InfoS("Forcing apply to claim the the object", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy))
+		updateOperation = ApplyOperationAdoptedAndUpdated
+	} else if existingControllerRefUID != requiredControllerRefUID {
+		// This is not the place to handle adoption.
+		err := fmt.Errorf("%s %q isn't controlled by us", gvk, naming.ObjRef(requiredCopy))
+		reportApplyEvent(recorder, requiredCopy, err, "update")
+		return rejected, err
+	}
+
+	if len(options.EnsureFinalizers) != 0 && existing.GetDeletionTimestamp() != nil {
+		// The object is being finalized, updating it could bring back the finalizer that's already been removed.
+		klog.V(4).InfoS("Object is being deleted, skipping apply", "GVK", gvk, "Ref", naming.ObjRef(existing))
+		return ApplyResult[T]{
+			Object:    existing,
+			Changed:   false,
+			Operation: ApplyOperationUnchanged,
+		}, nil
+	}
+
+	upToDate, err := isHashUpToDate(requiredCopy, existing)
+	if err != nil {
+		return ApplyResult[T]{}, fmt.Errorf("can't compare hashes: %w", err)
+	}
+
+	if upToDate && options.VerifyHashIntegrity {
+		comparableExisting := withoutFinalizers(existing, options.EnsureFinalizers)
+		if len(ignorePaths) != 0 {
+			comparableExisting = comparableExisting.DeepCopyObject().(T)
+			err = zeroIgnoredPaths(comparableExisting, ignorePaths)
+			if err != nil {
+				return ApplyResult[T]{}, err
+			}
+		}
+
+		intact, err := isHashIntact(comparableExisting, requiredCopy)
+		if err != nil {
+			return ApplyResult[T]{}, fmt.Errorf("can't verify hash integrity: %w", err)
+		}
+		if !intact {
+			klog.V(2).InfoS("Existing object doesn't match its hash annotation, it will be updated", "GVK", gvk, "Ref", naming.ObjRef(existing))
+			upToDate = false
+		}
+	}
+
+	if upToDate && !hasFinalizers(existing, options.EnsureFinalizers) {
+		klog.V(2).InfoS("Existing object is missing an ensured finalizer, it will be updated", "GVK", gvk, "Ref", naming.ObjRef(existing))
+		upToDate = false
+	}
+
+	var driftReason string
+	if upToDate && getDriftReasonFunc != nil {
+		driftReason = getDriftReasonFunc(requiredCopy, existing)
+		if len(driftReason) != 0 {
+			klog.V(2).InfoS("Existing object drifted from the required state, it will be updated", "GVK", gvk, "Ref", naming.ObjRef(existing), "Reason", driftReason)
+			upToDate = false
+		}
+	}
+
+	// If they are the same do nothing.
+	if upToDate {
+		if options.EmitUnchangedEvents {
+			reportUnchangedEvent(recorder, existing)
+		}
+		return ApplyResult[T]{
+			Object:    existing,
+			Changed:   false,
+			Operation: ApplyOperationUnchanged,
+		}, nil
+	}
+
+	resourcemerge.MergeMetadataInPlace(requiredCopy, existing)
+
+	if options.SkipOwnershipCheck {
+		requiredCopy.SetOwnerReferences(existing.GetOwnerReferences())
+	}
+
+	if len(options.EnsureFinalizers) != 0 {
+		// Finalizers of other actors have to stay until they are done with their cleanup.
+		for _, f := range existing.GetFinalizers() {
+			if !slices.Contains(requiredCopy.GetFinalizers(), f) {
+				requiredCopy.SetFinalizers(append(requiredCopy.GetFinalizers(), f))
+			}
+		}
+	}
+
+	if len(ignorePaths) != 0 {
+		// Existing objects come from a cache, they must not share any values with the object being written.
+		err = carryOverIgnoredPaths(requiredCopy, existing.DeepCopyObject(), ignorePaths)
+		if err != nil {
+			return ApplyResult[T]{}, err
+		}
+	}
+
+	// Project allocated fields, like spec.clusterIP for services.
+	if projectFunc != nil {
+		projectFunc(&requiredCopy, existing)
+	}
+
+	// Merged metadata can grow the object.
+	err = verifyObjectSize(requiredCopy, *gvk, options.MaxObjectSizeBytes)
+	if err != nil {
+		return rejected, err
+	}
+
+	if options.ReconcileToken != nil {
+		err = checkReconcileToken(existing, *options.ReconcileToken, *gvk)
+		if err != nil {
+			return rejected, err
+		}
+		setReconcileToken(requiredCopy, *options.ReconcileToken)
+	}
+
+	if len(options.ControllerName) != 0 {
+		setReconciledBy(requiredCopy, options.ControllerName)
+	}
+
+	var recreateReason string
+	var propagationPolicy *metav1.DeletionPropagation
+	if getRecreateReasonFunc != nil {
+		recreateReason, propagationPolicy, err = getRecreateReasonFunc(requiredCopy, existing)
+		if err != nil {
+			return ApplyResult[T]{}, fmt.Errorf("can't get recreate reason: %w", err)
 		}
 	}
 	if len(recreateReason) > 0 {
@@ -330,6 +1434,9 @@ func ApplyGenericWithHandlers[T kubeinterfaces.ObjectInterface](
 			"Ref", naming.ObjRefWithUID(existing),
 		)
 
+		if propagationPolicy == nil {
+			propagationPolicy = options.RecreatePropagationPolicy
+		}
 		if propagationPolicy == nil {
 			propagationPolicy = pointer.Ptr(metav1.DeletePropagationBackground)
 		}
@@ -337,44 +1444,397 @@ func ApplyGenericWithHandlers[T kubeinterfaces.ObjectInterface](
 		err := control.Delete(ctx, existing.GetName(), metav1.DeleteOptions{
 			PropagationPolicy: propagationPolicy,
 		})
-		ReportDeleteEvent(recorder, existing, err)
+		reportApplyEvent(recorder, existing, err, "delete")
 		if err != nil {
-			return *new(T), false, err
+			typedErr, _ := classifyApplyError(err)
+			return ApplyResult[T]{Operation: ApplyOperationRecreated}, typedErr
+		}
+
+		// Recreations allowed by RecreateOnImmutable lose state the server kept for the object,
+		// so they are reported once the delete went through.
+		if options.RecreateOnImmutable {
+			recorder.Eventf(
+				existing,
+				corev1.EventTypeWarning,
+				fmt.Sprintf("%sRecreating", gvk.Kind),
+				"Recreating %s %s because %s",
+				gvk.Kind, naming.ObjRef(existing), recreateReason,
+			)
 		}
 
 		resourcemerge.SanitizeObject(requiredCopy)
-		created, err := control.Create(ctx, requiredCopy, createOptions)
-		ReportCreateEvent(recorder, requiredCopy, err)
+		created, err := createWithOwnerReferenceFallback(ctx, control, requiredCopy, createOptions, options)
+		reportApplyCreateEvent(recorder, requiredCopy, err)
+		if isNamespaceTerminatingAPIError(err) {
+			return ApplyResult[T]{Operation: ApplyOperationRecreated}, &NamespaceTerminatingError{
+				Namespace: requiredCopy.GetNamespace(),
+				Err:       err,
+			}
+		}
 		if err != nil {
-			return *new(T), false, err
+			typedErr, _ := classifyApplyError(err)
+			return ApplyResult[T]{Operation: ApplyOperationRecreated}, typedErr
 		}
 
-		return created, true, nil
+		if options.PostCreateWaitForCache {
+			err = waitForCreatedObjectInCache(ctx, unwrappedControl, created)
+		}
+
+		return ApplyResult[T]{
+			Object:    created,
+			Changed:   true,
+			Operation: ApplyOperationRecreated,
+		}, err
 	}
 
-	// Honor the required RV if it was already set.
-	// Required objects set RV in case their input is based on a previous version of itself.
-	if len(requiredCopy.GetResourceVersion()) == 0 {
-		requiredCopy.SetResourceVersion(existing.GetResourceVersion())
+	changedField, err := findChangedImmutableField(requiredCopy, existing, options.ImmutableFields)
+	if err != nil {
+		return ApplyResult[T]{}, fmt.Errorf("can't check immutable fields: %w", err)
+	}
+	if changedField != nil {
+		err := &ImmutableFieldError{
+			GVK:   *gvk,
+			Ref:   naming.ObjRef(requiredCopy),
+			Field: *changedField,
+		}
+		reportApplyEvent(recorder, requiredCopy, err, "update")
+		return rejected, err
 	}
 
-	actual, err := control.Update(
-		ctx,
-		requiredCopy,
-		metav1.UpdateOptions{
-			FieldValidation: metav1.FieldValidationStrict,
-		},
-	)
+	// Required objects set RV in case their input is based on a previous version of itself, it is honored if set.
+	resourcemerge.PreserveServerFields(existing, requiredCopy)
+	if len(options.ExpectedResourceVersion) != 0 {
+		requiredCopy.SetResourceVersion(options.ExpectedResourceVersion)
+	}
+
+	if options.StampReconcileTime {
+		stampReconcileTime(requiredCopy, options.now())
+	}
+
+	actual, err := updateWithStrategy(ctx, control, existing, requiredCopy, *gvk, options.UpdateStrategy, options.fieldValidation())
+	if apierrors.IsNotFound(err) && options.IgnoreNotFoundOnUpdate {
+		klog.V(2).InfoS("Object was deleted before it could be updated, ignoring", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy))
+		return ApplyResult[T]{
+			Changed:   false,
+			Operation: ApplyOperationUnchanged,
+		}, nil
+	}
+	if apierrors.IsConflict(err) && len(options.ExpectedResourceVersion) != 0 {
+		klog.V(2).InfoS("Object was modified since the expected resource version", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy), "ExpectedResourceVersion", options.ExpectedResourceVersion)
+		typedErr, _ := classifyApplyError(err)
+		return ApplyResult[T]{Operation: updateOperation}, &ResourceVersionConflictError{
+			GVK:                     *gvk,
+			Ref:                     naming.ObjRef(requiredCopy),
+			ExpectedResourceVersion: options.ExpectedResourceVersion,
+			Err:                     typedErr,
+		}
+	}
+	if apierrors.IsConflict(err) && options.ConflictRetryBudget.take() {
+		klog.V(2).InfoS("Hit update conflict, retrying with a live object.", "GVK", gvk, "Ref", naming.ObjRef(requiredCopy))
+		liveControl := &liveApplyControl[T]{
+			ctx:     ctx,
+			control: unwrappedControl,
+		}
+		if options.ResourceVersionMatch == metav1.ResourceVersionMatchNotOlderThan {
+			liveControl.minResourceVersion = existing.GetResourceVersion()
+		}
+		return applyGenericWithResult(ctx, liveControl, recorder, required, options, projectFunc, getRecreateReasonFunc, getDriftReasonFunc)
+	}
 	if apierrors.IsConflict(err) {
 		klog.V(2).InfoS("Hit update conflict, will retry.", "Service", klog.KObj(requiredCopy))
 	} else {
-		ReportUpdateEvent(recorder, requiredCopy, err)
+		reportApplyEvent(recorder, requiredCopy, err, "update")
+	}
+	if err != nil {
+		typedErr, _ := classifyApplyError(err)
+		return ApplyResult[T]{Operation: updateOperation}, fmt.Errorf("can't update %s %q: %w", gvk, naming.ObjRef(requiredCopy), typedErr)
+	}
+
+	if len(driftReason) != 0 {
+		reportDriftCorrectedEvent(recorder, requiredCopy, driftReason)
+	}
+
+	return ApplyResult[T]{
+		Object:    actual,
+		Changed:   true,
+		Operation: updateOperation,
+	}, nil
+}
+
+func ApplyGenericWithHandlers[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	control ApplyControlInterface[T],
+	recorder record.EventRecorder,
+	required T,
+	options ApplyOptions,
+	projectFunc func(required *T, existing T),
+	getRecreateReasonFunc func(required T, existing T) (string, *metav1.DeletionPropagation, error),
+) (T, bool, error) {
+	return applyGenericWithHandlers(ctx, control, recorder, required, options, projectFunc, getRecreateReasonFunc, nil)
+}
+
+func applyGenericWithHandlers[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	control ApplyControlInterface[T],
+	recorder record.EventRecorder,
+	required T,
+	options ApplyOptions,
+	projectFunc func(required *T, existing T),
+	getRecreateReasonFunc func(required T, existing T) (string, *metav1.DeletionPropagation, error),
+	getDriftReasonFunc func(required T, existing T) string,
+) (T, bool, error) {
+	res, err := applyGenericWithResult[T](ctx, control, recorder, required, options, projectFunc, getRecreateReasonFunc, getDriftReasonFunc)
+	klog.V(4).InfoS("Applied object", "GVK", resource.GetObjectGVKOrUnknown(required), "Ref", naming.ObjRef(required), "Operation", res.Operation, "Error", err)
+	return res.Object, res.Changed, err
+}
+
+// ApplyGenericWithTransform is like ApplyGeneric but calls transform on the object to be updated,
+// after the metadata was merged, so callers can carry forward fields the apply doesn't know about.
+// The transform isn't part of the hash, so fields it sets don't trigger updates on their own.
+func ApplyGenericWithTransform[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	control ApplyControlInterface[T],
+	recorder record.EventRecorder,
+	required T,
+	options ApplyOptions,
+	transform func(required T, existing T),
+) (T, bool, error) {
+	return ApplyGenericWithHandlers[T](
+		ctx,
+		control,
+		recorder,
+		required,
+		options,
+		func(required *T, existing T) {
+			transform(*required, existing)
+		},
+		nil,
+	)
+}
+
+// findGeneratedName returns the name of the existing object matching the labels of the required object
+// that uses a generated name, or an empty string if there is none.
+func findGeneratedName[T kubeinterfaces.ObjectInterface](control ApplyControlInterface[T], required T) (string, error) {
+	if len(required.GetLabels()) == 0 {
+		return "", fmt.Errorf("objects with a generated name need labels to be looked up by")
 	}
+
+	existingObjs, err := control.ListCached(labels.SelectorFromSet(required.GetLabels()))
+	if err != nil {
+		return "", fmt.Errorf("can't list objects: %w", err)
+	}
+
+	var names []string
+	for _, existing := range existingObjs {
+		if existing.GetGenerateName() != required.GetGenerateName() {
+			continue
+		}
+		names = append(names, existing.GetName())
+	}
+
+	switch len(names) {
+	case 0:
+		return "", nil
+	case 1:
+		return names[0], nil
+	default:
+		return "", fmt.Errorf("labels match multiple objects: %s", strings.Join(names, ", "))
+	}
+}
+
+// findGeneratedNameByHash returns the name of the existing object, among the ones matching the labels and the generateName
+// of the required object, that has the same hash, or an empty string if there is none.
+// If duplicates were created before, the first one by name is returned.
+func findGeneratedNameByHash[T kubeinterfaces.ObjectInterface](control ApplyControlInterface[T], required T) (string, error) {
+	existingObjs, err := control.ListCached(labels.SelectorFromSet(required.GetLabels()))
+	if err != nil {
+		return "", fmt.Errorf("can't list objects: %w", err)
+	}
+
+	requiredHash := required.GetAnnotations()[naming.ManagedHash]
+
+	var names []string
+	for _, existing := range existingObjs {
+		if existing.GetGenerateName() != required.GetGenerateName() {
+			continue
+		}
+		if existing.GetAnnotations()[naming.ManagedHash] != requiredHash {
+			continue
+		}
+		names = append(names, existing.GetName())
+	}
+
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	slices.Sort(names)
+	return names[0], nil
+}
+
+func isBlockOwnerDeletionForbiddenError(err error) bool {
+	return apierrors.IsForbidden(err) && strings.Contains(err.Error(), "cannot set blockOwnerDeletion")
+}
+
+// stampReconcileTime sets the LastAppliedTimeAnnotation on obj to now.
+func stampReconcileTime(obj metav1.Object, now time.Time) {
+	annotations := maps.Clone(obj.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[naming.LastAppliedTimeAnnotation] = now.UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+}
+
+// ReconcileToken identifies an operator instance writing objects.
+// Instances taking over the reconciliation later have newer tokens.
+type ReconcileToken struct {
+	Identity   string
+	AcquiredAt time.Time
+}
+
+func (t ReconcileToken) String() string {
+	return fmt.Sprintf("%s@%s", t.Identity, t.AcquiredAt.UTC().Format(time.RFC3339Nano))
+}
+
+func parseReconcileToken(s string) (ReconcileToken, error) {
+	idx := strings.LastIndex(s, "@")
+	if idx < 0 {
+		return ReconcileToken{}, fmt.Errorf("reconcile token %q is missing a separator", s)
+	}
+
+	acquiredAt, err := time.Parse(time.RFC3339Nano, s[idx+1:])
+	if err != nil {
+		return ReconcileToken{}, fmt.Errorf("can't parse reconcile token %q time: %w", s, err)
+	}
+
+	return ReconcileToken{
+		Identity:   s[:idx],
+		AcquiredAt: acquiredAt,
+	}, nil
+}
+
+// checkReconcileToken returns a ReconcileTokenConflictError if the existing object was written
+// by another operator instance holding a newer token.
+// Malformed tokens are overwritten, as nobody could be relying on them.
+func checkReconcileToken(existing metav1.Object, token ReconcileToken, gvk schema.GroupVersionKind) error {
+	v, ok := existing.GetAnnotations()[naming.ReconcileTokenAnnotation]
+	if !ok {
+		return nil
+	}
+
+	existingToken, err := parseReconcileToken(v)
+	if err != nil {
+		klog.InfoS("Overwriting malformed reconcile token", "GVK", gvk, "Ref", naming.ObjRef(existing), "Error", err)
+		return nil
+	}
+
+	if existingToken.Identity != token.Identity && existingToken.AcquiredAt.After(token.AcquiredAt) {
+		return &ReconcileTokenConflictError{
+			GVK:           gvk,
+			Ref:           naming.ObjRef(existing),
+			Token:         token,
+			ExistingToken: existingToken,
+		}
+	}
+
+	return nil
+}
+
+func setReconcileToken(obj metav1.Object, token ReconcileToken) {
+	annotations := maps.Clone(obj.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[naming.ReconcileTokenAnnotation] = token.String()
+	obj.SetAnnotations(annotations)
+}
+
+// findProtectedOwnerReference returns the first ownerReference of obj pointing to one of the protected kinds, if any.
+func findProtectedOwnerReference(obj metav1.Object, protectedGVKs []schema.GroupVersionKind) *metav1.OwnerReference {
+	for _, ref := range obj.GetOwnerReferences() {
+		ownerGK := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).GroupKind()
+		for _, protectedGVK := range protectedGVKs {
+			if protectedGVK.GroupKind() == ownerGK {
+				return &ref
+			}
+		}
+	}
+
+	return nil
+}
+
+// setReconciledBy sets the ReconciledByAnnotation on obj to the controller name.
+func setReconciledBy(obj metav1.Object, controllerName string) {
+	annotations := maps.Clone(obj.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[naming.ReconciledByAnnotation] = controllerName
+	obj.SetAnnotations(annotations)
+}
+
+// postCreateCachePollInterval is how often the lister is checked for a created object, see ApplyOptions.PostCreateWaitForCache.
+const postCreateCachePollInterval = 50 * time.Millisecond
+
+// waitForCreatedObjectInCache polls the lister until it has the created object. Objects with a different UID,
+// like the one a recreation has just deleted, don't count.
+func waitForCreatedObjectInCache[T kubeinterfaces.ObjectInterface](ctx context.Context, control ApplyControlInterface[T], created T) error {
+	err := apimachineryutilwait.PollUntilContextCancel(ctx, postCreateCachePollInterval, true, func(context.Context) (bool, error) {
+		cached, err := control.GetCached(created.GetName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return cached.GetUID() == created.GetUID(), nil
+	})
 	if err != nil {
-		return *new(T), false, fmt.Errorf("can't update %s %q: %w", gvk, naming.ObjRef(requiredCopy), err)
+		return fmt.Errorf("can't wait for created object %q to appear in the cache: %w", naming.ObjRefWithUID(created), err)
+	}
+
+	klog.V(4).InfoS("Created object is visible in the cache", "Ref", naming.ObjRefWithUID(created))
+	return nil
+}
+
+// createWithOwnerReferenceFallback creates the object and, if allowed by the options, retries the create
+// with blockOwnerDeletion unset when the caller lacks the permissions to set it.
+func createWithOwnerReferenceFallback[T kubeinterfaces.ObjectInterface](ctx context.Context, control ApplyControlInterface[T], obj T, opts metav1.CreateOptions, options ApplyOptions) (T, error) {
+	if options.StampReconcileTime {
+		stampReconcileTime(obj, options.now())
 	}
 
-	return actual, true, nil
+	if options.ReconcileToken != nil {
+		setReconcileToken(obj, *options.ReconcileToken)
+	}
+
+	if len(options.ControllerName) != 0 {
+		setReconciledBy(obj, options.ControllerName)
+	}
+
+	created, err := control.Create(ctx, obj, opts)
+	if !options.DowngradeBlockOwnerDeletion || !isBlockOwnerDeletionForbiddenError(err) {
+		return created, err
+	}
+
+	ownerRefs := obj.GetOwnerReferences()
+	downgraded := false
+	for i := range ownerRefs {
+		if ownerRefs[i].BlockOwnerDeletion != nil && *ownerRefs[i].BlockOwnerDeletion {
+			ownerRefs[i].BlockOwnerDeletion = pointer.Ptr(false)
+			downgraded = true
+		}
+	}
+	if !downgraded {
+		return created, err
+	}
+
+	klog.V(2).InfoS("Retrying create without blockOwnerDeletion", "Ref", klog.KObj(obj), "Error", err)
+	obj.SetOwnerReferences(ownerRefs)
+
+	return control.Create(ctx, obj, opts)
 }
 
 func ApplyGeneric[T kubeinterfaces.ObjectInterface](
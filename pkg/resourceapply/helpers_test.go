@@ -3,12 +3,36 @@
 package resourceapply
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
 	hash2 "github.com/scylladb/scylla-operator/pkg/util/hash"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
 )
 
 type A struct {
@@ -85,3 +109,1857 @@ func TestHashObjectOrderMatters(t *testing.T) {
 		t.Errorf("expected different hash for slices of same elements but different order, hash1: %q, hash2: %q", hashObjectsOrDie(objs...), hashObjectsOrDie(objsCopy))
 	}
 }
+
+func newNamespaceTerminatingError(namespace string) error {
+	err := apierrors.NewForbidden(
+		corev1.Resource("configmaps"),
+		"",
+		fmt.Errorf("unable to create new content in namespace %s because it is being terminated", namespace),
+	)
+	err.ErrStatus.Details.Causes = append(err.ErrStatus.Details.Causes, metav1.StatusCause{
+		Type:    corev1.NamespaceTerminatingCause,
+		Message: fmt.Sprintf("namespace %s is being terminated", namespace),
+		Field:   "metadata.namespace",
+	})
+	return err
+}
+
+func TestApplyGeneric(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	newConfigMapWithData := func(data map[string]string) *corev1.ConfigMap {
+		cm := newConfigMap()
+		cm.Data = data
+		return cm
+	}
+
+	withHash := func(cm *corev1.ConfigMap) *corev1.ConfigMap {
+		apimachineryutilruntime.Must(SetHashAnnotation(cm))
+		return cm
+	}
+
+	// Spec-only applies hash the object without its labels and annotations.
+	withSpecOnlyHash := func(cm *corev1.ConfigMap) *corev1.ConfigMap {
+		hashed := cm.DeepCopy()
+		hashed.Labels = map[string]string{}
+		hashed.Annotations = map[string]string{}
+		apimachineryutilruntime.Must(SetHashAnnotation(hashed))
+		cm.Annotations[naming.ManagedHash] = hashed.Annotations[naming.ManagedHash]
+		return cm
+	}
+
+	newGeneratedConfigMap := func(value string) *corev1.ConfigMap {
+		cm := newConfigMapWithData(map[string]string{"key": value})
+		cm.Name = ""
+		cm.GenerateName = "test-"
+		cm.Labels["job"] = "one-off"
+		return cm
+	}
+
+	withGeneratedName := func(cm *corev1.ConfigMap, name string) *corev1.ConfigMap {
+		cm.Name = name
+		return cm
+	}
+
+	withTypeMeta := func(cm *corev1.ConfigMap) *corev1.ConfigMap {
+		cm.TypeMeta = metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		}
+		return cm
+	}
+
+	otherControllerRef := metav1.OwnerReference{
+		Controller:         pointer.Ptr(true),
+		UID:                "other-uid",
+		APIVersion:         "v1",
+		Kind:               "Pod",
+		Name:               "other",
+		BlockOwnerDeletion: pointer.Ptr(true),
+	}
+
+	withOwnerReferences := func(cm *corev1.ConfigMap, ownerReferences []metav1.OwnerReference) *corev1.ConfigMap {
+		cm.OwnerReferences = ownerReferences
+		return cm
+	}
+
+	// dataRecreateReasonFunc requires recreating the object whenever its data changes.
+	dataRecreateReasonFunc := func(propagationPolicy *metav1.DeletionPropagation) func(required, existing *corev1.ConfigMap) (string, *metav1.DeletionPropagation, error) {
+		return func(required, existing *corev1.ConfigMap) (string, *metav1.DeletionPropagation, error) {
+			if reflect.DeepEqual(required.Data, existing.Data) {
+				return "", nil, nil
+			}
+			return "data changed", propagationPolicy, nil
+		}
+	}
+
+	// The fake client doesn't generate names, so the reactor does it like the apiserver would.
+	generateNameReactor := func(action clienttesting.Action) (bool, runtime.Object, error) {
+		obj := action.(clienttesting.CreateAction).GetObject().(*corev1.ConfigMap)
+		if len(obj.Name) == 0 && len(obj.GenerateName) != 0 {
+			obj.Name = obj.GenerateName + "1"
+		}
+		return false, nil, nil
+	}
+
+	forbidBlockOwnerDeletionReactor := func(action clienttesting.Action) (bool, runtime.Object, error) {
+		obj := action.(clienttesting.CreateAction).GetObject().(*corev1.ConfigMap)
+		for _, ref := range obj.OwnerReferences {
+			if ref.BlockOwnerDeletion != nil && *ref.BlockOwnerDeletion {
+				return true, nil, apierrors.NewForbidden(
+					corev1.Resource("configmaps"),
+					"test",
+					fmt.Errorf("cannot set blockOwnerDeletion if an ownerReference refers to a resource you can't set finalizers on: , <nil>"),
+				)
+			}
+		}
+		return false, nil, nil
+	}
+
+	immutableFields, err := ImmutableFieldsFromCRD(newImmutabilityTestCRD(), "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		finalizer      = "scylla-operator.scylladb.com/test-finalizer"
+		otherFinalizer = "example.com/other"
+		gate           = "ExperimentalConfig"
+	)
+
+	clock := testingclock.NewFakePassiveClock(time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC))
+	const (
+		oldStamp = "2000-01-01T00:00:00Z"
+		newStamp = "2021-02-03T04:05:06Z"
+	)
+
+	oldToken := ReconcileToken{Identity: "operator-0", AcquiredAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newToken := ReconcileToken{Identity: "operator-1", AcquiredAt: time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)}
+
+	eventObject := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "parent",
+		},
+	}
+
+	tt := []struct {
+		name                  string
+		existing              []runtime.Object
+		cache                 []runtime.Object // nil cache means autofill from the client
+		reactors              map[string]clienttesting.ReactionFunc
+		createDelay           time.Duration
+		withoutLiveGet        bool
+		includeEventObjects   bool
+		required              *corev1.ConfigMap
+		options               ApplyOptions
+		getRecreateReasonFunc func(required, existing *corev1.ConfigMap) (string, *metav1.DeletionPropagation, error)
+		expectedCM            *corev1.ConfigMap
+		expectedOperation     ApplyOperation
+		expectedChanged       bool
+		expectedErr           error
+		expectedEvents        []string
+		expectedActions       []string
+	}{
+		{
+			name:              "creates a new configmap when there is none",
+			existing:          nil,
+			required:          newConfigMap(),
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:              "does nothing if the same configmap already exists",
+			existing:          []runtime.Object{withHash(newConfigMap())},
+			required:          newConfigMap(),
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name:              "updates the configmap if its hash differs",
+			existing:          []runtime.Object{withHash(newConfigMap())},
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:                  "recreates the configmap in the background if the kind requires it",
+			existing:              []runtime.Object{withHash(newConfigMap())},
+			required:              newConfigMapWithData(map[string]string{"foo": "bar"}),
+			getRecreateReasonFunc: dataRecreateReasonFunc(nil),
+			expectedCM:            withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation:     ApplyOperationRecreated,
+			expectedChanged:       true,
+			expectedErr:           nil,
+			expectedEvents: []string{
+				"Normal ConfigMapDeleted ConfigMap default/test deleted",
+				"Normal ConfigMapCreated ConfigMap default/test created",
+			},
+			expectedActions: []string{"delete Background", "create"},
+		},
+		{
+			name:     "recreates the configmap with the configured propagation policy",
+			existing: []runtime.Object{withHash(newConfigMap())},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				RecreatePropagationPolicy: pointer.Ptr(metav1.DeletePropagationForeground),
+			},
+			getRecreateReasonFunc: dataRecreateReasonFunc(nil),
+			expectedCM:            withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation:     ApplyOperationRecreated,
+			expectedChanged:       true,
+			expectedErr:           nil,
+			expectedEvents: []string{
+				"Normal ConfigMapDeleted ConfigMap default/test deleted",
+				"Normal ConfigMapCreated ConfigMap default/test created",
+			},
+			expectedActions: []string{"delete Foreground", "create"},
+		},
+		{
+			name:     "recreates the configmap with the propagation policy required by the kind",
+			existing: []runtime.Object{withHash(newConfigMap())},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				RecreatePropagationPolicy: pointer.Ptr(metav1.DeletePropagationForeground),
+			},
+			getRecreateReasonFunc: dataRecreateReasonFunc(pointer.Ptr(metav1.DeletePropagationOrphan)),
+			expectedCM:            withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation:     ApplyOperationRecreated,
+			expectedChanged:       true,
+			expectedErr:           nil,
+			expectedEvents: []string{
+				"Normal ConfigMapDeleted ConfigMap default/test deleted",
+				"Normal ConfigMapCreated ConfigMap default/test created",
+			},
+			expectedActions: []string{"delete Orphan", "create"},
+		},
+		{
+			name:     "adopts an orphaned configmap when forcing ownership",
+			existing: []runtime.Object{withOwnerReferences(newConfigMap(), nil)},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				ForceOwnership: true,
+			},
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationAdoptedAndUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:              "fails to update an orphaned configmap without forcing ownership",
+			existing:          []runtime.Object{withOwnerReferences(newConfigMap(), nil)},
+			required:          newConfigMap(),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr:       fmt.Errorf(`/v1, Kind=ConfigMap "default/test" isn't controlled by us`),
+			expectedEvents:    []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: /v1, Kind=ConfigMap "default/test" isn't controlled by us`},
+			expectedActions:   nil,
+		},
+		{
+			name:              "fails to update a configmap controlled by someone else",
+			existing:          []runtime.Object{withHash(withOwnerReferences(newConfigMap(), []metav1.OwnerReference{otherControllerRef}))},
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr:       fmt.Errorf(`/v1, Kind=ConfigMap "default/test" isn't controlled by us`),
+			expectedEvents:    []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: /v1, Kind=ConfigMap "default/test" isn't controlled by us`},
+			expectedActions:   nil,
+		},
+		{
+			name:              "fails to create a configmap without a controllerRef",
+			existing:          nil,
+			required:          withOwnerReferences(newConfigMap(), nil),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr:       fmt.Errorf(`/v1, Kind=ConfigMap "default/test" is missing controllerRef`),
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name:     "fails to create a configmap in a terminating namespace",
+			existing: nil,
+			reactors: map[string]clienttesting.ReactionFunc{
+				"create": func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, newNamespaceTerminatingError(action.GetNamespace())
+				},
+			},
+			required:          newConfigMap(),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   false,
+			expectedErr: &NamespaceTerminatingError{
+				Namespace: "default",
+				Err:       newNamespaceTerminatingError("default"),
+			},
+			expectedEvents:  []string{`Warning NamespaceTerminating Can't create ConfigMap default/test because namespace "default" is being terminated`},
+			expectedActions: []string{"create"},
+		},
+		{
+			name: "keeps the keys under preserved prefixes",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Annotations["example.com/node-a"] = "a"
+					cm.Annotations["example.com/node-b"] = "b"
+					cm.Labels["example.com/zone"] = "zone-a"
+					return cm
+				}(),
+			},
+			// The required object tries to change, remove and add keys under the preserved prefix, none of which may happen.
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMapWithData(map[string]string{"foo": "bar"})
+				cm.Annotations["example.com/node-a"] = "changed"
+				cm.Annotations["example.com/node-b-"] = ""
+				cm.Annotations["example.com/node-c"] = "c"
+				cm.Labels["example.com/zone-"] = ""
+				return cm
+			}(),
+			options: ApplyOptions{
+				PreserveKeyPrefixes: []string{"example.com/"},
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.Annotations["example.com/node-a"] = "a"
+				cm.Annotations["example.com/node-b"] = "b"
+				cm.Labels["example.com/zone"] = "zone-a"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "creates a configmap with the required managed-by label",
+			existing: nil,
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Labels["app.kubernetes.io/managed-by"] = "scylla-operator"
+				return cm
+			}(),
+			options: ApplyOptions{
+				RequireManagedByLabel: "app.kubernetes.io/managed-by",
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Labels["app.kubernetes.io/managed-by"] = "scylla-operator"
+				return withHash(cm)
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "rejects a configmap missing the required managed-by label",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				RequireManagedByLabel: "app.kubernetes.io/managed-by",
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &MissingRequiredLabelError{
+				GVK:   corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:   "default/test",
+				Label: "app.kubernetes.io/managed-by",
+			},
+			expectedEvents:  nil,
+			expectedActions: nil,
+		},
+		{
+			name:     "fails to create a configmap when blockOwnerDeletion is forbidden",
+			existing: nil,
+			reactors: map[string]clienttesting.ReactionFunc{
+				"create": forbidBlockOwnerDeletionReactor,
+			},
+			required: newConfigMap(),
+			// The client returns an empty object along with the error.
+			expectedCM:        &corev1.ConfigMap{},
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   false,
+			expectedErr: &APIError{
+				Class: APIErrorClassForbidden,
+				Err: apierrors.NewForbidden(
+					corev1.Resource("configmaps"),
+					"test",
+					fmt.Errorf("cannot set blockOwnerDeletion if an ownerReference refers to a resource you can't set finalizers on: , <nil>"),
+				),
+			},
+			expectedEvents:  []string{`Warning CreateConfigMapFailed Failed to create ConfigMap default/test: configmaps "test" is forbidden: cannot set blockOwnerDeletion if an ownerReference refers to a resource you can't set finalizers on: , <nil> map[scylla-operator.scylladb.com/apply-error-class:Forbidden]`},
+			expectedActions: []string{"create"},
+		},
+		{
+			name:     "retries the create with blockOwnerDeletion downgraded",
+			existing: nil,
+			reactors: map[string]clienttesting.ReactionFunc{
+				"create": forbidBlockOwnerDeletionReactor,
+			},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				DowngradeBlockOwnerDeletion: true,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMap())
+				cm.OwnerReferences[0].BlockOwnerDeletion = pointer.Ptr(false)
+				return cm
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create", "create"},
+		},
+		{
+			name:     "creates a configmap in an allowed namespace",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				AllowedNamespaces: sets.New("default", "other"),
+			},
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "rejects a configmap in a namespace that isn't allowed",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				AllowedNamespaces: sets.New("other"),
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &NamespaceNotAllowedError{
+				GVK:       corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:       "default/test",
+				Namespace: "default",
+			},
+			expectedEvents:  nil,
+			expectedActions: nil,
+		},
+		{
+			name:     "rejects a configmap in any namespace when no namespace is allowed",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				AllowedNamespaces: sets.New[string](),
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &NamespaceNotAllowedError{
+				GVK:       corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:       "default/test",
+				Namespace: "default",
+			},
+			expectedEvents:  nil,
+			expectedActions: nil,
+		},
+		{
+			name:     "creates a configmap with a generated name",
+			existing: nil,
+			reactors: map[string]clienttesting.ReactionFunc{
+				"create": generateNameReactor,
+			},
+			required: newGeneratedConfigMap("one"),
+			options: ApplyOptions{
+				GenerateName: true,
+			},
+			expectedCM:        withGeneratedName(withHash(newGeneratedConfigMap("one")), "test-1"),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test-1 created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "reuses a generated configmap with the same content when deduplicating by hash",
+			existing: []runtime.Object{withGeneratedName(withHash(newGeneratedConfigMap("one")), "test-0")},
+			reactors: map[string]clienttesting.ReactionFunc{
+				"create": generateNameReactor,
+			},
+			required: newGeneratedConfigMap("one"),
+			options: ApplyOptions{
+				GenerateName:               true,
+				DeduplicateGeneratedByHash: true,
+			},
+			expectedCM:        withGeneratedName(withHash(newGeneratedConfigMap("one")), "test-0"),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name:     "creates another generated configmap for a different content when deduplicating by hash",
+			existing: []runtime.Object{withGeneratedName(withHash(newGeneratedConfigMap("one")), "test-0")},
+			reactors: map[string]clienttesting.ReactionFunc{
+				"create": generateNameReactor,
+			},
+			required: newGeneratedConfigMap("two"),
+			options: ApplyOptions{
+				GenerateName:               true,
+				DeduplicateGeneratedByHash: true,
+			},
+			expectedCM:        withGeneratedName(withHash(newGeneratedConfigMap("two")), "test-1"),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test-1 created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "emits an event for an unchanged configmap when enabled",
+			existing: []runtime.Object{withHash(newConfigMap())},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				EmitUnchangedEvents: true,
+			},
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUnchanged ConfigMap default/test unchanged"},
+			expectedActions:   nil,
+		},
+		{
+			name: "doesn't reconcile a tampered configmap with a matching hash by default",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+					cm.Data["foo"] = "drifted"
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.Data["foo"] = "drifted"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name: "reconciles a tampered configmap with a matching hash when verifying hash integrity",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+					cm.Data["foo"] = "drifted"
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				VerifyHashIntegrity: true,
+			},
+			expectedCM:        withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name: "leaves an intact configmap with server fields and metadata of other actors alone when verifying hash integrity",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+					cm.UID = "existing-uid"
+					cm.ResourceVersion = "42"
+					cm.CreationTimestamp = metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+					cm.Labels["other-actor-label"] = "value"
+					cm.Annotations["other-actor-annotation"] = "value"
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				VerifyHashIntegrity: true,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.UID = "existing-uid"
+				cm.ResourceVersion = "42"
+				cm.CreationTimestamp = metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+				cm.Labels["other-actor-label"] = "value"
+				cm.Annotations["other-actor-annotation"] = "value"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name:     "adds the ensured finalizers on create without hashing them",
+			existing: nil,
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Finalizers = []string{otherFinalizer}
+				return cm
+			}(),
+			options: ApplyOptions{
+				EnsureFinalizers: []string{finalizer, otherFinalizer, finalizer},
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Finalizers = []string{otherFinalizer}
+				cm = withHash(cm)
+				cm.Finalizers = []string{otherFinalizer, finalizer}
+				return cm
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name: "adds back an ensured finalizer and keeps the ones of other actors",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Finalizers = []string{otherFinalizer}
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				EnsureFinalizers: []string{finalizer},
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.Finalizers = []string{finalizer, otherFinalizer}
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name: "doesn't add an ensured finalizer back to a configmap that is being deleted",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.DeletionTimestamp = pointer.Ptr(metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+					cm.Finalizers = []string{otherFinalizer}
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				EnsureFinalizers: []string{finalizer},
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMap())
+				cm.DeletionTimestamp = pointer.Ptr(metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+				cm.Finalizers = []string{otherFinalizer}
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name:     "creates a configmap within the size limit",
+			existing: nil,
+			required: newConfigMapWithData(map[string]string{"data": strings.Repeat("x", 10)}),
+			options: ApplyOptions{
+				MaxObjectSizeBytes: 1024,
+			},
+			expectedCM:        withHash(newConfigMapWithData(map[string]string{"data": strings.Repeat("x", 10)})),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "doesn't create a configmap exceeding the size limit",
+			existing: nil,
+			required: newConfigMapWithData(map[string]string{"data": strings.Repeat("x", 2048)}),
+			options: ApplyOptions{
+				MaxObjectSizeBytes: 1024,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &ObjectTooLargeError{
+				GVK:          corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:          "default/test",
+				SizeBytes:    2452,
+				MaxSizeBytes: 1024,
+			},
+			expectedEvents:  nil,
+			expectedActions: nil,
+		},
+		{
+			name:     "doesn't update a configmap to exceed the size limit",
+			existing: []runtime.Object{withHash(newConfigMapWithData(map[string]string{"data": strings.Repeat("x", 10)}))},
+			required: newConfigMapWithData(map[string]string{"data": strings.Repeat("x", 2048)}),
+			options: ApplyOptions{
+				MaxObjectSizeBytes: 1024,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &ObjectTooLargeError{
+				GVK:          corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:          "default/test",
+				SizeBytes:    2452,
+				MaxSizeBytes: 1024,
+			},
+			expectedEvents:  nil,
+			expectedActions: nil,
+		},
+		{
+			name:              "updates a configmap with a stale spec created concurrently by us",
+			existing:          []runtime.Object{withHash(newConfigMapWithData(map[string]string{"foo": "stale"}))},
+			cache:             []runtime.Object{},
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"create", "get", "update"},
+		},
+		{
+			name:              "leaves a configmap with the same spec created concurrently by us unchanged",
+			existing:          []runtime.Object{withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))},
+			cache:             []runtime.Object{},
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        withHash(newConfigMapWithData(map[string]string{"foo": "bar"})),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   []string{"create", "get"},
+		},
+		{
+			name: "doesn't take over a configmap created concurrently by someone else",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := newConfigMapWithData(map[string]string{"foo": "theirs"})
+					cm.OwnerReferences[0].UID = "other-uid"
+					return withHash(cm)
+				}(),
+			},
+			cache:             []runtime.Object{},
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr:       fmt.Errorf(`/v1, Kind=ConfigMap "default/test" isn't controlled by us`),
+			expectedEvents:    []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: /v1, Kind=ConfigMap "default/test" isn't controlled by us`},
+			expectedActions:   []string{"create", "get"},
+		},
+		{
+			name:              "returns the create error for a concurrently created configmap when the control can't read the live object",
+			existing:          []runtime.Object{withHash(newConfigMapWithData(map[string]string{"foo": "stale"}))},
+			cache:             []runtime.Object{},
+			withoutLiveGet:    true,
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   false,
+			expectedErr: &APIError{
+				Class: APIErrorClassAlreadyExists,
+				Err:   apierrors.NewAlreadyExists(corev1.Resource("configmaps"), "test"),
+			},
+			expectedEvents:  nil,
+			expectedActions: []string{"create"},
+		},
+		{
+			name:              "fails to update a configmap missing from the apiserver but present in the cache",
+			existing:          nil,
+			cache:             []runtime.Object{withHash(newConfigMap())},
+			required:          newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   false,
+			expectedErr: fmt.Errorf(`can't update /v1, Kind=ConfigMap "default/test": %w`, &APIError{
+				Class: APIErrorClassNotFound,
+				Err:   apierrors.NewNotFound(corev1.Resource("configmaps"), "test"),
+			}),
+			expectedEvents:  []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: configmaps "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
+			expectedActions: []string{"update"},
+		},
+		{
+			name:     "leaves a configmap missing from the apiserver but present in the cache unchanged when ignoring not found",
+			existing: nil,
+			cache:    []runtime.Object{withHash(newConfigMap())},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				IgnoreNotFoundOnUpdate: true,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:              "waits for a slow call without a timeout",
+			existing:          nil,
+			createDelay:       200 * time.Millisecond,
+			required:          newConfigMap(),
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:        "fails a slow call exceeding the timeout",
+			existing:    nil,
+			createDelay: 200 * time.Millisecond,
+			required:    newConfigMap(),
+			options: ApplyOptions{
+				TimeoutPerCall: 10 * time.Millisecond,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   false,
+			expectedErr: &CallTimeoutError{
+				Verb:    "create",
+				Timeout: 10 * time.Millisecond,
+				Err:     context.DeadlineExceeded,
+			},
+			expectedEvents:  []string{`Warning CreateConfigMapFailed Failed to create ConfigMap default/test: create call didn't finish within 10ms: context deadline exceeded map[scylla-operator.scylladb.com/apply-error-class:CallTimeout]`},
+			expectedActions: nil,
+		},
+		{
+			name:                "records events against the applied object by default",
+			existing:            nil,
+			includeEventObjects: true,
+			required:            withTypeMeta(newConfigMap()),
+			expectedCM:          withHash(withTypeMeta(newConfigMap())),
+			expectedOperation:   ApplyOperationCreated,
+			expectedChanged:     true,
+			expectedErr:         nil,
+			expectedEvents:      []string{"Normal ConfigMapCreated ConfigMap default/test created involvedObject{kind=ConfigMap,apiVersion=v1}"},
+			expectedActions:     []string{"create"},
+		},
+		{
+			name:                "records events against the event object",
+			existing:            nil,
+			includeEventObjects: true,
+			required:            newConfigMap(),
+			options: ApplyOptions{
+				EventObject: eventObject,
+			},
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created involvedObject{kind=Pod,apiVersion=v1}"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "updates an un-owned configmap without changing its ownerReferences when skipping the ownership check",
+			existing: []runtime.Object{withHash(withOwnerReferences(newConfigMap(), nil))},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				SkipOwnershipCheck: true,
+			},
+			expectedCM:        withOwnerReferences(withHash(newConfigMapWithData(map[string]string{"foo": "bar"})), nil),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "updates a configmap controlled by someone else without changing its ownerReferences when skipping the ownership check",
+			existing: []runtime.Object{withHash(withOwnerReferences(newConfigMap(), []metav1.OwnerReference{otherControllerRef}))},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				SkipOwnershipCheck: true,
+			},
+			expectedCM:        withOwnerReferences(withHash(newConfigMapWithData(map[string]string{"foo": "bar"})), []metav1.OwnerReference{otherControllerRef}),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "stamps the reconcile time on create",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				StampReconcileTime: true,
+				Clock:              clock,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMap())
+				cm.Annotations[naming.LastAppliedTimeAnnotation] = newStamp
+				return cm
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name: "keeps the reconcile time when nothing else changes",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Annotations[naming.LastAppliedTimeAnnotation] = oldStamp
+					return cm
+				}(),
+			},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				StampReconcileTime: true,
+				Clock:              clock,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMap())
+				cm.Annotations[naming.LastAppliedTimeAnnotation] = oldStamp
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name: "refreshes the reconcile time on update",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Annotations[naming.LastAppliedTimeAnnotation] = oldStamp
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				StampReconcileTime: true,
+				Clock:              clock,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.Annotations[naming.LastAppliedTimeAnnotation] = newStamp
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "records the controller name on create",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				ControllerName: "first",
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMap())
+				cm.Annotations[naming.ReconciledByAnnotation] = "first"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name: "keeps the controller name when nothing else changes",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Annotations[naming.ReconciledByAnnotation] = "first"
+					return cm
+				}(),
+			},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				ControllerName: "second",
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMap())
+				cm.Annotations[naming.ReconciledByAnnotation] = "first"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name: "records the controller name on update",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Annotations[naming.ReconciledByAnnotation] = "first"
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			options: ApplyOptions{
+				ControllerName: "second",
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.Annotations[naming.ReconciledByAnnotation] = "second"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name: "carries over the controller name on update without one",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMap())
+					cm.Annotations[naming.ReconciledByAnnotation] = "second"
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "bar"}),
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "bar"}))
+				cm.Annotations[naming.ReconciledByAnnotation] = "second"
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name: "refuses to adopt a configmap owned by a protected owner",
+			existing: []runtime.Object{
+				withOwnerReferences(newConfigMap(), []metav1.OwnerReference{
+					{
+						APIVersion: "other.example.com/v2",
+						Kind:       "Database",
+						Name:       "other",
+						UID:        "other-uid",
+					},
+				}),
+			},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				ForceOwnership: true,
+				ProtectedOwnerGVKs: []schema.GroupVersionKind{
+					{Group: "other.example.com", Version: "v1", Kind: "Database"},
+				},
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &ProtectedOwnerError{
+				GVK: corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref: "default/test",
+				OwnerRef: metav1.OwnerReference{
+					APIVersion: "other.example.com/v2",
+					Kind:       "Database",
+					Name:       "other",
+					UID:        "other-uid",
+				},
+			},
+			expectedEvents:  []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: /v1, Kind=ConfigMap "default/test" is owned by other.example.com/v2, Kind=Database "other" which takes precedence, refusing to adopt it`},
+			expectedActions: nil,
+		},
+		{
+			name: "adopts a configmap whose owner isn't protected",
+			existing: []runtime.Object{
+				withOwnerReferences(newConfigMap(), []metav1.OwnerReference{
+					{
+						APIVersion: "other.example.com/v2",
+						Kind:       "Database",
+						Name:       "other",
+						UID:        "other-uid",
+					},
+				}),
+			},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				ForceOwnership: true,
+			},
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationAdoptedAndUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "sets the required metadata on create in spec only mode",
+			existing: nil,
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Labels["app"] = "test"
+				cm.Annotations["note"] = "operator"
+				return cm
+			}(),
+			options: ApplyOptions{
+				SpecOnly: true,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Labels["app"] = "test"
+				cm.Annotations["note"] = "operator"
+				return withSpecOnlyHash(cm)
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name: "ignores metadata changes in spec only mode",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := newConfigMap()
+					cm.Labels["app"] = "test"
+					cm.Annotations["note"] = "operator"
+					cm = withSpecOnlyHash(cm)
+					cm.Labels = map[string]string{"app": "user", "team": "db"}
+					delete(cm.Annotations, "note")
+					return cm
+				}(),
+			},
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Labels["app"] = "other"
+				return cm
+			}(),
+			options: ApplyOptions{
+				SpecOnly: true,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.Labels["app"] = "test"
+				cm.Annotations["note"] = "operator"
+				cm = withSpecOnlyHash(cm)
+				cm.Labels = map[string]string{"app": "user", "team": "db"}
+				delete(cm.Annotations, "note")
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name: "keeps the user metadata when updating the spec in spec only mode",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := newConfigMap()
+					cm.Labels["app"] = "test"
+					cm.Annotations["note"] = "operator"
+					cm = withSpecOnlyHash(cm)
+					cm.Labels = map[string]string{"app": "user", "team": "db"}
+					delete(cm.Annotations, "note")
+					return cm
+				}(),
+			},
+			required: func() *corev1.ConfigMap {
+				cm := newConfigMapWithData(map[string]string{"foo": "bar"})
+				cm.Labels["app"] = "other"
+				return cm
+			}(),
+			options: ApplyOptions{
+				SpecOnly: true,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := newConfigMapWithData(map[string]string{"foo": "bar"})
+				cm.Labels["app"] = "other"
+				cm = withSpecOnlyHash(cm)
+				cm.Labels = map[string]string{"app": "user", "team": "db"}
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "doesn't create a configmap whose feature gate is disabled",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				FeatureGates:        map[string]bool{gate: false},
+				RequiredFeatureGate: gate,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationUnchanged,
+			expectedChanged:   false,
+			expectedErr:       nil,
+			expectedEvents:    nil,
+			expectedActions:   nil,
+		},
+		{
+			name:     "creates a configmap whose feature gate is enabled",
+			existing: nil,
+			required: newConfigMap(),
+			options: ApplyOptions{
+				FeatureGates:        map[string]bool{gate: true},
+				RequiredFeatureGate: gate,
+			},
+			expectedCM:        withHash(newConfigMap()),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name:     "deletes a configmap whose feature gate got disabled with the configured propagation policy",
+			existing: []runtime.Object{withHash(newConfigMap())},
+			required: newConfigMap(),
+			options: ApplyOptions{
+				FeatureGates:              map[string]bool{gate: false},
+				RequiredFeatureGate:       gate,
+				RecreatePropagationPolicy: pointer.Ptr(metav1.DeletePropagationForeground),
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationDeleted,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapDeleted ConfigMap default/test deleted"},
+			expectedActions:   []string{"delete Foreground"},
+		},
+		{
+			name:     "records the reconcile token on create",
+			existing: nil,
+			required: newConfigMapWithData(map[string]string{"foo": "old"}),
+			options: ApplyOptions{
+				ReconcileToken: &oldToken,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "old"}))
+				cm.Annotations[naming.ReconcileTokenAnnotation] = oldToken.String()
+				return cm
+			}(),
+			expectedOperation: ApplyOperationCreated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapCreated ConfigMap default/test created"},
+			expectedActions:   []string{"create"},
+		},
+		{
+			name: "takes over a configmap written with an older reconcile token",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMapWithData(map[string]string{"foo": "old"}))
+					cm.Annotations[naming.ReconcileTokenAnnotation] = oldToken.String()
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "new"}),
+			options: ApplyOptions{
+				ReconcileToken: &newToken,
+			},
+			expectedCM: func() *corev1.ConfigMap {
+				cm := withHash(newConfigMapWithData(map[string]string{"foo": "new"}))
+				cm.Annotations[naming.ReconcileTokenAnnotation] = newToken.String()
+				return cm
+			}(),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name: "refuses to write over a configmap written with a newer reconcile token",
+			existing: []runtime.Object{
+				func() *corev1.ConfigMap {
+					cm := withHash(newConfigMapWithData(map[string]string{"foo": "new"}))
+					cm.Annotations[naming.ReconcileTokenAnnotation] = newToken.String()
+					return cm
+				}(),
+			},
+			required: newConfigMapWithData(map[string]string{"foo": "old"}),
+			options: ApplyOptions{
+				ReconcileToken: &oldToken,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &ReconcileTokenConflictError{
+				GVK:           corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:           "default/test",
+				Token:         oldToken,
+				ExistingToken: newToken,
+			},
+			expectedEvents:  nil,
+			expectedActions: nil,
+		},
+		{
+			name:     "updates the mutable fields of a configmap",
+			existing: []runtime.Object{withHash(newConfigMapWithData(map[string]string{"locked": "initial", "mutable": "initial"}))},
+			required: newConfigMapWithData(map[string]string{"locked": "initial", "mutable": "changed"}),
+			options: ApplyOptions{
+				ImmutableFields: immutableFields,
+			},
+			expectedCM:        withHash(newConfigMapWithData(map[string]string{"locked": "initial", "mutable": "changed"})),
+			expectedOperation: ApplyOperationUpdated,
+			expectedChanged:   true,
+			expectedErr:       nil,
+			expectedEvents:    []string{"Normal ConfigMapUpdated ConfigMap default/test updated"},
+			expectedActions:   []string{"update"},
+		},
+		{
+			name:     "rejects changing an immutable field of a configmap",
+			existing: []runtime.Object{withHash(newConfigMapWithData(map[string]string{"locked": "initial", "mutable": "initial"}))},
+			required: newConfigMapWithData(map[string]string{"locked": "changed", "mutable": "initial"}),
+			options: ApplyOptions{
+				ImmutableFields: immutableFields,
+			},
+			expectedCM:        nil,
+			expectedOperation: ApplyOperationRejected,
+			expectedChanged:   false,
+			expectedErr: &ImmutableFieldError{
+				GVK:   corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:   "default/test",
+				Field: immutableFields[0],
+			},
+			expectedEvents:  []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: /v1, Kind=ConfigMap "default/test" can't be updated because field data.locked is immutable: locked is immutable`},
+			expectedActions: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Client holds the state so it has to persists the iterations.
+			client := fake.NewSimpleClientset(tc.existing...)
+			for verb, reaction := range tc.reactors {
+				client.PrependReactor(verb, "configmaps", reaction)
+			}
+
+			// ApplyGeneric needs to be reentrant so running it the second time should give the same results.
+			iterations := 2
+			if tc.expectedErr != nil {
+				iterations = 1
+			}
+			for i := range iterations {
+				t.Run("", func(t *testing.T) {
+					ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer ctxCancel()
+
+					recorder := record.NewFakeRecorder(10)
+					recorder.IncludeObject = tc.includeEventObjects
+
+					configMapCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+					configMapLister := corev1listers.NewConfigMapLister(configMapCache)
+
+					if tc.cache != nil {
+						for _, obj := range tc.cache {
+							err := configMapCache.Add(obj)
+							if err != nil {
+								t.Fatal(err)
+							}
+						}
+					} else {
+						configMapList, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{
+							LabelSelector: labels.Everything().String(),
+						})
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						for i := range configMapList.Items {
+							err := configMapCache.Add(&configMapList.Items[i])
+							if err != nil {
+								t.Fatal(err)
+							}
+						}
+					}
+					client.ClearActions()
+
+					control := NewApplyControlFuncs[*corev1.ConfigMap](configMapLister.ConfigMaps(tc.required.Namespace), client.CoreV1().ConfigMaps(tc.required.Namespace))
+					if tc.withoutLiveGet {
+						control.GetFunc = nil
+					}
+					if tc.createDelay != 0 {
+						create := control.CreateFunc
+						// The fake client doesn't honor the context, so the delay emulates a slow apiserver that does.
+						control.CreateFunc = func(ctx context.Context, obj *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+							select {
+							case <-ctx.Done():
+								return nil, ctx.Err()
+							case <-time.After(tc.createDelay):
+							}
+							return create(ctx, obj, opts)
+						}
+					}
+
+					required := tc.required.DeepCopy()
+					gotRes, gotErr := ApplyGenericWithResult[*corev1.ConfigMap](ctx, control, recorder, required, tc.options, nil, tc.getRecreateReasonFunc)
+
+					var gotActions []string
+					for _, action := range client.Actions() {
+						gotAction := action.GetVerb()
+						if deleteAction, ok := action.(clienttesting.DeleteAction); ok && deleteAction.GetDeleteOptions().PropagationPolicy != nil {
+							gotAction = fmt.Sprintf("%s %s", gotAction, *deleteAction.GetDeleteOptions().PropagationPolicy)
+						}
+						gotActions = append(gotActions, gotAction)
+					}
+					if !reflect.DeepEqual(gotErr, tc.expectedErr) {
+						t.Fatalf("expected %v, got %v", tc.expectedErr, gotErr)
+					}
+
+					if !equality.Semantic.DeepEqual(required, tc.required) {
+						t.Errorf("required object was mutated:\n%s", cmp.Diff(tc.required, required))
+					}
+
+					if !equality.Semantic.DeepEqual(gotRes.Object, tc.expectedCM) {
+						t.Errorf("expected and got configmaps differ:\n%s", cmp.Diff(tc.expectedCM, gotRes.Object))
+					}
+
+					// Make sure such object was actually created.
+					if gotErr == nil && gotRes.Object != nil {
+						createdCM, err := client.CoreV1().ConfigMaps(gotRes.Object.Namespace).Get(ctx, gotRes.Object.Name, metav1.GetOptions{})
+						if err != nil {
+							t.Error(err)
+						}
+						if !equality.Semantic.DeepEqual(createdCM, gotRes.Object) {
+							t.Errorf("created and returned configmaps differ:\n%s", cmp.Diff(createdCM, gotRes.Object))
+						}
+					}
+
+					close(recorder.Events)
+					var gotEvents []string
+					for e := range recorder.Events {
+						gotEvents = append(gotEvents, e)
+					}
+
+					if i == 0 {
+						if gotRes.Operation != tc.expectedOperation {
+							t.Errorf("expected operation %q, got %q", tc.expectedOperation, gotRes.Operation)
+						}
+
+						if gotRes.Changed != tc.expectedChanged {
+							t.Errorf("expected %t, got %t", tc.expectedChanged, gotRes.Changed)
+						}
+
+						if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+							t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+						}
+
+						if !reflect.DeepEqual(gotActions, tc.expectedActions) {
+							t.Errorf("expected and got actions differ:\n%s", cmp.Diff(tc.expectedActions, gotActions))
+						}
+					} else {
+						if gotRes.Changed {
+							t.Errorf("object changed in iteration %d", i)
+						}
+
+						// Unchanged events are the only ones expected once the object converged.
+						var expectedEvents []string
+						if tc.options.EmitUnchangedEvents {
+							expectedEvents = []string{fmt.Sprintf("Normal ConfigMapUnchanged ConfigMap %s unchanged", naming.ObjRef(gotRes.Object))}
+						}
+						if !reflect.DeepEqual(gotEvents, expectedEvents) {
+							t.Errorf("unexpected events in iteration %d:\n%s", i, cmp.Diff(expectedEvents, gotEvents))
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestApplyGenericHashVersionMigration(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	client := fake.NewSimpleClientset()
+
+	apply := func(required *corev1.ConfigMap) (bool, []string, error) {
+		t.Helper()
+
+		configMapCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		configMapList, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range configMapList.Items {
+			err = configMapCache.Add(&configMapList.Items[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		recorder := record.NewFakeRecorder(10)
+		_, changed, err := ApplyConfigMap(ctx, client.CoreV1(), corev1listers.NewConfigMapLister(configMapCache), recorder, required, ApplyOptions{})
+
+		close(recorder.Events)
+		var events []string
+		for e := range recorder.Events {
+			events = append(events, e)
+		}
+
+		return changed, events, err
+	}
+
+	gotChanged, _, err := apply(newConfigMap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotChanged {
+		t.Fatal("expected the object to be created")
+	}
+
+	v1CM, err := client.CoreV1().ConfigMaps("default").Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v1CM.Annotations[naming.ManagedHashVersion]; ok {
+		t.Errorf("expected objects hashed with %q not to have the %q annotation", hashVersionV1, naming.ManagedHashVersion)
+	}
+
+	// Simulate an upgrade to a release using a different hashing scheme.
+	originalHashVersion := currentHashVersion
+	originalHashFuncs := hashFuncs
+	defer func() {
+		currentHashVersion = originalHashVersion
+		hashFuncs = originalHashFuncs
+	}()
+	hashFuncs = map[string]hashFunc{
+		hashVersionV1: originalHashFuncs[hashVersionV1],
+		"v2": func(obj metav1.Object) (string, error) {
+			h, err := originalHashFuncs[hashVersionV1](obj)
+			if err != nil {
+				return "", err
+			}
+			return "v2-" + h, nil
+		},
+	}
+	currentHashVersion = "v2"
+
+	gotChanged, gotEvents, err := apply(newConfigMap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotChanged {
+		t.Error("expected an unchanged object not to be updated after changing the hash version")
+	}
+	if len(gotEvents) != 0 {
+		t.Errorf("expected no events, got %v", gotEvents)
+	}
+
+	changedCM := newConfigMap()
+	changedCM.Data["foo"] = "bar"
+	gotChanged, _, err = apply(changedCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotChanged {
+		t.Error("expected a changed object to be updated after changing the hash version")
+	}
+
+	v2CM, err := client.CoreV1().ConfigMaps("default").Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v2CM.Annotations[naming.ManagedHashVersion] != "v2" {
+		t.Errorf("expected hash version %q, got %q", "v2", v2CM.Annotations[naming.ManagedHashVersion])
+	}
+
+	gotChanged, _, err = apply(changedCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotChanged {
+		t.Error("expected the object to be stable after updating it with the new hash version")
+	}
+}
+
+func TestApplyGenericPostCreateWaitForCache(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	tt := []struct {
+		name                    string
+		options                 ApplyOptions
+		cacheDelayLookups       int32
+		ctxTimeout              time.Duration
+		expectedErr             bool
+		expectedMinCacheLookups int32
+		expectedMaxCacheLookups int32
+	}{
+		{
+			name:                    "doesn't look into the cache after create by default",
+			options:                 ApplyOptions{},
+			cacheDelayLookups:       3,
+			ctxTimeout:              30 * time.Second,
+			expectedErr:             false,
+			expectedMinCacheLookups: 0,
+			expectedMaxCacheLookups: 0,
+		},
+		{
+			name:                    "waits until the created object appears in the cache",
+			options:                 ApplyOptions{PostCreateWaitForCache: true},
+			cacheDelayLookups:       3,
+			ctxTimeout:              30 * time.Second,
+			expectedErr:             false,
+			expectedMinCacheLookups: 4,
+			expectedMaxCacheLookups: 4,
+		},
+		{
+			name:                    "gives up when the context expires before the object appears in the cache",
+			options:                 ApplyOptions{PostCreateWaitForCache: true},
+			cacheDelayLookups:       math.MaxInt32,
+			ctxTimeout:              300 * time.Millisecond,
+			expectedErr:             true,
+			expectedMinCacheLookups: 1,
+			expectedMaxCacheLookups: math.MaxInt32,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), tc.ctxTimeout)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset()
+			required := newConfigMap()
+
+			// The fake cache only sees the object after a number of lookups following the create, like a lagging informer.
+			var created atomic.Bool
+			var cacheLookups atomic.Int32
+			control := ApplyControlFuncs[*corev1.ConfigMap]{
+				GetCachedFunc: func(name string) (*corev1.ConfigMap, error) {
+					if !created.Load() {
+						return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+					}
+					if cacheLookups.Add(1) <= tc.cacheDelayLookups {
+						return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+					}
+					return client.CoreV1().ConfigMaps(required.Namespace).Get(ctx, name, metav1.GetOptions{})
+				},
+				CreateFunc: func(ctx context.Context, obj *corev1.ConfigMap, opts metav1.CreateOptions) (*corev1.ConfigMap, error) {
+					cm, err := client.CoreV1().ConfigMaps(required.Namespace).Create(ctx, obj, opts)
+					if err == nil {
+						created.Store(true)
+					}
+					return cm, err
+				},
+				UpdateFunc: client.CoreV1().ConfigMaps(required.Namespace).Update,
+				DeleteFunc: client.CoreV1().ConfigMaps(required.Namespace).Delete,
+			}
+
+			_, changed, err := ApplyGeneric[*corev1.ConfigMap](ctx, control, record.NewFakeRecorder(10), required, tc.options)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if !changed {
+				t.Errorf("expected the object to be created")
+			}
+
+			gotCacheLookups := cacheLookups.Load()
+			if gotCacheLookups < tc.expectedMinCacheLookups || gotCacheLookups > tc.expectedMaxCacheLookups {
+				t.Errorf("expected between %d and %d cache lookups after create, got %d", tc.expectedMinCacheLookups, tc.expectedMaxCacheLookups, gotCacheLookups)
+			}
+		})
+	}
+}
+
+func TestReportEvents(t *testing.T) {
+	t.Parallel()
+
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+			},
+		}
+	}
+
+	notFoundErr := apierrors.NewNotFound(corev1.Resource("configmaps"), "test")
+
+	reportApplyUpdateEvent := func(recorder record.EventRecorder, obj runtime.Object, operationErr error) {
+		reportApplyEvent(recorder, obj, operationErr, "update")
+	}
+
+	tt := []struct {
+		name           string
+		report         func(recorder record.EventRecorder, obj runtime.Object, operationErr error)
+		operationErr   error
+		expectedEvents []string
+	}{
+		{
+			name:           "public update event doesn't carry the error class",
+			report:         ReportUpdateEvent,
+			operationErr:   notFoundErr,
+			expectedEvents: []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: configmaps "test" not found`},
+		},
+		{
+			name:           "public delete event doesn't carry the error class",
+			report:         ReportDeleteEvent,
+			operationErr:   notFoundErr,
+			expectedEvents: []string{`Warning DeleteConfigMapFailed Failed to delete ConfigMap default/test: configmaps "test" not found`},
+		},
+		{
+			name:           "apply update event carries the error class",
+			report:         reportApplyUpdateEvent,
+			operationErr:   notFoundErr,
+			expectedEvents: []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: configmaps "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
+		},
+		{
+			name:           "apply event of an unclassified error has no annotations",
+			report:         reportApplyUpdateEvent,
+			operationErr:   fmt.Errorf("foo"),
+			expectedEvents: []string{`Warning UpdateConfigMapFailed Failed to update ConfigMap default/test: foo`},
+		},
+		{
+			name:           "apply create event of a success",
+			report:         reportApplyCreateEvent,
+			operationErr:   nil,
+			expectedEvents: []string{`Normal ConfigMapCreated ConfigMap default/test created`},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(10)
+			tc.report(recorder, newConfigMap(), tc.operationErr)
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+		})
+	}
+}
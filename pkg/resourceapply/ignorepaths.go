@@ -0,0 +1,108 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/klog/v2"
+)
+
+// comparisonIgnorePath is a parsed ApplyOptions.ComparisonIgnorePaths expression.
+type comparisonIgnorePath struct {
+	expression string
+	jsonPath   *jsonpath.JSONPath
+}
+
+// parseComparisonIgnorePaths parses the JSONPath expressions, they can be given with or without the surrounding braces.
+func parseComparisonIgnorePaths(expressions []string) ([]comparisonIgnorePath, error) {
+	paths := make([]comparisonIgnorePath, 0, len(expressions))
+	for _, expression := range expressions {
+		template := expression
+		if !strings.HasPrefix(template, "{") {
+			template = fmt.Sprintf("{%s}", template)
+		}
+
+		jp := jsonpath.New(expression).AllowMissingKeys(true)
+		err := jp.Parse(template)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse comparison ignore path %q: %w", expression, err)
+		}
+
+		paths = append(paths, comparisonIgnorePath{
+			expression: expression,
+			jsonPath:   jp,
+		})
+	}
+
+	return paths, nil
+}
+
+// findSettableValues returns the values of obj matched by the path.
+// Matches that can't be set, like map values, are reported as an error, as they couldn't be ignored.
+func (p *comparisonIgnorePath) findSettableValues(obj any) ([]reflect.Value, error) {
+	results, err := p.jsonPath.FindResults(obj)
+	if err != nil {
+		return nil, fmt.Errorf("can't evaluate comparison ignore path %q: %w", p.expression, err)
+	}
+
+	var values []reflect.Value
+	for _, result := range results {
+		for _, v := range result {
+			if !v.CanSet() {
+				return nil, fmt.Errorf("comparison ignore path %q matches a value of type %s that can't be ignored, only struct fields and list items can", p.expression, v.Type())
+			}
+			values = append(values, v)
+		}
+	}
+
+	return values, nil
+}
+
+// zeroIgnoredPaths sets the values of obj matched by any of the paths to their zero values.
+// obj has to be a pointer to a typed object.
+func zeroIgnoredPaths(obj any, paths []comparisonIgnorePath) error {
+	for i := range paths {
+		values, err := paths[i].findSettableValues(obj)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range values {
+			v.Set(reflect.Zero(v.Type()))
+		}
+	}
+
+	return nil
+}
+
+// carryOverIgnoredPaths copies the values matched by the paths from existing to required, so the values
+// written by other actors are kept when the object is updated. The matches are paired by their position,
+// paths matching a different number of values in each object can't be paired and are left as they are.
+func carryOverIgnoredPaths(required, existing any, paths []comparisonIgnorePath) error {
+	for i := range paths {
+		requiredValues, err := paths[i].findSettableValues(required)
+		if err != nil {
+			return err
+		}
+
+		existingValues, err := paths[i].findSettableValues(existing)
+		if err != nil {
+			return err
+		}
+
+		if len(requiredValues) != len(existingValues) {
+			klog.V(4).InfoS("Can't pair values matched by comparison ignore path, keeping the required ones", "Path", paths[i].expression, "Required", len(requiredValues), "Existing", len(existingValues))
+			continue
+		}
+
+		for j := range requiredValues {
+			requiredValues[j].Set(existingValues[j])
+		}
+	}
+
+	return nil
+}
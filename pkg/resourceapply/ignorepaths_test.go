@@ -0,0 +1,245 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyGenericComparisonIgnorePaths(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	newSts := func(image string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1alpha1",
+						Kind:               "ScyllaDBDatacenter",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Ptr(int32(1)),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"foo": "bar",
+					},
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"foo": "bar",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "scylla",
+								Image: image,
+							},
+							{
+								Name:  "sidecar",
+								Image: image,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	options := ApplyOptions{
+		ComparisonIgnorePaths: []string{"{.spec.template.spec.containers[*].image}"},
+	}
+
+	client := fake.NewSimpleClientset()
+
+	apply := func(required *appsv1.StatefulSet) (*appsv1.StatefulSet, bool) {
+		t.Helper()
+
+		stsCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		stsList, err := client.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range stsList.Items {
+			err = stsCache.Add(&stsList.Items[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, changed, err := ApplyStatefulSet(ctx, client.AppsV1(), appsv1listers.NewStatefulSetLister(stsCache), record.NewFakeRecorder(10), required, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return got, changed
+	}
+
+	getImages := func(sts *appsv1.StatefulSet) []string {
+		var images []string
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		return images
+	}
+
+	_, changed := apply(newSts("scylladb/scylla:6.0"))
+	if !changed {
+		t.Fatal("expected the StatefulSet to be created")
+	}
+
+	// Another actor rolls out a new image, leaving the hash annotation as it is.
+	existing, err := client.AppsV1().StatefulSets("default").Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range existing.Spec.Template.Spec.Containers {
+		existing.Spec.Template.Spec.Containers[i].Image = "scylladb/scylla:6.1"
+	}
+	_, err = client.AppsV1().StatefulSets("default").Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A different required image mustn't cause an update, the images are ignored.
+	got, changed := apply(newSts("scylladb/scylla:7.0"))
+	if changed {
+		t.Error("expected no update when only ignored fields differ")
+	}
+	expectedImages := []string{"scylladb/scylla:6.1", "scylladb/scylla:6.1"}
+	if !reflect.DeepEqual(getImages(got), expectedImages) {
+		t.Errorf("expected and got images differ:\n%s", cmp.Diff(expectedImages, getImages(got)))
+	}
+
+	// An update for another reason has to keep the images written by the other actor.
+	required := newSts("scylladb/scylla:7.0")
+	required.Spec.Replicas = pointer.Ptr(int32(3))
+	got, changed = apply(required)
+	if !changed {
+		t.Error("expected an update when a compared field differs")
+	}
+	if !reflect.DeepEqual(getImages(got), expectedImages) {
+		t.Errorf("expected and got images differ:\n%s", cmp.Diff(expectedImages, getImages(got)))
+	}
+	if *got.Spec.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %d", *got.Spec.Replicas)
+	}
+	if required.Spec.Template.Spec.Containers[0].Image != "scylladb/scylla:7.0" {
+		t.Errorf("required object was mutated, got image %q", required.Spec.Template.Spec.Containers[0].Image)
+	}
+}
+
+func TestZeroIgnoredPaths(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		paths         []string
+		obj           *corev1.Pod
+		expectedObj   *corev1.Pod
+		expectedError string
+	}{
+		{
+			name:  "zeroes a nested field of all list items",
+			paths: []string{".spec.containers[*].image"},
+			obj: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a", Image: "a:1"},
+						{Name: "b", Image: "b:1"},
+					},
+				},
+			},
+			expectedObj: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a"},
+						{Name: "b"},
+					},
+				},
+			},
+		},
+		{
+			name:  "zeroes a single list item field and a missing field is ignored",
+			paths: []string{"{.spec.containers[1].image}", "{.spec.nodeSelector.missing}"},
+			obj: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a", Image: "a:1"},
+						{Name: "b", Image: "b:1"},
+					},
+				},
+			},
+			expectedObj: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a", Image: "a:1"},
+						{Name: "b"},
+					},
+				},
+			},
+		},
+		{
+			name:  "map values can't be ignored",
+			paths: []string{".metadata.labels.foo"},
+			obj: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			},
+			expectedError: `comparison ignore path ".metadata.labels.foo" matches a value of type string that can't be ignored, only struct fields and list items can`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			paths, err := parseComparisonIgnorePaths(tc.paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = zeroIgnoredPaths(tc.obj, paths)
+			if len(tc.expectedError) != 0 {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.obj, tc.expectedObj) {
+				t.Errorf("expected and got objects differ:\n%s", cmp.Diff(tc.expectedObj, tc.obj))
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ImmutableField is a field that a CEL transition rule of a CRD forbids changing, see ImmutableFieldsFromCRD.
+type ImmutableField struct {
+	// Path is the path of the field from the object root, e.g. ["spec", "version"].
+	Path []string
+	// Rule is the CEL rule the field was derived from.
+	Rule string
+	// Message is the message of the rule, if it has one.
+	Message string
+}
+
+func (f *ImmutableField) String() string {
+	return strings.Join(f.Path, ".")
+}
+
+var celFieldSelectorRegexp = regexp.MustCompile(`^(self|oldSelf)((?:\.[A-Za-z_][A-Za-z0-9_]*)*)$`)
+
+// parseImmutabilityClause recognizes a clause of the form `self.a.b == oldSelf.a.b`, in any order of the operands,
+// and returns the field selector relative to the schema node that holds the rule.
+func parseImmutabilityClause(clause string) ([]string, bool) {
+	clause = strings.TrimSpace(clause)
+	for strings.HasPrefix(clause, "(") && strings.HasSuffix(clause, ")") {
+		clause = strings.TrimSpace(clause[1 : len(clause)-1])
+	}
+
+	operands := strings.Split(clause, "==")
+	if len(operands) != 2 {
+		return nil, false
+	}
+
+	lhs := celFieldSelectorRegexp.FindStringSubmatch(strings.TrimSpace(operands[0]))
+	rhs := celFieldSelectorRegexp.FindStringSubmatch(strings.TrimSpace(operands[1]))
+	if lhs == nil || rhs == nil || lhs[1] == rhs[1] || lhs[2] != rhs[2] {
+		return nil, false
+	}
+
+	if len(lhs[2]) == 0 {
+		return []string{}, true
+	}
+
+	return strings.Split(lhs[2][1:], "."), true
+}
+
+// parseImmutabilityRule returns the field selectors a rule requires to stay the same.
+// Only the subset of CEL used for immutability is understood: conjunctions of equalities between
+// a field of self and the same field of oldSelf. Clauses combined by anything other than a conjunction
+// can't be evaluated on their own, so rules containing them are ignored altogether and left to the apiserver.
+func parseImmutabilityRule(rule string) [][]string {
+	if strings.ContainsAny(rule, "|?!<>") {
+		return nil
+	}
+
+	var selectors [][]string
+	for _, clause := range strings.Split(rule, "&&") {
+		selector, ok := parseImmutabilityClause(clause)
+		if ok {
+			selectors = append(selectors, selector)
+		}
+	}
+
+	return selectors
+}
+
+func collectImmutableFields(schema *apiextensionsv1.JSONSchemaProps, path []string, fields []ImmutableField) []ImmutableField {
+	for _, validation := range schema.XValidations {
+		for _, selector := range parseImmutabilityRule(validation.Rule) {
+			fields = append(fields, ImmutableField{
+				Path:    append(slices.Clone(path), selector...),
+				Rule:    validation.Rule,
+				Message: validation.Message,
+			})
+		}
+	}
+
+	// List items aren't followed, rules on them would need the items to be correlated between the objects.
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		property := schema.Properties[name]
+		fields = collectImmutableFields(&property, append(slices.Clone(path), name), fields)
+	}
+
+	return fields
+}
+
+// ImmutableFieldsFromCRD returns the fields of the given CRD version that are made immutable by CEL transition rules
+// in x-kubernetes-validations, so ApplyOptions.ImmutableFields can reject updates that the apiserver would refuse.
+// Only rules under object properties are taken into account, see parseImmutabilityRule for the supported subset.
+func ImmutableFieldsFromCRD(crd *apiextensionsv1.CustomResourceDefinition, version string) ([]ImmutableField, error) {
+	for _, v := range crd.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			return nil, fmt.Errorf("version %q of CRD %q has no schema", version, crd.Name)
+		}
+
+		return collectImmutableFields(v.Schema.OpenAPIV3Schema, []string{}, nil), nil
+	}
+
+	return nil, fmt.Errorf("CRD %q has no version %q", crd.Name, version)
+}
+
+// findChangedImmutableField returns the first immutable field that differs between the objects.
+// Like with CEL transition rules, fields missing in either object aren't compared.
+func findChangedImmutableField(required, existing runtime.Object, fields []ImmutableField) (*ImmutableField, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	requiredUnstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(required)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert required object to unstructured: %w", err)
+	}
+
+	existingUnstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert existing object to unstructured: %w", err)
+	}
+
+	for i := range fields {
+		requiredValue, requiredFound, err := unstructured.NestedFieldNoCopy(requiredUnstructured, fields[i].Path...)
+		if err != nil || !requiredFound {
+			continue
+		}
+
+		existingValue, existingFound, err := unstructured.NestedFieldNoCopy(existingUnstructured, fields[i].Path...)
+		if err != nil || !existingFound {
+			continue
+		}
+
+		if !reflect.DeepEqual(requiredValue, existingValue) {
+			return &fields[i], nil
+		}
+	}
+
+	return nil, nil
+}
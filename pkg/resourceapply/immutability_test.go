@@ -0,0 +1,102 @@
+package resourceapply
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func newImmutabilityTestCRD(dataValidations ...apiextensionsv1.ValidationRule) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name: "v1",
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"data": {
+									Type:         "object",
+									XValidations: dataValidations,
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"locked": {
+											Type: "string",
+											XValidations: []apiextensionsv1.ValidationRule{
+												{
+													Rule:    "self == oldSelf",
+													Message: "locked is immutable",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestImmutableFieldsFromCRD(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name           string
+		validations    []apiextensionsv1.ValidationRule
+		expectedFields []ImmutableField
+	}{
+		{
+			name: "field rules",
+			expectedFields: []ImmutableField{
+				{Path: []string{"data", "locked"}, Rule: "self == oldSelf", Message: "locked is immutable"},
+			},
+		},
+		{
+			name: "parent rules selecting fields in a conjunction",
+			validations: []apiextensionsv1.ValidationRule{
+				{Rule: "oldSelf.a == self.a && (self.b.c == oldSelf.b.c)"},
+			},
+			expectedFields: []ImmutableField{
+				{Path: []string{"data", "a"}, Rule: "oldSelf.a == self.a && (self.b.c == oldSelf.b.c)"},
+				{Path: []string{"data", "b", "c"}, Rule: "oldSelf.a == self.a && (self.b.c == oldSelf.b.c)"},
+				{Path: []string{"data", "locked"}, Rule: "self == oldSelf", Message: "locked is immutable"},
+			},
+		},
+		{
+			name: "ignores rules it can't evaluate",
+			validations: []apiextensionsv1.ValidationRule{
+				{Rule: "!has(oldSelf.a) || self.a == oldSelf.a"},
+				{Rule: "self.a == oldSelf.b"},
+				{Rule: "self.a == self.a"},
+				{Rule: "size(self.a) > 0"},
+			},
+			expectedFields: []ImmutableField{
+				{Path: []string{"data", "locked"}, Rule: "self == oldSelf", Message: "locked is immutable"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ImmutableFieldsFromCRD(newImmutabilityTestCRD(tc.validations...), "v1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.expectedFields) {
+				t.Errorf("expected and got fields differ:\n%s", cmp.Diff(tc.expectedFields, got))
+			}
+		})
+	}
+
+	_, err := ImmutableFieldsFromCRD(newImmutabilityTestCRD(), "v2")
+	if err == nil {
+		t.Errorf("expected an error for a missing version")
+	}
+}
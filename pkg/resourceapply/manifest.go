@@ -0,0 +1,83 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var manifestCodecs = serializer.NewCodecFactory(kscheme.Scheme, serializer.EnableStrict)
+
+// ApplyManifest decodes a single YAML or JSON manifest and applies it with the ApplyX method matching its kind,
+// so bundled static manifests can be applied idempotently.
+// The manifest has to set apiVersion and kind, unknown fields are rejected.
+// The returned object is only meaningful when no error is returned.
+func (a *Applier) ApplyManifest(ctx context.Context, manifest []byte, options ApplyOptions) (kubeinterfaces.ObjectInterface, bool, error) {
+	obj, gvk, err := manifestCodecs.UniversalDeserializer().Decode(manifest, nil, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("can't decode manifest: %w", err)
+	}
+
+	switch required := obj.(type) {
+	case *corev1.ConfigMap:
+		return a.ApplyConfigMap(ctx, required, options)
+	case *corev1.Secret:
+		return a.ApplySecret(ctx, required, options)
+	case *corev1.Service:
+		return a.ApplyService(ctx, required, options)
+	case *corev1.ServiceAccount:
+		return a.ApplyServiceAccount(ctx, required, options)
+	case *corev1.Namespace:
+		return a.ApplyNamespace(ctx, required, options)
+	case *corev1.Endpoints:
+		return a.ApplyEndpoints(ctx, required, options)
+	case *corev1.Pod:
+		return a.ApplyPod(ctx, required, options)
+	case *corev1.PersistentVolumeClaim:
+		return a.ApplyPersistentVolumeClaim(ctx, required, options)
+	case *discoveryv1.EndpointSlice:
+		return a.ApplyEndpointSlice(ctx, required, options)
+	case *appsv1.StatefulSet:
+		return a.ApplyStatefulSet(ctx, required, options)
+	case *appsv1.DaemonSet:
+		return a.ApplyDaemonSet(ctx, required, options)
+	case *appsv1.Deployment:
+		return a.ApplyDeployment(ctx, required, options)
+	case *batchv1.Job:
+		return a.ApplyJob(ctx, required, options)
+	case *coordinationv1.Lease:
+		return a.ApplyLease(ctx, required, options)
+	case *policyv1.PodDisruptionBudget:
+		return a.ApplyPodDisruptionBudget(ctx, required, options)
+	case *networkingv1.Ingress:
+		return a.ApplyIngress(ctx, required, options)
+	case *networkingv1.NetworkPolicy:
+		return a.ApplyNetworkPolicy(ctx, required, options)
+	case *rbacv1.ClusterRole:
+		return a.ApplyClusterRole(ctx, required, options)
+	case *rbacv1.Role:
+		return a.ApplyRole(ctx, required, options)
+	case *rbacv1.RoleBinding:
+		return a.ApplyRoleBinding(ctx, required, options)
+	case *rbacv1.ClusterRoleBinding:
+		return a.ApplyClusterRoleBinding(ctx, required, options)
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return a.ApplyHorizontalPodAutoscaler(ctx, required, options)
+	default:
+		return nil, false, fmt.Errorf("can't apply manifest of unsupported kind %s", gvk)
+	}
+}
@@ -0,0 +1,166 @@
+package resourceapply
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplierApplyManifest(t *testing.T) {
+	t.Parallel()
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	manifest := []byte(`apiVersion: v1
+kind: Service
+metadata:
+  namespace: default
+  name: static
+  labels:
+    app: static
+spec:
+  selector:
+    app: static
+  ports:
+  - name: http
+    port: 80
+`)
+
+	client := fake.NewSimpleClientset()
+
+	applyManifest := func() (bool, []string, error) {
+		t.Helper()
+
+		svcCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		svcList, err := client.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range svcList.Items {
+			err = svcCache.Add(&svcList.Items[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		recorder := record.NewFakeRecorder(10)
+		applier := NewApplier(
+			client,
+			ApplierListers{
+				Services: corev1listers.NewServiceLister(svcCache),
+			},
+			recorder,
+		)
+
+		got, changed, err := applier.ApplyManifest(ctx, manifest, ApplyOptions{AllowMissingControllerRef: true})
+		if err == nil {
+			if _, ok := got.(*corev1.Service); !ok {
+				t.Errorf("expected a Service, got %T", got)
+			}
+		}
+
+		close(recorder.Events)
+		var events []string
+		for e := range recorder.Events {
+			events = append(events, e)
+		}
+
+		return changed, events, err
+	}
+
+	changed, events, err := applyManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Errorf("expected the service to be created")
+	}
+	expectedEvents := []string{"Normal ServiceCreated Service default/static created"}
+	if !reflect.DeepEqual(events, expectedEvents) {
+		t.Errorf("expected and got events differ:\n%s", cmp.Diff(expectedEvents, events))
+	}
+
+	svc, err := client.CoreV1().Services("default").Get(ctx, "static", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPorts := []corev1.ServicePort{{Name: "http", Port: 80}}
+	if !reflect.DeepEqual(svc.Spec.Ports, expectedPorts) {
+		t.Errorf("expected and got ports differ:\n%s", cmp.Diff(expectedPorts, svc.Spec.Ports))
+	}
+
+	changed, events, err = applyManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Errorf("expected reapplying the same manifest to be a no-op")
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events, got %q", events)
+	}
+}
+
+func TestApplierApplyManifestErrors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		manifest    string
+		expectedErr string
+	}{
+		{
+			name: "rejects unsupported kinds",
+			manifest: `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  namespace: default
+  name: static
+`,
+			expectedErr: "can't apply manifest of unsupported kind batch/v1, Kind=CronJob",
+		},
+		{
+			name: "rejects unknown fields",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: default
+  name: static
+datum: {}
+`,
+			expectedErr: "can't decode manifest",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset()
+			applier := NewApplier(client, ApplierListers{}, record.NewFakeRecorder(10))
+
+			_, _, err := applier.ApplyManifest(ctx, []byte(tc.manifest), ApplyOptions{})
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expectedErr) {
+				t.Errorf("expected error starting with %q, got %v", tc.expectedErr, err)
+			}
+
+			if len(client.Actions()) != 0 {
+				t.Errorf("expected no API calls, got %v", client.Actions())
+			}
+		})
+	}
+}
@@ -29,12 +29,7 @@ func ApplyPrometheus(
 ) (*monitoringv1.Prometheus, bool, error) {
 	return ApplyPrometheusWithControl(
 		ctx,
-		ApplyControlFuncs[*monitoringv1.Prometheus]{
-			GetCachedFunc: lister.Prometheuses(required.Namespace).Get,
-			CreateFunc:    client.Prometheuses(required.Namespace).Create,
-			UpdateFunc:    client.Prometheuses(required.Namespace).Update,
-			DeleteFunc:    client.Prometheuses(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*monitoringv1.Prometheus](lister.Prometheuses(required.Namespace), client.Prometheuses(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -61,12 +56,7 @@ func ApplyPrometheusRule(
 ) (*monitoringv1.PrometheusRule, bool, error) {
 	return ApplyPrometheusRuleWithControl(
 		ctx,
-		ApplyControlFuncs[*monitoringv1.PrometheusRule]{
-			GetCachedFunc: lister.PrometheusRules(required.Namespace).Get,
-			CreateFunc:    client.PrometheusRules(required.Namespace).Create,
-			UpdateFunc:    client.PrometheusRules(required.Namespace).Update,
-			DeleteFunc:    client.PrometheusRules(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*monitoringv1.PrometheusRule](lister.PrometheusRules(required.Namespace), client.PrometheusRules(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -93,12 +83,34 @@ func ApplyServiceMonitor(
 ) (*monitoringv1.ServiceMonitor, bool, error) {
 	return ApplyServiceMonitorWithControl(
 		ctx,
-		ApplyControlFuncs[*monitoringv1.ServiceMonitor]{
-			GetCachedFunc: lister.ServiceMonitors(required.Namespace).Get,
-			CreateFunc:    client.ServiceMonitors(required.Namespace).Create,
-			UpdateFunc:    client.ServiceMonitors(required.Namespace).Update,
-			DeleteFunc:    client.ServiceMonitors(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*monitoringv1.ServiceMonitor](lister.ServiceMonitors(required.Namespace), client.ServiceMonitors(required.Namespace)),
+		recorder,
+		required,
+		options,
+	)
+}
+
+func ApplyPodMonitorWithControl(
+	ctx context.Context,
+	control ApplyControlInterface[*monitoringv1.PodMonitor],
+	recorder record.EventRecorder,
+	required *monitoringv1.PodMonitor,
+	options ApplyOptions,
+) (*monitoringv1.PodMonitor, bool, error) {
+	return ApplyGeneric[*monitoringv1.PodMonitor](ctx, control, recorder, required, options)
+}
+
+func ApplyPodMonitor(
+	ctx context.Context,
+	client monitoringv1client.PodMonitorsGetter,
+	lister monitoringv1listers.PodMonitorLister,
+	recorder record.EventRecorder,
+	required *monitoringv1.PodMonitor,
+	options ApplyOptions,
+) (*monitoringv1.PodMonitor, bool, error) {
+	return ApplyPodMonitorWithControl(
+		ctx,
+		NewApplyControlFuncs[*monitoringv1.PodMonitor](lister.PodMonitors(required.Namespace), client.PodMonitors(required.Namespace)),
 		recorder,
 		required,
 		options,
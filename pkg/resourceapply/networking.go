@@ -18,7 +18,20 @@ func ApplyIngressWithControl(
 	required *networkingv1.Ingress,
 	options ApplyOptions,
 ) (*networkingv1.Ingress, bool, error) {
-	return ApplyGeneric[*networkingv1.Ingress](ctx, control, recorder, required, options)
+	return ApplyGenericWithHandlers[*networkingv1.Ingress](
+		ctx,
+		control,
+		recorder,
+		required,
+		options,
+		func(required **networkingv1.Ingress, existing *networkingv1.Ingress) {
+			// Ingresses without a class get the default one set on create, keep it so updates don't drop it.
+			if (*required).Spec.IngressClassName == nil {
+				(*required).Spec.IngressClassName = existing.Spec.IngressClassName
+			}
+		},
+		nil,
+	)
 }
 
 func ApplyIngress(
@@ -31,12 +44,34 @@ func ApplyIngress(
 ) (*networkingv1.Ingress, bool, error) {
 	return ApplyIngressWithControl(
 		ctx,
-		ApplyControlFuncs[*networkingv1.Ingress]{
-			GetCachedFunc: lister.Ingresses(required.Namespace).Get,
-			CreateFunc:    client.Ingresses(required.Namespace).Create,
-			UpdateFunc:    client.Ingresses(required.Namespace).Update,
-			DeleteFunc:    client.Ingresses(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*networkingv1.Ingress](lister.Ingresses(required.Namespace), client.Ingresses(required.Namespace)),
+		recorder,
+		required,
+		options,
+	)
+}
+
+func ApplyNetworkPolicyWithControl(
+	ctx context.Context,
+	control ApplyControlInterface[*networkingv1.NetworkPolicy],
+	recorder record.EventRecorder,
+	required *networkingv1.NetworkPolicy,
+	options ApplyOptions,
+) (*networkingv1.NetworkPolicy, bool, error) {
+	return ApplyGeneric[*networkingv1.NetworkPolicy](ctx, control, recorder, required, options)
+}
+
+func ApplyNetworkPolicy(
+	ctx context.Context,
+	client networkingv1client.NetworkPoliciesGetter,
+	lister networkingv1listers.NetworkPolicyLister,
+	recorder record.EventRecorder,
+	required *networkingv1.NetworkPolicy,
+	options ApplyOptions,
+) (*networkingv1.NetworkPolicy, bool, error) {
+	return ApplyNetworkPolicyWithControl(
+		ctx,
+		NewApplyControlFuncs[*networkingv1.NetworkPolicy](lister.NetworkPolicies(required.Namespace), client.NetworkPolicies(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -112,6 +112,31 @@ func TestApplyIngress(t *testing.T) {
 			expectedErr:     nil,
 			expectedEvents:  []string{"Normal IngressUpdated Ingress default/test updated"},
 		},
+		{
+			name: "keeps the defaulted ingress class when the required ingress doesn't set it",
+			existing: []runtime.Object{
+				func() *networkingv1.Ingress {
+					ingress := newIngress()
+					ingress.Spec.IngressClassName = pointer.Ptr("default-class")
+					return ingress
+				}(),
+			},
+			required: func() *networkingv1.Ingress {
+				ingress := newIngress()
+				ingress.Spec.DefaultBackend.Service.Port.Number = 42
+				return ingress
+			}(),
+			expectedIngress: func() *networkingv1.Ingress {
+				ingress := newIngress()
+				ingress.Spec.DefaultBackend.Service.Port.Number = 42
+				apimachineryutilruntime.Must(SetHashAnnotation(ingress))
+				ingress.Spec.IngressClassName = pointer.Ptr("default-class")
+				return ingress
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal IngressUpdated Ingress default/test updated"},
+		},
 		{
 			name:     "fails to create the ingress without a controllerRef",
 			existing: nil,
@@ -226,8 +251,8 @@ func TestApplyIngress(t *testing.T) {
 			}(),
 			expectedIngress: nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update networking.k8s.io/v1, Kind=Ingress "default/test": %w`, apierrors.NewNotFound(networkingv1.Resource("ingresses"), "test")),
-			expectedEvents:  []string{`Warning UpdateIngressFailed Failed to update Ingress default/test: ingresses.networking.k8s.io "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update networking.k8s.io/v1, Kind=Ingress "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(networkingv1.Resource("ingresses"), "test")}),
+			expectedEvents:  []string{`Warning UpdateIngressFailed Failed to update Ingress default/test: ingresses.networking.k8s.io "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -490,3 +515,217 @@ func TestApplyIngress(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyNetworkPolicy(t *testing.T) {
+	// Using a generating function prevents unwanted mutations.
+	newNetworkPolicy := func() *networkingv1.NetworkPolicy {
+		return &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app": "scylla",
+					},
+				},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{
+								IPBlock: &networkingv1.IPBlock{
+									CIDR: "10.0.0.0/8",
+								},
+							},
+						},
+					},
+				},
+				PolicyTypes: []networkingv1.PolicyType{
+					networkingv1.PolicyTypeIngress,
+				},
+			},
+		}
+	}
+
+	newNetworkPolicyWithHash := func() *networkingv1.NetworkPolicy {
+		np := newNetworkPolicy()
+		apimachineryutilruntime.Must(SetHashAnnotation(np))
+		return np
+	}
+
+	tt := []struct {
+		name                  string
+		existing              []runtime.Object
+		required              *networkingv1.NetworkPolicy
+		expectedNetworkPolicy *networkingv1.NetworkPolicy
+		expectedChanged       bool
+		expectedErr           error
+		expectedEvents        []string
+	}{
+		{
+			name:                  "creates a new networkpolicy when there is none",
+			existing:              nil,
+			required:              newNetworkPolicy(),
+			expectedNetworkPolicy: newNetworkPolicyWithHash(),
+			expectedChanged:       true,
+			expectedErr:           nil,
+			expectedEvents:        []string{"Normal NetworkPolicyCreated NetworkPolicy default/test created"},
+		},
+		{
+			name: "does nothing if the same networkpolicy already exists",
+			existing: []runtime.Object{
+				newNetworkPolicyWithHash(),
+			},
+			required:              newNetworkPolicy(),
+			expectedNetworkPolicy: newNetworkPolicyWithHash(),
+			expectedChanged:       false,
+			expectedErr:           nil,
+			expectedEvents:        nil,
+		},
+		{
+			name:     "fails to create the networkpolicy without a controllerRef",
+			existing: nil,
+			required: func() *networkingv1.NetworkPolicy {
+				np := newNetworkPolicy()
+				np.OwnerReferences = nil
+				return np
+			}(),
+			expectedNetworkPolicy: nil,
+			expectedChanged:       false,
+			expectedErr:           fmt.Errorf(`networking.k8s.io/v1, Kind=NetworkPolicy "default/test" is missing controllerRef`),
+			expectedEvents:        nil,
+		},
+		{
+			name: "updates the networkpolicy if ingress rules differ",
+			existing: []runtime.Object{
+				newNetworkPolicyWithHash(),
+			},
+			required: func() *networkingv1.NetworkPolicy {
+				np := newNetworkPolicy()
+				np.Spec.Ingress[0].From[0].IPBlock.CIDR = "192.168.0.0/16"
+				return np
+			}(),
+			expectedNetworkPolicy: func() *networkingv1.NetworkPolicy {
+				np := newNetworkPolicy()
+				np.Spec.Ingress[0].From[0].IPBlock.CIDR = "192.168.0.0/16"
+				apimachineryutilruntime.Must(SetHashAnnotation(np))
+				return np
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal NetworkPolicyUpdated NetworkPolicy default/test updated"},
+		},
+		{
+			name: "fails to update the networkpolicy if it isn't owned by us",
+			existing: []runtime.Object{
+				func() *networkingv1.NetworkPolicy {
+					np := newNetworkPolicy()
+					np.OwnerReferences = nil
+					return np
+				}(),
+			},
+			required:              newNetworkPolicy(),
+			expectedNetworkPolicy: nil,
+			expectedChanged:       false,
+			expectedErr:           fmt.Errorf(`networking.k8s.io/v1, Kind=NetworkPolicy "default/test" isn't controlled by us`),
+			expectedEvents:        []string{`Warning UpdateNetworkPolicyFailed Failed to update NetworkPolicy default/test: networking.k8s.io/v1, Kind=NetworkPolicy "default/test" isn't controlled by us`},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Client holds the state so it has to persists the iterations.
+			client := fake.NewSimpleClientset(tc.existing...)
+
+			// ApplyNetworkPolicy needs to be reentrant so running it the second time should give the same results.
+			iterations := 2
+			if tc.expectedErr != nil {
+				iterations = 1
+			}
+			for i := range iterations {
+				t.Run("", func(t *testing.T) {
+					ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer ctxCancel()
+
+					recorder := record.NewFakeRecorder(10)
+
+					npCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+					npLister := networkingv1listers.NewNetworkPolicyLister(npCache)
+
+					npList, err := client.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{
+						LabelSelector: labels.Everything().String(),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					for i := range npList.Items {
+						err := npCache.Add(&npList.Items[i])
+						if err != nil {
+							t.Fatal(err)
+						}
+					}
+
+					gotObj, gotChanged, gotErr := ApplyNetworkPolicy(ctx, client.NetworkingV1(), npLister, recorder, tc.required, ApplyOptions{})
+					if !reflect.DeepEqual(gotErr, tc.expectedErr) {
+						t.Fatalf("expected %v, got %v", tc.expectedErr, gotErr)
+					}
+
+					if !equality.Semantic.DeepEqual(gotObj, tc.expectedNetworkPolicy) {
+						t.Errorf("expected %#v, got %#v, diff:\n%s", tc.expectedNetworkPolicy, gotObj, cmp.Diff(tc.expectedNetworkPolicy, gotObj))
+					}
+
+					// Make sure such object was actually created.
+					if gotObj != nil {
+						createdNetworkPolicy, err := client.NetworkingV1().NetworkPolicies(gotObj.Namespace).Get(ctx, gotObj.Name, metav1.GetOptions{})
+						if err != nil {
+							t.Error(err)
+						}
+						if !equality.Semantic.DeepEqual(createdNetworkPolicy, gotObj) {
+							t.Errorf("created and returned networkpolicies differ:\n%s", cmp.Diff(createdNetworkPolicy, gotObj))
+						}
+					}
+
+					if i == 0 {
+						if gotChanged != tc.expectedChanged {
+							t.Errorf("expected %t, got %t", tc.expectedChanged, gotChanged)
+						}
+					} else {
+						if gotChanged {
+							t.Errorf("object changed in iteration %d", i)
+						}
+					}
+
+					close(recorder.Events)
+					var gotEvents []string
+					for e := range recorder.Events {
+						gotEvents = append(gotEvents, e)
+					}
+					if i == 0 {
+						if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+							t.Errorf("expected %v, got %v, diff:\n%s", tc.expectedEvents, gotEvents, cmp.Diff(tc.expectedEvents, gotEvents))
+						}
+					} else {
+						if len(gotEvents) > 0 {
+							t.Errorf("unexpected events: %v", gotEvents)
+						}
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// PatchClient is implemented by the typed clients, both namespaced and cluster-scoped.
+type PatchClient[T kubeinterfaces.ObjectInterface] interface {
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+}
+
+type jsonPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+func makeReparentPatch(obj metav1.Object, ownerReferences []metav1.OwnerReference) ([]byte, error) {
+	var ops []jsonPatchOperation
+
+	if len(obj.GetUID()) != 0 {
+		ops = append(ops, jsonPatchOperation{
+			Op:    "test",
+			Path:  "/metadata/uid",
+			Value: obj.GetUID(),
+		})
+	}
+
+	if len(obj.GetResourceVersion()) != 0 {
+		ops = append(ops, jsonPatchOperation{
+			Op:    "test",
+			Path:  "/metadata/resourceVersion",
+			Value: obj.GetResourceVersion(),
+		})
+	}
+
+	ops = append(ops, jsonPatchOperation{
+		// Add replaces the value if it's already present.
+		Op:    "add",
+		Path:  "/metadata/ownerReferences",
+		Value: ownerReferences,
+	})
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal reparent patch: %w", err)
+	}
+
+	return patch, nil
+}
+
+// ReparentOwnership makes newController the controller of obj, replacing its current controllerRef.
+// Only metadata.ownerReferences are patched, so the object keeps its hash and the next apply by the new controller
+// doesn't have to update it. Other ownerReferences are kept.
+// The patch is bound to the object's UID and resource version, so it fails if the object changed since it was read.
+// Objects without a controllerRef are only claimed with ApplyOptions.ForceOwnership.
+func ReparentOwnership[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	client PatchClient[T],
+	obj T,
+	newController metav1.OwnerReference,
+	options ApplyOptions,
+) (T, bool, error) {
+	gvk := resource.GetObjectGVKOrUnknown(obj)
+
+	if newController.Controller == nil || !*newController.Controller {
+		return *new(T), false, fmt.Errorf("new owner of %s %q isn't a controllerRef", gvk, naming.ObjRef(obj))
+	}
+
+	if len(newController.UID) == 0 {
+		return *new(T), false, fmt.Errorf("new controllerRef of %s %q is missing UID", gvk, naming.ObjRef(obj))
+	}
+
+	if options.AllowedNamespaces != nil && !options.AllowedNamespaces.Has(obj.GetNamespace()) {
+		return *new(T), false, &NamespaceNotAllowedError{
+			GVK:       *gvk,
+			Ref:       naming.ObjRef(obj),
+			Namespace: obj.GetNamespace(),
+		}
+	}
+
+	if len(options.RequireManagedByLabel) != 0 {
+		_, ok := obj.GetLabels()[options.RequireManagedByLabel]
+		if !ok {
+			return *new(T), false, &MissingRequiredLabelError{
+				GVK:   *gvk,
+				Ref:   naming.ObjRef(obj),
+				Label: options.RequireManagedByLabel,
+			}
+		}
+	}
+
+	if obj.GetDeletionTimestamp() != nil {
+		return *new(T), false, fmt.Errorf("%s %q is being deleted", gvk, naming.ObjRef(obj))
+	}
+
+	existingControllerRef := metav1.GetControllerOfNoCopy(obj)
+	if existingControllerRef == nil && !options.ForceOwnership {
+		return *new(T), false, fmt.Errorf("%s %q has no controllerRef to replace", gvk, naming.ObjRef(obj))
+	}
+
+	if existingControllerRef != nil && existingControllerRef.UID == newController.UID {
+		return obj, false, nil
+	}
+
+	ownerReferences := make([]metav1.OwnerReference, 0, len(obj.GetOwnerReferences())+1)
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			continue
+		}
+
+		if ref.UID == newController.UID {
+			continue
+		}
+
+		ownerReferences = append(ownerReferences, ref)
+	}
+	ownerReferences = append(ownerReferences, newController)
+
+	patch, err := makeReparentPatch(obj, ownerReferences)
+	if err != nil {
+		return *new(T), false, err
+	}
+
+	patched, err := client.Patch(ctx, obj.GetName(), types.JSONPatchType, patch, metav1.PatchOptions{})
+	if err != nil && options.DowngradeBlockOwnerDeletion && isBlockOwnerDeletionForbiddenError(err) {
+		klog.V(2).InfoS("Not allowed to set blockOwnerDeletion, retrying without it", "GVK", gvk, "Ref", naming.ObjRef(obj))
+		ownerReferences[len(ownerReferences)-1].BlockOwnerDeletion = nil
+		patch, err = makeReparentPatch(obj, ownerReferences)
+		if err != nil {
+			return *new(T), false, err
+		}
+		patched, err = client.Patch(ctx, obj.GetName(), types.JSONPatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		typedErr, _ := classifyApplyError(err)
+		return *new(T), false, fmt.Errorf("can't reparent %s %q: %w", gvk, naming.ObjRef(obj), typedErr)
+	}
+
+	klog.V(2).InfoS("Reparented object", "GVK", gvk, "Ref", naming.ObjRef(obj), "NewControllerUID", newController.UID)
+
+	return patched, true, nil
+}
@@ -0,0 +1,212 @@
+package resourceapply
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReparentOwnership(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newBaseConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	newController := metav1.OwnerReference{
+		Controller:         pointer.Ptr(true),
+		UID:                "ijklmnop",
+		APIVersion:         "scylla.scylladb.com/v1alpha1",
+		Kind:               "ScyllaDBDatacenter",
+		Name:               "basic",
+		BlockOwnerDeletion: pointer.Ptr(true),
+	}
+
+	nonControllerRef := metav1.OwnerReference{
+		UID:        "qrstuvwx",
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       "other",
+	}
+
+	newConfigMap := func() *corev1.ConfigMap {
+		cm := newBaseConfigMap()
+		cm.UID = "cm-uid"
+		cm.ResourceVersion = "1"
+		cm.Data["foo"] = "bar"
+		cm.OwnerReferences = append(cm.OwnerReferences, nonControllerRef)
+		return cm
+	}
+
+	tt := []struct {
+		name                    string
+		existing                *corev1.ConfigMap
+		obj                     *corev1.ConfigMap
+		newController           metav1.OwnerReference
+		options                 ApplyOptions
+		expectedChanged         bool
+		expectedErr             bool
+		expectedOwnerReferences []metav1.OwnerReference
+	}{
+		{
+			name:            "migrates the object from the old owner to the new one",
+			existing:        newConfigMap(),
+			obj:             newConfigMap(),
+			newController:   newController,
+			expectedChanged: true,
+			expectedErr:     false,
+			expectedOwnerReferences: []metav1.OwnerReference{
+				nonControllerRef,
+				newController,
+			},
+		},
+		{
+			name: "does nothing if the object is already controlled by the new owner",
+			existing: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.OwnerReferences = []metav1.OwnerReference{newController}
+				return cm
+			}(),
+			obj: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.OwnerReferences = []metav1.OwnerReference{newController}
+				return cm
+			}(),
+			newController:           newController,
+			expectedChanged:         false,
+			expectedErr:             false,
+			expectedOwnerReferences: []metav1.OwnerReference{newController},
+		},
+		{
+			name: "refuses to claim an object without a controllerRef",
+			existing: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.OwnerReferences = nil
+				return cm
+			}(),
+			obj: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.OwnerReferences = nil
+				return cm
+			}(),
+			newController:           newController,
+			expectedChanged:         false,
+			expectedErr:             true,
+			expectedOwnerReferences: nil,
+		},
+		{
+			name: "claims an object without a controllerRef when forcing ownership",
+			existing: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.OwnerReferences = nil
+				return cm
+			}(),
+			obj: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.OwnerReferences = nil
+				return cm
+			}(),
+			newController: newController,
+			options: ApplyOptions{
+				ForceOwnership: true,
+			},
+			expectedChanged:         true,
+			expectedErr:             false,
+			expectedOwnerReferences: []metav1.OwnerReference{newController},
+		},
+		{
+			name:     "refuses an owner that isn't a controller",
+			existing: newConfigMap(),
+			obj:      newConfigMap(),
+			newController: func() metav1.OwnerReference {
+				ref := newController
+				ref.Controller = nil
+				return ref
+			}(),
+			expectedChanged: false,
+			expectedErr:     true,
+			expectedOwnerReferences: []metav1.OwnerReference{
+				newBaseConfigMap().OwnerReferences[0],
+				nonControllerRef,
+			},
+		},
+		{
+			name: "fails if the object changed since it was read",
+			existing: func() *corev1.ConfigMap {
+				cm := newConfigMap()
+				cm.ResourceVersion = "2"
+				return cm
+			}(),
+			obj:             newConfigMap(),
+			newController:   newController,
+			expectedChanged: false,
+			expectedErr:     true,
+			expectedOwnerReferences: []metav1.OwnerReference{
+				newBaseConfigMap().OwnerReferences[0],
+				nonControllerRef,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existing)
+
+			_, gotChanged, err := ReparentOwnership[*corev1.ConfigMap](ctx, client.CoreV1().ConfigMaps(tc.obj.Namespace), tc.obj, tc.newController, tc.options)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected changed %t, got %t", tc.expectedChanged, gotChanged)
+			}
+
+			got, err := client.CoreV1().ConfigMaps(tc.obj.Namespace).Get(ctx, tc.obj.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				t.Fatalf("object is gone: %v", err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got.OwnerReferences, tc.expectedOwnerReferences) {
+				t.Errorf("expected and got ownerReferences differ:\n%s", cmp.Diff(tc.expectedOwnerReferences, got.OwnerReferences))
+			}
+
+			if !reflect.DeepEqual(got.Data, tc.existing.Data) {
+				t.Errorf("data changed:\n%s", cmp.Diff(tc.existing.Data, got.Data))
+			}
+		})
+	}
+}
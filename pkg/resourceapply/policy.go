@@ -31,12 +31,7 @@ func ApplyPodDisruptionBudget(
 ) (*policyv1.PodDisruptionBudget, bool, error) {
 	return ApplyPodDisruptionBudgetWithControl(
 		ctx,
-		ApplyControlFuncs[*policyv1.PodDisruptionBudget]{
-			GetCachedFunc: lister.PodDisruptionBudgets(required.Namespace).Get,
-			CreateFunc:    client.PodDisruptionBudgets(required.Namespace).Create,
-			UpdateFunc:    client.PodDisruptionBudgets(required.Namespace).Update,
-			DeleteFunc:    client.PodDisruptionBudgets(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*policyv1.PodDisruptionBudget](lister.PodDisruptionBudgets(required.Namespace), client.PodDisruptionBudgets(required.Namespace)),
 		recorder,
 		required,
 		options,
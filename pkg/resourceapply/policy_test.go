@@ -222,8 +222,8 @@ func TestApplyPodDisruptionBudget(t *testing.T) {
 			}(),
 			expectedPDB:     nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update policy/v1, Kind=PodDisruptionBudget "default/test": %w`, apierrors.NewNotFound(policyv1.Resource("poddisruptionbudgets"), "test")),
-			expectedEvents:  []string{`Warning UpdatePodDisruptionBudgetFailed Failed to update PodDisruptionBudget default/test: poddisruptionbudgets.policy "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update policy/v1, Kind=PodDisruptionBudget "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(policyv1.Resource("poddisruptionbudgets"), "test")}),
+			expectedEvents:  []string{`Warning UpdatePodDisruptionBudgetFailed Failed to update PodDisruptionBudget default/test: poddisruptionbudgets.policy "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
@@ -11,6 +11,9 @@ import (
 	"k8s.io/client-go/tools/record"
 )
 
+// ApplyClusterRoleWithControl applies a ClusterRole. For aggregated ClusterRoles, i.e. those with an aggregationRule,
+// only the aggregationRule is managed. Their rules are written by the aggregation controller from the ClusterRoles
+// matching its selectors, so they're neither hashed nor overwritten.
 func ApplyClusterRoleWithControl(
 	ctx context.Context,
 	control ApplyControlInterface[*rbacv1.ClusterRole],
@@ -18,7 +21,26 @@ func ApplyClusterRoleWithControl(
 	required *rbacv1.ClusterRole,
 	options ApplyOptions,
 ) (*rbacv1.ClusterRole, bool, error) {
-	return ApplyGeneric[*rbacv1.ClusterRole](ctx, control, recorder, required, options)
+	if required.AggregationRule == nil {
+		return ApplyGeneric[*rbacv1.ClusterRole](ctx, control, recorder, required, options)
+	}
+
+	if required.Rules != nil {
+		required = required.DeepCopy()
+		required.Rules = nil
+	}
+
+	return ApplyGenericWithHandlers[*rbacv1.ClusterRole](
+		ctx,
+		control,
+		recorder,
+		required,
+		options,
+		func(required **rbacv1.ClusterRole, existing *rbacv1.ClusterRole) {
+			(*required).Rules = existing.Rules
+		},
+		nil,
+	)
 }
 
 func ApplyClusterRole(
@@ -31,12 +53,7 @@ func ApplyClusterRole(
 ) (*rbacv1.ClusterRole, bool, error) {
 	return ApplyClusterRoleWithControl(
 		ctx,
-		ApplyControlFuncs[*rbacv1.ClusterRole]{
-			GetCachedFunc: lister.Get,
-			CreateFunc:    client.ClusterRoles().Create,
-			UpdateFunc:    client.ClusterRoles().Update,
-			DeleteFunc:    client.ClusterRoles().Delete,
-		},
+		NewApplyControlFuncs[*rbacv1.ClusterRole](lister, client.ClusterRoles()),
 		recorder,
 		required,
 		options,
@@ -63,12 +80,7 @@ func ApplyRole(
 ) (*rbacv1.Role, bool, error) {
 	return ApplyRoleWithControl(
 		ctx,
-		ApplyControlFuncs[*rbacv1.Role]{
-			GetCachedFunc: lister.Roles(required.Namespace).Get,
-			CreateFunc:    client.Roles(required.Namespace).Create,
-			UpdateFunc:    client.Roles(required.Namespace).Update,
-			DeleteFunc:    client.Roles(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*rbacv1.Role](lister.Roles(required.Namespace), client.Roles(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -108,12 +120,7 @@ func ApplyRoleBinding(
 ) (*rbacv1.RoleBinding, bool, error) {
 	return ApplyRoleBindingWithControl(
 		ctx,
-		ApplyControlFuncs[*rbacv1.RoleBinding]{
-			GetCachedFunc: lister.RoleBindings(required.Namespace).Get,
-			CreateFunc:    client.RoleBindings(required.Namespace).Create,
-			UpdateFunc:    client.RoleBindings(required.Namespace).Update,
-			DeleteFunc:    client.RoleBindings(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*rbacv1.RoleBinding](lister.RoleBindings(required.Namespace), client.RoleBindings(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -153,12 +160,7 @@ func ApplyClusterRoleBinding(
 ) (*rbacv1.ClusterRoleBinding, bool, error) {
 	return ApplyClusterRoleBindingWithControl(
 		ctx,
-		ApplyControlFuncs[*rbacv1.ClusterRoleBinding]{
-			GetCachedFunc: lister.Get,
-			CreateFunc:    client.ClusterRoleBindings().Create,
-			UpdateFunc:    client.ClusterRoleBindings().Update,
-			DeleteFunc:    client.ClusterRoleBindings().Delete,
-		},
+		NewApplyControlFuncs[*rbacv1.ClusterRoleBinding](lister, client.ClusterRoleBindings()),
 		recorder,
 		required,
 		options,
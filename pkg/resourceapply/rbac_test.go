@@ -49,6 +49,36 @@ func TestApplyClusterRole(t *testing.T) {
 		return cr
 	}
 
+	newAggregatedCr := func(aggregationLabel string) *rbacv1.ClusterRole {
+		cr := newCr()
+		cr.Rules = nil
+		cr.AggregationRule = &rbacv1.AggregationRule{
+			ClusterRoleSelectors: []metav1.LabelSelector{
+				{
+					MatchLabels: map[string]string{
+						aggregationLabel: "true",
+					},
+				},
+			},
+		}
+		return cr
+	}
+
+	newAggregatedCrWithHash := func(aggregationLabel string) *rbacv1.ClusterRole {
+		cr := newAggregatedCr(aggregationLabel)
+		apimachineryutilruntime.Must(SetHashAnnotation(cr))
+		return cr
+	}
+
+	// aggregatedRules are written by the aggregation controller.
+	aggregatedRules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"scylla.scylladb.com"},
+			Resources: []string{"scyllaclusters"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+
 	tt := []struct {
 		name                      string
 		existing                  []runtime.Object
@@ -255,8 +285,8 @@ func TestApplyClusterRole(t *testing.T) {
 			}(),
 			expectedCr:      nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=ClusterRole "test": %w`, apierrors.NewNotFound(rbacv1.Resource("clusterroles"), "test")),
-			expectedEvents:  []string{`Warning UpdateClusterRoleFailed Failed to update ClusterRole test: clusterroles.rbac.authorization.k8s.io "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=ClusterRole "test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(rbacv1.Resource("clusterroles"), "test")}),
+			expectedEvents:  []string{`Warning UpdateClusterRoleFailed Failed to update ClusterRole test: clusterroles.rbac.authorization.k8s.io "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has ownerRef and required not",
@@ -408,6 +438,60 @@ func TestApplyClusterRole(t *testing.T) {
 			expectedErr:     nil,
 			expectedEvents:  []string{"Normal ClusterRoleUpdated ClusterRole test updated"},
 		},
+		{
+			name:                      "creates an aggregated cr without the rules of the required one",
+			existing:                  nil,
+			allowMissingControllerRef: true,
+			required: func() *rbacv1.ClusterRole {
+				cr := newAggregatedCr("example.com/aggregate-to-test")
+				cr.Rules = aggregatedRules
+				return cr
+			}(),
+			expectedCr:      newAggregatedCrWithHash("example.com/aggregate-to-test"),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal ClusterRoleCreated ClusterRole test created"},
+		},
+		{
+			name: "does nothing if only the rules written by the aggregation controller differ",
+			existing: []runtime.Object{
+				func() *rbacv1.ClusterRole {
+					cr := newAggregatedCrWithHash("example.com/aggregate-to-test")
+					cr.Rules = aggregatedRules
+					return cr
+				}(),
+			},
+			allowMissingControllerRef: true,
+			required:                  newAggregatedCr("example.com/aggregate-to-test"),
+			expectedCr: func() *rbacv1.ClusterRole {
+				cr := newAggregatedCrWithHash("example.com/aggregate-to-test")
+				cr.Rules = aggregatedRules
+				return cr
+			}(),
+			expectedChanged: false,
+			expectedErr:     nil,
+			expectedEvents:  nil,
+		},
+		{
+			name: "updates the aggregation rule of the cr and keeps the rules written by the aggregation controller",
+			existing: []runtime.Object{
+				func() *rbacv1.ClusterRole {
+					cr := newAggregatedCrWithHash("example.com/aggregate-to-test")
+					cr.Rules = aggregatedRules
+					return cr
+				}(),
+			},
+			allowMissingControllerRef: true,
+			required:                  newAggregatedCr("example.com/aggregate-to-other"),
+			expectedCr: func() *rbacv1.ClusterRole {
+				cr := newAggregatedCrWithHash("example.com/aggregate-to-other")
+				cr.Rules = aggregatedRules
+				return cr
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal ClusterRoleUpdated ClusterRole test updated"},
+		},
 	}
 
 	for _, tc := range tt {
@@ -748,8 +832,8 @@ func TestApplyClusterRoleBinding(t *testing.T) {
 			}(),
 			expectedCrb:     nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=ClusterRoleBinding "test": %w`, apierrors.NewNotFound(rbacv1.Resource("clusterrolebindings"), "test")),
-			expectedEvents:  []string{`Warning UpdateClusterRoleBindingFailed Failed to update ClusterRoleBinding test: clusterrolebindings.rbac.authorization.k8s.io "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=ClusterRoleBinding "test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(rbacv1.Resource("clusterrolebindings"), "test")}),
+			expectedEvents:  []string{`Warning UpdateClusterRoleBindingFailed Failed to update ClusterRoleBinding test: clusterrolebindings.rbac.authorization.k8s.io "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has ownerRef and required hasn't",
@@ -1229,8 +1313,8 @@ func TestApplyRoleBinding(t *testing.T) {
 			}(),
 			expectedRB:      nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=RoleBinding "default/test": %w`, apierrors.NewNotFound(rbacv1.Resource("rolebindings"), "test")),
-			expectedEvents:  []string{`Warning UpdateRoleBindingFailed Failed to update RoleBinding default/test: rolebindings.rbac.authorization.k8s.io "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=RoleBinding "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(rbacv1.Resource("rolebindings"), "test")}),
+			expectedEvents:  []string{`Warning UpdateRoleBindingFailed Failed to update RoleBinding default/test: rolebindings.rbac.authorization.k8s.io "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has ownerRef and required hasn't",
@@ -1767,8 +1851,8 @@ func TestApplyRole(t *testing.T) {
 			}(),
 			expectedRole:    nil,
 			expectedChanged: false,
-			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=Role "default/test": %w`, apierrors.NewNotFound(rbacv1.Resource("roles"), "test")),
-			expectedEvents:  []string{`Warning UpdateRoleFailed Failed to update Role default/test: roles.rbac.authorization.k8s.io "test" not found`},
+			expectedErr:     fmt.Errorf(`can't update rbac.authorization.k8s.io/v1, Kind=Role "default/test": %w`, &APIError{Class: APIErrorClassNotFound, Err: apierrors.NewNotFound(rbacv1.Resource("roles"), "test")}),
+			expectedEvents:  []string{`Warning UpdateRoleFailed Failed to update Role default/test: roles.rbac.authorization.k8s.io "test" not found map[scylla-operator.scylladb.com/apply-error-class:NotFound]`},
 		},
 		{
 			name: "update fails if the existing object has no ownerRef",
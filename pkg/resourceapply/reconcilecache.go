@@ -0,0 +1,160 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type reconcileCacheKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// ReconcileCache holds the objects read and written by applies within a single reconcile, see ApplyOptions.ReconcileCache.
+// Cached reads are served from it before hitting the lister and objects returned by writes replace the cached ones,
+// so later applies of the same object within the reconcile see the latest written state instead of a stale cache.
+// It must never outlive the reconcile it was created for, a new one has to be created for every reconcile.
+// It's safe for concurrent use.
+type ReconcileCache struct {
+	lock    sync.Mutex
+	objects map[reconcileCacheKey]kubeinterfaces.ObjectInterface
+}
+
+func NewReconcileCache() *ReconcileCache {
+	return &ReconcileCache{
+		objects: map[reconcileCacheKey]kubeinterfaces.ObjectInterface{},
+	}
+}
+
+func (c *ReconcileCache) get(key reconcileCacheKey) (kubeinterfaces.ObjectInterface, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	obj, ok := c.objects[key]
+	return obj, ok
+}
+
+func (c *ReconcileCache) set(key reconcileCacheKey, obj kubeinterfaces.ObjectInterface) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.objects[key] = obj
+}
+
+func (c *ReconcileCache) delete(key reconcileCacheKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.objects, key)
+}
+
+// Len returns the number of cached objects.
+func (c *ReconcileCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.objects)
+}
+
+// reconcileCachedApplyControl serves cached reads of the wrapped control from a ReconcileCache
+// and keeps the cache in sync with the writes. Failed writes evict the object, so it's read again.
+type reconcileCachedApplyControl[T kubeinterfaces.ObjectInterface] struct {
+	control   ApplyControlInterface[T]
+	cache     *ReconcileCache
+	gvk       schema.GroupVersionKind
+	namespace string
+}
+
+var _ ApplyControlInterface[kubeinterfaces.ObjectInterface] = &reconcileCachedApplyControl[kubeinterfaces.ObjectInterface]{}
+var _ ApplyControlPatcher[kubeinterfaces.ObjectInterface] = &reconcileCachedApplyControl[kubeinterfaces.ObjectInterface]{}
+
+func (c *reconcileCachedApplyControl[T]) key(name string) reconcileCacheKey {
+	return reconcileCacheKey{
+		gvk:       c.gvk,
+		namespace: c.namespace,
+		name:      name,
+	}
+}
+
+// store caches the result of a call, or evicts the object when the call failed.
+func (c *reconcileCachedApplyControl[T]) store(name string, obj T, err error) {
+	if err != nil {
+		c.cache.delete(c.key(name))
+		return
+	}
+
+	c.cache.set(c.key(name), obj)
+}
+
+func (c *reconcileCachedApplyControl[T]) GetCached(name string) (T, error) {
+	cached, ok := c.cache.get(c.key(name))
+	if ok {
+		obj, ok := cached.(T)
+		if ok {
+			return obj, nil
+		}
+	}
+
+	obj, err := c.control.GetCached(name)
+	if err != nil {
+		return obj, err
+	}
+	c.cache.set(c.key(name), obj)
+
+	return obj, nil
+}
+
+func (c *reconcileCachedApplyControl[T]) ListCached(selector labels.Selector) ([]T, error) {
+	return c.control.ListCached(selector)
+}
+
+func (c *reconcileCachedApplyControl[T]) Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+	obj, err := c.control.Get(ctx, name, opts)
+	c.store(name, obj, err)
+	return obj, err
+}
+
+func (c *reconcileCachedApplyControl[T]) Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error) {
+	created, err := c.control.Create(ctx, obj, opts)
+	if err != nil {
+		c.cache.delete(c.key(obj.GetName()))
+		return created, err
+	}
+
+	// Created objects may have a server generated name.
+	c.cache.set(c.key(created.GetName()), created)
+
+	return created, nil
+}
+
+func (c *reconcileCachedApplyControl[T]) Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error) {
+	updated, err := c.control.Update(ctx, obj, opts)
+	c.store(obj.GetName(), updated, err)
+	return updated, err
+}
+
+func (c *reconcileCachedApplyControl[T]) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+	patcher, ok := c.control.(ApplyControlPatcher[T])
+	if !ok {
+		return *new(T), fmt.Errorf("patching isn't supported by this control")
+	}
+
+	patched, err := patcher.Patch(ctx, name, pt, data, opts)
+	c.store(name, patched, err)
+	return patched, err
+}
+
+func (c *reconcileCachedApplyControl[T]) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	c.cache.delete(c.key(name))
+	return c.control.Delete(ctx, name, opts)
+}
@@ -0,0 +1,234 @@
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newConfigMapListerForTest(t testing.TB, ctx context.Context, client *fake.Clientset) corev1listers.ConfigMapLister {
+	t.Helper()
+
+	cmCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	cmList, err := client.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range cmList.Items {
+		err = cmCache.Add(&cmList.Items[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return corev1listers.NewConfigMapLister(cmCache)
+}
+
+func TestReconcileCache(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	existing := newConfigMap()
+	existing.Data = map[string]string{"key": "initial"}
+	err := SetHashAnnotation(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := fake.NewSimpleClientset(existing)
+	// The lister isn't refreshed, so it goes stale once the object is written.
+	lister := newConfigMapListerForTest(t, ctx, client)
+	reconcileCache := NewReconcileCache()
+
+	control := &reconcileCachedApplyControl[*corev1.ConfigMap]{
+		control: ApplyControlFuncs[*corev1.ConfigMap]{
+			GetCachedFunc: lister.ConfigMaps("default").Get,
+		},
+		cache:     reconcileCache,
+		gvk:       schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		namespace: "default",
+	}
+	listerCM, err := lister.ConfigMaps("default").Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cachedCM, err := control.GetCached("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cachedCM, listerCM) {
+		t.Errorf("expected cached and lister reads to match:\n%s", cmp.Diff(listerCM, cachedCM))
+	}
+	cachedCM, err = control.GetCached("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cachedCM, listerCM) {
+		t.Errorf("expected repeated cached reads to match the lister:\n%s", cmp.Diff(listerCM, cachedCM))
+	}
+	if reconcileCache.Len() != 1 {
+		t.Errorf("expected 1 cached object, got %d", reconcileCache.Len())
+	}
+
+	required := newConfigMap()
+	required.Data = map[string]string{"key": "updated"}
+	options := ApplyOptions{ReconcileCache: reconcileCache}
+
+	_, changed, err := ApplyConfigMap(ctx, client.CoreV1(), lister, record.NewFakeRecorder(10), required, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatalf("expected the object to be updated")
+	}
+
+	updatedCM, err := client.CoreV1().ConfigMaps("default").Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cachedCM, err = control.GetCached("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cachedCM, updatedCM) {
+		t.Errorf("expected the cache to hold the written object:\n%s", cmp.Diff(updatedCM, cachedCM))
+	}
+
+	// Within the reconcile, the written object is used instead of the stale lister one.
+	client.ClearActions()
+	_, changed, err = ApplyConfigMap(ctx, client.CoreV1(), lister, record.NewFakeRecorder(10), required, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Errorf("expected no change within the same reconcile")
+	}
+	if len(client.Actions()) != 0 {
+		t.Errorf("expected no API calls, got %v", client.Actions())
+	}
+
+	// Objects pruned because of a disabled feature gate are evicted from the cache.
+	options.FeatureGates = map[string]bool{"ExperimentalConfig": false}
+	options.RequiredFeatureGate = "ExperimentalConfig"
+	_, changed, err = ApplyConfigMap(ctx, client.CoreV1(), lister, record.NewFakeRecorder(10), required, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatalf("expected the object to be pruned")
+	}
+	if reconcileCache.Len() != 0 {
+		t.Errorf("expected the pruned object to be evicted, got %d cached objects", reconcileCache.Len())
+	}
+}
+
+func BenchmarkApplyGenericReconcileCache(b *testing.B) {
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	const objectCount = 100
+	const appliesPerObject = 5
+
+	var required []*corev1.ConfigMap
+	client := fake.NewSimpleClientset()
+	for i := 0; i < objectCount; i++ {
+		cm := newConfigMap()
+		cm.Name = fmt.Sprintf("test-%d", i)
+		for j := 0; j < 50; j++ {
+			cm.Data[fmt.Sprintf("key-%d", j)] = fmt.Sprintf("value-%d", j)
+		}
+		required = append(required, cm)
+
+		existing := cm.DeepCopy()
+		err := SetHashAnnotation(existing)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = client.CoreV1().ConfigMaps(existing.Namespace).Create(ctx, existing, metav1.CreateOptions{})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	lister := newConfigMapListerForTest(b, ctx, client)
+	recorder := record.NewFakeRecorder(0)
+
+	for _, useCache := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cache=%t", useCache), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				options := ApplyOptions{}
+				if useCache {
+					options.ReconcileCache = NewReconcileCache()
+				}
+
+				for i := 0; i < appliesPerObject; i++ {
+					for _, cm := range required {
+						_, _, err := ApplyConfigMap(ctx, client.CoreV1(), lister, recorder, cm, options)
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// ConflictRetryBudget limits the number of conflict retries made by all applies sharing it, see ApplyOptions.ConflictRetryBudget.
+// It's safe for concurrent use.
+type ConflictRetryBudget struct {
+	remaining atomic.Int64
+}
+
+func NewConflictRetryBudget(retries int) *ConflictRetryBudget {
+	b := &ConflictRetryBudget{}
+	b.remaining.Store(int64(retries))
+	return b
+}
+
+// Remaining returns the number of retries left in the budget.
+func (b *ConflictRetryBudget) Remaining() int {
+	if b == nil {
+		return 0
+	}
+	return int(max(b.remaining.Load(), 0))
+}
+
+// take consumes one retry and reports whether there was any left. A nil budget never allows retries.
+func (b *ConflictRetryBudget) take() bool {
+	if b == nil {
+		return false
+	}
+	return b.remaining.Add(-1) >= 0
+}
+
+func validateResourceVersionMatch(match metav1.ResourceVersionMatch) error {
+	switch match {
+	case "", metav1.ResourceVersionMatchNotOlderThan:
+		return nil
+	default:
+		return fmt.Errorf("unsupported resource version match %q", match)
+	}
+}
+
+// isResourceVersionOlder reports whether rv is older than minRV. Resource versions are opaque, those that aren't
+// numbers can't be compared and are never considered older.
+func isResourceVersionOlder(rv, minRV string) bool {
+	rvNumber, err := strconv.ParseUint(rv, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	minRVNumber, err := strconv.ParseUint(minRV, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return rvNumber < minRVNumber
+}
+
+// liveApplyControl reads the objects from the apiserver instead of the cache, so a retry after a conflict
+// sees the object that caused it. Cached reads don't take a context, so the one of the apply is used for them.
+// With minResourceVersion set, reads are allowed to be served from the watch cache of the apiserver
+// as long as they are at least that new, see ApplyOptions.ResourceVersionMatch.
+type liveApplyControl[T kubeinterfaces.ObjectInterface] struct {
+	ctx                context.Context
+	control            ApplyControlInterface[T]
+	minResourceVersion string
+}
+
+var _ ApplyControlInterface[kubeinterfaces.ObjectInterface] = &liveApplyControl[kubeinterfaces.ObjectInterface]{}
+var _ ApplyControlPatcher[kubeinterfaces.ObjectInterface] = &liveApplyControl[kubeinterfaces.ObjectInterface]{}
+
+func (c *liveApplyControl[T]) GetCached(name string) (T, error) {
+	if len(c.minResourceVersion) == 0 {
+		return c.control.Get(c.ctx, name, metav1.GetOptions{})
+	}
+
+	obj, err := c.control.Get(c.ctx, name, metav1.GetOptions{
+		ResourceVersion: c.minResourceVersion,
+	})
+	if err != nil || !isResourceVersionOlder(obj.GetResourceVersion(), c.minResourceVersion) {
+		return obj, err
+	}
+
+	klog.V(2).InfoS("Read an object older than the minimum resource version, retrying with a quorum read", "Ref", naming.ObjRef(obj), "ResourceVersion", obj.GetResourceVersion(), "MinResourceVersion", c.minResourceVersion)
+	obj, err = c.control.Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return obj, err
+	}
+	if isResourceVersionOlder(obj.GetResourceVersion(), c.minResourceVersion) {
+		return *new(T), fmt.Errorf("read %q at resource version %q which is older than the minimum resource version %q", naming.ObjRef(obj), obj.GetResourceVersion(), c.minResourceVersion)
+	}
+
+	return obj, nil
+}
+
+func (c *liveApplyControl[T]) ListCached(selector labels.Selector) ([]T, error) {
+	return c.control.ListCached(selector)
+}
+
+func (c *liveApplyControl[T]) Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+	return c.control.Get(ctx, name, opts)
+}
+
+func (c *liveApplyControl[T]) Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error) {
+	return c.control.Create(ctx, obj, opts)
+}
+
+func (c *liveApplyControl[T]) Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error) {
+	return c.control.Update(ctx, obj, opts)
+}
+
+func (c *liveApplyControl[T]) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+	patcher, ok := c.control.(ApplyControlPatcher[T])
+	if !ok {
+		return *new(T), fmt.Errorf("patching isn't supported by this control")
+	}
+	return patcher.Patch(ctx, name, pt, data, opts)
+}
+
+func (c *liveApplyControl[T]) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.control.Delete(ctx, name, opts)
+}
@@ -0,0 +1,371 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyGenericConflictRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	newExisting := func(name string) *corev1.ConfigMap {
+		cm := newConfigMap()
+		cm.Name = name
+		apimachineryutilruntime.Must(SetHashAnnotation(cm))
+		return cm
+	}
+
+	newRequired := func(name string) *corev1.ConfigMap {
+		cm := newConfigMap()
+		cm.Name = name
+		cm.Data["foo"] = "bar"
+		return cm
+	}
+
+	tt := []struct {
+		name              string
+		budget            int
+		conflictingWrites int
+		objectNames       []string
+		expectedUpdates   int
+		expectedFailures  int
+		expectedRemaining int
+	}{
+		{
+			name:              "budget caps the total retries across applies",
+			budget:            2,
+			conflictingWrites: -1,
+			objectNames:       []string{"a", "b", "c"},
+			expectedUpdates:   5,
+			expectedFailures:  3,
+			expectedRemaining: 0,
+		},
+		{
+			name:              "retry recovers from a transient conflict",
+			budget:            2,
+			conflictingWrites: 1,
+			objectNames:       []string{"a"},
+			expectedUpdates:   2,
+			expectedFailures:  0,
+			expectedRemaining: 1,
+		},
+		{
+			name:              "empty budget doesn't retry",
+			budget:            0,
+			conflictingWrites: -1,
+			objectNames:       []string{"a", "b"},
+			expectedUpdates:   2,
+			expectedFailures:  2,
+			expectedRemaining: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var existingObjects []runtime.Object
+			for _, name := range tc.objectNames {
+				existingObjects = append(existingObjects, newExisting(name))
+			}
+			client := fake.NewSimpleClientset(existingObjects...)
+
+			updates := 0
+			client.PrependReactor("update", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				updates++
+				if tc.conflictingWrites < 0 || updates <= tc.conflictingWrites {
+					name := action.(clienttesting.UpdateAction).GetObject().(*corev1.ConfigMap).Name
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, name, fmt.Errorf("object has been modified"))
+				}
+				return false, nil, nil
+			})
+
+			budget := NewConflictRetryBudget(tc.budget)
+
+			failures := 0
+			for _, name := range tc.objectNames {
+				required := newRequired(name)
+				_, _, err := ApplyGeneric[*corev1.ConfigMap](
+					ctx,
+					ApplyControlFuncs[*corev1.ConfigMap]{
+						GetCachedFunc: func(name string) (*corev1.ConfigMap, error) {
+							return newExisting(name), nil
+						},
+						GetFunc:    client.CoreV1().ConfigMaps(required.Namespace).Get,
+						CreateFunc: client.CoreV1().ConfigMaps(required.Namespace).Create,
+						UpdateFunc: client.CoreV1().ConfigMaps(required.Namespace).Update,
+						DeleteFunc: client.CoreV1().ConfigMaps(required.Namespace).Delete,
+					},
+					record.NewFakeRecorder(10),
+					required,
+					ApplyOptions{
+						ConflictRetryBudget: budget,
+					},
+				)
+				if err != nil {
+					if !apierrors.IsConflict(err) {
+						t.Fatalf("expected a conflict error, got %v", err)
+					}
+					failures++
+				}
+			}
+
+			if updates != tc.expectedUpdates {
+				t.Errorf("expected %d updates, got %d", tc.expectedUpdates, updates)
+			}
+
+			if failures != tc.expectedFailures {
+				t.Errorf("expected %d failed applies, got %d", tc.expectedFailures, failures)
+			}
+
+			if budget.Remaining() != tc.expectedRemaining {
+				t.Errorf("expected %d remaining retries, got %d", tc.expectedRemaining, budget.Remaining())
+			}
+		})
+	}
+}
+
+func TestApplyGenericResourceVersionMatch(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	newExisting := func(resourceVersion string) *corev1.ConfigMap {
+		cm := newConfigMap()
+		apimachineryutilruntime.Must(SetHashAnnotation(cm))
+		cm.ResourceVersion = resourceVersion
+		return cm
+	}
+
+	required := newConfigMap()
+	required.Data["foo"] = "bar"
+
+	var getResourceVersions []string
+	var updateResourceVersions []string
+	control := ApplyControlFuncs[*corev1.ConfigMap]{
+		GetCachedFunc: func(name string) (*corev1.ConfigMap, error) {
+			return newExisting("10"), nil
+		},
+		GetFunc: func(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+			getResourceVersions = append(getResourceVersions, opts.ResourceVersion)
+			if len(opts.ResourceVersion) != 0 {
+				// A lagging watch cache serves an object older than requested.
+				return newExisting("5"), nil
+			}
+			return newExisting("12"), nil
+		},
+		UpdateFunc: func(ctx context.Context, obj *corev1.ConfigMap, opts metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+			updateResourceVersions = append(updateResourceVersions, obj.ResourceVersion)
+			if obj.ResourceVersion != "12" {
+				return nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.Name, fmt.Errorf("object has been modified"))
+			}
+			return obj, nil
+		},
+	}
+
+	_, changed, err := ApplyGeneric[*corev1.ConfigMap](ctx, control, record.NewFakeRecorder(10), required, ApplyOptions{
+		ConflictRetryBudget:  NewConflictRetryBudget(1),
+		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Errorf("expected the object to be updated")
+	}
+
+	expectedGetResourceVersions := []string{"10", ""}
+	if !reflect.DeepEqual(getResourceVersions, expectedGetResourceVersions) {
+		t.Errorf("expected and got get resource versions differ:\n%s", cmp.Diff(expectedGetResourceVersions, getResourceVersions))
+	}
+	expectedUpdateResourceVersions := []string{"10", "12"}
+	if !reflect.DeepEqual(updateResourceVersions, expectedUpdateResourceVersions) {
+		t.Errorf("expected and got update resource versions differ:\n%s", cmp.Diff(expectedUpdateResourceVersions, updateResourceVersions))
+	}
+
+	_, _, err = ApplyGeneric[*corev1.ConfigMap](ctx, control, record.NewFakeRecorder(10), required, ApplyOptions{
+		ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+	})
+	if err == nil {
+		t.Errorf("expected an error for an unsupported resource version match")
+	}
+}
+
+func TestApplyGenericExpectedResourceVersion(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	newExisting := func(resourceVersion string) *corev1.ConfigMap {
+		cm := newConfigMap()
+		apimachineryutilruntime.Must(SetHashAnnotation(cm))
+		cm.ResourceVersion = resourceVersion
+		return cm
+	}
+
+	tt := []struct {
+		name                           string
+		expectedResourceVersion        string
+		expectedErr                    error
+		expectedUpdateResourceVersions []string
+	}{
+		{
+			name:                           "updates the object when it's still at the expected resource version",
+			expectedResourceVersion:        "12",
+			expectedErr:                    nil,
+			expectedUpdateResourceVersions: []string{"12"},
+		},
+		{
+			name:                    "fails with a conflict without retrying when the object was modified since the expected resource version",
+			expectedResourceVersion: "11",
+			expectedErr: &ResourceVersionConflictError{
+				GVK:                     corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+				Ref:                     "default/test",
+				ExpectedResourceVersion: "11",
+				Err: &APIError{
+					Class: APIErrorClassConflict,
+					Err:   apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test", fmt.Errorf("object has been modified")),
+				},
+			},
+			expectedUpdateResourceVersions: []string{"11"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			required := newConfigMap()
+			required.Data["foo"] = "bar"
+
+			// The cache lags behind the live object which is at resource version "12".
+			var updateResourceVersions []string
+			control := ApplyControlFuncs[*corev1.ConfigMap]{
+				GetCachedFunc: func(name string) (*corev1.ConfigMap, error) {
+					return newExisting("10"), nil
+				},
+				GetFunc: func(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+					return newExisting("12"), nil
+				},
+				UpdateFunc: func(ctx context.Context, obj *corev1.ConfigMap, opts metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+					updateResourceVersions = append(updateResourceVersions, obj.ResourceVersion)
+					if obj.ResourceVersion != "12" {
+						return nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.Name, fmt.Errorf("object has been modified"))
+					}
+					return obj, nil
+				},
+			}
+
+			budget := NewConflictRetryBudget(1)
+			_, _, err := ApplyGeneric[*corev1.ConfigMap](ctx, control, record.NewFakeRecorder(10), required, ApplyOptions{
+				ConflictRetryBudget:     budget,
+				ExpectedResourceVersion: tc.expectedResourceVersion,
+			})
+			if !reflect.DeepEqual(err, tc.expectedErr) {
+				t.Fatalf("expected and got errors differ:\n%s", cmp.Diff(tc.expectedErr, err))
+			}
+			if err != nil && !apierrors.IsConflict(err) {
+				t.Errorf("expected the error to be a conflict, got %v", err)
+			}
+
+			if !reflect.DeepEqual(updateResourceVersions, tc.expectedUpdateResourceVersions) {
+				t.Errorf("expected and got update resource versions differ:\n%s", cmp.Diff(tc.expectedUpdateResourceVersions, updateResourceVersions))
+			}
+
+			if budget.Remaining() != 1 {
+				t.Errorf("expected the conflict retry budget to stay untouched, got %d remaining retries", budget.Remaining())
+			}
+		})
+	}
+}
@@ -31,12 +31,7 @@ func ApplyScyllaDBDatacenter(
 ) (*scyllav1alpha1.ScyllaDBDatacenter, bool, error) {
 	return ApplyScyllaDBDatacenterWithControl(
 		ctx,
-		ApplyControlFuncs[*scyllav1alpha1.ScyllaDBDatacenter]{
-			GetCachedFunc: lister.ScyllaDBDatacenters(required.Namespace).Get,
-			CreateFunc:    client.ScyllaDBDatacenters(required.Namespace).Create,
-			UpdateFunc:    client.ScyllaDBDatacenters(required.Namespace).Update,
-			DeleteFunc:    client.ScyllaDBDatacenters(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*scyllav1alpha1.ScyllaDBDatacenter](lister.ScyllaDBDatacenters(required.Namespace), client.ScyllaDBDatacenters(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -63,12 +58,7 @@ func ApplyRemoteOwner(
 ) (*scyllav1alpha1.RemoteOwner, bool, error) {
 	return ApplyRemoteOwnerWithControl(
 		ctx,
-		ApplyControlFuncs[*scyllav1alpha1.RemoteOwner]{
-			GetCachedFunc: lister.RemoteOwners(required.Namespace).Get,
-			CreateFunc:    client.RemoteOwners(required.Namespace).Create,
-			UpdateFunc:    client.RemoteOwners(required.Namespace).Update,
-			DeleteFunc:    client.RemoteOwners(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*scyllav1alpha1.RemoteOwner](lister.RemoteOwners(required.Namespace), client.RemoteOwners(required.Namespace)),
 		recorder,
 		required,
 		options,
@@ -95,12 +85,7 @@ func ApplyScyllaDBManagerClusterRegistration(
 ) (*scyllav1alpha1.ScyllaDBManagerClusterRegistration, bool, error) {
 	return ApplyScyllaDBManagerClusterRegistrationWithControl(
 		ctx,
-		ApplyControlFuncs[*scyllav1alpha1.ScyllaDBManagerClusterRegistration]{
-			GetCachedFunc: lister.ScyllaDBManagerClusterRegistrations(required.Namespace).Get,
-			CreateFunc:    client.ScyllaDBManagerClusterRegistrations(required.Namespace).Create,
-			UpdateFunc:    client.ScyllaDBManagerClusterRegistrations(required.Namespace).Update,
-			DeleteFunc:    client.ScyllaDBManagerClusterRegistrations(required.Namespace).Delete,
-		},
+		NewApplyControlFuncs[*scyllav1alpha1.ScyllaDBManagerClusterRegistration](lister.ScyllaDBManagerClusterRegistrations(required.Namespace), client.ScyllaDBManagerClusterRegistrations(required.Namespace)),
 		recorder,
 		required,
 		options,
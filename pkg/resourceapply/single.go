@@ -0,0 +1,75 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apimachineryutilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// EnsureSingle makes required the only object of its kind controlled by owner.
+// Any other object controlled by owner is deleted first, so the apply can't get stuck on quota,
+// and then required is applied. The control has to be scoped to the namespace of required and support cached listing.
+// The returned bool is true if anything was deleted or the apply changed the object.
+func EnsureSingle(
+	ctx context.Context,
+	control ApplyControlUntypedInterface,
+	recorder record.EventRecorder,
+	owner metav1.Object,
+	required kubeinterfaces.ObjectInterface,
+	options ApplyOptions,
+) (kubeinterfaces.ObjectInterface, bool, error) {
+	existingObjs, err := control.ListCached(labels.Everything())
+	if err != nil {
+		return nil, false, fmt.Errorf("can't list %s: %w", resource.GetObjectGVKOrUnknown(required), err)
+	}
+
+	deleted := false
+	var deletionErrors []error
+	for _, existing := range existingObjs {
+		if existing.GetName() == required.GetName() {
+			continue
+		}
+
+		if existing.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		controllerRef := metav1.GetControllerOfNoCopy(existing)
+		if controllerRef == nil || controllerRef.UID != owner.GetUID() {
+			continue
+		}
+
+		klog.V(2).InfoS("Deleting duplicate object", "GVK", resource.GetObjectGVKOrUnknown(existing), "Ref", naming.ObjRefWithUID(existing))
+		uid := existing.GetUID()
+		propagationPolicy := metav1.DeletePropagationBackground
+		err = control.Delete(ctx, existing.GetName(), metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID: &uid,
+			},
+			PropagationPolicy: &propagationPolicy,
+		})
+		reportApplyEvent(recorder, existing, err, "delete")
+		if err != nil {
+			deletionErrors = append(deletionErrors, err)
+			continue
+		}
+		deleted = true
+	}
+	err = apimachineryutilerrors.NewAggregate(deletionErrors)
+	if err != nil {
+		return nil, deleted, fmt.Errorf("can't delete duplicate(s): %w", err)
+	}
+
+	obj, changed, err := Apply(ctx, required, control, options, recorder)
+	return obj, deleted || changed, err
+}
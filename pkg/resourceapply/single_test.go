@@ -0,0 +1,149 @@
+package resourceapply
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestEnsureSingle(t *testing.T) {
+	t.Parallel()
+
+	owner := &metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "basic",
+		UID:       "abcdefgh",
+	}
+
+	newServiceAccount := func(name string, controllerUID types.UID) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       types.UID(name + "-uid"),
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                controllerUID,
+						APIVersion:         "scylla.scylladb.com/v1alpha1",
+						Kind:               "ScyllaDBDatacenter",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+		}
+	}
+
+	newRequiredServiceAccount := func() *corev1.ServiceAccount {
+		sa := newServiceAccount("basic-member", owner.UID)
+		sa.UID = ""
+		return sa
+	}
+
+	tt := []struct {
+		name                    string
+		existing                []runtime.Object
+		expectedServiceAccounts []string
+		expectedChanged         bool
+		expectedEvents          []string
+	}{
+		{
+			name: "two existing service accounts converge to one",
+			existing: []runtime.Object{
+				newServiceAccount("basic-member", owner.UID),
+				newServiceAccount("basic-member-duplicate", owner.UID),
+			},
+			expectedServiceAccounts: []string{"basic-member"},
+			expectedChanged:         true,
+			expectedEvents: []string{
+				"Normal ServiceAccountDeleted ServiceAccount default/basic-member-duplicate deleted",
+				"Normal ServiceAccountUpdated ServiceAccount default/basic-member updated",
+			},
+		},
+		{
+			name: "service accounts controlled by others are kept",
+			existing: []runtime.Object{
+				newServiceAccount("other", "other-uid"),
+			},
+			expectedServiceAccounts: []string{"basic-member", "other"},
+			expectedChanged:         true,
+			expectedEvents: []string{
+				"Normal ServiceAccountCreated ServiceAccount default/basic-member created",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewSimpleClientset(tc.existing...)
+
+			saCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, obj := range tc.existing {
+				err := saCache.Add(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			_, gotChanged, err := EnsureSingle(
+				ctx,
+				NewApplyControlFuncs[*corev1.ServiceAccount](
+					corev1listers.NewServiceAccountLister(saCache).ServiceAccounts("default"),
+					client.CoreV1().ServiceAccounts("default"),
+				).ToUntyped(),
+				recorder,
+				owner,
+				newRequiredServiceAccount(),
+				ApplyOptions{},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gotChanged != tc.expectedChanged {
+				t.Errorf("expected changed %t, got %t", tc.expectedChanged, gotChanged)
+			}
+
+			saList, err := client.CoreV1().ServiceAccounts("default").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotServiceAccounts []string
+			for _, sa := range saList.Items {
+				gotServiceAccounts = append(gotServiceAccounts, sa.Name)
+			}
+			if !reflect.DeepEqual(gotServiceAccounts, tc.expectedServiceAccounts) {
+				t.Errorf("expected and got service accounts differ:\n%s", cmp.Diff(tc.expectedServiceAccounts, gotServiceAccounts))
+			}
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+		})
+	}
+}
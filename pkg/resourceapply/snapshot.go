@@ -0,0 +1,95 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	snapshotv1client "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned/typed/snapshot/v1"
+	snapshotv1listers "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/listers/snapshot/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// volumeSnapshotImmutableFields are the fields of a VolumeSnapshot that can't be changed once it's created.
+var volumeSnapshotImmutableFields = []ImmutableField{
+	{
+		Path:    []string{"spec", "source", "persistentVolumeClaimName"},
+		Rule:    "self == oldSelf",
+		Message: "persistentVolumeClaimName is immutable",
+	},
+	{
+		Path:    []string{"spec", "source", "volumeSnapshotContentName"},
+		Rule:    "self == oldSelf",
+		Message: "volumeSnapshotContentName is immutable",
+	},
+}
+
+// ApplyVolumeSnapshotWithControl applies a VolumeSnapshot. The snapshot source is immutable, so changing it
+// is refused with an ImmutableFieldError, unless options.RecreateOnImmutable is set, in which case the VolumeSnapshot
+// is deleted and created again. The status is written by the snapshot controller, so it's never hashed nor written.
+func ApplyVolumeSnapshotWithControl(
+	ctx context.Context,
+	control ApplyControlInterface[*snapshotv1.VolumeSnapshot],
+	recorder record.EventRecorder,
+	required *snapshotv1.VolumeSnapshot,
+	options ApplyOptions,
+) (*snapshotv1.VolumeSnapshot, bool, error) {
+	if required.Status != nil {
+		required = required.DeepCopy()
+		required.Status = nil
+	}
+
+	if !options.RecreateOnImmutable {
+		options.ImmutableFields = append(slices.Clone(options.ImmutableFields), volumeSnapshotImmutableFields...)
+	}
+
+	return ApplyGenericWithHandlers[*snapshotv1.VolumeSnapshot](
+		ctx,
+		control,
+		recorder,
+		required,
+		options,
+		func(required **snapshotv1.VolumeSnapshot, existing *snapshotv1.VolumeSnapshot) {
+			// The snapshot controller sets the default class when none is requested, keep it.
+			if (*required).Spec.VolumeSnapshotClassName == nil {
+				(*required).Spec.VolumeSnapshotClassName = existing.Spec.VolumeSnapshotClassName
+			}
+		},
+		func(required *snapshotv1.VolumeSnapshot, existing *snapshotv1.VolumeSnapshot) (string, *metav1.DeletionPropagation, error) {
+			if !options.RecreateOnImmutable {
+				return "", nil, nil
+			}
+
+			changedField, err := findChangedImmutableField(required, existing, volumeSnapshotImmutableFields)
+			if err != nil {
+				return "", nil, fmt.Errorf("can't check immutable fields: %w", err)
+			}
+			if changedField == nil {
+				return "", nil, nil
+			}
+
+			return fmt.Sprintf("%s changed, the existing snapshot is deleted according to its deletion policy", changedField.String()), nil, nil
+		},
+	)
+}
+
+func ApplyVolumeSnapshot(
+	ctx context.Context,
+	client snapshotv1client.VolumeSnapshotsGetter,
+	lister snapshotv1listers.VolumeSnapshotLister,
+	recorder record.EventRecorder,
+	required *snapshotv1.VolumeSnapshot,
+	options ApplyOptions,
+) (*snapshotv1.VolumeSnapshot, bool, error) {
+	return ApplyVolumeSnapshotWithControl(
+		ctx,
+		NewApplyControlFuncs[*snapshotv1.VolumeSnapshot](lister.VolumeSnapshots(required.Namespace), client.VolumeSnapshots(required.Namespace)),
+		recorder,
+		required,
+		options,
+	)
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	snapshotv1 "github.com/scylladb/scylla-operator/pkg/externalapi/snapshot/v1"
+	snapshotfake "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/clientset/versioned/fake"
+	snapshotv1listers "github.com/scylladb/scylla-operator/pkg/externalclient/snapshot/listers/snapshot/v1"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachineryutilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyVolumeSnapshot(t *testing.T) {
+	// Using a generating function prevents unwanted mutations.
+	newVolumeSnapshot := func() *snapshotv1.VolumeSnapshot {
+		return &snapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels:    map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1alpha1",
+						Kind:               "ScyllaDBDatacenter",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: snapshotv1.VolumeSnapshotSpec{
+				Source: snapshotv1.VolumeSnapshotSource{
+					PersistentVolumeClaimName: pointer.Ptr("data-basic-0"),
+				},
+			},
+		}
+	}
+
+	newVolumeSnapshotWithHash := func() *snapshotv1.VolumeSnapshot {
+		vs := newVolumeSnapshot()
+		apimachineryutilruntime.Must(SetHashAnnotation(vs))
+		return vs
+	}
+
+	newReadyStatus := func() *snapshotv1.VolumeSnapshotStatus {
+		return &snapshotv1.VolumeSnapshotStatus{
+			BoundVolumeSnapshotContentName: pointer.Ptr("snapcontent-1"),
+			CreationTime:                   pointer.Ptr(metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))),
+			ReadyToUse:                     pointer.Ptr(true),
+			RestoreSize:                    pointer.Ptr(resource.MustParse("10Gi")),
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		existing               []runtime.Object
+		required               *snapshotv1.VolumeSnapshot
+		options                ApplyOptions
+		expectedVolumeSnapshot *snapshotv1.VolumeSnapshot
+		expectedChanged        bool
+		expectedErr            error
+		expectedEvents         []string
+	}{
+		{
+			name:                   "creates a new volumesnapshot when there is none",
+			existing:               nil,
+			required:               newVolumeSnapshot(),
+			expectedVolumeSnapshot: newVolumeSnapshotWithHash(),
+			expectedChanged:        true,
+			expectedErr:            nil,
+			expectedEvents:         []string{"Normal VolumeSnapshotCreated VolumeSnapshot default/test created"},
+		},
+		{
+			name:     "doesn't hash nor write the status of the required volumesnapshot",
+			existing: nil,
+			required: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshot()
+				vs.Status = newReadyStatus()
+				return vs
+			}(),
+			expectedVolumeSnapshot: newVolumeSnapshotWithHash(),
+			expectedChanged:        true,
+			expectedErr:            nil,
+			expectedEvents:         []string{"Normal VolumeSnapshotCreated VolumeSnapshot default/test created"},
+		},
+		{
+			name: "does nothing when only the status of the existing volumesnapshot changed",
+			existing: []runtime.Object{
+				func() *snapshotv1.VolumeSnapshot {
+					vs := newVolumeSnapshotWithHash()
+					vs.Status = newReadyStatus()
+					return vs
+				}(),
+			},
+			required: newVolumeSnapshot(),
+			expectedVolumeSnapshot: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshotWithHash()
+				vs.Status = newReadyStatus()
+				return vs
+			}(),
+			expectedChanged: false,
+			expectedErr:     nil,
+			expectedEvents:  nil,
+		},
+		{
+			name: "keeps the defaulted class when the volumesnapshot is updated",
+			existing: []runtime.Object{
+				func() *snapshotv1.VolumeSnapshot {
+					vs := newVolumeSnapshotWithHash()
+					vs.Spec.VolumeSnapshotClassName = pointer.Ptr("default")
+					return vs
+				}(),
+			},
+			required: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshot()
+				vs.Labels["foo"] = "bar"
+				return vs
+			}(),
+			expectedVolumeSnapshot: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshot()
+				vs.Labels["foo"] = "bar"
+				apimachineryutilruntime.Must(SetHashAnnotation(vs))
+				vs.Spec.VolumeSnapshotClassName = pointer.Ptr("default")
+				return vs
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents:  []string{"Normal VolumeSnapshotUpdated VolumeSnapshot default/test updated"},
+		},
+		{
+			name: "refuses to change the source of the volumesnapshot",
+			existing: []runtime.Object{
+				newVolumeSnapshotWithHash(),
+			},
+			required: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshot()
+				vs.Spec.Source.PersistentVolumeClaimName = pointer.Ptr("data-basic-1")
+				return vs
+			}(),
+			expectedVolumeSnapshot: nil,
+			expectedChanged:        false,
+			expectedErr: &ImmutableFieldError{
+				GVK:   snapshotv1.GroupVersion.WithKind("VolumeSnapshot"),
+				Ref:   "default/test",
+				Field: volumeSnapshotImmutableFields[0],
+			},
+			expectedEvents: []string{`Warning UpdateVolumeSnapshotFailed Failed to update VolumeSnapshot default/test: snapshot.storage.k8s.io/v1, Kind=VolumeSnapshot "default/test" can't be updated because field spec.source.persistentVolumeClaimName is immutable: persistentVolumeClaimName is immutable`},
+		},
+		{
+			name: "recreates the volumesnapshot when its source changes and recreation is allowed",
+			existing: []runtime.Object{
+				newVolumeSnapshotWithHash(),
+			},
+			required: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshot()
+				vs.Spec.Source.PersistentVolumeClaimName = pointer.Ptr("data-basic-1")
+				return vs
+			}(),
+			options: ApplyOptions{
+				RecreateOnImmutable: true,
+			},
+			expectedVolumeSnapshot: func() *snapshotv1.VolumeSnapshot {
+				vs := newVolumeSnapshot()
+				vs.Spec.Source.PersistentVolumeClaimName = pointer.Ptr("data-basic-1")
+				apimachineryutilruntime.Must(SetHashAnnotation(vs))
+				return vs
+			}(),
+			expectedChanged: true,
+			expectedErr:     nil,
+			expectedEvents: []string{
+				"Normal VolumeSnapshotDeleted VolumeSnapshot default/test deleted",
+				"Warning VolumeSnapshotRecreating Recreating VolumeSnapshot default/test because spec.source.persistentVolumeClaimName changed, the existing snapshot is deleted according to its deletion policy",
+				"Normal VolumeSnapshotCreated VolumeSnapshot default/test created",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Client holds the state so it has to persists the iterations.
+			client := snapshotfake.NewSimpleClientset(tc.existing...)
+
+			// ApplyVolumeSnapshot needs to be reentrant so running it the second time should give the same results.
+			iterations := 2
+			if tc.expectedErr != nil {
+				iterations = 1
+			}
+			for i := range iterations {
+				t.Run("", func(t *testing.T) {
+					ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer ctxCancel()
+
+					recorder := record.NewFakeRecorder(10)
+
+					vsCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+					vsLister := snapshotv1listers.NewVolumeSnapshotLister(vsCache)
+
+					vsList, err := client.SnapshotV1().VolumeSnapshots("").List(ctx, metav1.ListOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					for i := range vsList.Items {
+						err := vsCache.Add(&vsList.Items[i])
+						if err != nil {
+							t.Fatal(err)
+						}
+					}
+
+					gotObj, gotChanged, gotErr := ApplyVolumeSnapshot(ctx, client.SnapshotV1(), vsLister, recorder, tc.required, tc.options)
+					if !reflect.DeepEqual(gotErr, tc.expectedErr) {
+						t.Fatalf("expected %v, got %v", tc.expectedErr, gotErr)
+					}
+
+					if !equality.Semantic.DeepEqual(gotObj, tc.expectedVolumeSnapshot) {
+						t.Errorf("expected %#v, got %#v, diff:\n%s", tc.expectedVolumeSnapshot, gotObj, cmp.Diff(tc.expectedVolumeSnapshot, gotObj))
+					}
+
+					// Make sure such object was actually created.
+					if gotObj != nil {
+						createdVolumeSnapshot, err := client.SnapshotV1().VolumeSnapshots(gotObj.Namespace).Get(ctx, gotObj.Name, metav1.GetOptions{})
+						if err != nil {
+							t.Error(err)
+						}
+						if !equality.Semantic.DeepEqual(createdVolumeSnapshot, gotObj) {
+							t.Errorf("created and returned volumesnapshots differ:\n%s", cmp.Diff(createdVolumeSnapshot, gotObj))
+						}
+					}
+
+					if i == 0 {
+						if gotChanged != tc.expectedChanged {
+							t.Errorf("expected %t, got %t", tc.expectedChanged, gotChanged)
+						}
+					} else {
+						if gotChanged {
+							t.Errorf("object changed in iteration %d", i)
+						}
+					}
+
+					close(recorder.Events)
+					var gotEvents []string
+					for e := range recorder.Events {
+						gotEvents = append(gotEvents, e)
+					}
+					if i == 0 {
+						if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+							t.Errorf("expected %v, got %v, diff:\n%s", tc.expectedEvents, gotEvents, cmp.Diff(tc.expectedEvents, gotEvents))
+						}
+					} else {
+						if len(gotEvents) > 0 {
+							t.Errorf("unexpected events: %v", gotEvents)
+						}
+					}
+				})
+			}
+		})
+	}
+}
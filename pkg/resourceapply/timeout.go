@@ -0,0 +1,96 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// timeoutApplyControl bounds every API call made through the wrapped control, see ApplyOptions.TimeoutPerCall.
+// Cached reads don't reach the apiserver, so they are passed through.
+type timeoutApplyControl[T kubeinterfaces.ObjectInterface] struct {
+	control ApplyControlInterface[T]
+	timeout time.Duration
+}
+
+var _ ApplyControlInterface[kubeinterfaces.ObjectInterface] = &timeoutApplyControl[kubeinterfaces.ObjectInterface]{}
+var _ ApplyControlPatcher[kubeinterfaces.ObjectInterface] = &timeoutApplyControl[kubeinterfaces.ObjectInterface]{}
+
+func (c *timeoutApplyControl[T]) GetCached(name string) (T, error) {
+	return c.control.GetCached(name)
+}
+
+func (c *timeoutApplyControl[T]) ListCached(selector labels.Selector) ([]T, error) {
+	return c.control.ListCached(selector)
+}
+
+func (c *timeoutApplyControl[T]) Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+	callCtx, callCtxCancel := context.WithTimeout(ctx, c.timeout)
+	defer callCtxCancel()
+
+	obj, err := c.control.Get(callCtx, name, opts)
+	return obj, c.wrapError(ctx, callCtx, "get", err)
+}
+
+func (c *timeoutApplyControl[T]) Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error) {
+	callCtx, callCtxCancel := context.WithTimeout(ctx, c.timeout)
+	defer callCtxCancel()
+
+	created, err := c.control.Create(callCtx, obj, opts)
+	return created, c.wrapError(ctx, callCtx, "create", err)
+}
+
+func (c *timeoutApplyControl[T]) Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error) {
+	callCtx, callCtxCancel := context.WithTimeout(ctx, c.timeout)
+	defer callCtxCancel()
+
+	updated, err := c.control.Update(callCtx, obj, opts)
+	return updated, c.wrapError(ctx, callCtx, "update", err)
+}
+
+func (c *timeoutApplyControl[T]) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error) {
+	patcher, ok := c.control.(ApplyControlPatcher[T])
+	if !ok {
+		return *new(T), fmt.Errorf("patching isn't supported by this control")
+	}
+
+	callCtx, callCtxCancel := context.WithTimeout(ctx, c.timeout)
+	defer callCtxCancel()
+
+	patched, err := patcher.Patch(callCtx, name, pt, data, opts)
+	return patched, c.wrapError(ctx, callCtx, "patch", err)
+}
+
+func (c *timeoutApplyControl[T]) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	callCtx, callCtxCancel := context.WithTimeout(ctx, c.timeout)
+	defer callCtxCancel()
+
+	err := c.control.Delete(callCtx, name, opts)
+	return c.wrapError(ctx, callCtx, "delete", err)
+}
+
+// wrapError turns an error caused by the call deadline into a CallTimeoutError.
+// Errors caused by the parent context are returned as they are, the caller is going away anyway.
+func (c *timeoutApplyControl[T]) wrapError(ctx context.Context, callCtx context.Context, verb string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil || !errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return &CallTimeoutError{
+		Verb:    verb,
+		Timeout: c.timeout,
+		Err:     err,
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/scylladb/scylla-operator/pkg/helpers"
+	"github.com/scylladb/scylla-operator/pkg/kubeinterfaces"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// UpdateStrategy selects how an apply writes the required state over an existing object.
+type UpdateStrategy string
+
+const (
+	// UpdateStrategyFullUpdate replaces the whole object using an update call. It's the default.
+	UpdateStrategyFullUpdate UpdateStrategy = "FullUpdate"
+	// UpdateStrategyStrategicMergePatch sends a strategic merge patch computed from the existing object.
+	// The apiserver only supports it for the built-in kinds.
+	UpdateStrategyStrategicMergePatch UpdateStrategy = "StrategicMergePatch"
+	// UpdateStrategyMergePatch sends a JSON merge patch computed from the existing object.
+	UpdateStrategyMergePatch UpdateStrategy = "MergePatch"
+	// UpdateStrategyJSONPatch sends a JSON patch replacing the top level fields that differ from the existing object.
+	UpdateStrategyJSONPatch UpdateStrategy = "JSONPatch"
+	// UpdateStrategyApplyPatch sends the required object as a server-side apply,
+	// forcing the ownership of the fields it sets, like a full update would.
+	UpdateStrategyApplyPatch UpdateStrategy = "ApplyPatch"
+)
+
+// ApplyControlPatcher is implemented by controls that can patch objects.
+// Every update strategy but UpdateStrategyFullUpdate needs it.
+type ApplyControlPatcher[T kubeinterfaces.ObjectInterface] interface {
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (T, error)
+}
+
+// validateUpdateStrategy checks that the strategy exists and can be used for objects of the kind.
+func validateUpdateStrategy(strategy UpdateStrategy, gvk schema.GroupVersionKind) error {
+	switch strategy {
+	case "", UpdateStrategyFullUpdate, UpdateStrategyMergePatch, UpdateStrategyJSONPatch:
+		return nil
+
+	case UpdateStrategyStrategicMergePatch:
+		if !kscheme.Scheme.Recognizes(gvk) {
+			return fmt.Errorf("update strategy %q isn't supported for %s, it's only available for built-in kinds", strategy, gvk)
+		}
+		return nil
+
+	case UpdateStrategyApplyPatch:
+		if len(gvk.Kind) == 0 || gvk.Group == "unknown" {
+			return fmt.Errorf("update strategy %q needs a known kind, got %s", strategy, gvk)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown update strategy %q", strategy)
+	}
+}
+
+// updateWithStrategy writes required over existing using the strategy.
+// Patches are computed against existing and are conditional on the resourceVersion of required,
+// so they keep the optimistic concurrency of a full update.
+func updateWithStrategy[T kubeinterfaces.ObjectInterface](
+	ctx context.Context,
+	control ApplyControlInterface[T],
+	existing T,
+	required T,
+	gvk schema.GroupVersionKind,
+	strategy UpdateStrategy,
+	fieldValidation string,
+) (T, error) {
+	if len(strategy) == 0 || strategy == UpdateStrategyFullUpdate {
+		return control.Update(
+			ctx,
+			required,
+			metav1.UpdateOptions{
+				FieldValidation: fieldValidation,
+			},
+		)
+	}
+
+	patcher, ok := any(control).(ApplyControlPatcher[T])
+	if !ok {
+		return *new(T), fmt.Errorf("patching isn't supported by this control")
+	}
+
+	patchType, patch, err := makeUpdatePatch(existing, required, gvk, strategy)
+	if err != nil {
+		return *new(T), fmt.Errorf("can't make %s patch: %w", strategy, err)
+	}
+
+	opts := metav1.PatchOptions{
+		FieldValidation: fieldValidation,
+	}
+	if patchType == types.ApplyPatchType {
+		opts.FieldManager = naming.OperatorAppName
+		opts.Force = pointer.Ptr(true)
+	}
+
+	return patcher.Patch(ctx, required.GetName(), patchType, patch, opts)
+}
+
+func makeUpdatePatch[T kubeinterfaces.ObjectInterface](existing T, required T, gvk schema.GroupVersionKind, strategy UpdateStrategy) (types.PatchType, []byte, error) {
+	// The patch has to carry the resourceVersion as a precondition, so it can't be equal on both sides.
+	existingCopy := existing.DeepCopyObject().(T)
+	existingCopy.SetResourceVersion("")
+
+	switch strategy {
+	case UpdateStrategyStrategicMergePatch:
+		patch, err := helpers.CreateTwoWayMergePatch(existingCopy, required)
+		return types.StrategicMergePatchType, patch, err
+
+	case UpdateStrategyMergePatch:
+		existingJSON, err := json.Marshal(existingCopy)
+		if err != nil {
+			return "", nil, fmt.Errorf("can't marshal existing object: %w", err)
+		}
+
+		requiredJSON, err := json.Marshal(required)
+		if err != nil {
+			return "", nil, fmt.Errorf("can't marshal required object: %w", err)
+		}
+
+		patch, err := jsonpatch.CreateMergePatch(existingJSON, requiredJSON)
+		return types.MergePatchType, patch, err
+
+	case UpdateStrategyJSONPatch:
+		patch, err := makeJSONPatch(existing, required)
+		return types.JSONPatchType, patch, err
+
+	case UpdateStrategyApplyPatch:
+		requiredCopy := required.DeepCopyObject().(T)
+		requiredCopy.GetObjectKind().SetGroupVersionKind(gvk)
+		requiredCopy.SetManagedFields(nil)
+		patch, err := json.Marshal(requiredCopy)
+		return types.ApplyPatchType, patch, err
+
+	default:
+		return "", nil, fmt.Errorf("unknown update strategy %q", strategy)
+	}
+}
+
+// makeJSONPatch returns a JSON patch that replaces every top level field of existing that differs in required,
+// guarded by a test of the resourceVersion, if it's known.
+func makeJSONPatch(existing, required kubeinterfaces.ObjectInterface) ([]byte, error) {
+	toMap := func(obj kubeinterfaces.ObjectInterface) (map[string]any, error) {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		m := map[string]any{}
+		err = json.Unmarshal(data, &m)
+		if err != nil {
+			return nil, err
+		}
+
+		return m, nil
+	}
+
+	existingMap, err := toMap(existing)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert existing object: %w", err)
+	}
+
+	requiredMap, err := toMap(required)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert required object: %w", err)
+	}
+
+	var operations []jsonPatchOperation
+	if len(required.GetResourceVersion()) != 0 {
+		operations = append(operations, jsonPatchOperation{
+			Op:    "test",
+			Path:  "/metadata/resourceVersion",
+			Value: required.GetResourceVersion(),
+		})
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(requiredMap)) {
+		if reflect.DeepEqual(existingMap[k], requiredMap[k]) {
+			continue
+		}
+
+		// Adding an existing member replaces it.
+		operations = append(operations, jsonPatchOperation{
+			Op:    "add",
+			Path:  "/" + k,
+			Value: requiredMap[k],
+		})
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(existingMap)) {
+		_, ok := requiredMap[k]
+		if ok {
+			continue
+		}
+
+		operations = append(operations, jsonPatchOperation{
+			Op:   "remove",
+			Path: "/" + k,
+		})
+	}
+
+	return json.Marshal(operations)
+}
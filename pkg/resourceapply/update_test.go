@@ -0,0 +1,259 @@
+package resourceapply
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyDeploymentUpdateStrategy(t *testing.T) {
+	t.Parallel()
+
+	newDeployment := func(replicas int32, image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Ptr(replicas),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"foo": "bar",
+					},
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"foo": "bar",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "scylla",
+								Image: image,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name              string
+		strategy          UpdateStrategy
+		expectedVerb      string
+		expectedPatchType types.PatchType
+	}{
+		{
+			name:         "default strategy updates the whole object",
+			strategy:     "",
+			expectedVerb: "update",
+		},
+		{
+			name:         "full update updates the whole object",
+			strategy:     UpdateStrategyFullUpdate,
+			expectedVerb: "update",
+		},
+		{
+			name:              "strategic merge patch",
+			strategy:          UpdateStrategyStrategicMergePatch,
+			expectedVerb:      "patch",
+			expectedPatchType: types.StrategicMergePatchType,
+		},
+		{
+			name:              "merge patch",
+			strategy:          UpdateStrategyMergePatch,
+			expectedVerb:      "patch",
+			expectedPatchType: types.MergePatchType,
+		},
+		{
+			name:              "json patch",
+			strategy:          UpdateStrategyJSONPatch,
+			expectedVerb:      "patch",
+			expectedPatchType: types.JSONPatchType,
+		},
+		{
+			name:              "apply patch",
+			strategy:          UpdateStrategyApplyPatch,
+			expectedVerb:      "patch",
+			expectedPatchType: types.ApplyPatchType,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			client := fake.NewClientset()
+
+			apply := func(t *testing.T, required *appsv1.Deployment) (*appsv1.Deployment, bool) {
+				t.Helper()
+
+				deploymentCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+				deploymentList, err := client.AppsV1().Deployments("").List(ctx, metav1.ListOptions{
+					LabelSelector: labels.Everything().String(),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range deploymentList.Items {
+					err = deploymentCache.Add(&deploymentList.Items[i])
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				client.ClearActions()
+
+				got, changed, err := ApplyDeployment(ctx, client.AppsV1(), appsv1listers.NewDeploymentLister(deploymentCache), record.NewFakeRecorder(10), required, ApplyOptions{
+					UpdateStrategy: tc.strategy,
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return got, changed
+			}
+
+			_, changed := apply(t, newDeployment(1, "scylladb/scylla:1"))
+			if !changed {
+				t.Fatalf("expected the deployment to be created")
+			}
+
+			required := newDeployment(3, "scylladb/scylla:2")
+			got, changed := apply(t, required)
+			if !changed {
+				t.Errorf("expected the deployment to be changed")
+			}
+
+			var writes []clienttesting.Action
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "list" || action.GetVerb() == "get" || action.GetVerb() == "watch" {
+					continue
+				}
+				writes = append(writes, action)
+			}
+			if len(writes) != 1 {
+				t.Fatalf("expected a single write, got %v", writes)
+			}
+			if writes[0].GetVerb() != tc.expectedVerb {
+				t.Errorf("expected verb %q, got %q", tc.expectedVerb, writes[0].GetVerb())
+			}
+			if len(tc.expectedPatchType) != 0 {
+				patchAction, ok := writes[0].(clienttesting.PatchAction)
+				if !ok {
+					t.Fatalf("expected a patch action, got %T", writes[0])
+				}
+				if patchAction.GetPatchType() != tc.expectedPatchType {
+					t.Errorf("expected patch type %q, got %q", tc.expectedPatchType, patchAction.GetPatchType())
+				}
+			}
+
+			stored, err := client.AppsV1().Deployments("default").Get(ctx, "test", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, deployment := range []*appsv1.Deployment{got, stored} {
+				if *deployment.Spec.Replicas != 3 {
+					t.Errorf("expected 3 replicas, got %d", *deployment.Spec.Replicas)
+				}
+				if deployment.Spec.Template.Spec.Containers[0].Image != "scylladb/scylla:2" {
+					t.Errorf("expected image %q, got %q", "scylladb/scylla:2", deployment.Spec.Template.Spec.Containers[0].Image)
+				}
+			}
+
+			_, changed = apply(t, required)
+			if changed {
+				t.Errorf("expected no change when the deployment is up to date")
+			}
+		})
+	}
+}
+
+func TestValidateUpdateStrategy(t *testing.T) {
+	t.Parallel()
+
+	deploymentGVK := appsv1.SchemeGroupVersion.WithKind("Deployment")
+	customGVK := schema.GroupVersionKind{Group: "scylla.scylladb.com", Version: "v1alpha1", Kind: "ScyllaDBDatacenter"}
+
+	tt := []struct {
+		name        string
+		strategy    UpdateStrategy
+		gvk         schema.GroupVersionKind
+		expectedErr bool
+	}{
+		{
+			name:        "strategic merge patch is allowed for built-in kinds",
+			strategy:    UpdateStrategyStrategicMergePatch,
+			gvk:         deploymentGVK,
+			expectedErr: false,
+		},
+		{
+			name:        "strategic merge patch is rejected for custom kinds",
+			strategy:    UpdateStrategyStrategicMergePatch,
+			gvk:         customGVK,
+			expectedErr: true,
+		},
+		{
+			name:        "merge patch is allowed for custom kinds",
+			strategy:    UpdateStrategyMergePatch,
+			gvk:         customGVK,
+			expectedErr: false,
+		},
+		{
+			name:        "apply patch is rejected for unknown kinds",
+			strategy:    UpdateStrategyApplyPatch,
+			gvk:         schema.GroupVersionKind{Group: "unknown", Version: "unknown", Kind: "Foo"},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown strategy is rejected",
+			strategy:    "Replace",
+			gvk:         deploymentGVK,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateUpdateStrategy(tc.strategy, tc.gvk)
+			if tc.expectedErr != (err != nil) {
+				t.Errorf("expected error %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+type warningCollectorKey struct{}
+
+// warningCollector gathers the warnings the apiserver returned for the calls made with its context.
+type warningCollector struct {
+	lock     sync.Mutex
+	warnings []string
+}
+
+func withWarningCollector(ctx context.Context) (context.Context, *warningCollector) {
+	collector := &warningCollector{}
+	return context.WithValue(ctx, warningCollectorKey{}, collector), collector
+}
+
+func (c *warningCollector) add(text string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !slices.Contains(c.warnings, text) {
+		c.warnings = append(c.warnings, text)
+	}
+}
+
+// take returns the collected warnings and forgets them.
+func (c *warningCollector) take() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	warnings := c.warnings
+	c.warnings = nil
+	return warnings
+}
+
+// WarningHandler passes the warnings returned for calls made by applies with ApplyOptions.FieldValidation
+// set to metav1.FieldValidationWarn to the apply, which reports them as events. Other warnings go to the fallback.
+// It has to be set as the WarningHandlerWithContext of the rest config the clients used by the applies are built from.
+type WarningHandler struct {
+	fallback rest.WarningHandlerWithContext
+}
+
+var _ rest.WarningHandlerWithContext = &WarningHandler{}
+
+func NewWarningHandler(fallback rest.WarningHandlerWithContext) *WarningHandler {
+	return &WarningHandler{
+		fallback: fallback,
+	}
+}
+
+func (h *WarningHandler) HandleWarningHeaderWithContext(ctx context.Context, code int, agent string, text string) {
+	collector, ok := ctx.Value(warningCollectorKey{}).(*warningCollector)
+	if ok && code == 299 && len(text) != 0 {
+		collector.add(text)
+		return
+	}
+
+	if h.fallback != nil {
+		h.fallback.HandleWarningHeaderWithContext(ctx, code, agent, text)
+	}
+}
+
+func reportWarningEvents(recorder record.EventRecorder, obj runtime.Object, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't get object metadata")
+		return
+	}
+	gvk, err := resource.GetObjectGVK(obj)
+	if err != nil {
+		klog.ErrorS(err, "can't determine object GVK", "Object", klog.KObj(objMeta))
+		return
+	}
+
+	for _, warning := range warnings {
+		recorder.Eventf(
+			obj,
+			corev1.EventTypeNormal,
+			"ApplyWarning",
+			"%s %s was applied with a warning: %s",
+			gvk.Kind, naming.ObjRef(objMeta), warning,
+		)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2025 ScyllaDB
+
+package resourceapply
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyGenericFieldValidationWarnings(t *testing.T) {
+	t.Parallel()
+
+	// Using a generating function prevents unwanted mutations.
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller:         pointer.Ptr(true),
+						UID:                "abcdefgh",
+						APIVersion:         "scylla.scylladb.com/v1",
+						Kind:               "ScyllaCluster",
+						Name:               "basic",
+						BlockOwnerDeletion: pointer.Ptr(true),
+					},
+				},
+			},
+			Data: map[string]string{},
+		}
+	}
+
+	tt := []struct {
+		name                    string
+		fieldValidation         string
+		expectedFieldValidation string
+		expectedEvents          []string
+	}{
+		{
+			name:                    "warnings aren't reported with the default strict validation",
+			fieldValidation:         "",
+			expectedFieldValidation: metav1.FieldValidationStrict,
+			expectedEvents: []string{
+				"Normal ConfigMapCreated ConfigMap default/test created",
+			},
+		},
+		{
+			name:                    "warnings are reported as events with warn validation",
+			fieldValidation:         metav1.FieldValidationWarn,
+			expectedFieldValidation: metav1.FieldValidationWarn,
+			expectedEvents: []string{
+				"Normal ConfigMapCreated ConfigMap default/test created",
+				`Normal ApplyWarning ConfigMap default/test was applied with a warning: unknown field "data.deprecated"`,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer ctxCancel()
+
+			var gotFieldValidation string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v1/namespaces/default/configmaps" {
+					http.Error(w, "unexpected request", http.StatusNotFound)
+					return
+				}
+				gotFieldValidation = r.URL.Query().Get("fieldValidation")
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Add("Warning", `299 - "unknown field \"data.deprecated\""`)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			var fallbackWarnings []string
+			client, err := kubernetes.NewForConfig(&rest.Config{
+				Host: server.URL,
+				ContentConfig: rest.ContentConfig{
+					ContentType: "application/json",
+				},
+				WarningHandlerWithContext: NewWarningHandler(warningHandlerFunc(func(text string) {
+					fallbackWarnings = append(fallbackWarnings, text)
+				})),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			recorder := record.NewFakeRecorder(10)
+
+			_, changed, err := ApplyConfigMap(ctx, client.CoreV1(), corev1listers.NewConfigMapLister(cmCache), recorder, newConfigMap(), ApplyOptions{
+				FieldValidation: tc.fieldValidation,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !changed {
+				t.Errorf("expected the object to be created")
+			}
+
+			if gotFieldValidation != tc.expectedFieldValidation {
+				t.Errorf("expected field validation %q, got %q", tc.expectedFieldValidation, gotFieldValidation)
+			}
+
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if !reflect.DeepEqual(gotEvents, tc.expectedEvents) {
+				t.Errorf("expected and got events differ:\n%s", cmp.Diff(tc.expectedEvents, gotEvents))
+			}
+
+			// Warnings that aren't reported as events are passed to the fallback.
+			expectedFallbackWarnings := []string{`unknown field "data.deprecated"`}
+			if tc.fieldValidation == metav1.FieldValidationWarn {
+				expectedFallbackWarnings = nil
+			}
+			if !reflect.DeepEqual(fallbackWarnings, expectedFallbackWarnings) {
+				t.Errorf("expected and got fallback warnings differ:\n%s", cmp.Diff(expectedFallbackWarnings, fallbackWarnings))
+			}
+		})
+	}
+}
+
+type warningHandlerFunc func(text string)
+
+func (f warningHandlerFunc) HandleWarningHeaderWithContext(_ context.Context, _ int, _ string, text string) {
+	f(text)
+}
@@ -1,22 +1,26 @@
 package resourcemerge
 
 import (
+	"reflect"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func isRemovalKey(k string) bool {
+// IsRemovalKey reports whether k marks a key for removal from the existing object when merging metadata.
+func IsRemovalKey(k string) bool {
 	return strings.HasSuffix(k, "-")
 }
 
-func toRemovalKey(k string) string {
+// ToRemovalKey returns the key that marks k for removal from the existing object when merging metadata.
+func ToRemovalKey(k string) string {
 	return k + "-"
 }
 
 func cleanRemovalKeys(m map[string]string) map[string]string {
 	for k := range m {
-		if isRemovalKey(k) {
+		if IsRemovalKey(k) {
 			delete(m, k)
 		}
 	}
@@ -33,7 +37,7 @@ func SanitizeObject(obj metav1.Object) {
 func MergeMapInPlaceWithoutRemovalKeys(required map[string]string, existing map[string]string) {
 	for existingKey, existingValue := range existing {
 		// Don't copy removed keys.
-		_, isRemoved := required[toRemovalKey(existingKey)]
+		_, isRemoved := required[ToRemovalKey(existingKey)]
 		if isRemoved {
 			continue
 		}
@@ -53,3 +57,38 @@ func MergeMetadataInPlace(required metav1.Object, existing metav1.Object) {
 	MergeMapInPlaceWithoutRemovalKeys(required.GetAnnotations(), existing.GetAnnotations())
 	MergeMapInPlaceWithoutRemovalKeys(required.GetLabels(), existing.GetLabels())
 }
+
+// PreserveServerFields copies the fields populated by the apiserver from existing into required,
+// so required can be sent as an update without resetting them.
+// It carries over the uid, generation, creationTimestamp and status, and the resourceVersion
+// unless required already has one, as it is then based on a specific version of the object.
+// Objects without metadata, or of a different type than existing, are left untouched.
+func PreserveServerFields(existing, required runtime.Object) {
+	if reflect.TypeOf(existing) != reflect.TypeOf(required) {
+		return
+	}
+
+	existingMeta, ok := existing.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	requiredMeta, ok := required.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	requiredMeta.SetUID(existingMeta.GetUID())
+	requiredMeta.SetGeneration(existingMeta.GetGeneration())
+	requiredMeta.SetCreationTimestamp(existingMeta.GetCreationTimestamp())
+	if len(requiredMeta.GetResourceVersion()) == 0 {
+		requiredMeta.SetResourceVersion(existingMeta.GetResourceVersion())
+	}
+
+	// Status is owned by the controllers of the kind, the required object never sets it.
+	existingStatus := reflect.ValueOf(existing).Elem().FieldByName("Status")
+	requiredStatus := reflect.ValueOf(required).Elem().FieldByName("Status")
+	if existingStatus.IsValid() && requiredStatus.IsValid() && requiredStatus.CanSet() {
+		requiredStatus.Set(reflect.ValueOf(existing.DeepCopyObject()).Elem().FieldByName("Status"))
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestIsRemovalKey(t *testing.T) {
@@ -34,7 +38,7 @@ func TestIsRemovalKey(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			got := isRemovalKey(tc.key)
+			got := IsRemovalKey(tc.key)
 
 			if got != tc.expected {
 				t.Errorf("expected %t, got %t", tc.expected, got)
@@ -54,9 +58,9 @@ func FuzzToRemovalKey(f *testing.F) {
 	}
 	f.Fuzz(func(t *testing.T, k string) {
 		t.Logf("key: %q", k)
-		rk := toRemovalKey(k)
+		rk := ToRemovalKey(k)
 
-		if !isRemovalKey(rk) {
+		if !IsRemovalKey(rk) {
 			t.Errorf("%q isn't a removal key", rk)
 		}
 	})
@@ -359,3 +363,146 @@ func TestMergeMetadataInPlace(t *testing.T) {
 		})
 	}
 }
+
+func TestPreserveServerFields(t *testing.T) {
+	creationTimestamp := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	newExisting := func() *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "foo",
+				UID:               "uid",
+				ResourceVersion:   "42",
+				Generation:        3,
+				CreationTimestamp: creationTimestamp,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				ServiceName: "existing",
+			},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 3,
+				ReadyReplicas:      2,
+			},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		existing runtime.Object
+		required runtime.Object
+		expected runtime.Object
+	}{
+		{
+			name:     "server metadata and status are carried over",
+			existing: newExisting(),
+			required: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					ServiceName: "required",
+				},
+			},
+			expected: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "foo",
+					UID:               "uid",
+					ResourceVersion:   "42",
+					Generation:        3,
+					CreationTimestamp: creationTimestamp,
+				},
+				Spec: appsv1.StatefulSetSpec{
+					ServiceName: "required",
+				},
+				Status: appsv1.StatefulSetStatus{
+					ObservedGeneration: 3,
+					ReadyReplicas:      2,
+				},
+			},
+		},
+		{
+			name:     "required resourceVersion is kept",
+			existing: newExisting(),
+			required: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "foo",
+					ResourceVersion: "41",
+				},
+			},
+			expected: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "foo",
+					UID:               "uid",
+					ResourceVersion:   "41",
+					Generation:        3,
+					CreationTimestamp: creationTimestamp,
+				},
+				Status: appsv1.StatefulSetStatus{
+					ObservedGeneration: 3,
+					ReadyReplicas:      2,
+				},
+			},
+		},
+		{
+			name: "kinds without status only get server metadata",
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "foo",
+					UID:             "uid",
+					ResourceVersion: "42",
+				},
+				Data: map[string]string{
+					"key": "existing",
+				},
+			},
+			required: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Data: map[string]string{
+					"key": "required",
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "foo",
+					UID:             "uid",
+					ResourceVersion: "42",
+				},
+				Data: map[string]string{
+					"key": "required",
+				},
+			},
+		},
+		{
+			name:     "objects of different types are left untouched",
+			existing: newExisting(),
+			required: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := tc.existing.DeepCopyObject()
+			got := tc.required.DeepCopyObject()
+			PreserveServerFields(existing, got)
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected and got differs: %s", cmp.Diff(tc.expected, got))
+			}
+
+			if !reflect.DeepEqual(existing, tc.existing) {
+				t.Errorf("existing object was modified: %s", cmp.Diff(tc.existing, existing))
+			}
+		})
+	}
+}
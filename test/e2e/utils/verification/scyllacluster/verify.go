@@ -98,7 +98,7 @@ func verifyStatefulset(ctx context.Context, client corev1client.CoreV1Interface,
 	}
 }
 
-func verifyPodDisruptionBudget(sc *scyllav1.ScyllaCluster, pdb *policyv1.PodDisruptionBudget, sdc *scyllav1alpha1.ScyllaDBDatacenter) {
+func verifyPodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, sdc *scyllav1alpha1.ScyllaDBDatacenter, selectorLabels map[string]string) {
 	o.Expect(pdb.ObjectMeta.OwnerReferences).To(o.BeEquivalentTo(
 		[]metav1.OwnerReference{
 			{
@@ -113,7 +113,7 @@ func verifyPodDisruptionBudget(sc *scyllav1.ScyllaCluster, pdb *policyv1.PodDisr
 	)
 	o.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(o.Equal(1))
 	o.Expect(pdb.Spec.Selector).ToNot(o.BeNil())
-	o.Expect(pdb.Spec.Selector.MatchLabels).To(o.Equal(naming.ClusterLabelsForScyllaCluster(sc)))
+	o.Expect(pdb.Spec.Selector.MatchLabels).To(o.Equal(selectorLabels))
 	o.Expect(pdb.Spec.Selector.MatchExpressions).To(o.Equal([]metav1.LabelSelectorRequirement{
 		{
 			Key:      "batch.kubernetes.io/job-name",
@@ -241,6 +241,22 @@ func VerifyWithOptions(ctx context.Context, kubeClient kubernetes.Interface, scy
 				condType: "ConfigControllerDegraded",
 				status:   metav1.ConditionFalse,
 			},
+			{
+				condType: "PVCControllerProgressing",
+				status:   metav1.ConditionFalse,
+			},
+			{
+				condType: "PVCControllerDegraded",
+				status:   metav1.ConditionFalse,
+			},
+			{
+				condType: "NetworkPolicyControllerProgressing",
+				status:   metav1.ConditionFalse,
+			},
+			{
+				condType: "NetworkPolicyControllerDegraded",
+				status:   metav1.ConditionFalse,
+			},
 			{
 				condType: "ScyllaDBDatacenterControllerProgressing",
 				status:   metav1.ConditionFalse,
@@ -316,9 +332,19 @@ func VerifyWithOptions(ctx context.Context, kubeClient kubernetes.Interface, scy
 		o.Expect(sc.Status.Upgrade.FromVersion).To(o.Equal(sc.Status.Upgrade.ToVersion))
 	}
 
-	pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets(sc.Namespace).Get(ctx, naming.PodDisruptionBudgetNameForScyllaCluster(sc), metav1.GetOptions{})
-	o.Expect(err).NotTo(o.HaveOccurred())
-	verifyPodDisruptionBudget(sc, pdb, sdc)
+	if len(sdc.Spec.Racks) > 1 {
+		for _, rack := range sdc.Spec.Racks {
+			pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets(sc.Namespace).Get(ctx, naming.PodDisruptionBudgetNameForRack(rack, sdc), metav1.GetOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+			rackSelectorLabels, err := naming.RackSelectorLabels(rack, sdc)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			verifyPodDisruptionBudget(pdb, sdc, rackSelectorLabels)
+		}
+	} else {
+		pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets(sc.Namespace).Get(ctx, naming.PodDisruptionBudgetNameForScyllaCluster(sc), metav1.GetOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+		verifyPodDisruptionBudget(pdb, sdc, naming.ClusterLabelsForScyllaCluster(sc))
+	}
 
 	verifyPersistentVolumeClaims(ctx, kubeClient.CoreV1(), sc)
 
@@ -109,7 +109,7 @@ func verifyStatefulset(ctx context.Context, client corev1client.CoreV1Interface,
 	}
 }
 
-func verifyPodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, sdc *scyllav1alpha1.ScyllaDBDatacenter) {
+func verifyPodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, sdc *scyllav1alpha1.ScyllaDBDatacenter, selectorLabels map[string]string) {
 	o.Expect(pdb.ObjectMeta.OwnerReferences).To(o.BeEquivalentTo(
 		[]metav1.OwnerReference{
 			{
@@ -124,7 +124,7 @@ func verifyPodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, sdc *scyllav1a
 	)
 	o.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(o.Equal(1))
 	o.Expect(pdb.Spec.Selector).ToNot(o.BeNil())
-	o.Expect(pdb.Spec.Selector.MatchLabels).To(o.Equal(naming.ClusterLabels(sdc)))
+	o.Expect(pdb.Spec.Selector.MatchLabels).To(o.Equal(selectorLabels))
 	o.Expect(pdb.Spec.Selector.MatchExpressions).To(o.Equal([]metav1.LabelSelectorRequirement{
 		{
 			Key:      "batch.kubernetes.io/job-name",
@@ -252,6 +252,22 @@ func VerifyWithOptions(ctx context.Context, kubeClient kubernetes.Interface, scy
 				condType: "ConfigControllerDegraded",
 				status:   metav1.ConditionFalse,
 			},
+			{
+				condType: "PVCControllerProgressing",
+				status:   metav1.ConditionFalse,
+			},
+			{
+				condType: "PVCControllerDegraded",
+				status:   metav1.ConditionFalse,
+			},
+			{
+				condType: "NetworkPolicyControllerProgressing",
+				status:   metav1.ConditionFalse,
+			},
+			{
+				condType: "NetworkPolicyControllerDegraded",
+				status:   metav1.ConditionFalse,
+			},
 		}
 
 		if utilfeature.DefaultMutableFeatureGate.Enabled(features.AutomaticTLSCertificates) || sdc.Spec.ScyllaDB.AlternatorOptions != nil {
@@ -323,9 +339,19 @@ func VerifyWithOptions(ctx context.Context, kubeClient kubernetes.Interface, scy
 		o.Expect(*rackStatus.UpdatedNodes).To(o.Equal(s.Status.UpdatedReplicas))
 	}
 
-	pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets(sdc.Namespace).Get(ctx, naming.PodDisruptionBudgetName(sdc), metav1.GetOptions{})
-	o.Expect(err).NotTo(o.HaveOccurred())
-	verifyPodDisruptionBudget(pdb, sdc)
+	if len(sdc.Spec.Racks) > 1 {
+		for _, rack := range sdc.Spec.Racks {
+			pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets(sdc.Namespace).Get(ctx, naming.PodDisruptionBudgetNameForRack(rack, sdc), metav1.GetOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+			rackSelectorLabels, err := naming.RackSelectorLabels(rack, sdc)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			verifyPodDisruptionBudget(pdb, sdc, rackSelectorLabels)
+		}
+	} else {
+		pdb, err := kubeClient.PolicyV1().PodDisruptionBudgets(sdc.Namespace).Get(ctx, naming.PodDisruptionBudgetName(sdc), metav1.GetOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+		verifyPodDisruptionBudget(pdb, sdc, naming.ClusterLabels(sdc))
+	}
 
 	verifyPersistentVolumeClaims(ctx, kubeClient.CoreV1(), sdc)
 